@@ -57,6 +57,18 @@ func (backupStatus *BackupStatus) SetAsCompleted() {
 	backupStatus.StoppedAt = ptr.To(metav1.Now())
 }
 
+// SetDuration sets the duration of the backup from its start and stop
+// times, leaving it untouched when either of them is unknown
+func (backupStatus *BackupStatus) SetDuration() {
+	if backupStatus.StartedAt == nil || backupStatus.StoppedAt == nil {
+		return
+	}
+
+	backupStatus.Duration = &metav1.Duration{
+		Duration: backupStatus.StoppedAt.Sub(backupStatus.StartedAt.Time),
+	}
+}
+
 // SetAsStarted marks a certain backup as started
 func (backupStatus *BackupStatus) SetAsStarted(podName, containerID string, method BackupMethod) {
 	backupStatus.Phase = BackupPhaseStarted
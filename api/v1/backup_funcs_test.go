@@ -81,6 +81,25 @@ var _ = Describe("BackupStatus structure", func() {
 			BackupSnapshotElementStatus{Name: "cluster-example-snapshot-2", Type: string(utils.PVCRolePgWal)}))
 	})
 
+	It("can compute the backup duration", func() {
+		startedAt := metav1.NewTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+		status := BackupStatus{
+			StartedAt: &startedAt,
+			StoppedAt: ptr.To(metav1.NewTime(startedAt.Add(90 * time.Second))),
+		}
+		status.SetDuration()
+		Expect(status.Duration).ToNot(BeNil())
+		Expect(status.Duration.Duration).To(Equal(90 * time.Second))
+	})
+
+	It("leaves the duration unset when the start time is unknown", func() {
+		status := BackupStatus{
+			StoppedAt: ptr.To(metav1.Now()),
+		}
+		status.SetDuration()
+		Expect(status.Duration).To(BeNil())
+	})
+
 	Context("backup phases", func() {
 		When("the backup phase is `running`", func() {
 			It("can tell if a backup is in progress or done", func() {
@@ -18,6 +18,7 @@ package v1
 
 import (
 	barmanApi "github.com/cloudnative-pg/barman-cloud/pkg/api"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -130,6 +131,12 @@ type BackupSpec struct {
 	// +kubebuilder:validation:Enum=primary;prefer-standby
 	Target BackupTarget `json:"target,omitempty"`
 
+	// The name of the instance that should perform this backup. When set,
+	// it takes precedence over the target policy, and the backup stays
+	// pending until the instance is ready.
+	// +optional
+	Instance string `json:"instance,omitempty"`
+
 	// The backup method to be used, possible options are `barmanObjectStore`,
 	// `volumeSnapshot` or `plugin`. Defaults to: `barmanObjectStore`.
 	// +optional
@@ -237,6 +244,17 @@ type BackupStatus struct {
 	// +optional
 	StoppedAt *metav1.Time `json:"stoppedAt,omitempty"`
 
+	// The time elapsed between the start and the end of the backup
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// An estimate of the size of the backup. For the object store methods
+	// this is the size of the databases when the backup was taken, before
+	// compression and without the WAL files, while for the volume snapshot
+	// method it is the sum of the restore sizes of the snapshots
+	// +optional
+	BackupSize *resource.Quantity `json:"backupSize,omitempty"`
+
 	// The starting WAL
 	// +optional
 	BeginWal string `json:"beginWal,omitempty"`
@@ -48,4 +48,24 @@ var (
 			Message: err.Error(),
 		}
 	}
+
+	// BuildPluginReadyCondition builds the condition reporting whether
+	// the plugin is ready, given the error returned by its Probe endpoint
+	BuildPluginReadyCondition = func(pluginName string, err error) metav1.Condition {
+		if err != nil {
+			return metav1.Condition{
+				Type:    string(ConditionPluginReadyPrefix) + pluginName,
+				Status:  metav1.ConditionFalse,
+				Reason:  string(ConditionReasonPluginNotReady),
+				Message: err.Error(),
+			}
+		}
+
+		return metav1.Condition{
+			Type:    string(ConditionPluginReadyPrefix) + pluginName,
+			Status:  metav1.ConditionTrue,
+			Reason:  string(ConditionReasonPluginReady),
+			Message: "Plugin is ready",
+		}
+	}
 )
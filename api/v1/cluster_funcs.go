@@ -267,6 +267,16 @@ func (c *CertificatesConfiguration) getServerAltDNSNames() []string {
 	return c.ServerAltDNSNames
 }
 
+// GetClientCertificatesRenewalThreshold gets the percentage of the lifetime
+// of the client certificates under which they are renewed
+func (c *CertificatesConfiguration) GetClientCertificatesRenewalThreshold() int {
+	if c == nil || c.ClientCertificatesRenewalThreshold <= 0 {
+		return DefaultClientCertificatesRenewalThreshold
+	}
+
+	return c.ClientCertificatesRenewalThreshold
+}
+
 // HasElements returns true if it contains any Reference
 func (s *SQLRefs) HasElements() bool {
 	if s == nil {
@@ -333,6 +343,12 @@ func (in ExternalCluster) GetServerName() string {
 	return in.Name
 }
 
+// HasConnectionParameters returns true when the external cluster can be
+// reached through a PostgreSQL connection
+func (in ExternalCluster) HasConnectionParameters() bool {
+	return in.ConnectionParameters != nil || in.ConnectionParametersSecret != nil
+}
+
 // IsEnabled returns true when this plugin is enabled
 func (config *PluginConfiguration) IsEnabled() bool {
 	if config.Enabled == nil {
@@ -487,6 +503,9 @@ func (cluster *Cluster) GetExternalClusterSecrets() *stringset.Data {
 			if externalCluster.SSLRootCert != nil {
 				secrets.Put(externalCluster.SSLRootCert.Name)
 			}
+			if externalCluster.ConnectionParametersSecret != nil {
+				secrets.Put(externalCluster.ConnectionParametersSecret.Name)
+			}
 		}
 	}
 	return secrets
@@ -658,6 +677,41 @@ func (cluster *Cluster) GetMaxStartDelay() int32 {
 	return DefaultStartupDelay
 }
 
+// IsStreamingRequiredForReadiness checks whether the readiness probe of
+// the streaming replicas requires an active WAL receiver
+func (cluster *Cluster) IsStreamingRequiredForReadiness() bool {
+	return cluster.Spec.Probes != nil &&
+		cluster.Spec.Probes.ReadinessRequiresStreaming != nil &&
+		*cluster.Spec.Probes.ReadinessRequiresStreaming
+}
+
+// GetMaxStandbyLagForReady parses the maximum lag of a replica over which
+// its readiness probe fails. The lag is expressed either in bytes or as a
+// duration, and zero values mean that the check is disabled
+func (cluster *Cluster) GetMaxStandbyLagForReady() (maxLagBytes int64, maxLagDuration time.Duration, err error) {
+	if cluster.Spec.Probes == nil || cluster.Spec.Probes.MaxStandbyLagForReady == "" {
+		return 0, 0, nil
+	}
+
+	value := cluster.Spec.Probes.MaxStandbyLagForReady
+	if duration, err := time.ParseDuration(value); err == nil {
+		if duration <= 0 {
+			return 0, 0, fmt.Errorf("the maximum lag must be positive: %s", value)
+		}
+		return 0, duration, nil
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("the maximum lag is neither a duration nor a size: %s", value)
+	}
+	if quantity.Sign() <= 0 {
+		return 0, 0, fmt.Errorf("the maximum lag must be positive: %s", value)
+	}
+
+	return quantity.Value(), 0, nil
+}
+
 // GetMaxStopDelay get the amount of time PostgreSQL has to stop
 func (cluster *Cluster) GetMaxStopDelay() int32 {
 	if cluster.Spec.MaxStopDelay > 0 {
@@ -756,6 +810,22 @@ func (cluster *Cluster) IsInstanceFenced(instance string) bool {
 	return fencedInstances.Has(instance)
 }
 
+// IsInstanceRolloutSkipped checks whether the instance is listed among the
+// ones to be excluded from rolling updates
+func (cluster *Cluster) IsInstanceRolloutSkipped(instance string) bool {
+	skippedInstances, ok := cluster.Annotations[utils.SkipRolloutInstancesAnnotationName]
+	if !ok {
+		return false
+	}
+
+	for _, skippedInstance := range strings.Split(skippedInstances, ",") {
+		if strings.TrimSpace(skippedInstance) == instance {
+			return true
+		}
+	}
+	return false
+}
+
 // ShouldResizeInUseVolumes is true when we should resize PVC we already
 // created
 func (cluster *Cluster) ShouldResizeInUseVolumes() bool {
@@ -1102,6 +1172,10 @@ func (cluster *Cluster) UsesConfigMap(config string) (ok bool) {
 	if _, ok := cluster.Status.ConfigMapResourceVersion.Metrics[config]; ok {
 		return true
 	}
+	if includeConfigMap := cluster.Spec.PostgresConfiguration.IncludeConfigMap; includeConfigMap != nil &&
+		includeConfigMap.Name == config {
+		return true
+	}
 	return false
 }
 
@@ -1367,6 +1441,17 @@ func (cluster *Cluster) IsReadOnlyServiceEnabled() bool {
 	return !slices.Contains(cluster.Spec.Managed.Services.DisabledDefaultServices, ServiceSelectorTypeRO)
 }
 
+// GetFreshReplicaMaxLag gets the maximum replication lag, in bytes, of
+// a replica for it to be considered fresh
+func (cluster *Cluster) GetFreshReplicaMaxLag() int64 {
+	if cluster.Spec.Managed == nil || cluster.Spec.Managed.Services == nil ||
+		cluster.Spec.Managed.Services.FreshReplicaMaxLag == nil {
+		return DefaultFreshReplicaMaxLag
+	}
+
+	return cluster.Spec.Managed.Services.FreshReplicaMaxLag.Value()
+}
+
 // GetRecoverySourcePlugin returns the configuration of the plugin being
 // the recovery source of the cluster. If no such plugin have been configured,
 // nil is returned
@@ -1446,6 +1531,13 @@ func (target *RecoveryTarget) BuildPostgresOptions() string {
 	} else {
 		result += "recovery_target_inclusive = true\n"
 	}
+	if target.RecoveryTargetAction != "" {
+		// This overrides the default action set by the restore
+		// procedure, as the last occurrence of a parameter wins
+		result += fmt.Sprintf(
+			"recovery_target_action = '%v'\n",
+			target.RecoveryTargetAction)
+	}
 
 	return result
 }
@@ -1476,3 +1568,26 @@ func (p *Probe) ApplyInto(k8sProbe *corev1.Probe) {
 		k8sProbe.TerminationGracePeriodSeconds = p.TerminationGracePeriodSeconds
 	}
 }
+
+// GetSupportedPrivileges returns the privileges that can be granted
+// on an object of this type
+func (objectType GrantObjectType) GetSupportedPrivileges() []GrantPrivilege {
+	switch objectType {
+	case GrantObjectTypeSchema:
+		return []GrantPrivilege{GrantPrivilegeUsage, GrantPrivilegeCreate}
+	case GrantObjectTypeTable:
+		return []GrantPrivilege{
+			GrantPrivilegeSelect,
+			GrantPrivilegeInsert,
+			GrantPrivilegeUpdate,
+			GrantPrivilegeDelete,
+			GrantPrivilegeTruncate,
+			GrantPrivilegeReferences,
+			GrantPrivilegeTrigger,
+		}
+	case GrantObjectTypeSequence:
+		return []GrantPrivilege{GrantPrivilegeUsage, GrantPrivilegeSelect, GrantPrivilegeUpdate}
+	default:
+		return nil
+	}
+}
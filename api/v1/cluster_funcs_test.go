@@ -771,6 +771,21 @@ var _ = Describe("A config map resource version", func() {
 		found := cluster.UsesConfigMap("a-configmap")
 		Expect(found).To(BeTrue())
 	})
+
+	It("contains the configmap included in the PostgreSQL configuration", func() {
+		cluster := Cluster{
+			Spec: ClusterSpec{
+				PostgresConfiguration: PostgresConfiguration{
+					IncludeConfigMap: &ConfigMapKeySelector{
+						LocalObjectReference: LocalObjectReference{Name: "a-configmap"},
+						Key:                  "custom.conf",
+					},
+				},
+			},
+		}
+		Expect(cluster.UsesConfigMap("a-configmap")).To(BeTrue())
+		Expect(cluster.UsesConfigMap("another-configmap")).To(BeFalse())
+	})
 })
 
 var _ = Describe("PostgreSQL version detection", func() {
@@ -938,6 +953,26 @@ var _ = Describe("Fencing annotation", func() {
 	})
 })
 
+var _ = Describe("Skip rollout annotation", func() {
+	It("detects the instances listed in the annotation", func() {
+		cluster := Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					utils.SkipRolloutInstancesAnnotationName: "one, two",
+				},
+			},
+		}
+		Expect(cluster.IsInstanceRolloutSkipped("one")).To(BeTrue())
+		Expect(cluster.IsInstanceRolloutSkipped("two")).To(BeTrue())
+		Expect(cluster.IsInstanceRolloutSkipped("three")).To(BeFalse())
+	})
+
+	It("doesn't skip any instance when the annotation doesn't exist", func() {
+		cluster := Cluster{}
+		Expect(cluster.IsInstanceRolloutSkipped("one")).To(BeFalse())
+	})
+})
+
 var _ = Describe("Replication slots names for instances", func() {
 	It("returns an empty name when no replication slots are configured", func() {
 		cluster := Cluster{}
@@ -1733,4 +1768,64 @@ var _ = Describe("Probes configuration", func() {
 		Expect(originalProbe).To(BeEquivalentTo(*configuredProbe),
 			"configured probe should not be modified with zero values")
 	})
+
+	It("requires streaming for readiness only when enabled", func() {
+		cluster := &Cluster{}
+		Expect(cluster.IsStreamingRequiredForReadiness()).To(BeFalse())
+
+		cluster.Spec.Probes = &ProbesConfiguration{ReadinessRequiresStreaming: ptr.To(false)}
+		Expect(cluster.IsStreamingRequiredForReadiness()).To(BeFalse())
+
+		cluster.Spec.Probes.ReadinessRequiresStreaming = ptr.To(true)
+		Expect(cluster.IsStreamingRequiredForReadiness()).To(BeTrue())
+	})
+
+	It("parses the maximum standby lag for readiness", func() {
+		cluster := &Cluster{}
+		maxLagBytes, maxLagDuration, err := cluster.GetMaxStandbyLagForReady()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(maxLagBytes).To(BeZero())
+		Expect(maxLagDuration).To(BeZero())
+
+		cluster.Spec.Probes = &ProbesConfiguration{MaxStandbyLagForReady: "1m"}
+		maxLagBytes, maxLagDuration, err = cluster.GetMaxStandbyLagForReady()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(maxLagBytes).To(BeZero())
+		Expect(maxLagDuration).To(Equal(time.Minute))
+
+		cluster.Spec.Probes.MaxStandbyLagForReady = "16Mi"
+		maxLagBytes, maxLagDuration, err = cluster.GetMaxStandbyLagForReady()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(maxLagBytes).To(BeEquivalentTo(16 * 1024 * 1024))
+		Expect(maxLagDuration).To(BeZero())
+
+		cluster.Spec.Probes.MaxStandbyLagForReady = "wrong"
+		_, _, err = cluster.GetMaxStandbyLagForReady()
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Recovery target PostgreSQL options", func() {
+	It("returns no options without a recovery target", func() {
+		var target *RecoveryTarget
+		Expect(target.BuildPostgresOptions()).To(BeEmpty())
+	})
+
+	It("doesn't set the recovery target action by default", func() {
+		target := &RecoveryTarget{TargetLSN: "1/1"}
+		Expect(target.BuildPostgresOptions()).To(Equal(
+			"recovery_target_lsn = '1/1'\n" +
+				"recovery_target_inclusive = true\n"))
+	})
+
+	It("sets the requested recovery target action", func() {
+		target := &RecoveryTarget{
+			TargetName:           "before_migration",
+			RecoveryTargetAction: RecoveryTargetActionPause,
+		}
+		Expect(target.BuildPostgresOptions()).To(Equal(
+			"recovery_target_name = 'before_migration'\n" +
+				"recovery_target_inclusive = true\n" +
+				"recovery_target_action = 'pause'\n"))
+	})
 })
@@ -494,6 +494,18 @@ type ProbesConfiguration struct {
 
 	// The readiness probe configuration
 	Readiness *Probe `json:"readiness,omitempty"`
+
+	// When enabled, the readiness probe of a streaming replica fails
+	// while its WAL receiver is not streaming from the source.
+	// Disabled by default
+	// +optional
+	ReadinessRequiresStreaming *bool `json:"readinessRequiresStreaming,omitempty"`
+
+	// The maximum lag of a replica, either in bytes (for example `64Mi`)
+	// or as a duration (for example `30s`), over which its readiness probe
+	// fails until it catches up. Disabled by default
+	// +optional
+	MaxStandbyLagForReady string `json:"maxStandbyLagForReady,omitempty"`
 }
 
 // Probe describes a health check to be performed against a container to determine whether it is
@@ -856,6 +868,15 @@ type ClusterStatus struct {
 	// +optional
 	LastFailedBackup string `json:"lastFailedBackup,omitempty"`
 
+	// The outcome of the last enforcement of the backup retention policy
+	// +optional
+	LastRetentionRun *RetentionRunStatus `json:"lastRetentionRun,omitempty"`
+
+	// The last verification of a base backup, whose outcome is reported
+	// in the `BackupVerified` condition
+	// +optional
+	LastBackupVerification *BackupVerificationStatus `json:"lastBackupVerification,omitempty"`
+
 	// The commit hash number of which this operator running
 	// +optional
 	CommitHash string `json:"cloudNativePGCommitHash,omitempty"`
@@ -919,6 +940,103 @@ type ClusterStatus struct {
 	// WAL file, and Time of latest checkpoint
 	// +optional
 	DemotionToken string `json:"demotionToken,omitempty"`
+
+	// Import contains the progress of the logical import, as reported
+	// by the job bootstrapping the cluster via `initdb.import`
+	// +optional
+	Import *ImportStatus `json:"import,omitempty"`
+}
+
+// BackupVerificationStatus contains the information about
+// the verification of a base backup
+type BackupVerificationStatus struct {
+	// The name of the verified backup
+	BackupName string `json:"backupName"`
+
+	// The name of the job running the verification
+	JobName string `json:"jobName"`
+
+	// When the verification started
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// When the verification completed, empty while it is running
+	// +optional
+	StoppedAt *metav1.Time `json:"stoppedAt,omitempty"`
+}
+
+// RetentionRunStatus contains the outcome of an enforcement
+// of the backup retention policy
+type RetentionRunStatus struct {
+	// The time when the retention policy was enforced, stored as a date
+	// in RFC3339 format
+	Time string `json:"time"`
+
+	// The retention policy which was enforced
+	// +optional
+	RetentionPolicy string `json:"retentionPolicy,omitempty"`
+
+	// The number of backups deleted from the object store
+	// +optional
+	DeletedBackups int `json:"deletedBackups,omitempty"`
+
+	// The error raised while enforcing the retention policy, if any
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// ImportPhase is the step of the logical import being executed
+type ImportPhase string
+
+const (
+	// ImportPhaseExporting means the databases are being exported from the source
+	ImportPhaseExporting ImportPhase = "Exporting"
+
+	// ImportPhaseImporting means the databases are being restored into the cluster
+	ImportPhaseImporting ImportPhase = "Importing"
+
+	// ImportPhaseAnalyzing means the statistics of the imported databases are being collected
+	ImportPhaseAnalyzing ImportPhase = "Analyzing"
+
+	// ImportPhaseCompleted means the logical import has been completed
+	ImportPhaseCompleted ImportPhase = "Completed"
+)
+
+// ImportStatus contains the progress of the logical import
+type ImportStatus struct {
+	// The step of the import being executed
+	// +optional
+	Phase ImportPhase `json:"phase,omitempty"`
+
+	// The database being processed in the current phase
+	// +optional
+	CurrentDatabase string `json:"currentDatabase,omitempty"`
+
+	// The number of databases already processed in the current phase
+	// +optional
+	DatabasesDone int `json:"databasesDone,omitempty"`
+
+	// The number of databases to be imported
+	// +optional
+	DatabasesTotal int `json:"databasesTotal,omitempty"`
+
+	// The tables whose data is being copied into the current database,
+	// as reported by `pg_stat_progress_copy`
+	// +optional
+	CopyingTables []string `json:"copyingTables,omitempty"`
+
+	// The number of bytes already processed by the running copies
+	// of table data, as reported by `pg_stat_progress_copy`
+	// +optional
+	BytesProcessed int64 `json:"bytesProcessed,omitempty"`
+
+	// The time when the import (or its last restart) began
+	// +optional
+	StartTime string `json:"startTime,omitempty"`
+
+	// The time of the last progress update
+	// +optional
+	LastUpdateTime string `json:"lastUpdateTime,omitempty"`
 }
 
 // SwitchReplicaClusterStatus contains all the statuses regarding the switch of a cluster to a replica cluster
@@ -949,6 +1067,29 @@ const (
 	ConditionBackup ClusterConditionType = "LastBackupSucceeded"
 	// ConditionClusterReady represents whether a cluster is Ready
 	ConditionClusterReady ClusterConditionType = "Ready"
+	// ConditionStandbyAvailableForBackup represents whether a standby instance
+	// is available to run the backups targeting a standby
+	ConditionStandbyAvailableForBackup ClusterConditionType = "StandbyAvailableForBackup"
+	// ConditionPluginReadyPrefix is the prefix of the conditions representing
+	// whether each plugin is ready, as reported by its Probe endpoint
+	ConditionPluginReadyPrefix ClusterConditionType = "PluginReady-"
+	// ConditionFailoverPaused represents whether a failover has been
+	// withheld because the automatic failover is paused
+	ConditionFailoverPaused ClusterConditionType = "FailoverPaused"
+	// ConditionPrimaryUpdatePending represents whether the update of the
+	// primary instance is waiting for the user to request a switchover
+	// or a restart, as required by the supervised primary update strategy
+	ConditionPrimaryUpdatePending ClusterConditionType = "PrimaryUpdatePending"
+	// ConditionRecoveryWindowCovered represents whether the backup retention
+	// policy covers the minimum recovery window declared on the cluster
+	ConditionRecoveryWindowCovered ClusterConditionType = "RecoveryWindowCovered"
+	// ConditionBackupVerified represents whether the latest base backup
+	// that has been verified could be restored
+	ConditionBackupVerified ClusterConditionType = "BackupVerified"
+	// ConditionIncludeConfigMapReady represents whether the ConfigMap included
+	// in the PostgreSQL configuration exists, contains the referenced key, and
+	// is watched by the operator
+	ConditionIncludeConfigMapReady ClusterConditionType = "IncludeConfigMapReady"
 )
 
 // ConditionStatus defines conditions of resources
@@ -997,6 +1138,76 @@ const (
 
 	// DetachedVolume is the reason that is set when we do a rolling upgrade to add a PVC volume to a cluster
 	DetachedVolume ConditionReason = "DetachedVolume"
+
+	// ConditionReasonStandbyAvailable means that the condition changed because at
+	// least a standby instance is ready to run the backups targeting a standby
+	ConditionReasonStandbyAvailable ConditionReason = "StandbyAvailable"
+
+	// ConditionReasonNoStandbyAvailable means that the condition changed because no
+	// standby instance is ready to run the backups targeting a standby
+	ConditionReasonNoStandbyAvailable ConditionReason = "NoStandbyAvailable"
+
+	// ConditionReasonPluginReady means that the condition changed because the
+	// plugin reported being ready
+	ConditionReasonPluginReady ConditionReason = "PluginReady"
+
+	// ConditionReasonPluginNotReady means that the condition changed because the
+	// plugin couldn't be probed or reported not being ready
+	ConditionReasonPluginNotReady ConditionReason = "PluginNotReady"
+
+	// ConditionReasonFailoverPaused means that the condition changed because
+	// the primary isn't healthy but the automatic failover is paused
+	ConditionReasonFailoverPaused ConditionReason = "FailoverPaused"
+
+	// ConditionReasonFailoverResumed means that the condition changed because
+	// the automatic failover is not paused anymore, or the primary recovered
+	ConditionReasonFailoverResumed ConditionReason = "FailoverResumed"
+
+	// ConditionReasonWaitingForSupervisedUpdate means that the primary instance
+	// needs to be updated and the operator is waiting for the user to act
+	ConditionReasonWaitingForSupervisedUpdate ConditionReason = "WaitingForSupervisedUpdate"
+
+	// ConditionReasonPrimaryUpdated means that the primary instance
+	// doesn't need to be updated anymore
+	ConditionReasonPrimaryUpdated ConditionReason = "PrimaryUpdated"
+
+	// ConditionReasonRecoveryWindowCovered means that the backup retention
+	// policy is not shorter than the declared minimum recovery window
+	ConditionReasonRecoveryWindowCovered ConditionReason = "RecoveryWindowCovered"
+
+	// ConditionReasonRetentionPolicyTooShort means that the backup retention
+	// policy is shorter than the declared minimum recovery window
+	ConditionReasonRetentionPolicyTooShort ConditionReason = "RetentionPolicyTooShort"
+
+	// ConditionReasonInvalidRecoveryWindow means that the declared minimum
+	// recovery window cannot be parsed
+	ConditionReasonInvalidRecoveryWindow ConditionReason = "InvalidRecoveryWindow"
+
+	// ConditionReasonBackupVerificationSucceeded means that the verified
+	// base backup has been restored and reached a consistent state
+	ConditionReasonBackupVerificationSucceeded ConditionReason = "BackupVerificationSucceeded"
+
+	// ConditionReasonBackupVerificationFailed means that the verified
+	// base backup could not be restored
+	ConditionReasonBackupVerificationFailed ConditionReason = "BackupVerificationFailed"
+
+	// ConditionReasonIncludeConfigMapReady means that the ConfigMap included
+	// in the PostgreSQL configuration contains the referenced key and is
+	// watched by the operator
+	ConditionReasonIncludeConfigMapReady ConditionReason = "IncludeConfigMapReady"
+
+	// ConditionReasonIncludeConfigMapNotFound means that the ConfigMap
+	// included in the PostgreSQL configuration doesn't exist
+	ConditionReasonIncludeConfigMapNotFound ConditionReason = "IncludeConfigMapNotFound"
+
+	// ConditionReasonIncludeConfigMapKeyNotFound means that the ConfigMap
+	// included in the PostgreSQL configuration doesn't contain the referenced key
+	ConditionReasonIncludeConfigMapKeyNotFound ConditionReason = "IncludeConfigMapKeyNotFound"
+
+	// ConditionReasonIncludeConfigMapNotWatched means that the ConfigMap
+	// included in the PostgreSQL configuration doesn't have the reload label,
+	// and its changes are not applied until the cluster is reconciled again
+	ConditionReasonIncludeConfigMapNotWatched ConditionReason = "IncludeConfigMapNotWatched"
 )
 
 // EmbeddedObjectMetadata contains metadata to be inherited by all resources related to a Cluster
@@ -1211,6 +1422,14 @@ const (
 	// FailureThreshold of startupProbe, the formula is `FailureThreshold = ceiling(startDelay / periodSeconds)`,
 	// the minimum value is 1
 	DefaultStartupDelay = 3600
+
+	// DefaultClientCertificatesRenewalThreshold is the default percentage of the
+	// lifetime of a client certificate under which it is renewed
+	DefaultClientCertificatesRenewalThreshold = 33
+
+	// DefaultFreshReplicaMaxLag is the default maximum replication lag, in bytes,
+	// of a replica for it to be selected by the services of type "ro-fresh"
+	DefaultFreshReplicaMaxLag = 16 * 1024 * 1024
 )
 
 // SynchronousReplicaConfigurationMethod configures whether to use
@@ -1307,6 +1526,18 @@ type PostgresConfiguration struct {
 	// +optional
 	PgIdent []string `json:"pg_ident,omitempty"`
 
+	// Typed PostgreSQL Host Based Authentication rules, validated by
+	// the operator and rendered in the pg_hba.conf file after the
+	// operator-managed rules and the ones listed in `pg_hba`
+	// +optional
+	HBARules []HBARule `json:"hbaRules,omitempty"`
+
+	// Typed PostgreSQL User Name Maps, validated by the operator and
+	// rendered in the pg_ident.conf file after the operator-managed
+	// maps and the ones listed in `pg_ident`
+	// +optional
+	IdentMaps []IdentMap `json:"identMaps,omitempty"`
+
 	// Requirements to be met by sync replicas. This will affect how the "synchronous_standby_names" parameter will be
 	// set up.
 	// +optional
@@ -1332,6 +1563,14 @@ type PostgresConfiguration struct {
 	// Defaults to false.
 	// +optional
 	EnableAlterSystem bool `json:"enableAlterSystem,omitempty"`
+
+	// The reference to the key of a ConfigMap containing a block of
+	// PostgreSQL configuration parameters, in the `postgresql.conf` format,
+	// which is included in the configuration of every instance.
+	// The parameters set in `parameters` take precedence over the ones in
+	// the ConfigMap, and the fixed parameters are ignored.
+	// +optional
+	IncludeConfigMap *ConfigMapKeySelector `json:"includeConfigMap,omitempty"`
 }
 
 // BootstrapConfiguration contains information about how to create the PostgreSQL
@@ -1354,6 +1593,79 @@ type BootstrapConfiguration struct {
 	PgBaseBackup *BootstrapPgBaseBackup `json:"pg_basebackup,omitempty"`
 }
 
+// HBAConnectionType is the type of connection matched by an HBA rule
+// +kubebuilder:validation:Enum=local;host;hostssl;hostnossl;hostgssenc;hostnogssenc
+type HBAConnectionType string
+
+// These are the valid HBA connection types
+const (
+	HBAConnectionTypeLocal        HBAConnectionType = "local"
+	HBAConnectionTypeHost         HBAConnectionType = "host"
+	HBAConnectionTypeHostSSL      HBAConnectionType = "hostssl"
+	HBAConnectionTypeHostNoSSL    HBAConnectionType = "hostnossl"
+	HBAConnectionTypeHostGSSEnc   HBAConnectionType = "hostgssenc"
+	HBAConnectionTypeHostNoGSSEnc HBAConnectionType = "hostnogssenc"
+)
+
+// HBARule is a typed rule of the pg_hba.conf file
+type HBARule struct {
+	// The connection type matched by this rule
+	Type HBAConnectionType `json:"type"`
+
+	// The databases matched by this rule, defaulting to `all`
+	// +optional
+	Databases []string `json:"databases,omitempty"`
+
+	// The roles matched by this rule. When both `users` and `groups`
+	// are empty, the rule matches every role
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// The roles whose members are matched by this rule, such as
+	// the groups declared in the managed roles
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// The client address matched by this rule, as a host name, an IP
+	// address range in CIDR notation, or one of the `all`, `samehost`
+	// and `samenet` keywords. Required unless the connection type is `local`
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// The authentication method, such as `scram-sha-256` or `cert`
+	Method string `json:"method"`
+
+	// The options of the authentication method
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// IdentMap is a typed user name map of the pg_ident.conf file
+type IdentMap struct {
+	// The name of the map, to be referenced by the `map` option
+	// of the HBA rules
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// The mappings of the map
+	// +kubebuilder:validation:MinItems=1
+	Mappings []IdentMapping `json:"mappings"`
+}
+
+// IdentMapping maps an operating system user name to a database role
+type IdentMapping struct {
+	// The user name as seen by the authentication system. When it starts
+	// with a slash (`/`), the rest of the string is a regular expression
+	// +kubebuilder:validation:MinLength=1
+	SystemUsername string `json:"systemUsername"`
+
+	// The database role the system user name is allowed to connect as.
+	// When the system user name is a regular expression, it can refer to
+	// its first capture group with `\1`
+	// +kubebuilder:validation:MinLength=1
+	DatabaseUsername string `json:"databaseUsername"`
+}
+
 // LDAPScheme defines the possible schemes for LDAP
 type LDAPScheme string
 
@@ -1465,6 +1777,15 @@ type CertificatesConfiguration struct {
 	// The list of the server alternative DNS names to be added to the generated server TLS certificates, when required.
 	// +optional
 	ServerAltDNSNames []string `json:"serverAltDNSNames,omitempty"`
+
+	// The percentage of the lifetime of the client certificates generated by
+	// the operator, such as the one of the `streaming_replica` user, under which
+	// they are renewed. Defaults to 33, meaning that a client certificate is
+	// renewed when less than one third of its lifetime is left.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	ClientCertificatesRenewalThreshold int `json:"clientCertificatesRenewalThreshold,omitempty"`
 }
 
 // CertificatesStatus contains configuration certificates and related expiration dates.
@@ -1649,6 +1970,26 @@ type Import struct {
 	// +optional
 	SchemaOnly bool `json:"schemaOnly,omitempty"`
 
+	// List of table patterns to be excluded from the import, passed to
+	// `pg_dump` via the `--exclude-table` option. In the `monolith` type,
+	// they are applied to every imported database
+	// +optional
+	ExcludeTables []string `json:"excludeTables,omitempty"`
+
+	// List of schema patterns to be excluded from the import, passed to
+	// `pg_dump` via the `--exclude-schema` option. In the `monolith` type,
+	// they are applied to every imported database
+	// +optional
+	ExcludeSchemas []string `json:"excludeSchemas,omitempty"`
+
+	// The number of parallel jobs used by `pg_dump` and `pg_restore`
+	// to export and import each database. When set, the dumps are
+	// stored in the ephemeral volume instead of the PGDATA one.
+	// Default: sequential import
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Jobs int `json:"jobs,omitempty"`
+
 	// List of custom options to pass to the `pg_dump` command. IMPORTANT:
 	// Use these options with caution and at your own risk, as the operator
 	// does not validate their content. Be aware that certain options may
@@ -1832,8 +2173,31 @@ type RecoveryTarget struct {
 	// in Postgres, `recovery_target_inclusive` will be true
 	// +optional
 	Exclusive *bool `json:"exclusive,omitempty"`
+
+	// The action PostgreSQL takes once the recovery target is reached,
+	// setting `recovery_target_action`. `promote` (default) ends the
+	// recovery and starts the cluster. `pause` pauses the recovery, so that
+	// the restored data can be inspected before calling
+	// `pg_wal_replay_resume()`, which ends the recovery. It requires a
+	// recovery target to be set
+	// +kubebuilder:validation:Enum=promote;pause
+	// +optional
+	RecoveryTargetAction RecoveryTargetAction `json:"recoveryTargetAction,omitempty"`
 }
 
+// RecoveryTargetAction is the action PostgreSQL takes once
+// the recovery target is reached
+type RecoveryTargetAction string
+
+const (
+	// RecoveryTargetActionPromote ends the recovery and promotes the server
+	RecoveryTargetActionPromote = RecoveryTargetAction("promote")
+
+	// RecoveryTargetActionPause pauses the recovery until pg_wal_replay_resume()
+	// is called, then the server is promoted
+	RecoveryTargetActionPause = RecoveryTargetAction("pause")
+)
+
 // StorageConfiguration is the configuration used to create and reconcile PVCs,
 // usable for WAL volumes, PGDATA volumes, or tablespaces
 type StorageConfiguration struct {
@@ -1996,6 +2360,22 @@ type BackupConfiguration struct {
 	// +kubebuilder:default:=prefer-standby
 	// +optional
 	Target BackupTarget `json:"target,omitempty"`
+
+	// The configuration of the periodic verification of the base backups
+	// stored in the object store
+	// +optional
+	Verification *BackupVerificationConfiguration `json:"verification,omitempty"`
+}
+
+// BackupVerificationConfiguration contains the configuration of the
+// verification of the base backups stored in the object store
+type BackupVerificationConfiguration struct {
+	// The schedule of the verification of the latest completed base backup,
+	// in Cron format, see https://pkg.go.dev/github.com/robfig/cron#hdr-CRON_Expression_Format.
+	// The verification restores the backup in a throwaway Pod, replaying
+	// the WAL files required to reach a consistent state, without ever
+	// writing to the object store
+	Schedule string `json:"schedule"`
 }
 
 // MonitoringConfiguration is the type containing all the monitoring
@@ -2055,6 +2435,14 @@ type ExternalCluster struct {
 	// +optional
 	ConnectionParameters map[string]string `json:"connectionParameters,omitempty"`
 
+	// The reference to a secret whose keys, used as libpq keywords, are
+	// merged into the connection parameters when connecting to the server.
+	// The values in `connectionParameters` take precedence over the ones in
+	// the secret, which cannot contain the `sslcert`, `sslkey`, `sslrootcert`
+	// and `passfile` keys, as they are managed by the operator
+	// +optional
+	ConnectionParametersSecret *LocalObjectReference `json:"connectionParametersSecret,omitempty"`
+
 	// The reference to an SSL certificate to be used to connect to this
 	// instance
 	// +optional
@@ -2101,7 +2489,7 @@ const (
 
 // ServiceSelectorType describes a valid value for generating the service selectors.
 // It indicates which type of service the selector applies to, such as read-write, read, or read-only
-// +kubebuilder:validation:Enum=rw;r;ro
+// +kubebuilder:validation:Enum=rw;r;ro;ro-fresh
 type ServiceSelectorType string
 
 // Constants representing the valid values for ServiceSelectorType.
@@ -2112,6 +2500,10 @@ const (
 	ServiceSelectorTypeR ServiceSelectorType = "r"
 	// ServiceSelectorTypeRO selects the read-only service.
 	ServiceSelectorTypeRO ServiceSelectorType = "ro"
+	// ServiceSelectorTypeROFresh selects the replicas whose replication lag is
+	// within the maximum lag of the fresh replicas. It is only valid for the
+	// additional managed services.
+	ServiceSelectorTypeROFresh ServiceSelectorType = "ro-fresh"
 )
 
 // ServiceUpdateStrategy describes how the changes to the managed service should be handled
@@ -2134,13 +2526,19 @@ type ManagedServices struct {
 	// Additional is a list of additional managed services specified by the user.
 	// +optional
 	Additional []ManagedService `json:"additional,omitempty"`
+	// FreshReplicaMaxLag is the maximum replication lag of a replica for it
+	// to be selected by the additional services of type "ro-fresh".
+	// Defaults to 16Mi
+	// +optional
+	FreshReplicaMaxLag *resource.Quantity `json:"freshReplicaMaxLag,omitempty"`
 }
 
 // ManagedService represents a specific service managed by the cluster.
 // It includes the type of service and its associated template specification.
 type ManagedService struct {
 	// SelectorType specifies the type of selectors that the service will have.
-	// Valid values are "rw", "r", and "ro", representing read-write, read, and read-only services.
+	// Valid values are "rw", "r", "ro", and "ro-fresh", representing read-write, read,
+	// read-only, and read-only on the replicas with a low replication lag services.
 	SelectorType ServiceSelectorType `json:"selectorType"`
 
 	// UpdateStrategy describes how the service differences should be reconciled
@@ -2161,6 +2559,10 @@ type ManagedConfiguration struct {
 	// Services roles managed by the `Cluster`
 	// +optional
 	Services *ManagedServices `json:"services,omitempty"`
+	// Privileges on the objects of the application database granted
+	// to the roles, managed by the `Cluster`
+	// +optional
+	Grants []GrantConfiguration `json:"grants,omitempty"`
 }
 
 // PluginConfiguration specifies a plugin that need to be loaded for this
@@ -2310,6 +2712,62 @@ type RoleConfiguration struct {
 	BypassRLS bool `json:"bypassrls,omitempty"` // Row-Level Security
 }
 
+// GrantObjectType is the type of database object a privilege is granted on
+// +kubebuilder:validation:Enum=schema;table;sequence
+type GrantObjectType string
+
+// values taken by GrantObjectType
+const (
+	GrantObjectTypeSchema   GrantObjectType = "schema"
+	GrantObjectTypeTable    GrantObjectType = "table"
+	GrantObjectTypeSequence GrantObjectType = "sequence"
+)
+
+// GrantPrivilege is a privilege that can be granted on a database object
+// +kubebuilder:validation:Enum=SELECT;INSERT;UPDATE;DELETE;TRUNCATE;REFERENCES;TRIGGER;USAGE;CREATE;EXECUTE
+type GrantPrivilege string
+
+// values taken by GrantPrivilege
+const (
+	GrantPrivilegeSelect     GrantPrivilege = "SELECT"
+	GrantPrivilegeInsert     GrantPrivilege = "INSERT"
+	GrantPrivilegeUpdate     GrantPrivilege = "UPDATE"
+	GrantPrivilegeDelete     GrantPrivilege = "DELETE"
+	GrantPrivilegeTruncate   GrantPrivilege = "TRUNCATE"
+	GrantPrivilegeReferences GrantPrivilege = "REFERENCES"
+	GrantPrivilegeTrigger    GrantPrivilege = "TRIGGER"
+	GrantPrivilegeUsage      GrantPrivilege = "USAGE"
+	GrantPrivilegeCreate     GrantPrivilege = "CREATE"
+	GrantPrivilegeExecute    GrantPrivilege = "EXECUTE"
+)
+
+// GrantConfiguration is the set of privileges a role holds on a schema,
+// a table or a sequence of the application database. The declared
+// privileges are authoritative: the missing ones are granted and the
+// ones not listed are revoked.
+//
+// Reference: https://www.postgresql.org/docs/current/sql-grant.html
+type GrantConfiguration struct {
+	// Name of the role the privileges are granted to
+	Role string `json:"role"`
+
+	// The type of the object the privileges are granted on
+	ObjectType GrantObjectType `json:"objectType"`
+
+	// The schema, or the schema containing the table or the sequence
+	Schema string `json:"schema"`
+
+	// The name of the table or the sequence. Must be empty when
+	// `objectType` is `schema`
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// The privileges the role holds on the object. An empty list
+	// revokes every privilege
+	// +optional
+	Privileges []GrantPrivilege `json:"privileges,omitempty"`
+}
+
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
@@ -2405,6 +2863,10 @@ type ConfigMapResourceVersion struct {
 	// Map keys are the config map names, map values are the versions
 	// +optional
 	Metrics map[string]string `json:"metrics,omitempty"`
+
+	// The version of the config map included in the PostgreSQL configuration
+	// +optional
+	PostgresqlInclude string `json:"postgresqlInclude,omitempty"`
 }
 
 func init() {
@@ -70,3 +70,41 @@ func (dbList *DatabaseList) MustHaveManagedResourceExclusivity(reference *Databa
 	pointers := toSliceWithPointers(dbList.Items)
 	return ensureManagedResourceExclusivity(reference, pointers)
 }
+
+// GetSupportedPrivileges returns the privileges that can be granted
+// by default on the objects of this type
+func (objectType DefaultPrivilegesObjectType) GetSupportedPrivileges() []GrantPrivilege {
+	switch objectType {
+	case DefaultPrivilegesObjectTypeTables:
+		return GrantObjectTypeTable.GetSupportedPrivileges()
+	case DefaultPrivilegesObjectTypeSequences:
+		return GrantObjectTypeSequence.GetSupportedPrivileges()
+	case DefaultPrivilegesObjectTypeFunctions:
+		return []GrantPrivilege{GrantPrivilegeExecute}
+	case DefaultPrivilegesObjectTypeTypes:
+		return []GrantPrivilege{GrantPrivilegeUsage}
+	case DefaultPrivilegesObjectTypeSchemas:
+		return GrantObjectTypeSchema.GetSupportedPrivileges()
+	default:
+		return nil
+	}
+}
+
+// GetCatalogObjectType returns the code used by the `pg_default_acl`
+// catalog for the objects of this type
+func (objectType DefaultPrivilegesObjectType) GetCatalogObjectType() string {
+	switch objectType {
+	case DefaultPrivilegesObjectTypeTables:
+		return "r"
+	case DefaultPrivilegesObjectTypeSequences:
+		return "S"
+	case DefaultPrivilegesObjectTypeFunctions:
+		return "f"
+	case DefaultPrivilegesObjectTypeTypes:
+		return "T"
+	case DefaultPrivilegesObjectTypeSchemas:
+		return "n"
+	default:
+		return ""
+	}
+}
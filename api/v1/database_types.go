@@ -162,6 +162,91 @@ type DatabaseSpec struct {
 	// +kubebuilder:default:=retain
 	// +optional
 	ReclaimPolicy DatabaseReclaimPolicy `json:"databaseReclaimPolicy,omitempty"`
+
+	// The list of extensions to be managed in the database
+	// +optional
+	Extensions []ExtensionSpec `json:"extensions,omitempty"`
+
+	// The privileges automatically granted on the objects that will
+	// be created in the database
+	// +optional
+	DefaultPrivileges []DefaultPrivilegesConfiguration `json:"defaultPrivileges,omitempty"`
+}
+
+// DefaultPrivilegesObjectType is the type of the objects default privileges
+// are applied to
+// +kubebuilder:validation:Enum=tables;sequences;functions;types;schemas
+type DefaultPrivilegesObjectType string
+
+// values taken by DefaultPrivilegesObjectType
+const (
+	DefaultPrivilegesObjectTypeTables    DefaultPrivilegesObjectType = "tables"
+	DefaultPrivilegesObjectTypeSequences DefaultPrivilegesObjectType = "sequences"
+	DefaultPrivilegesObjectTypeFunctions DefaultPrivilegesObjectType = "functions"
+	DefaultPrivilegesObjectTypeTypes     DefaultPrivilegesObjectType = "types"
+	DefaultPrivilegesObjectTypeSchemas   DefaultPrivilegesObjectType = "schemas"
+)
+
+// DefaultPrivilegesConfiguration is the set of privileges a role is
+// automatically granted on the objects of a type created by another role,
+// built around the `ALTER DEFAULT PRIVILEGES` SQL command of PostgreSQL.
+// The declared privileges are authoritative: the missing ones are granted
+// and the ones not listed are revoked.
+//
+// Reference: https://www.postgresql.org/docs/current/sql-alterdefaultprivileges.html
+type DefaultPrivilegesConfiguration struct {
+	// Maps to the `FOR ROLE` clause of `ALTER DEFAULT PRIVILEGES`.
+	// The name of the role creating the objects.
+	Grantor string `json:"grantor"`
+
+	// The name of the role the privileges are granted to
+	Grantee string `json:"grantee"`
+
+	// The type of the objects the privileges are granted on
+	ObjectType DefaultPrivilegesObjectType `json:"objectType"`
+
+	// Maps to the `IN SCHEMA` clause of `ALTER DEFAULT PRIVILEGES`.
+	// The schema the objects are created in. If empty, the privileges
+	// are granted on the objects created in any schema. Must be empty
+	// when `objectType` is `schemas`
+	// +optional
+	Schema string `json:"schema,omitempty"`
+
+	// The privileges granted on the objects. An empty list revokes
+	// every privilege
+	// +optional
+	Privileges []GrantPrivilege `json:"privileges,omitempty"`
+}
+
+// ExtensionSpec configures an extension in a database, built around the
+// `CREATE EXTENSION`, `ALTER EXTENSION`, and `DROP EXTENSION` SQL commands
+// of PostgreSQL.
+type ExtensionSpec struct {
+	// The name of the extension, as listed in the
+	// `pg_available_extensions` view of PostgreSQL.
+	Name string `json:"name"`
+
+	// Ensure the extension is `present` or `absent` in the database -
+	// defaults to "present".
+	// +kubebuilder:default:="present"
+	// +kubebuilder:validation:Enum=present;absent
+	// +optional
+	Ensure EnsureOption `json:"ensure,omitempty"`
+
+	// Maps to the `VERSION` parameter of `CREATE EXTENSION`.
+	// Maps to the `UPDATE TO` command of `ALTER EXTENSION`.
+	// The version of the extension to install. If empty, the default
+	// version of the extension is installed, and the extension is
+	// never updated.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Maps to the `SCHEMA` parameter of `CREATE EXTENSION`.
+	// Maps to the `SET SCHEMA` command of `ALTER EXTENSION`.
+	// The name of the schema in which to install the objects of the
+	// extension. If empty, the current default creation schema is used.
+	// +optional
+	Schema string `json:"schema,omitempty"`
 }
 
 // DatabaseStatus defines the observed state of Database
@@ -41,6 +41,16 @@ func (scheduledBackup ScheduledBackup) IsImmediate() bool {
 	return *scheduledBackup.Spec.Immediate
 }
 
+// IsSkippedWhenUnavailable checks if the scheduled backups have to be
+// skipped when the cluster is under maintenance or not healthy
+func (scheduledBackup ScheduledBackup) IsSkippedWhenUnavailable() bool {
+	if scheduledBackup.Spec.SkipWhenUnavailable == nil {
+		return false
+	}
+
+	return *scheduledBackup.Spec.SkipWhenUnavailable
+}
+
 // GetName gets the scheduled backup name
 func (scheduledBackup *ScheduledBackup) GetName() string {
 	return scheduledBackup.Name
@@ -78,6 +78,12 @@ type ScheduledBackupSpec struct {
 	// Overrides the default settings specified in the cluster '.backup.volumeSnapshot.onlineConfiguration' stanza
 	// +optional
 	OnlineConfiguration *OnlineConfiguration `json:"onlineConfiguration,omitempty"`
+
+	// When enabled, a scheduled backup is skipped, instead of being created,
+	// if the cluster is under maintenance or is not healthy. Skipped backups
+	// are recorded in the status. Defaults to: `false`.
+	// +optional
+	SkipWhenUnavailable *bool `json:"skipWhenUnavailable,omitempty"`
 }
 
 // ScheduledBackupStatus defines the observed state of ScheduledBackup
@@ -93,6 +99,15 @@ type ScheduledBackupStatus struct {
 	// Next time we will run a backup
 	// +optional
 	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// The last time a scheduled backup was skipped because the cluster
+	// was unavailable
+	// +optional
+	LastSkippedTime *metav1.Time `json:"lastSkippedTime,omitempty"`
+
+	// The reason why the last scheduled backup was skipped
+	// +optional
+	LastSkippedReason string `json:"lastSkippedReason,omitempty"`
 }
 
 // +genclient
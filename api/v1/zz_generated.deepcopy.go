@@ -134,6 +134,11 @@ func (in *BackupConfiguration) DeepCopyInto(out *BackupConfiguration) {
 		*out = new(pkgapi.BarmanObjectStoreConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(BackupVerificationConfiguration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfiguration.
@@ -304,6 +309,16 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 		in, out := &in.StoppedAt, &out.StoppedAt
 		*out = (*in).DeepCopy()
 	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.BackupSize != nil {
+		in, out := &in.BackupSize, &out.BackupSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	if in.BackupLabelFile != nil {
 		in, out := &in.BackupLabelFile, &out.BackupLabelFile
 		*out = make([]byte, len(*in))
@@ -344,6 +359,44 @@ func (in *BackupStatus) DeepCopy() *BackupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationConfiguration) DeepCopyInto(out *BackupVerificationConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationConfiguration.
+func (in *BackupVerificationConfiguration) DeepCopy() *BackupVerificationConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationStatus) DeepCopyInto(out *BackupVerificationStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.StoppedAt != nil {
+		in, out := &in.StoppedAt, &out.StoppedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationStatus.
+func (in *BackupVerificationStatus) DeepCopy() *BackupVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BootstrapConfiguration) DeepCopyInto(out *BootstrapConfiguration) {
 	*out = *in
@@ -935,6 +988,16 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.LastRetentionRun != nil {
+		in, out := &in.LastRetentionRun, &out.LastRetentionRun
+		*out = new(RetentionRunStatus)
+		**out = **in
+	}
+	if in.LastBackupVerification != nil {
+		in, out := &in.LastBackupVerification, &out.LastBackupVerification
+		*out = new(BackupVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PoolerIntegrations != nil {
 		in, out := &in.PoolerIntegrations, &out.PoolerIntegrations
 		*out = new(PoolerIntegrations)
@@ -965,6 +1028,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		}
 	}
 	out.SwitchReplicaClusterStatus = in.SwitchReplicaClusterStatus
+	if in.Import != nil {
+		in, out := &in.Import, &out.Import
+		*out = new(ImportStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -1120,6 +1188,18 @@ func (in *DatabaseSpec) DeepCopyInto(out *DatabaseSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]ExtensionSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultPrivileges != nil {
+		in, out := &in.DefaultPrivileges, &out.DefaultPrivileges
+		*out = make([]DefaultPrivilegesConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSpec.
@@ -1152,6 +1232,26 @@ func (in *DatabaseStatus) DeepCopy() *DatabaseStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultPrivilegesConfiguration) DeepCopyInto(out *DefaultPrivilegesConfiguration) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]GrantPrivilege, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultPrivilegesConfiguration.
+func (in *DefaultPrivilegesConfiguration) DeepCopy() *DefaultPrivilegesConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultPrivilegesConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmbeddedObjectMetadata) DeepCopyInto(out *EmbeddedObjectMetadata) {
 	*out = *in
@@ -1206,6 +1306,21 @@ func (in *EphemeralVolumesSizeLimitConfiguration) DeepCopy() *EphemeralVolumesSi
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionSpec) DeepCopyInto(out *ExtensionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionSpec.
+func (in *ExtensionSpec) DeepCopy() *ExtensionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalCluster) DeepCopyInto(out *ExternalCluster) {
 	*out = *in
@@ -1216,6 +1331,11 @@ func (in *ExternalCluster) DeepCopyInto(out *ExternalCluster) {
 			(*out)[key] = val
 		}
 	}
+	if in.ConnectionParametersSecret != nil {
+		in, out := &in.ConnectionParametersSecret, &out.ConnectionParametersSecret
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
 	if in.SSLCert != nil {
 		in, out := &in.SSLCert, &out.SSLCert
 		*out = new(corev1.SecretKeySelector)
@@ -1258,6 +1378,98 @@ func (in *ExternalCluster) DeepCopy() *ExternalCluster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantConfiguration) DeepCopyInto(out *GrantConfiguration) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]GrantPrivilege, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantConfiguration.
+func (in *GrantConfiguration) DeepCopy() *GrantConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HBARule) DeepCopyInto(out *HBARule) {
+	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HBARule.
+func (in *HBARule) DeepCopy() *HBARule {
+	if in == nil {
+		return nil
+	}
+	out := new(HBARule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentMap) DeepCopyInto(out *IdentMap) {
+	*out = *in
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = make([]IdentMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentMap.
+func (in *IdentMap) DeepCopy() *IdentMap {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentMapping) DeepCopyInto(out *IdentMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentMapping.
+func (in *IdentMapping) DeepCopy() *IdentMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageCatalog) DeepCopyInto(out *ImageCatalog) {
 	*out = *in
@@ -1371,6 +1583,16 @@ func (in *Import) DeepCopyInto(out *Import) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExcludeTables != nil {
+		in, out := &in.ExcludeTables, &out.ExcludeTables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeSchemas != nil {
+		in, out := &in.ExcludeSchemas, &out.ExcludeSchemas
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.PgDumpExtraOptions != nil {
 		in, out := &in.PgDumpExtraOptions, &out.PgDumpExtraOptions
 		*out = make([]string, len(*in))
@@ -1408,6 +1630,26 @@ func (in *ImportSource) DeepCopy() *ImportSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportStatus) DeepCopyInto(out *ImportStatus) {
+	*out = *in
+	if in.CopyingTables != nil {
+		in, out := &in.CopyingTables, &out.CopyingTables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportStatus.
+func (in *ImportStatus) DeepCopy() *ImportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceID) DeepCopyInto(out *InstanceID) {
 	*out = *in
@@ -1513,6 +1755,13 @@ func (in *ManagedConfiguration) DeepCopyInto(out *ManagedConfiguration) {
 		*out = new(ManagedServices)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]GrantConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedConfiguration.
@@ -1610,6 +1859,11 @@ func (in *ManagedServices) DeepCopyInto(out *ManagedServices) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FreshReplicaMaxLag != nil {
+		in, out := &in.FreshReplicaMaxLag, &out.FreshReplicaMaxLag
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedServices.
@@ -2156,6 +2410,20 @@ func (in *PostgresConfiguration) DeepCopyInto(out *PostgresConfiguration) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.HBARules != nil {
+		in, out := &in.HBARules, &out.HBARules
+		*out = make([]HBARule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IdentMaps != nil {
+		in, out := &in.IdentMaps, &out.IdentMaps
+		*out = make([]IdentMap, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.SyncReplicaElectionConstraint.DeepCopyInto(&out.SyncReplicaElectionConstraint)
 	if in.AdditionalLibraries != nil {
 		in, out := &in.AdditionalLibraries, &out.AdditionalLibraries
@@ -2167,6 +2435,11 @@ func (in *PostgresConfiguration) DeepCopyInto(out *PostgresConfiguration) {
 		*out = new(LDAPConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IncludeConfigMap != nil {
+		in, out := &in.IncludeConfigMap, &out.IncludeConfigMap
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresConfiguration.
@@ -2217,6 +2490,11 @@ func (in *ProbesConfiguration) DeepCopyInto(out *ProbesConfiguration) {
 		*out = new(Probe)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ReadinessRequiresStreaming != nil {
+		in, out := &in.ReadinessRequiresStreaming, &out.ReadinessRequiresStreaming
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbesConfiguration.
@@ -2489,6 +2767,21 @@ func (in *ReplicationSlotsHAConfiguration) DeepCopy() *ReplicationSlotsHAConfigu
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetentionRunStatus) DeepCopyInto(out *RetentionRunStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionRunStatus.
+func (in *RetentionRunStatus) DeepCopy() *RetentionRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RetentionRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleConfiguration) DeepCopyInto(out *RoleConfiguration) {
 	*out = *in
@@ -2636,6 +2929,11 @@ func (in *ScheduledBackupSpec) DeepCopyInto(out *ScheduledBackupSpec) {
 		*out = new(OnlineConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SkipWhenUnavailable != nil {
+		in, out := &in.SkipWhenUnavailable, &out.SkipWhenUnavailable
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackupSpec.
@@ -2663,6 +2961,10 @@ func (in *ScheduledBackupStatus) DeepCopyInto(out *ScheduledBackupStatus) {
 		in, out := &in.NextScheduleTime, &out.NextScheduleTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastSkippedTime != nil {
+		in, out := &in.LastSkippedTime, &out.LastSkippedTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledBackupStatus.
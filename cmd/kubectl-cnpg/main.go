@@ -29,6 +29,8 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/backup"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/certificate"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/cluster"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/debug"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/destroy"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/fence"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/fio"
@@ -40,13 +42,17 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/maintenance"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/pgadmin"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/pgbench"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/pgbouncer"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/promote"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/promotiontoken"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/psql"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/reload"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/report"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/restart"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/snapshot"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/status"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/wait"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/walstatus"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/versions"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -112,6 +118,8 @@ func main() {
 	subcommands := []*cobra.Command{
 		backup.NewCmd(),
 		certificate.NewCmd(),
+		cluster.NewCmd(),
+		debug.NewCmd(),
 		destroy.NewCmd(),
 		fence.NewCmd(),
 		fio.NewCmd(),
@@ -121,7 +129,9 @@ func main() {
 		maintenance.NewCmd(),
 		pgadmin.NewCmd(),
 		pgbench.NewCmd(),
+		pgbouncer.NewCmd(),
 		promote.NewCmd(),
+		promotiontoken.NewCmd(),
 		psql.NewCmd(),
 		publication.NewCmd(),
 		reload.NewCmd(),
@@ -131,6 +141,8 @@ func main() {
 		status.NewCmd(),
 		subscription.NewCmd(),
 		versions.NewCmd(),
+		wait.NewCmd(),
+		walstatus.NewCmd(),
 	}
 
 	for _, cmd := range subcommands {
@@ -21,22 +21,39 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"os"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	"github.com/spf13/cobra"
 
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
 )
 
 // NewCmd create a new cobra command
 func NewCmd() *cobra.Command {
+	var serverName string
+
 	cmd := cobra.Command{
 		Use: "backup [backup_name]",
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			if !cmd.Flags().Changed("server-name") {
+				return nil
+			}
+			return postgres.ValidateBackupServerName(serverName)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			contextLogger := log.FromContext(cmd.Context())
 			backupURL := url.Local(url.PathPgBackup, url.LocalPort)
-			resp, err := http.Get(backupURL + "?name=" + args[0])
+
+			query := neturl.Values{}
+			query.Set("name", args[0])
+			if cmd.Flags().Changed("server-name") {
+				query.Set("serverName", serverName)
+			}
+
+			resp, err := http.Get(backupURL + "?" + query.Encode())
 			if err != nil {
 				contextLogger.Error(err, "Error while requesting backup")
 				return err
@@ -82,5 +99,9 @@ func NewCmd() *cobra.Command {
 		Args: cobra.ExactArgs(1),
 	}
 
+	cmd.Flags().StringVar(&serverName, "server-name", "",
+		"Store the backup under this server name in the object store, "+
+			"instead of the one configured in the cluster")
+
 	return &cmd
 }
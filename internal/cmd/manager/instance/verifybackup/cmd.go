@@ -0,0 +1,98 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package verifybackup implements the "instance verify-backup" subcommand of the operator
+package verifybackup
+
+import (
+	"context"
+	"os"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/spf13/cobra"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/istio"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/management/linkerd"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+)
+
+// NewCmd creates the "verify-backup" subcommand
+func NewCmd() *cobra.Command {
+	var (
+		clusterName string
+		namespace   string
+		pgData      string
+		backupName  string
+	)
+
+	cmd := &cobra.Command{
+		Use:           "verify-backup [flags]",
+		SilenceErrors: true,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return management.WaitForGetCluster(cmd.Context(), ctrl.ObjectKey{
+				Name:      clusterName,
+				Namespace: namespace,
+			})
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			contextLogger := log.FromContext(ctx)
+
+			info := postgres.InitInfo{
+				ClusterName: clusterName,
+				Namespace:   namespace,
+				PgData:      pgData,
+			}
+
+			err := execute(ctx, info, backupName)
+			if err != nil {
+				contextLogger.Error(err, "Error while verifying the backup", "backupName", backupName)
+			}
+			return err
+		},
+		PostRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := istio.TryInvokeQuitEndpoint(cmd.Context()); err != nil {
+				return err
+			}
+
+			return linkerd.TryInvokeShutdownEndpoint(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVar(&clusterName, "cluster-name", os.Getenv("CLUSTER_NAME"), "The name of the "+
+		"cluster whose backup should be verified")
+	cmd.Flags().StringVar(&namespace, "namespace", os.Getenv("NAMESPACE"), "The namespace of "+
+		"the cluster")
+	cmd.Flags().StringVar(&pgData, "pg-data", os.Getenv("PGDATA"), "The PGDATA where the backup is restored")
+	cmd.Flags().StringVar(&backupName, "backup-name", "", "The name of the Backup to be verified")
+
+	return cmd
+}
+
+func execute(ctx context.Context, info postgres.InitInfo, backupName string) error {
+	typedClient, err := management.NewControllerRuntimeClient()
+	if err != nil {
+		return err
+	}
+
+	if err := info.EnsureTargetDirectoriesDoNotExist(ctx); err != nil {
+		return err
+	}
+
+	return info.VerifyBackup(ctx, typedClient, backupName)
+}
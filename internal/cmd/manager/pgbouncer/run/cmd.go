@@ -26,6 +26,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/execlog"
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -42,6 +43,7 @@ import (
 func NewCmd() *cobra.Command {
 	var (
 		poolerNamespacedName types.NamespacedName
+		drainTimeout         time.Duration
 
 		errorMissingPoolerNamespacedName = fmt.Errorf("missing pooler name or namespace")
 	)
@@ -49,6 +51,7 @@ func NewCmd() *cobra.Command {
 	const (
 		poolerNameEnvVar      = "POOLER_NAME"
 		poolerNamespaceEnvVar = "NAMESPACE"
+		drainTimeoutEnvVar    = "DRAIN_TIMEOUT"
 	)
 
 	cmd := &cobra.Command{
@@ -71,7 +74,7 @@ func NewCmd() *cobra.Command {
 			)
 			contextLogger := log.FromContext(ctx)
 
-			if err := runSubCommand(ctx, poolerNamespacedName); err != nil {
+			if err := runSubCommand(ctx, poolerNamespacedName, drainTimeout); err != nil {
 				contextLogger.Error(err, "Error while running manager")
 				return err
 			}
@@ -91,11 +94,43 @@ func NewCmd() *cobra.Command {
 		os.Getenv(poolerNamespaceEnvVar),
 		"The namespace of the cluster and of the Pod in k8s. "+
 			"Defaults to the value of the NAMESPACE environment variable")
+	cmd.Flags().DurationVar(
+		&drainTimeout,
+		"drain-timeout",
+		getDefaultDrainTimeout(os.Getenv(drainTimeoutEnvVar)),
+		"The maximum time to wait for the client connections to be drained when "+
+			"pgbouncer is being shut down, before terminating it immediately. "+
+			"Set it to zero to disable draining. "+
+			"Defaults to the value of the DRAIN_TIMEOUT environment variable, or 25s")
 
 	return cmd
 }
 
-func runSubCommand(ctx context.Context, poolerNamespacedName types.NamespacedName) error {
+// defaultDrainTimeout is the default time allowed for pgbouncer to drain the
+// connections. It is lower than the default termination grace period of
+// a Pod, to leave the time for an immediate shutdown if draining fails
+const defaultDrainTimeout = 25 * time.Second
+
+// getDefaultDrainTimeout parses the drain timeout passed via the environment,
+// falling back to the default one when missing or invalid
+func getDefaultDrainTimeout(value string) time.Duration {
+	if value == "" {
+		return defaultDrainTimeout
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		return defaultDrainTimeout
+	}
+
+	return timeout
+}
+
+func runSubCommand(
+	ctx context.Context,
+	poolerNamespacedName types.NamespacedName,
+	drainTimeout time.Duration,
+) error {
 	var err error
 
 	contextLogger := log.FromContext(ctx)
@@ -134,7 +169,7 @@ func runSubCommand(ctx context.Context, poolerNamespacedName types.NamespacedNam
 	}
 
 	startReconciler(ctx, reconciler)
-	registerSignalHandler(ctx, reconciler, pgBouncerCmd)
+	registerSignalHandler(ctx, reconciler, pgBouncerCmd, drainTimeout)
 
 	if err = streamingCmd.Wait(); err != nil {
 		var exitError *exec.ExitError
@@ -149,9 +184,14 @@ func runSubCommand(ctx context.Context, poolerNamespacedName types.NamespacedNam
 	return nil
 }
 
-// registerSignalHandler handles signals from k8s, notifying postgres as
+// registerSignalHandler handles signals from k8s, notifying pgbouncer as
 // needed
-func registerSignalHandler(ctx context.Context, reconciler *controller.PgBouncerReconciler, command *exec.Cmd) {
+func registerSignalHandler(
+	ctx context.Context,
+	reconciler *controller.PgBouncerReconciler,
+	command *exec.Cmd,
+	drainTimeout time.Duration,
+) {
 	contextLogger := log.FromContext(ctx)
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
@@ -170,16 +210,48 @@ func registerSignalHandler(ctx context.Context, reconciler *controller.PgBouncer
 
 		reconciler.Stop()
 
-		if command != nil {
-			contextLogger.Info("Shutting down pgbouncer instance")
-			err := command.Process.Signal(syscall.SIGINT)
-			if err != nil {
-				contextLogger.Error(err, "Unable to send SIGINT to pgbouncer instance")
+		if command == nil {
+			return
+		}
+
+		if drainTimeout > 0 {
+			contextLogger.Info("Draining pgbouncer connections", "timeout", drainTimeout)
+			err = drainPgBouncer(ctx, reconciler, drainTimeout)
+			if err == nil {
+				contextLogger.Info("Pgbouncer connections drained, instance shut down")
+				return
+			}
+			contextLogger.Error(err, "Unable to drain pgbouncer connections, terminating immediately")
+
+			// PgBouncer interprets SIGQUIT as an immediate shutdown request,
+			// while SIGTERM waits for the clients to disconnect
+			if err = command.Process.Signal(syscall.SIGQUIT); err != nil {
+				contextLogger.Error(err, "Unable to send SIGQUIT to pgbouncer instance")
 			}
+			return
+		}
+
+		contextLogger.Info("Shutting down pgbouncer instance")
+		err = command.Process.Signal(syscall.SIGINT)
+		if err != nil {
+			contextLogger.Error(err, "Unable to send SIGINT to pgbouncer instance")
 		}
 	}()
 }
 
+// drainPgBouncer gracefully drains the pgbouncer connections, giving up
+// after the passed timeout
+func drainPgBouncer(
+	ctx context.Context,
+	reconciler *controller.PgBouncerReconciler,
+	timeout time.Duration,
+) error {
+	drainCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	return reconciler.Drain(drainCtx)
+}
+
 // startWebServer start the web server for handling probes given
 // a certain PostgreSQL instance
 func startWebServer(ctx context.Context) error {
@@ -0,0 +1,35 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = DescribeTable("getDefaultDrainTimeout",
+	func(value string, expected time.Duration) {
+		Expect(getDefaultDrainTimeout(value)).To(Equal(expected))
+	},
+	Entry("uses the default when unset", "", defaultDrainTimeout),
+	Entry("parses a valid duration", "1m", time.Minute),
+	Entry("accepts zero to disable draining", "0s", time.Duration(0)),
+	Entry("uses the default when invalid", "forever", defaultDrainTimeout),
+	Entry("uses the default when negative", "-5s", defaultDrainTimeout),
+)
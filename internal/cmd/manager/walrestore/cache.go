@@ -0,0 +1,225 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package walrestore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/fileutils"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// CacheDirectory is the directory where we keep a copy of the WAL files
+// that were restored from the object store, to avoid downloading them
+// again when PostgreSQL requests them more than once
+const CacheDirectory = postgres.ScratchDataDirectory + "/wal-restore-cache"
+
+// walCache is an on-disk cache of the WAL segments restored from the
+// object store, bounded by a maximum size. Every invocation of
+// wal-restore is a separate process, so the cache is stateless and the
+// last access time of each entry is tracked via the modification time
+// of the corresponding file, which is used to evict the least recently
+// used entries first.
+//
+// Only regular WAL segments are cached: their names include the timeline,
+// so a segment coming from a different timeline is a different entry.
+// History files, partial WAL files and backup labels are never cached,
+// as their content can change or be superseded in the archive.
+type walCache struct {
+	directory string
+	maxSize   int64
+}
+
+// newWALCache creates a new WAL cache in the passed directory. A cache
+// with a maximum size lower or equal to zero is disabled
+func newWALCache(directory string, maxSize int64) *walCache {
+	return &walCache{
+		directory: directory,
+		maxSize:   maxSize,
+	}
+}
+
+// getWALCacheSize gets the maximum size of the WAL restore cache from
+// the cluster annotations. The cache is disabled if the annotation
+// is not set
+func getWALCacheSize(cluster *apiv1.Cluster) (int64, error) {
+	value, ok := cluster.Annotations[utils.WALRestoreCacheSizeAnnotationName]
+	if !ok || value == "" {
+		return 0, nil
+	}
+
+	size, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for the %s annotation: %w",
+			utils.WALRestoreCacheSizeAnnotationName, err)
+	}
+
+	return size.Value(), nil
+}
+
+// isEnabled checks if the cache should be used
+func (c *walCache) isEnabled() bool {
+	return c.maxSize > 0
+}
+
+// isCacheable checks if the passed WAL file can be stored in the cache
+func isCacheable(walName string) bool {
+	return postgres.IsWALFile(walName)
+}
+
+// Get copies the cached WAL file, if present, to the destination path,
+// marking it as recently used. Returns true if the WAL file was found
+func (c *walCache) Get(walName, destinationPath string) (bool, error) {
+	if !c.isEnabled() || !isCacheable(walName) {
+		return false, nil
+	}
+
+	cachedFile := path.Join(c.directory, walName)
+	exists, err := fileutils.FileExists(cachedFile)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	if err := copyFileAtomic(cachedFile, destinationPath); err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	if err := os.Chtimes(cachedFile, now, now); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// Put stores a copy of the passed file in the cache with the name of the
+// WAL file, and evicts the least recently used entries if the cache
+// exceeds its maximum size
+func (c *walCache) Put(walName, sourcePath string) error {
+	if !c.isEnabled() || !isCacheable(walName) {
+		return nil
+	}
+
+	if err := fileutils.EnsureDirectoryExists(c.directory); err != nil {
+		return err
+	}
+
+	if err := copyFileAtomic(sourcePath, path.Join(c.directory, walName)); err != nil {
+		return err
+	}
+
+	return c.evict()
+}
+
+// evict removes the least recently used entries from the cache until
+// its total size is lower or equal to the maximum size
+func (c *walCache) evict() error {
+	entries, err := os.ReadDir(c.directory)
+	if err != nil {
+		return err
+	}
+
+	files := make([]os.FileInfo, 0, len(entries))
+	var totalSize int64
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !isCacheable(entry.Name()) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			// The file has been removed by a concurrent eviction
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		files = append(files, info)
+		totalSize += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	for _, file := range files {
+		if totalSize <= c.maxSize {
+			break
+		}
+
+		err := os.Remove(path.Join(c.directory, file.Name()))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		totalSize -= file.Size()
+	}
+
+	return nil
+}
+
+// copyFileAtomic copies the source file to the destination path via a
+// temporary file in the destination directory, so that a reader never
+// sees a partially written file. We can't just link the files, as the
+// cache directory and the PostgreSQL data directory are usually stored
+// in different volumes
+func copyFileAtomic(sourcePath, destinationPath string) (err error) {
+	source, err := os.Open(sourcePath) //nolint:gosec
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := source.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	temporary, err := os.CreateTemp(path.Dir(destinationPath), "."+path.Base(destinationPath)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(temporary.Name())
+		}
+	}()
+
+	if _, err = io.Copy(temporary, source); err != nil {
+		_ = temporary.Close()
+		return err
+	}
+	if err = temporary.Sync(); err != nil {
+		_ = temporary.Close()
+		return err
+	}
+	if err = temporary.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(temporary.Name(), destinationPath)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package walrestore
+
+import (
+	"os"
+	"path"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WAL restore cache", func() {
+	const (
+		firstWAL  = "000000010000000000000001"
+		secondWAL = "000000010000000000000002"
+		thirdWAL  = "000000010000000000000003"
+	)
+
+	var (
+		cacheDir string
+		workDir  string
+	)
+
+	writeWAL := func(name string, size int) string {
+		fileName := path.Join(workDir, name)
+		Expect(os.WriteFile(fileName, make([]byte, size), 0o600)).To(Succeed())
+		return fileName
+	}
+
+	setAccessTime := func(walName string, accessTime time.Time) {
+		Expect(os.Chtimes(path.Join(cacheDir, walName), accessTime, accessTime)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		cacheDir = path.Join(GinkgoT().TempDir(), "cache")
+		workDir = GinkgoT().TempDir()
+	})
+
+	It("misses WAL files that were never stored", func() {
+		walCache := newWALCache(cacheDir, 1024)
+		found, err := walCache.Get(firstWAL, path.Join(workDir, "RECOVERYXLOG"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("hits WAL files that were previously stored", func() {
+		walCache := newWALCache(cacheDir, 1024)
+		Expect(walCache.Put(firstWAL, writeWAL(firstWAL, 10))).To(Succeed())
+
+		destination := path.Join(workDir, "RECOVERYXLOG")
+		found, err := walCache.Get(firstWAL, destination)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(destination).To(BeARegularFile())
+
+		found, err = walCache.Get(secondWAL, destination)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("doesn't cache WAL files of a different timeline", func() {
+		walCache := newWALCache(cacheDir, 1024)
+		Expect(walCache.Put(firstWAL, writeWAL(firstWAL, 10))).To(Succeed())
+
+		found, err := walCache.Get("000000020000000000000001", path.Join(workDir, "RECOVERYXLOG"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("never caches history and partial files", func() {
+		walCache := newWALCache(cacheDir, 1024)
+		for _, name := range []string{"00000002.history", firstWAL + ".partial"} {
+			Expect(walCache.Put(name, writeWAL(name, 10))).To(Succeed())
+			Expect(path.Join(cacheDir, name)).ToNot(BeAnExistingFile())
+
+			found, err := walCache.Get(name, path.Join(workDir, "RECOVERYXLOG"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		}
+	})
+
+	It("does nothing when disabled", func() {
+		walCache := newWALCache(cacheDir, 0)
+		Expect(walCache.Put(firstWAL, writeWAL(firstWAL, 10))).To(Succeed())
+		Expect(cacheDir).ToNot(BeADirectory())
+	})
+
+	It("evicts the least recently used WAL files when full", func() {
+		walCache := newWALCache(cacheDir, 20)
+		now := time.Now()
+
+		Expect(walCache.Put(firstWAL, writeWAL(firstWAL, 10))).To(Succeed())
+		setAccessTime(firstWAL, now.Add(-2*time.Minute))
+		Expect(walCache.Put(secondWAL, writeWAL(secondWAL, 10))).To(Succeed())
+		setAccessTime(secondWAL, now.Add(-time.Minute))
+
+		// Reading the first WAL file makes it the most recently used one
+		found, err := walCache.Get(firstWAL, path.Join(workDir, "RECOVERYXLOG"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		Expect(walCache.Put(thirdWAL, writeWAL(thirdWAL, 10))).To(Succeed())
+		Expect(path.Join(cacheDir, firstWAL)).To(BeARegularFile())
+		Expect(path.Join(cacheDir, secondWAL)).ToNot(BeAnExistingFile())
+		Expect(path.Join(cacheDir, thirdWAL)).To(BeARegularFile())
+	})
+
+	It("reads the maximum size from the cluster annotations", func() {
+		cluster := &apiv1.Cluster{}
+		Expect(getWALCacheSize(cluster)).To(BeZero())
+
+		cluster.ObjectMeta = metav1.ObjectMeta{
+			Annotations: map[string]string{utils.WALRestoreCacheSizeAnnotationName: "1Gi"},
+		}
+		Expect(getWALCacheSize(cluster)).To(BeEquivalentTo(1024 * 1024 * 1024))
+
+		cluster.Annotations[utils.WALRestoreCacheSizeAnnotationName] = "wrong"
+		_, err := getWALCacheSize(cluster)
+		Expect(err).To(HaveOccurred())
+	})
+})
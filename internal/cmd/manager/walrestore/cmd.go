@@ -159,6 +159,27 @@ func run(ctx context.Context, pgData string, podName string, args []string) erro
 		return fmt.Errorf("while creating the restorer: %w", err)
 	}
 
+	cacheSize, err := getWALCacheSize(cluster)
+	if err != nil {
+		contextLog.Warning("Disabling the WAL restore cache", "error", err.Error())
+	}
+	walCache := newWALCache(CacheDirectory, cacheSize)
+
+	// Step 0: check if this WAL file has already been restored
+	// and is still in the local cache
+	var wasInCache bool
+	if wasInCache, err = walCache.Get(walName, destinationPath); err != nil {
+		contextLog.Warning("Error while restoring a file from the WAL cache, ignoring",
+			"walName", walName, "error", err.Error())
+	}
+	if wasInCache {
+		contextLog.Info("Restored WAL file from cache",
+			"walName", walName,
+			"currentPrimary", cluster.Status.CurrentPrimary,
+			"targetPrimary", cluster.Status.TargetPrimary)
+		return nil
+	}
+
 	// Step 1: check if this WAL file is not already in the spool
 	var wasInSpool bool
 	if wasInSpool, err = walRestorer.RestoreFromSpool(walName, destinationPath); err != nil {
@@ -169,6 +190,7 @@ func run(ctx context.Context, pgData string, podName string, args []string) erro
 			"walName", walName,
 			"currentPrimary", cluster.Status.CurrentPrimary,
 			"targetPrimary", cluster.Status.TargetPrimary)
+		storeInWALCache(ctx, walCache, walName, destinationPath)
 		return nil
 	}
 
@@ -206,6 +228,7 @@ func run(ctx context.Context, pgData string, podName string, args []string) erro
 	if walStatus[0].Err != nil {
 		return walStatus[0].Err
 	}
+	storeInWALCache(ctx, walCache, walName, destinationPath)
 
 	// Step 5: set end-of-wal-stream flag if any download job returned file-not-found
 	// We skip this step if streaming connection is not available
@@ -241,6 +264,15 @@ func run(ctx context.Context, pgData string, podName string, args []string) erro
 	return nil
 }
 
+// storeInWALCache adds a restored WAL file to the cache. Errors are
+// only logged, as the WAL file has already been restored
+func storeInWALCache(ctx context.Context, walCache *walCache, walName, restoredPath string) {
+	if err := walCache.Put(walName, restoredPath); err != nil {
+		log.FromContext(ctx).Warning("Error while storing a WAL file in the cache, ignoring",
+			"walName", walName, "error", err.Error())
+	}
+}
+
 // restoreWALViaPlugins requests every capable plugin to restore the passed
 // WAL file, and returns an error if every plugin failed. It will not return
 // an error if there's no plugin capable of WAL archiving too
@@ -417,7 +449,7 @@ func isStreamingAvailable(cluster *apiv1.Cluster, podName string) bool {
 			return false
 		}
 
-		return externalCluster.ConnectionParameters != nil
+		return externalCluster.HasConnectionParameters()
 	}
 
 	// Primary, we do not replicate from nobody
@@ -18,6 +18,7 @@ package backup
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strconv"
@@ -26,6 +27,7 @@ import (
 
 	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,6 +43,7 @@ type backupCommandOptions struct {
 	backupName          string
 	clusterName         string
 	target              apiv1.BackupTarget
+	instance            string
 	method              apiv1.BackupMethod
 	online              *bool
 	immediateCheckpoint *bool
@@ -63,6 +66,8 @@ func (options backupCommandOptions) getOnlineConfiguration() *apiv1.OnlineConfig
 // NewCmd creates the new "backup" subcommand
 func NewCmd() *cobra.Command {
 	var backupName, backupTarget, backupMethod, online, immediateCheckpoint, waitForArchive, pluginName string
+	var instance, target, maxReplayLag string
+	var wait bool
 	var pluginParameters pluginParameters
 
 	backupMethods := []string{
@@ -100,6 +105,30 @@ func NewCmd() *cobra.Command {
 				return fmt.Errorf("backup-target: %s is not supported by the backup command", backupTarget)
 			}
 
+			if len(instance) > 0 && len(backupTarget) > 0 {
+				return errors.New("instance and backup-target cannot be used together")
+			}
+			if len(target) > 0 {
+				if backupMethod != string(apiv1.BackupMethodVolumeSnapshot) {
+					return fmt.Errorf("target is allowed only when backup method is %s",
+						apiv1.BackupMethodVolumeSnapshot)
+				}
+				if len(instance) > 0 || len(backupTarget) > 0 {
+					return errors.New("target cannot be used together with instance or backup-target")
+				}
+				if !slices.Contains(snapshotTargets, target) {
+					return fmt.Errorf("target: %s is not supported by the backup command", target)
+				}
+			}
+			parsedMaxReplayLag, err := resource.ParseQuantity(maxReplayLag)
+			if err != nil {
+				return fmt.Errorf("while parsing the max-replay-lag value: %w", err)
+			}
+
+			if _, err := strconv.Atoi(instance); err == nil {
+				instance = fmt.Sprintf("%s-%s", clusterName, instance)
+			}
+
 			// Check if the backup method is correct
 			allowedBackupMethods := backupMethods
 			allowedBackupMethods = append(allowedBackupMethods, "")
@@ -121,7 +150,7 @@ func NewCmd() *cobra.Command {
 
 			var cluster apiv1.Cluster
 			// check if the cluster exists
-			err := plugin.Client.Get(
+			err = plugin.Client.Get(
 				cmd.Context(),
 				client.ObjectKey{
 					Namespace: plugin.Namespace,
@@ -133,6 +162,16 @@ func NewCmd() *cobra.Command {
 				return fmt.Errorf("while getting cluster %s: %w", clusterName, err)
 			}
 
+			if err := validateBackupMethod(&cluster, apiv1.BackupMethod(backupMethod)); err != nil {
+				return err
+			}
+
+			if len(instance) > 0 {
+				if err := validateBackupInstance(cmd.Context(), plugin.Client, &cluster, instance); err != nil {
+					return err
+				}
+			}
+
 			parsedOnline, err := parseOptionalBooleanString(online)
 			if err != nil {
 				return fmt.Errorf("while parsing the online value: %w", err)
@@ -146,19 +185,57 @@ func NewCmd() *cobra.Command {
 				return fmt.Errorf("while parsing the wait-for-archive value: %w", err)
 			}
 
-			return createBackup(
+			// Volume snapshots are taken from a streaming standby, unless
+			// the user chose the instance or asked for the primary
+			if backupMethod == string(apiv1.BackupMethodVolumeSnapshot) {
+				if err := validateVolumeSnapshotClasses(cmd.Context(), plugin.Client, &cluster); err != nil {
+					return err
+				}
+
+				if len(instance) == 0 && len(backupTarget) == 0 {
+					// Without an explicit target, the backup target of
+					// the cluster is honored, and a cluster without
+					// standbys can still be backed up from the primary
+					fallbackToPrimary := false
+					if len(target) == 0 {
+						target = string(getDefaultSnapshotTarget(&cluster))
+						fallbackToPrimary = true
+					}
+					source, err := getSnapshotSource(cmd.Context(), &cluster, snapshotPrecheckOptions{
+						target:              snapshotTarget(target),
+						fallbackToPrimary:   fallbackToPrimary,
+						maxReplayLagBytes:   parsedMaxReplayLag.Value(),
+						online:              parsedOnline,
+						immediateCheckpoint: parsedImmediateCheckpoint,
+					})
+					if err != nil {
+						return err
+					}
+					instance = source.instanceName
+				}
+			}
+
+			if err := createBackup(
 				cmd.Context(),
 				backupCommandOptions{
 					backupName:          backupName,
 					clusterName:         clusterName,
 					target:              apiv1.BackupTarget(backupTarget),
+					instance:            instance,
 					method:              apiv1.BackupMethod(backupMethod),
 					online:              parsedOnline,
 					immediateCheckpoint: parsedImmediateCheckpoint,
 					waitForArchive:      parsedWaitForArchive,
 					pluginName:          pluginName,
 					pluginParameters:    pluginParameters,
-				})
+				}); err != nil {
+				return err
+			}
+
+			if !wait {
+				return nil
+			}
+			return waitForBackup(cmd.Context(), plugin.Client, plugin.Namespace, backupName, 5*time.Second)
 		},
 	}
 
@@ -177,6 +254,35 @@ func NewCmd() *cobra.Command {
 		"If present, will override the backup target defined in cluster, "+
 			"valid values are primary and prefer-standby.",
 	)
+	backupSubcommand.Flags().StringVar(
+		&instance,
+		"instance",
+		"",
+		"If present, the name or the serial number of the instance that will take the backup. "+
+			"The instance must be ready and not fenced. Cannot be used together with backup-target.",
+	)
+	backupSubcommand.Flags().StringVar(
+		&target,
+		"target",
+		"",
+		"The kind of instance the volume snapshot will be taken from, valid values are "+
+			strings.Join(snapshotTargets, " and ")+". Defaults to the backup target defined in the cluster: "+
+			"with prefer-standby, a streaming standby whose replay lag is within max-replay-lag is chosen, "+
+			"or the primary when the cluster has no standby. Allowed only with the volumeSnapshot backup method, "+
+			"cannot be used together with instance or backup-target.",
+	)
+	backupSubcommand.Flags().StringVar(
+		&maxReplayLag,
+		"max-replay-lag",
+		"16Mi",
+		"The maximum replay lag of the standby the volume snapshot is taken from",
+	)
+	backupSubcommand.Flags().BoolVar(
+		&wait,
+		"wait",
+		false,
+		"Wait for the backup to be completed or failed, reporting its progress",
+	)
 	backupSubcommand.Flags().StringVarP(
 		&backupMethod,
 		"method",
@@ -220,6 +326,8 @@ func NewCmd() *cobra.Command {
 			"is allowed only when the backup method is set to 'plugin'",
 	)
 
+	backupSubcommand.AddCommand(newBackupListCmd())
+
 	return backupSubcommand
 }
 
@@ -235,6 +343,7 @@ func createBackup(ctx context.Context, options backupCommandOptions) error {
 				Name: options.clusterName,
 			},
 			Target:              options.target,
+			Instance:            options.instance,
 			Method:              options.method,
 			Online:              options.online,
 			OnlineConfiguration: options.getOnlineConfiguration(),
@@ -256,6 +365,25 @@ func createBackup(ctx context.Context, options backupCommandOptions) error {
 	return err
 }
 
+// validateBackupMethod checks that the requested backup method is
+// configured in the cluster, so that the backup can be taken
+func validateBackupMethod(cluster *apiv1.Cluster, method apiv1.BackupMethod) error {
+	switch method {
+	case apiv1.BackupMethodBarmanObjectStore:
+		if cluster.Spec.Backup == nil || cluster.Spec.Backup.BarmanObjectStore == nil {
+			return fmt.Errorf("cannot use the %s backup method: cluster %s has no "+
+				"'.spec.backup.barmanObjectStore' section", method, cluster.Name)
+		}
+	case apiv1.BackupMethodVolumeSnapshot:
+		if cluster.Spec.Backup == nil || cluster.Spec.Backup.VolumeSnapshot == nil {
+			return fmt.Errorf("cannot use the %s backup method: cluster %s has no "+
+				"'.spec.backup.volumeSnapshot' section", method, cluster.Name)
+		}
+	}
+
+	return nil
+}
+
 func parseOptionalBooleanString(rawBool string) (*bool, error) {
 	if rawBool == "" {
 		return nil, nil
@@ -0,0 +1,60 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("backup method validation", func() {
+	barmanOnlyCluster := &apiv1.Cluster{
+		Spec: apiv1.ClusterSpec{
+			Backup: &apiv1.BackupConfiguration{
+				BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{},
+			},
+		},
+	}
+	bothMethodsCluster := &apiv1.Cluster{
+		Spec: apiv1.ClusterSpec{
+			Backup: &apiv1.BackupConfiguration{
+				BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{},
+				VolumeSnapshot:    &apiv1.VolumeSnapshotConfiguration{},
+			},
+		},
+	}
+	noBackupCluster := &apiv1.Cluster{}
+
+	DescribeTable("checks the method is configured in the cluster",
+		func(cluster *apiv1.Cluster, method apiv1.BackupMethod, valid bool) {
+			err := validateBackupMethod(cluster, method)
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("no method requested", noBackupCluster, apiv1.BackupMethod(""), true),
+		Entry("barman with an object store", barmanOnlyCluster, apiv1.BackupMethodBarmanObjectStore, true),
+		Entry("snapshot without a snapshot configuration", barmanOnlyCluster, apiv1.BackupMethodVolumeSnapshot, false),
+		Entry("snapshot with both methods configured", bothMethodsCluster, apiv1.BackupMethodVolumeSnapshot, true),
+		Entry("barman without any backup configuration", noBackupCluster, apiv1.BackupMethodBarmanObjectStore, false),
+		Entry("plugin", noBackupCluster, apiv1.BackupMethodPlugin, true),
+	)
+})
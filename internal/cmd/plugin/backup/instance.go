@@ -0,0 +1,115 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// validateBackupInstance checks that the requested instance is part of
+// the cluster and is able to take a backup, i.e. it is ready and not fenced
+func validateBackupInstance(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+	instanceName string,
+) error {
+	var pod corev1.Pod
+	if err := cli.Get(
+		ctx,
+		client.ObjectKey{Namespace: cluster.Namespace, Name: instanceName},
+		&pod,
+	); err != nil {
+		return fmt.Errorf("while getting instance %s: %w", instanceName, err)
+	}
+
+	if pod.Labels[utils.ClusterLabelName] != cluster.Name {
+		return fmt.Errorf("instance %s is not part of cluster %s", instanceName, cluster.Name)
+	}
+
+	fencedInstances, err := utils.GetFencedInstances(cluster.Annotations)
+	if err != nil {
+		return fmt.Errorf("while getting the fenced instances of cluster %s: %w", cluster.Name, err)
+	}
+	if fencedInstances.Has(instanceName) || fencedInstances.Has(utils.FenceAllInstances) {
+		return fmt.Errorf("instance %s is fenced and cannot take a backup", instanceName)
+	}
+
+	if !utils.IsPodReady(pod) {
+		return fmt.Errorf("instance %s is not ready and cannot take a backup", instanceName)
+	}
+
+	return nil
+}
+
+// waitForBackup polls the backup until it is completed or failed,
+// reporting every change of phase
+func waitForBackup(
+	ctx context.Context,
+	cli client.Client,
+	namespace, backupName string,
+	pollingInterval time.Duration,
+) error {
+	ticker := time.NewTicker(pollingInterval)
+	defer ticker.Stop()
+
+	var lastPhase apiv1.BackupPhase
+	for {
+		var backup apiv1.Backup
+		if err := cli.Get(
+			ctx,
+			client.ObjectKey{Namespace: namespace, Name: backupName},
+			&backup,
+		); err != nil {
+			return fmt.Errorf("while getting backup %s: %w", backupName, err)
+		}
+
+		if backup.Status.Phase != "" && backup.Status.Phase != lastPhase {
+			lastPhase = backup.Status.Phase
+			if backup.Status.InstanceID != nil && backup.Status.InstanceID.PodName != "" {
+				fmt.Printf("backup/%s %s on instance %s\n", backupName, lastPhase, backup.Status.InstanceID.PodName)
+			} else {
+				fmt.Printf("backup/%s %s\n", backupName, lastPhase)
+			}
+		}
+
+		switch backup.Status.Phase {
+		case apiv1.BackupPhaseCompleted:
+			if backup.Status.BeginLSN != "" || backup.Status.EndLSN != "" {
+				fmt.Printf("backup/%s begin LSN: %s, end LSN: %s\n",
+					backupName, backup.Status.BeginLSN, backup.Status.EndLSN)
+			}
+			return nil
+		case apiv1.BackupPhaseFailed:
+			return fmt.Errorf("backup %s failed: %s", backupName, backup.Status.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
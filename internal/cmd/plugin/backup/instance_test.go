@@ -0,0 +1,147 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("validateBackupInstance", func() {
+	const namespace = "default"
+
+	var cluster *apiv1.Cluster
+
+	newPod := func(name, clusterName string, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{utils.ClusterLabelName: clusterName},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.ContainersReady, Status: status}},
+			},
+		}
+	}
+
+	newClient := func(objects ...client.Object) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(objects...).
+			Build()
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: namespace},
+		}
+	})
+
+	It("accepts a ready instance of the cluster", func(ctx SpecContext) {
+		cli := newClient(newPod("cluster-example-3", "cluster-example", true))
+		Expect(validateBackupInstance(ctx, cli, cluster, "cluster-example-3")).To(Succeed())
+	})
+
+	It("rejects an instance that doesn't exist", func(ctx SpecContext) {
+		cli := newClient()
+		Expect(validateBackupInstance(ctx, cli, cluster, "cluster-example-3")).ToNot(Succeed())
+	})
+
+	It("rejects an instance of another cluster", func(ctx SpecContext) {
+		cli := newClient(newPod("cluster-example-3", "another-cluster", true))
+		err := validateBackupInstance(ctx, cli, cluster, "cluster-example-3")
+		Expect(err).To(MatchError(ContainSubstring("is not part of cluster")))
+	})
+
+	It("rejects an instance that is not ready", func(ctx SpecContext) {
+		cli := newClient(newPod("cluster-example-3", "cluster-example", false))
+		err := validateBackupInstance(ctx, cli, cluster, "cluster-example-3")
+		Expect(err).To(MatchError(ContainSubstring("is not ready")))
+	})
+
+	It("rejects a fenced instance", func(ctx SpecContext) {
+		cluster.Annotations = map[string]string{
+			utils.FencedInstanceAnnotation: `["cluster-example-3"]`,
+		}
+		cli := newClient(newPod("cluster-example-3", "cluster-example", true))
+		err := validateBackupInstance(ctx, cli, cluster, "cluster-example-3")
+		Expect(err).To(MatchError(ContainSubstring("is fenced")))
+	})
+})
+
+var _ = Describe("waitForBackup", func() {
+	const namespace = "default"
+
+	newBackup := func(phase apiv1.BackupPhase, errorMessage string) *apiv1.Backup {
+		return &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-example", Namespace: namespace},
+			Status: apiv1.BackupStatus{
+				Phase: phase,
+				Error: errorMessage,
+			},
+		}
+	}
+
+	newClient := func(backup *apiv1.Backup) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(backup).
+			WithStatusSubresource(backup).
+			Build()
+	}
+
+	It("returns when the backup is completed", func(ctx SpecContext) {
+		cli := newClient(newBackup(apiv1.BackupPhaseCompleted, ""))
+		Expect(waitForBackup(ctx, cli, namespace, "backup-example", time.Millisecond)).To(Succeed())
+	})
+
+	It("reports the error of a failed backup", func(ctx SpecContext) {
+		cli := newClient(newBackup(apiv1.BackupPhaseFailed, "no space left on device"))
+		err := waitForBackup(ctx, cli, namespace, "backup-example", time.Millisecond)
+		Expect(err).To(MatchError(ContainSubstring("no space left on device")))
+	})
+
+	It("waits until the backup is completed", func(ctx SpecContext) {
+		backup := newBackup(apiv1.BackupPhaseRunning, "")
+		cli := newClient(backup)
+
+		go func() {
+			defer GinkgoRecover()
+			time.Sleep(20 * time.Millisecond)
+			backup.Status.Phase = apiv1.BackupPhaseCompleted
+			Expect(cli.Status().Update(ctx, backup)).To(Succeed())
+		}()
+
+		Expect(waitForBackup(ctx, cli, namespace, "backup-example", time.Millisecond)).To(Succeed())
+	})
+})
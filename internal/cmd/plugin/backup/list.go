@@ -0,0 +1,238 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cheynewallace/tabby"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// backupListFilter contains the criteria used to select the
+// backups to be listed
+type backupListFilter struct {
+	phase  apiv1.BackupPhase
+	method apiv1.BackupMethod
+}
+
+// backupListItem is the summary of a Backup resource
+// shown by the list command
+type backupListItem struct {
+	Name            string             `json:"name"`
+	Phase           apiv1.BackupPhase  `json:"phase,omitempty"`
+	Method          apiv1.BackupMethod `json:"method,omitempty"`
+	StartedAt       *metav1.Time       `json:"startedAt,omitempty"`
+	StoppedAt       *metav1.Time       `json:"stoppedAt,omitempty"`
+	Size            string             `json:"size,omitempty"`
+	ScheduledBackup string             `json:"scheduledBackup,omitempty"`
+}
+
+func newBackupListCmd() *cobra.Command {
+	var phase, method, output string
+
+	cmd := &cobra.Command{
+		Use:   "list CLUSTER",
+		Short: "List the backups of the cluster named CLUSTER",
+		Long: "Lists the Backup resources of the cluster, including the ones " +
+			"created by a ScheduledBackup, together with their status.",
+		Args: plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat := plugin.OutputFormat(output)
+			switch outputFormat {
+			case plugin.OutputFormatText, plugin.OutputFormatJSON, plugin.OutputFormatYAML:
+			default:
+				return fmt.Errorf("output: %s is not supported by the backup list command", output)
+			}
+
+			return backupList(
+				cmd.Context(),
+				plugin.Client,
+				args[0],
+				backupListFilter{
+					phase:  apiv1.BackupPhase(phase),
+					method: apiv1.BackupMethod(method),
+				},
+				outputFormat,
+			)
+		},
+	}
+
+	cmd.Flags().StringVar(&phase, "phase", "",
+		"If present, only the backups in this phase will be listed (i.e. completed, failed, running)")
+	cmd.Flags().StringVarP(&method, "method", "m", "",
+		"If present, only the backups taken with this method will be listed")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of text, json, or yaml")
+
+	return cmd
+}
+
+// backupList shows the backups of a cluster matching the passed filter
+func backupList(
+	ctx context.Context,
+	cli client.Client,
+	clusterName string,
+	filter backupListFilter,
+	format plugin.OutputFormat,
+) error {
+	items, err := getBackupListItems(ctx, cli, plugin.Namespace, clusterName, filter)
+	if err != nil {
+		return err
+	}
+
+	if format != plugin.OutputFormatText {
+		return plugin.Print(items, format, os.Stdout)
+	}
+
+	if len(items) == 0 {
+		fmt.Printf("No backups found for cluster %s\n", clusterName)
+		return nil
+	}
+
+	table := tabby.New()
+	table.AddHeader("Name", "Phase", "Method", "Started", "Stopped", "Size", "Scheduled Backup")
+	for _, item := range items {
+		table.AddLine(
+			item.Name,
+			item.Phase,
+			item.Method,
+			formatOptionalTime(item.StartedAt),
+			formatOptionalTime(item.StoppedAt),
+			orDash(item.Size),
+			orDash(item.ScheduledBackup),
+		)
+	}
+	table.Print()
+
+	return nil
+}
+
+// getBackupListItems gets the backups of a cluster matching the passed
+// filter, sorted by creation time
+func getBackupListItems(
+	ctx context.Context,
+	cli client.Client,
+	namespace string,
+	clusterName string,
+	filter backupListFilter,
+) ([]backupListItem, error) {
+	var backups apiv1.BackupList
+	if err := cli.List(ctx, &backups, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("while listing backups: %w", err)
+	}
+
+	var scheduledBackups apiv1.ScheduledBackupList
+	if err := cli.List(ctx, &scheduledBackups, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("while listing scheduled backups: %w", err)
+	}
+
+	var scheduledBackupNames []string
+	for _, scheduledBackup := range scheduledBackups.Items {
+		if scheduledBackup.Spec.Cluster.Name == clusterName {
+			scheduledBackupNames = append(scheduledBackupNames, scheduledBackup.Name)
+		}
+	}
+
+	clusterBackups := make([]apiv1.Backup, 0, len(backups.Items))
+	for _, backup := range backups.Items {
+		if backup.Spec.Cluster.Name == clusterName {
+			clusterBackups = append(clusterBackups, backup)
+		}
+	}
+	sort.SliceStable(clusterBackups, func(i, j int) bool {
+		if clusterBackups[i].CreationTimestamp.Equal(&clusterBackups[j].CreationTimestamp) {
+			return clusterBackups[i].Name < clusterBackups[j].Name
+		}
+		return clusterBackups[i].CreationTimestamp.Before(&clusterBackups[j].CreationTimestamp)
+	})
+
+	items := make([]backupListItem, 0, len(clusterBackups))
+	for _, backup := range clusterBackups {
+		method := backup.Status.Method
+		if method == "" {
+			method = backup.Spec.Method
+		}
+
+		if filter.phase != "" && !strings.EqualFold(string(backup.Status.Phase), string(filter.phase)) {
+			continue
+		}
+		if filter.method != "" && method != filter.method {
+			continue
+		}
+
+		item := backupListItem{
+			Name:            backup.Name,
+			Phase:           backup.Status.Phase,
+			Method:          method,
+			StartedAt:       backup.Status.StartedAt,
+			StoppedAt:       backup.Status.StoppedAt,
+			ScheduledBackup: getParentScheduledBackup(&backup, scheduledBackupNames),
+		}
+		if backup.Status.BackupSize != nil {
+			item.Size = backup.Status.BackupSize.String()
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// getParentScheduledBackup gets the name of the ScheduledBackup which
+// created the passed backup, if any. The backups created by a ScheduledBackup
+// are labelled with its name and their name is prefixed by it: the latter is
+// used when the label is missing, preferring the longest matching name
+func getParentScheduledBackup(backup *apiv1.Backup, scheduledBackupNames []string) string {
+	if parent := backup.Labels[utils.ParentScheduledBackupLabelName]; parent != "" {
+		return parent
+	}
+
+	parent := ""
+	for _, name := range scheduledBackupNames {
+		if strings.HasPrefix(backup.Name, name+"-") && len(name) > len(parent) {
+			parent = name
+		}
+	}
+	return parent
+}
+
+func formatOptionalTime(t *metav1.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
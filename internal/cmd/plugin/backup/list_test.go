@@ -0,0 +1,143 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getBackupListItems", func() {
+	const namespace = "default"
+
+	now := time.Now().Truncate(time.Second)
+
+	newBackup := func(
+		name, clusterName string,
+		created time.Time,
+		phase apiv1.BackupPhase,
+		method apiv1.BackupMethod,
+	) *apiv1.Backup {
+		return &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: apiv1.BackupSpec{
+				Cluster: apiv1.LocalObjectReference{Name: clusterName},
+			},
+			Status: apiv1.BackupStatus{
+				Phase:  phase,
+				Method: method,
+			},
+		}
+	}
+
+	newClient := func(objects ...client.Object) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(objects...).
+			Build()
+	}
+
+	var cli client.Client
+
+	BeforeEach(func() {
+		scheduled := newBackup("nightly-20250101000000", "cluster-example", now.Add(-time.Hour),
+			apiv1.BackupPhaseCompleted, apiv1.BackupMethodBarmanObjectStore)
+		size := resource.MustParse("1Gi")
+		scheduled.Status.BackupSize = &size
+
+		labelled := newBackup("custom-name", "cluster-example", now.Add(-30*time.Minute),
+			apiv1.BackupPhaseFailed, apiv1.BackupMethodVolumeSnapshot)
+		labelled.Labels = map[string]string{utils.ParentScheduledBackupLabelName: "hourly"}
+
+		onDemand := newBackup("cluster-example-20250101000000", "cluster-example", now.Add(-2*time.Hour),
+			apiv1.BackupPhaseRunning, "")
+		onDemand.Spec.Method = apiv1.BackupMethodBarmanObjectStore
+
+		other := newBackup("other-backup", "other-cluster", now,
+			apiv1.BackupPhaseCompleted, apiv1.BackupMethodBarmanObjectStore)
+
+		cli = newClient(
+			scheduled, labelled, onDemand, other,
+			&apiv1.ScheduledBackup{
+				ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: namespace},
+				Spec: apiv1.ScheduledBackupSpec{
+					Cluster: apiv1.LocalObjectReference{Name: "cluster-example"},
+				},
+			},
+		)
+	})
+
+	It("lists the backups of the cluster sorted by creation time", func(ctx SpecContext) {
+		items, err := getBackupListItems(ctx, cli, namespace, "cluster-example", backupListFilter{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(items).To(HaveLen(3))
+
+		Expect(items[0].Name).To(Equal("cluster-example-20250101000000"))
+		Expect(items[0].Method).To(Equal(apiv1.BackupMethodBarmanObjectStore))
+		Expect(items[0].ScheduledBackup).To(BeEmpty())
+
+		Expect(items[1].Name).To(Equal("nightly-20250101000000"))
+		Expect(items[1].ScheduledBackup).To(Equal("nightly"))
+		Expect(items[1].Size).To(Equal("1Gi"))
+
+		Expect(items[2].Name).To(Equal("custom-name"))
+		Expect(items[2].ScheduledBackup).To(Equal("hourly"))
+	})
+
+	It("filters the backups by phase", func(ctx SpecContext) {
+		items, err := getBackupListItems(ctx, cli, namespace, "cluster-example",
+			backupListFilter{phase: "Completed"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(items).To(HaveLen(1))
+		Expect(items[0].Name).To(Equal("nightly-20250101000000"))
+	})
+
+	It("filters the backups by method", func(ctx SpecContext) {
+		items, err := getBackupListItems(ctx, cli, namespace, "cluster-example",
+			backupListFilter{method: apiv1.BackupMethodVolumeSnapshot})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(items).To(HaveLen(1))
+		Expect(items[0].Name).To(Equal("custom-name"))
+	})
+})
+
+var _ = Describe("getParentScheduledBackup", func() {
+	It("prefers the longest matching ScheduledBackup name", func() {
+		backup := &apiv1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "daily-full-20250101000000"}}
+		Expect(getParentScheduledBackup(backup, []string{"daily", "daily-full"})).To(Equal("daily-full"))
+	})
+
+	It("returns an empty string for backups not created by a schedule", func() {
+		backup := &apiv1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "dailybackup"}}
+		Expect(getParentScheduledBackup(backup, []string{"daily"})).To(BeEmpty())
+	})
+})
@@ -0,0 +1,326 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	storagesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/plugin/resources"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// snapshotTarget is the kind of instance a volume snapshot backup
+// is taken from
+type snapshotTarget string
+
+const (
+	// snapshotTargetPrimary takes the volume snapshot from the primary
+	snapshotTargetPrimary snapshotTarget = "primary"
+
+	// snapshotTargetReplica takes the volume snapshot from a streaming
+	// standby whose replay lag is within the allowed threshold
+	snapshotTargetReplica snapshotTarget = "replica"
+)
+
+// snapshotTargets are the values accepted by the target option
+var snapshotTargets = []string{string(snapshotTargetPrimary), string(snapshotTargetReplica)}
+
+// snapshotSource is the instance chosen to take a volume snapshot backup
+type snapshotSource struct {
+	instanceName string
+	isPrimary    bool
+
+	// lsn is the current LSN of the primary, or the LSN replayed by
+	// the standby
+	lsn types.LSN
+
+	// replayLagBytes is the distance between the current LSN of the
+	// primary and the LSN replayed by the standby. It is nil for the primary
+	replayLagBytes *int64
+}
+
+// String implements the fmt.Stringer interface
+func (source snapshotSource) String() string {
+	if source.isPrimary {
+		return fmt.Sprintf("primary instance %s, current LSN %s", source.instanceName, source.lsn)
+	}
+	return fmt.Sprintf("standby instance %s, replayed LSN %s, replay lag %s",
+		source.instanceName, source.lsn,
+		resource.NewQuantity(*source.replayLagBytes, resource.BinarySI).String())
+}
+
+// snapshotPrecheckOptions are the options driving the choice of the
+// instance a volume snapshot backup is taken from
+type snapshotPrecheckOptions struct {
+	target snapshotTarget
+
+	// fallbackToPrimary allows taking the volume snapshot from the
+	// primary when targeting a replica of a cluster without standbys
+	fallbackToPrimary bool
+
+	maxReplayLagBytes   int64
+	online              *bool
+	immediateCheckpoint *bool
+}
+
+// selectSnapshotSource checks the status of the instances of the cluster
+// and chooses the one the volume snapshot backup will be taken from.
+// The returned warnings should be reported to the user
+func selectSnapshotSource(
+	cluster *apiv1.Cluster,
+	instances postgres.PostgresqlStatusList,
+	options snapshotPrecheckOptions,
+) (*snapshotSource, []string, error) {
+	var primary *postgres.PostgresqlStatus
+	for idx := range instances.Items {
+		if instances.Items[idx].Error == nil && instances.Items[idx].IsPrimary {
+			primary = &instances.Items[idx]
+			break
+		}
+	}
+	if primary == nil {
+		return nil, nil, fmt.Errorf("cannot find the primary instance of cluster %s", cluster.Name)
+	}
+
+	fencedInstances, err := utils.GetFencedInstances(cluster.Annotations)
+	if err != nil {
+		return nil, nil, fmt.Errorf("while getting the fenced instances of cluster %s: %w", cluster.Name, err)
+	}
+	isFenced := func(instanceName string) bool {
+		return fencedInstances.Has(instanceName) || fencedInstances.Has(utils.FenceAllInstances)
+	}
+
+	if options.target == snapshotTargetPrimary {
+		return checkPrimarySnapshotSource(cluster, primary, isFenced(primary.Pod.Name), options)
+	}
+
+	var best *snapshotSource
+	var rejections []string
+	for idx := range instances.Items {
+		instance := &instances.Items[idx]
+		if instance.IsPrimary || instance.Pod == nil {
+			continue
+		}
+
+		var reason string
+		replayLagBytes := getReplayLagBytes(primary.CurrentLsn, instance.ReplayLsn)
+		switch {
+		case instance.Error != nil:
+			reason = fmt.Sprintf("cannot get its status: %v", instance.Error)
+		case isFenced(instance.Pod.Name):
+			reason = "it is fenced"
+		case !instance.IsPodReady:
+			reason = "it is not ready"
+		case !instance.IsWalReceiverActive:
+			reason = "it is not streaming from the primary"
+		case replayLagBytes == nil:
+			reason = "its replay lag is unknown"
+		case *replayLagBytes > options.maxReplayLagBytes:
+			reason = fmt.Sprintf("its replay lag (%s) is higher than %s",
+				resource.NewQuantity(*replayLagBytes, resource.BinarySI).String(),
+				resource.NewQuantity(options.maxReplayLagBytes, resource.BinarySI).String())
+		}
+		if reason != "" {
+			rejections = append(rejections, fmt.Sprintf("%s: %s", instance.Pod.Name, reason))
+			continue
+		}
+
+		if best == nil || *replayLagBytes < *best.replayLagBytes {
+			best = &snapshotSource{
+				instanceName:   instance.Pod.Name,
+				lsn:            instance.ReplayLsn,
+				replayLagBytes: replayLagBytes,
+			}
+		}
+	}
+
+	if best == nil && len(rejections) == 0 && options.fallbackToPrimary {
+		source, warnings, err := checkPrimarySnapshotSource(cluster, primary, isFenced(primary.Pod.Name), options)
+		if err != nil {
+			return nil, nil, err
+		}
+		warnings = append([]string{
+			fmt.Sprintf("cluster %s has no standby, the volume snapshot is taken from the primary", cluster.Name),
+		}, warnings...)
+		return source, warnings, nil
+	}
+
+	if best == nil {
+		message := fmt.Sprintf("cluster %s has no standby suitable to take the volume snapshot", cluster.Name)
+		if len(rejections) > 0 {
+			slices.Sort(rejections)
+			message = fmt.Sprintf("%s (%s)", message, strings.Join(rejections, "; "))
+		}
+		return nil, nil, fmt.Errorf("%s, use --target=%s to take it from the primary", message, snapshotTargetPrimary)
+	}
+
+	return best, nil, nil
+}
+
+// getDefaultSnapshotTarget gets the kind of instance a volume snapshot
+// backup is taken from when it is not requested by the user, following
+// the backup target defined in the cluster
+func getDefaultSnapshotTarget(cluster *apiv1.Cluster) snapshotTarget {
+	if cluster.Spec.Backup != nil && cluster.Spec.Backup.Target == apiv1.BackupTargetPrimary {
+		return snapshotTargetPrimary
+	}
+
+	return snapshotTargetReplica
+}
+
+// getSnapshotSource collects the status of the instances of the cluster
+// and chooses the one the volume snapshot backup will be taken from,
+// reporting it to the user together with its LSN
+func getSnapshotSource(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	options snapshotPrecheckOptions,
+) (*snapshotSource, error) {
+	pods, _, err := resources.GetInstancePods(ctx, cluster.Name)
+	if err != nil {
+		return nil, fmt.Errorf("while getting the instances of cluster %s: %w", cluster.Name, err)
+	}
+
+	// The errors are reported by the status of every instance, and
+	// are considered while choosing the source
+	instances, _ := resources.ExtractInstancesStatus(ctx, plugin.Config, pods)
+	source, warnings, err := selectSnapshotSource(cluster, instances, options)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, warning := range warnings {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+	fmt.Printf("Taking the volume snapshot from %s\n", source)
+	return source, nil
+}
+
+// checkPrimarySnapshotSource checks if a volume snapshot backup can be
+// taken from the primary without disrupting the workload
+func checkPrimarySnapshotSource(
+	cluster *apiv1.Cluster,
+	primary *postgres.PostgresqlStatus,
+	fenced bool,
+	options snapshotPrecheckOptions,
+) (*snapshotSource, []string, error) {
+	if fenced {
+		return nil, nil, fmt.Errorf("primary instance %s is fenced and cannot take a backup", primary.Pod.Name)
+	}
+
+	snapshotConfig := cluster.Spec.Backup.VolumeSnapshot
+	online := snapshotConfig.GetOnline()
+	if options.online != nil {
+		online = *options.online
+	}
+	if !online {
+		return nil, nil, fmt.Errorf(
+			"an offline volume snapshot requires primary instance %s to be shut down, "+
+				"use --online=true or --target=%s", primary.Pod.Name, snapshotTargetReplica)
+	}
+
+	immediateCheckpoint := snapshotConfig.OnlineConfiguration.GetImmediateCheckpoint()
+	if options.immediateCheckpoint != nil {
+		immediateCheckpoint = *options.immediateCheckpoint
+	}
+	if immediateCheckpoint {
+		return nil, nil, fmt.Errorf(
+			"an online volume snapshot with an immediate checkpoint would impact the workload "+
+				"of primary instance %s, use --immediate-checkpoint=false or --target=%s",
+			primary.Pod.Name, snapshotTargetReplica)
+	}
+
+	warnings := []string{
+		fmt.Sprintf("the volume snapshot requires a checkpoint on primary instance %s, "+
+			"spread according to checkpoint_completion_target", primary.Pod.Name),
+	}
+	return &snapshotSource{
+		instanceName: primary.Pod.Name,
+		isPrimary:    true,
+		lsn:          primary.CurrentLsn,
+	}, warnings, nil
+}
+
+// getReplayLagBytes computes the distance, in bytes, between the current
+// LSN of the primary and the LSN replayed by a standby. It returns nil
+// when any of the two positions is not known
+func getReplayLagBytes(primaryLSN, replayLSN types.LSN) *int64 {
+	if primaryLSN == "" || replayLSN == "" {
+		return nil
+	}
+
+	primaryPosition, err := primaryLSN.Parse()
+	if err != nil {
+		return nil
+	}
+	replayPosition, err := replayLSN.Parse()
+	if err != nil {
+		return nil
+	}
+
+	lag := max(primaryPosition-replayPosition, 0)
+	return &lag
+}
+
+// validateVolumeSnapshotClasses checks that the VolumeSnapshotClasses
+// used by the volume snapshot configuration of the cluster exist
+func validateVolumeSnapshotClasses(ctx context.Context, cli client.Client, cluster *apiv1.Cluster) error {
+	snapshotConfig := cluster.Spec.Backup.VolumeSnapshot
+
+	classNames := []string{snapshotConfig.ClassName}
+	if cluster.ShouldCreateWalArchiveVolume() {
+		classNames = append(classNames, snapshotConfig.WalClassName)
+	}
+	for _, tablespace := range cluster.Spec.Tablespaces {
+		classNames = append(classNames, snapshotConfig.TablespaceClassName[tablespace.Name])
+	}
+
+	var errs []error
+	checked := make(map[string]bool, len(classNames))
+	for _, className := range classNames {
+		// An empty class name means that the default
+		// VolumeSnapshotClass of the CSI driver will be used
+		if className == "" || checked[className] {
+			continue
+		}
+		checked[className] = true
+
+		var snapshotClass storagesnapshotv1.VolumeSnapshotClass
+		err := cli.Get(ctx, client.ObjectKey{Name: className}, &snapshotClass)
+		switch {
+		case apierrs.IsNotFound(err):
+			errs = append(errs, fmt.Errorf("VolumeSnapshotClass %s does not exist", className))
+		case err != nil:
+			errs = append(errs, fmt.Errorf("while getting VolumeSnapshotClass %s: %w", className, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,253 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	storagesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("selectSnapshotSource", func() {
+	var cluster *apiv1.Cluster
+	var options snapshotPrecheckOptions
+
+	newStatus := func(name string, isPrimary bool) postgres.PostgresqlStatus {
+		return postgres.PostgresqlStatus{
+			Pod:                 &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}},
+			IsPrimary:           isPrimary,
+			IsPodReady:          true,
+			IsWalReceiverActive: !isPrimary,
+			CurrentLsn:          "0/5000000",
+			ReplayLsn:           "0/5000000",
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{
+					VolumeSnapshot: &apiv1.VolumeSnapshotConfiguration{},
+				},
+			},
+		}
+		options = snapshotPrecheckOptions{
+			target:            snapshotTargetReplica,
+			maxReplayLagBytes: 16 * 1024 * 1024,
+		}
+	})
+
+	Context("when targeting a replica", func() {
+		It("chooses the standby with the smallest replay lag", func() {
+			standby2 := newStatus("cluster-example-2", false)
+			standby2.ReplayLsn = "0/4000000"
+			standby3 := newStatus("cluster-example-3", false)
+			standby3.ReplayLsn = "0/4F00000"
+			instances := postgres.PostgresqlStatusList{Items: []postgres.PostgresqlStatus{
+				newStatus("cluster-example-1", true), standby2, standby3,
+			}}
+
+			source, warnings, err := selectSnapshotSource(cluster, instances, options)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+			Expect(source.instanceName).To(Equal("cluster-example-3"))
+			Expect(source.isPrimary).To(BeFalse())
+			Expect(source.lsn).To(BeEquivalentTo("0/4F00000"))
+			Expect(*source.replayLagBytes).To(BeEquivalentTo(0x100000))
+		})
+
+		It("skips standbys that are not suitable, reporting why", func() {
+			notReady := newStatus("cluster-example-2", false)
+			notReady.IsPodReady = false
+			notStreaming := newStatus("cluster-example-3", false)
+			notStreaming.IsWalReceiverActive = false
+			lagging := newStatus("cluster-example-4", false)
+			lagging.ReplayLsn = "0/1000000"
+			fenced := newStatus("cluster-example-5", false)
+			cluster.Annotations = map[string]string{utils.FencedInstanceAnnotation: `["cluster-example-5"]`}
+			instances := postgres.PostgresqlStatusList{Items: []postgres.PostgresqlStatus{
+				newStatus("cluster-example-1", true), notReady, notStreaming, lagging, fenced,
+			}}
+
+			_, _, err := selectSnapshotSource(cluster, instances, options)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cluster-example-2: it is not ready"))
+			Expect(err.Error()).To(ContainSubstring("cluster-example-3: it is not streaming from the primary"))
+			Expect(err.Error()).To(ContainSubstring("cluster-example-4: its replay lag (64Mi) is higher than 16Mi"))
+			Expect(err.Error()).To(ContainSubstring("cluster-example-5: it is fenced"))
+			Expect(err.Error()).To(ContainSubstring("--target=primary"))
+		})
+
+		It("fails when the cluster has no standby", func() {
+			instances := postgres.PostgresqlStatusList{Items: []postgres.PostgresqlStatus{
+				newStatus("cluster-example-1", true),
+			}}
+
+			_, _, err := selectSnapshotSource(cluster, instances, options)
+			Expect(err).To(MatchError(ContainSubstring("has no standby suitable")))
+		})
+
+		It("falls back to the primary when allowed and the cluster has no standby", func() {
+			options.fallbackToPrimary = true
+			instances := postgres.PostgresqlStatusList{Items: []postgres.PostgresqlStatus{
+				newStatus("cluster-example-1", true),
+			}}
+
+			source, warnings, err := selectSnapshotSource(cluster, instances, options)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(source.instanceName).To(Equal("cluster-example-1"))
+			Expect(source.isPrimary).To(BeTrue())
+			Expect(warnings).To(HaveLen(2))
+			Expect(warnings[0]).To(ContainSubstring("has no standby"))
+		})
+
+		It("doesn't fall back to the primary when the standbys are not suitable", func() {
+			options.fallbackToPrimary = true
+			notReady := newStatus("cluster-example-2", false)
+			notReady.IsPodReady = false
+			instances := postgres.PostgresqlStatusList{Items: []postgres.PostgresqlStatus{
+				newStatus("cluster-example-1", true), notReady,
+			}}
+
+			_, _, err := selectSnapshotSource(cluster, instances, options)
+			Expect(err).To(MatchError(ContainSubstring("cluster-example-2: it is not ready")))
+		})
+
+		It("fails when the primary cannot be found", func() {
+			instances := postgres.PostgresqlStatusList{Items: []postgres.PostgresqlStatus{
+				newStatus("cluster-example-2", false),
+			}}
+
+			_, _, err := selectSnapshotSource(cluster, instances, options)
+			Expect(err).To(MatchError(ContainSubstring("cannot find the primary")))
+		})
+	})
+
+	Context("without an explicit target", func() {
+		It("follows the backup target of the cluster", func() {
+			Expect(getDefaultSnapshotTarget(cluster)).To(Equal(snapshotTargetReplica))
+
+			cluster.Spec.Backup.Target = apiv1.BackupTargetStandby
+			Expect(getDefaultSnapshotTarget(cluster)).To(Equal(snapshotTargetReplica))
+
+			cluster.Spec.Backup.Target = apiv1.BackupTargetPrimary
+			Expect(getDefaultSnapshotTarget(cluster)).To(Equal(snapshotTargetPrimary))
+
+			Expect(getDefaultSnapshotTarget(&apiv1.Cluster{})).To(Equal(snapshotTargetReplica))
+		})
+	})
+
+	Context("when targeting the primary", func() {
+		var instances postgres.PostgresqlStatusList
+
+		BeforeEach(func() {
+			options.target = snapshotTargetPrimary
+			instances = postgres.PostgresqlStatusList{Items: []postgres.PostgresqlStatus{
+				newStatus("cluster-example-1", true), newStatus("cluster-example-2", false),
+			}}
+		})
+
+		It("warns about the checkpoint of an online snapshot", func() {
+			source, warnings, err := selectSnapshotSource(cluster, instances, options)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+			Expect(source.instanceName).To(Equal("cluster-example-1"))
+			Expect(source.isPrimary).To(BeTrue())
+			Expect(source.lsn).To(BeEquivalentTo("0/5000000"))
+		})
+
+		It("refuses an immediate checkpoint", func() {
+			cluster.Spec.Backup.VolumeSnapshot.OnlineConfiguration.ImmediateCheckpoint = ptr.To(true)
+			_, _, err := selectSnapshotSource(cluster, instances, options)
+			Expect(err).To(MatchError(ContainSubstring("immediate checkpoint would impact the workload")))
+
+			options.immediateCheckpoint = ptr.To(false)
+			_, _, err = selectSnapshotSource(cluster, instances, options)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("refuses an offline snapshot", func() {
+			options.online = ptr.To(false)
+			_, _, err := selectSnapshotSource(cluster, instances, options)
+			Expect(err).To(MatchError(ContainSubstring("requires primary instance cluster-example-1 to be shut down")))
+		})
+	})
+})
+
+var _ = Describe("validateVolumeSnapshotClasses", func() {
+	var cluster *apiv1.Cluster
+
+	newClient := func(objects ...client.Object) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(objects...).
+			Build()
+	}
+
+	newSnapshotClass := func(name string) *storagesnapshotv1.VolumeSnapshotClass {
+		return &storagesnapshotv1.VolumeSnapshotClass{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				WalStorage:  &apiv1.StorageConfiguration{Size: "1Gi"},
+				Tablespaces: []apiv1.TablespaceConfiguration{{Name: "tbs1"}},
+				Backup: &apiv1.BackupConfiguration{
+					VolumeSnapshot: &apiv1.VolumeSnapshotConfiguration{
+						ClassName:           "csi-snapclass",
+						WalClassName:        "csi-wal-snapclass",
+						TablespaceClassName: map[string]string{"tbs1": "csi-tbs-snapclass"},
+					},
+				},
+			},
+		}
+	})
+
+	It("accepts existing classes", func(ctx SpecContext) {
+		cli := newClient(
+			newSnapshotClass("csi-snapclass"),
+			newSnapshotClass("csi-wal-snapclass"),
+			newSnapshotClass("csi-tbs-snapclass"),
+		)
+		Expect(validateVolumeSnapshotClasses(ctx, cli, cluster)).To(Succeed())
+	})
+
+	It("accepts an empty configuration, using the default classes", func(ctx SpecContext) {
+		cluster.Spec.Backup.VolumeSnapshot = &apiv1.VolumeSnapshotConfiguration{}
+		Expect(validateVolumeSnapshotClasses(ctx, newClient(), cluster)).To(Succeed())
+	})
+
+	It("reports every missing class", func(ctx SpecContext) {
+		err := validateVolumeSnapshotClasses(ctx, newClient(newSnapshotClass("csi-snapclass")), cluster)
+		Expect(err).To(MatchError(ContainSubstring("VolumeSnapshotClass csi-wal-snapclass does not exist")))
+		Expect(err).To(MatchError(ContainSubstring("VolumeSnapshotClass csi-tbs-snapclass does not exist")))
+	})
+})
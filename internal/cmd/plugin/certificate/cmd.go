@@ -18,6 +18,8 @@ package certificate
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/spf13/cobra"
 
@@ -65,5 +67,43 @@ connect to the PostgreSQL cluster.`,
 	certificateCmd.Flags().Bool(
 		"dry-run", false, "If specified, the secret is not created")
 
+	certificateCmd.AddCommand(newExpiryCmd())
+
 	return certificateCmd
 }
+
+func newExpiryCmd() *cobra.Command {
+	var output string
+	var warningDays int
+
+	cmd := &cobra.Command{
+		Use:   "expiry CLUSTER",
+		Short: `Report the expiration of the certificates used by the cluster named CLUSTER`,
+		Long: "Reads the secrets containing the certificates used by the cluster, and reports " +
+			"how many days are left before each certificate expires.",
+		Args: plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat := plugin.OutputFormat(output)
+			switch outputFormat {
+			case plugin.OutputFormatText, plugin.OutputFormatJSON, plugin.OutputFormatYAML:
+			default:
+				return fmt.Errorf("output: %s is not supported by the certificate expiry command", output)
+			}
+
+			if warningDays < 0 {
+				return errors.New("warning-days must not be negative")
+			}
+
+			return Expiry(cmd.Context(), args[0], warningDays, outputFormat)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of text, json, or yaml")
+	cmd.Flags().IntVar(&warningDays, "warning-days", defaultWarningDays,
+		"Number of days before the expiration under which a certificate is reported as expiring")
+
+	return cmd
+}
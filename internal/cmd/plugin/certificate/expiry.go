@@ -0,0 +1,209 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/cheynewallace/tabby"
+	"github.com/logrusorgru/aurora/v4"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+)
+
+// defaultWarningDays is the default number of days before the expiration
+// of a certificate under which it is reported as expiring
+const defaultWarningDays = 30
+
+// Possible values of the status of a certificate
+const (
+	certificateStatusValid    = "Valid"
+	certificateStatusExpiring = "Expiring"
+	certificateStatusExpired  = "Expired"
+	certificateStatusError    = "Error"
+)
+
+// certificateExpiry is the expiration information of the certificate
+// stored in a secret used by a cluster
+type certificateExpiry struct {
+	Secret string `json:"secret"`
+
+	// Usage is the role of the certificate in the cluster
+	Usage string `json:"usage"`
+
+	Subject  string     `json:"subject,omitempty"`
+	NotAfter *time.Time `json:"notAfter,omitempty"`
+
+	// DaysUntilExpiry is the number of days until the certificate
+	// expires, negative when the certificate has already expired
+	DaysUntilExpiry int `json:"daysUntilExpiry"`
+
+	Status string `json:"status"`
+
+	// Error is the reason why the certificate couldn't be read
+	Error string `json:"error,omitempty"`
+}
+
+// certificateSecretReference is a secret containing a certificate
+// used by a cluster, together with the key holding the certificate
+type certificateSecretReference struct {
+	name  string
+	key   string
+	usage string
+}
+
+// getCertificateSecretReferences returns the secrets containing the
+// certificates used by a cluster
+func getCertificateSecretReferences(cluster *apiv1.Cluster) []certificateSecretReference {
+	return []certificateSecretReference{
+		{name: cluster.GetServerCASecretName(), key: certs.CACertKey, usage: "server CA"},
+		{name: cluster.GetServerTLSSecretName(), key: certs.TLSCertKey, usage: "server"},
+		{name: cluster.GetClientCASecretName(), key: certs.CACertKey, usage: "client CA"},
+		{name: cluster.GetReplicationSecretName(), key: certs.TLSCertKey, usage: "streaming replica client"},
+	}
+}
+
+// Expiry prints the expiration dates of the certificates used by a cluster
+func Expiry(ctx context.Context, clusterName string, warningDays int, format plugin.OutputFormat) error {
+	var cluster apiv1.Cluster
+	if err := plugin.Client.Get(
+		ctx,
+		client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName},
+		&cluster,
+	); err != nil {
+		return fmt.Errorf("while getting cluster %s: %w", clusterName, err)
+	}
+
+	expiries := getCertificatesExpiry(ctx, plugin.Client, &cluster, warningDays, time.Now())
+
+	if format != plugin.OutputFormatText {
+		return plugin.Print(expiries, format, os.Stdout)
+	}
+
+	printCertificatesExpiry(expiries)
+	return nil
+}
+
+// getCertificatesExpiry reads the certificates used by a cluster and
+// computes their expiration status relative to the passed time
+func getCertificatesExpiry(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+	warningDays int,
+	now time.Time,
+) []certificateExpiry {
+	references := getCertificateSecretReferences(cluster)
+	result := make([]certificateExpiry, 0, len(references))
+
+	for _, reference := range references {
+		result = append(result, getCertificateExpiry(ctx, cli, cluster.Namespace, reference, warningDays, now))
+	}
+
+	return result
+}
+
+func getCertificateExpiry(
+	ctx context.Context,
+	cli client.Client,
+	namespace string,
+	reference certificateSecretReference,
+	warningDays int,
+	now time.Time,
+) certificateExpiry {
+	result := certificateExpiry{
+		Secret: reference.name,
+		Usage:  reference.usage,
+		Status: certificateStatusError,
+	}
+
+	var secret corev1.Secret
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: reference.name}, &secret); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	data, ok := secret.Data[reference.key]
+	if !ok {
+		result.Error = fmt.Sprintf("missing %s key", reference.key)
+		return result
+	}
+
+	certificate, err := certs.KeyPair{Certificate: data}.ParseCertificate()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Subject = certificate.Subject.String()
+	result.NotAfter = &certificate.NotAfter
+	result.DaysUntilExpiry = int(math.Floor(certificate.NotAfter.Sub(now).Hours() / 24))
+
+	switch {
+	case !now.Before(certificate.NotAfter):
+		result.Status = certificateStatusExpired
+	case result.DaysUntilExpiry < warningDays:
+		result.Status = certificateStatusExpiring
+	default:
+		result.Status = certificateStatusValid
+	}
+
+	return result
+}
+
+// colorizeCertificateStatus highlights the certificates needing attention
+func colorizeCertificateStatus(status string) string {
+	switch status {
+	case certificateStatusValid:
+		return aurora.Green(status).String()
+	case certificateStatusExpiring:
+		return aurora.Yellow(status).String()
+	default:
+		return aurora.Red(status).String()
+	}
+}
+
+func printCertificatesExpiry(expiries []certificateExpiry) {
+	table := tabby.New()
+	table.AddHeader("Secret", "Usage", "Not After", "Days Left", "Status")
+
+	for _, expiry := range expiries {
+		if expiry.Error != "" {
+			table.AddLine(expiry.Secret, expiry.Usage, "-", "-",
+				fmt.Sprintf("%s: %s", colorizeCertificateStatus(expiry.Status), expiry.Error))
+			continue
+		}
+
+		table.AddLine(
+			expiry.Secret,
+			expiry.Usage,
+			expiry.NotAfter.Format(time.RFC3339),
+			expiry.DaysUntilExpiry,
+			colorizeCertificateStatus(expiry.Status),
+		)
+	}
+
+	table.Print()
+}
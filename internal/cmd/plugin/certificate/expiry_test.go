@@ -0,0 +1,108 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("certificate expiry", func() {
+	const namespace = "default"
+
+	var (
+		cli        client.Client
+		cluster    *apiv1.Cluster
+		serverLeaf *certs.KeyPair
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: namespace},
+			Spec: apiv1.ClusterSpec{
+				Certificates: &apiv1.CertificatesConfiguration{
+					ServerCASecret:  "my-ca",
+					ServerTLSSecret: "my-tls",
+					ClientCASecret:  "my-ca",
+				},
+			},
+		}
+
+		ca, err := certs.CreateRootCA("cluster-example", namespace)
+		Expect(err).ToNot(HaveOccurred())
+		serverLeaf, err = ca.CreateAndSignPair("cluster-example-rw", certs.CertTypeServer, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		cli = fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).WithObjects(
+			cluster,
+			ca.GenerateCASecret(namespace, "my-ca"),
+			serverLeaf.GenerateCertificateSecret(namespace, "my-tls"),
+		).Build()
+	})
+
+	It("reports the expiration of every certificate of the cluster", func(ctx SpecContext) {
+		expiries := getCertificatesExpiry(ctx, cli, cluster, defaultWarningDays, time.Now())
+		Expect(expiries).To(HaveLen(4))
+
+		Expect(expiries[0].Secret).To(Equal("my-ca"))
+		Expect(expiries[0].Usage).To(Equal("server CA"))
+		Expect(expiries[0].Status).To(Equal(certificateStatusValid))
+		Expect(expiries[0].DaysUntilExpiry).To(BeNumerically(">", defaultWarningDays))
+
+		Expect(expiries[1].Secret).To(Equal("my-tls"))
+		Expect(expiries[1].Subject).To(ContainSubstring("cluster-example-rw"))
+		Expect(expiries[1].Status).To(Equal(certificateStatusValid))
+
+		Expect(expiries[2].Secret).To(Equal("my-ca"))
+		Expect(expiries[2].Usage).To(Equal("client CA"))
+
+		// the replication secret is missing
+		Expect(expiries[3].Secret).To(Equal("cluster-example-replication"))
+		Expect(expiries[3].Status).To(Equal(certificateStatusError))
+		Expect(expiries[3].Error).ToNot(BeEmpty())
+	})
+
+	It("reports the certificates expiring within the warning threshold", func(ctx SpecContext) {
+		certificate, err := serverLeaf.ParseCertificate()
+		Expect(err).ToNot(HaveOccurred())
+
+		now := certificate.NotAfter.Add(-10 * 24 * time.Hour)
+		expiries := getCertificatesExpiry(ctx, cli, cluster, defaultWarningDays, now)
+		Expect(expiries[1].Status).To(Equal(certificateStatusExpiring))
+		Expect(expiries[1].DaysUntilExpiry).To(Equal(10))
+	})
+
+	It("reports the expired certificates", func(ctx SpecContext) {
+		certificate, err := serverLeaf.ParseCertificate()
+		Expect(err).ToNot(HaveOccurred())
+
+		now := certificate.NotAfter.Add(36 * time.Hour)
+		expiries := getCertificatesExpiry(ctx, cli, cluster, defaultWarningDays, now)
+		Expect(expiries[1].Status).To(Equal(certificateStatusExpired))
+		Expect(expiries[1].DaysUntilExpiry).To(Equal(-2))
+	})
+})
@@ -0,0 +1,63 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// annotateMaintenanceReason records the reason of the maintenance in progress
+// on the cluster, together with the current time. An empty reason removes
+// the annotations
+func annotateMaintenanceReason(
+	ctx context.Context,
+	cli client.Client,
+	namespace string,
+	clusterName string,
+	reason string,
+	now time.Time,
+) error {
+	var cluster apiv1.Cluster
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, &cluster); err != nil {
+		return fmt.Errorf("while getting cluster %s: %w", clusterName, err)
+	}
+
+	origCluster := cluster.DeepCopy()
+	if reason == "" {
+		delete(cluster.Annotations, utils.MaintenanceReasonAnnotationName)
+		delete(cluster.Annotations, utils.MaintenanceReasonTimestampAnnotationName)
+	} else {
+		if cluster.Annotations == nil {
+			cluster.Annotations = make(map[string]string)
+		}
+		cluster.Annotations[utils.MaintenanceReasonAnnotationName] = reason
+		cluster.Annotations[utils.MaintenanceReasonTimestampAnnotationName] = now.UTC().Format(time.RFC3339)
+	}
+
+	if err := cli.Patch(ctx, &cluster, client.MergeFrom(origCluster)); err != nil {
+		return fmt.Errorf("while patching cluster %s: %w", clusterName, err)
+	}
+
+	return nil
+}
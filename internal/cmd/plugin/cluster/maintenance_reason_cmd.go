@@ -0,0 +1,79 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+func newAnnotateMaintenanceReasonCmd() *cobra.Command {
+	var clearReason bool
+
+	cmd := &cobra.Command{
+		Use:   "annotate-maintenance-reason CLUSTER [REASON]",
+		Short: "Records the reason of the maintenance in progress on a cluster",
+		Long: "Records on the cluster named CLUSTER a free-text description of the maintenance in progress, " +
+			"together with the current time, so that other team members understand why the cluster " +
+			"is being worked on. The reason is shown by the status command.",
+		Example: "kubectl cnpg cluster annotate-maintenance-reason cluster-example \"Upgrading the storage class\"\n" +
+			"kubectl cnpg cluster annotate-maintenance-reason cluster-example --clear",
+		Args: cobra.RangeArgs(1, 2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusterName := args[0]
+
+			var reason string
+			switch {
+			case clearReason && len(args) > 1:
+				return fmt.Errorf("a reason cannot be specified together with --clear")
+			case !clearReason && len(args) < 2:
+				return fmt.Errorf("the maintenance reason is required, unless --clear is specified")
+			case !clearReason:
+				reason = args[1]
+			}
+
+			if err := annotateMaintenanceReason(
+				cmd.Context(),
+				plugin.Client,
+				plugin.Namespace,
+				clusterName,
+				reason,
+				time.Now(),
+			); err != nil {
+				return err
+			}
+
+			if clearReason {
+				fmt.Printf("Maintenance reason removed from cluster %s\n", clusterName)
+			} else {
+				fmt.Printf("Maintenance reason recorded on cluster %s\n", clusterName)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&clearReason, "clear", false, "Remove the maintenance reason from the cluster")
+
+	return cmd
+}
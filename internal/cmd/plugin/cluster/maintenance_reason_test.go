@@ -0,0 +1,73 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8client "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("annotateMaintenanceReason", func() {
+	var cli k8client.Client
+	clusterKey := k8client.ObjectKey{Namespace: "default", Name: "cluster-example"}
+
+	BeforeEach(func() {
+		cli = fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).WithObjects(
+			&apiv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        clusterKey.Name,
+					Namespace:   clusterKey.Namespace,
+					Annotations: map[string]string{"other": "annotation"},
+				},
+			},
+		).Build()
+	})
+
+	It("records the reason and the timestamp and then removes them", func(ctx SpecContext) {
+		now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		Expect(annotateMaintenanceReason(ctx, cli, clusterKey.Namespace, clusterKey.Name,
+			"Upgrading the storage class", now)).To(Succeed())
+
+		var cluster apiv1.Cluster
+		Expect(cli.Get(ctx, clusterKey, &cluster)).To(Succeed())
+		Expect(cluster.Annotations).To(HaveKeyWithValue(utils.MaintenanceReasonAnnotationName,
+			"Upgrading the storage class"))
+		Expect(cluster.Annotations).To(HaveKeyWithValue(utils.MaintenanceReasonTimestampAnnotationName,
+			"2025-01-02T03:04:05Z"))
+		Expect(cluster.Annotations).To(HaveKeyWithValue("other", "annotation"))
+
+		Expect(annotateMaintenanceReason(ctx, cli, clusterKey.Namespace, clusterKey.Name, "", now)).To(Succeed())
+		Expect(cli.Get(ctx, clusterKey, &cluster)).To(Succeed())
+		Expect(cluster.Annotations).ToNot(HaveKey(utils.MaintenanceReasonAnnotationName))
+		Expect(cluster.Annotations).ToNot(HaveKey(utils.MaintenanceReasonTimestampAnnotationName))
+		Expect(cluster.Annotations).To(HaveKeyWithValue("other", "annotation"))
+	})
+
+	It("fails when the cluster doesn't exist", func(ctx SpecContext) {
+		Expect(annotateMaintenanceReason(ctx, cli, "default", "missing", "reason", time.Now())).ToNot(Succeed())
+	})
+})
@@ -0,0 +1,418 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cheynewallace/tabby"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// resizeStorageOptions contains the options of the resize-storage command
+type resizeStorageOptions struct {
+	clusterName     string
+	size            *resource.Quantity
+	walSize         *resource.Quantity
+	wait            bool
+	timeout         time.Duration
+	pollingInterval time.Duration
+}
+
+// pvcExpansionPhase is the phase of the expansion of a PVC
+type pvcExpansionPhase string
+
+const (
+	// pvcExpansionPhasePending means the operator didn't request the new size yet
+	pvcExpansionPhasePending pvcExpansionPhase = "Pending"
+
+	// pvcExpansionPhaseResizing means the storage provider is resizing the volume
+	pvcExpansionPhaseResizing pvcExpansionPhase = "Resizing"
+
+	// pvcExpansionPhaseFileSystemResizePending means the volume has been expanded,
+	// but the file system still needs to be resized on the node
+	pvcExpansionPhaseFileSystemResizePending pvcExpansionPhase = "FileSystemResizePending"
+
+	// pvcExpansionPhaseFailed means the expansion failed and requires
+	// a manual intervention
+	pvcExpansionPhaseFailed pvcExpansionPhase = "Failed"
+
+	// pvcExpansionPhaseCompleted means the PVC has the requested capacity
+	pvcExpansionPhaseCompleted pvcExpansionPhase = "Completed"
+)
+
+// pvcExpansionStatus is the status of the expansion of a PVC
+type pvcExpansionStatus struct {
+	instanceName string
+	pvcName      string
+	role         string
+	requested    resource.Quantity
+	capacity     resource.Quantity
+	phase        pvcExpansionPhase
+	message      string
+}
+
+// isStuck is true when the PVC expansion can't proceed without
+// an external intervention
+func (s pvcExpansionStatus) isStuck() bool {
+	return s.phase == pvcExpansionPhaseFailed || s.phase == pvcExpansionPhaseFileSystemResizePending
+}
+
+// resizeStorage updates the storage size of a cluster and, if requested,
+// monitors the expansion of the PVCs
+func resizeStorage(ctx context.Context, cli client.Client, namespace string, options resizeStorageOptions) error {
+	var cluster apiv1.Cluster
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: options.clusterName}, &cluster); err != nil {
+		return fmt.Errorf("while getting cluster %s: %w", options.clusterName, err)
+	}
+
+	if err := validateResizeStorage(ctx, cli, &cluster, options); err != nil {
+		return err
+	}
+
+	resizedCluster := cluster.DeepCopy()
+	if options.size != nil {
+		resizedCluster.Spec.StorageConfiguration.Size = options.size.String()
+	}
+	if options.walSize != nil {
+		resizedCluster.Spec.WalStorage.Size = options.walSize.String()
+	}
+	if err := cli.Patch(ctx, resizedCluster, client.MergeFrom(&cluster)); err != nil {
+		return fmt.Errorf("while updating the storage size of cluster %s: %w", cluster.Name, err)
+	}
+	fmt.Printf("Storage size of cluster %s updated\n", cluster.Name)
+
+	if !options.wait {
+		return nil
+	}
+
+	return waitForPVCExpansion(ctx, cli, resizedCluster, options)
+}
+
+// validateResizeStorage checks that the requested sizes can be applied to
+// the cluster storage
+func validateResizeStorage(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+	options resizeStorageOptions,
+) error {
+	if !cluster.ShouldResizeInUseVolumes() {
+		return fmt.Errorf("cluster %s has `.spec.storage.resizeInUseVolumes` disabled: "+
+			"the operator won't expand the existing PVCs", cluster.Name)
+	}
+
+	if options.size != nil {
+		if err := validateNewStorageSize(
+			"storage", &cluster.Spec.StorageConfiguration, *options.size,
+		); err != nil {
+			return err
+		}
+	}
+
+	if options.walSize != nil {
+		if cluster.Spec.WalStorage == nil {
+			return fmt.Errorf("cluster %s has no WAL storage configured", cluster.Name)
+		}
+		if err := validateNewStorageSize("walStorage", cluster.Spec.WalStorage, *options.walSize); err != nil {
+			return err
+		}
+	}
+
+	return validateStorageClassesAllowExpansion(ctx, cli, cluster)
+}
+
+// validateNewStorageSize checks that the new size is greater than the current one
+func validateNewStorageSize(
+	name string,
+	configuration *apiv1.StorageConfiguration,
+	newSize resource.Quantity,
+) error {
+	currentSize := configuration.GetSizeOrNil()
+	if currentSize == nil {
+		return nil
+	}
+
+	if newSize.Cmp(*currentSize) <= 0 {
+		return fmt.Errorf("the new %s size (%s) must be greater than the current one (%s)",
+			name, newSize.String(), currentSize.String())
+	}
+
+	return nil
+}
+
+// validateStorageClassesAllowExpansion checks that the storage classes used
+// by the cluster PVCs allow volume expansion
+func validateStorageClassesAllowExpansion(ctx context.Context, cli client.Client, cluster *apiv1.Cluster) error {
+	pvcs, err := getClusterPVCs(ctx, cli, cluster)
+	if err != nil {
+		return err
+	}
+
+	checkedClasses := make(map[string]bool)
+	for _, pvc := range pvcs {
+		if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+			continue
+		}
+
+		className := *pvc.Spec.StorageClassName
+		if checkedClasses[className] {
+			continue
+		}
+		checkedClasses[className] = true
+
+		var storageClass storagev1.StorageClass
+		err := cli.Get(ctx, client.ObjectKey{Name: className}, &storageClass)
+		if apierrs.IsNotFound(err) || apierrs.IsForbidden(err) {
+			// We can't tell, let the storage provider decide
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("while getting storage class %s: %w", className, err)
+		}
+
+		if storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+			return fmt.Errorf("storage class %s, used by PVC %s, doesn't allow volume expansion",
+				className, pvc.Name)
+		}
+	}
+
+	return nil
+}
+
+// getClusterPVCs gets the PGDATA and WAL PVCs of the cluster
+func getClusterPVCs(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+) ([]corev1.PersistentVolumeClaim, error) {
+	var pvcList corev1.PersistentVolumeClaimList
+	if err := cli.List(
+		ctx,
+		&pvcList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{utils.ClusterLabelName: cluster.Name},
+	); err != nil {
+		return nil, fmt.Errorf("while listing the PVCs of cluster %s: %w", cluster.Name, err)
+	}
+
+	result := make([]corev1.PersistentVolumeClaim, 0, len(pvcList.Items))
+	for _, pvc := range pvcList.Items {
+		switch utils.PVCRole(pvc.Labels[utils.PvcRoleLabelName]) {
+		case utils.PVCRolePgData, utils.PVCRolePgWal:
+			result = append(result, pvc)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b corev1.PersistentVolumeClaim) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return result, nil
+}
+
+// waitForPVCExpansion monitors the expansion of the cluster PVCs until
+// every one of them reaches the requested size or the timeout expires
+func waitForPVCExpansion(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+	options resizeStorageOptions,
+) error {
+	timeout := time.NewTimer(options.timeout)
+	defer timeout.Stop()
+
+	ticker := time.NewTicker(options.pollingInterval)
+	defer ticker.Stop()
+
+	var lastReport string
+	var statuses []pvcExpansionStatus
+	for {
+		pvcs, err := getClusterPVCs(ctx, cli, cluster)
+		if err != nil {
+			return err
+		}
+
+		statuses = getPVCExpansionStatuses(cluster, pvcs)
+		if report := renderPVCExpansionStatuses(statuses); report != lastReport {
+			fmt.Print(report)
+			lastReport = report
+		}
+
+		if isPVCExpansionCompleted(statuses) {
+			fmt.Printf("Storage expansion of cluster %s completed\n", cluster.Name)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return fmt.Errorf("timeout waiting for the storage expansion of cluster %s: %s",
+				cluster.Name, describeStuckPVCs(statuses))
+		case <-ticker.C:
+		}
+	}
+}
+
+// getPVCExpansionStatuses computes the expansion status of the passed PVCs
+func getPVCExpansionStatuses(
+	cluster *apiv1.Cluster,
+	pvcs []corev1.PersistentVolumeClaim,
+) []pvcExpansionStatus {
+	statuses := make([]pvcExpansionStatus, 0, len(pvcs))
+	for _, pvc := range pvcs {
+		var expectedSize *resource.Quantity
+		switch utils.PVCRole(pvc.Labels[utils.PvcRoleLabelName]) {
+		case utils.PVCRolePgData:
+			expectedSize = cluster.Spec.StorageConfiguration.GetSizeOrNil()
+		case utils.PVCRolePgWal:
+			expectedSize = cluster.Spec.WalStorage.GetSizeOrNil()
+		}
+		if expectedSize == nil {
+			continue
+		}
+
+		statuses = append(statuses, getPVCExpansionStatus(pvc, *expectedSize))
+	}
+
+	return statuses
+}
+
+// getPVCExpansionStatus evaluates the expansion of a PVC against the expected size
+func getPVCExpansionStatus(pvc corev1.PersistentVolumeClaim, expectedSize resource.Quantity) pvcExpansionStatus {
+	status := pvcExpansionStatus{
+		instanceName: pvc.Labels[utils.InstanceNameLabelName],
+		pvcName:      pvc.Name,
+		role:         pvc.Labels[utils.PvcRoleLabelName],
+		requested:    pvc.Spec.Resources.Requests[corev1.ResourceStorage],
+		capacity:     pvc.Status.Capacity[corev1.ResourceStorage],
+	}
+
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		if condition.Type == corev1.PersistentVolumeClaimControllerResizeError ||
+			condition.Type == corev1.PersistentVolumeClaimNodeResizeError {
+			status.phase = pvcExpansionPhaseFailed
+			status.message = condition.Message
+			return status
+		}
+	}
+
+	allocatedResourceStatus := pvc.Status.AllocatedResourceStatuses[corev1.ResourceStorage]
+	if allocatedResourceStatus == corev1.PersistentVolumeClaimControllerResizeInfeasible ||
+		allocatedResourceStatus == corev1.PersistentVolumeClaimNodeResizeInfeasible {
+		status.phase = pvcExpansionPhaseFailed
+		status.message = "the storage provider reported the expansion as infeasible"
+		return status
+	}
+
+	switch {
+	case status.capacity.Cmp(expectedSize) >= 0:
+		status.phase = pvcExpansionPhaseCompleted
+	case status.requested.Cmp(expectedSize) < 0:
+		status.phase = pvcExpansionPhasePending
+		status.message = "waiting for the operator to request the new size"
+	case hasPVCCondition(pvc, corev1.PersistentVolumeClaimFileSystemResizePending):
+		status.phase = pvcExpansionPhaseFileSystemResizePending
+		status.message = "the file system resize is pending on the node, " +
+			"the instance Pod may need to be restarted"
+	default:
+		status.phase = pvcExpansionPhaseResizing
+	}
+
+	return status
+}
+
+// hasPVCCondition checks if the PVC has the passed condition set to true
+func hasPVCCondition(pvc corev1.PersistentVolumeClaim, conditionType corev1.PersistentVolumeClaimConditionType) bool {
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPVCExpansionCompleted is true when every PVC reached the requested size
+func isPVCExpansionCompleted(statuses []pvcExpansionStatus) bool {
+	for _, status := range statuses {
+		if status.phase != pvcExpansionPhaseCompleted {
+			return false
+		}
+	}
+
+	return true
+}
+
+// renderPVCExpansionStatuses renders the progress of the PVC expansion as a table
+func renderPVCExpansionStatuses(statuses []pvcExpansionStatus) string {
+	var builder strings.Builder
+	table := tabby.NewCustom(tabwriter.NewWriter(&builder, 0, 0, 4, ' ', 0))
+	table.AddHeader("Instance", "PVC", "Role", "Requested", "Capacity", "Phase", "Message")
+	for _, status := range statuses {
+		table.AddLine(
+			status.instanceName,
+			status.pvcName,
+			status.role,
+			status.requested.String(),
+			status.capacity.String(),
+			status.phase,
+			status.message,
+		)
+	}
+	table.Print()
+	builder.WriteString("\n")
+
+	return builder.String()
+}
+
+// describeStuckPVCs describes the PVCs whose expansion is not completed
+func describeStuckPVCs(statuses []pvcExpansionStatus) string {
+	var descriptions []string
+	for _, status := range statuses {
+		if status.phase == pvcExpansionPhaseCompleted {
+			continue
+		}
+
+		description := fmt.Sprintf("%s (instance %s) is %s", status.pvcName, status.instanceName, status.phase)
+		if status.isStuck() {
+			description += " and requires a manual intervention"
+		}
+		if status.message != "" {
+			description += ": " + status.message
+		}
+		descriptions = append(descriptions, description)
+	}
+
+	return strings.Join(descriptions, "; ")
+}
@@ -0,0 +1,95 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+func newResizeStorageCmd() *cobra.Command {
+	var (
+		size            string
+		walSize         string
+		noWait          bool
+		timeout         time.Duration
+		pollingInterval time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "resize-storage CLUSTER",
+		Short: "Increases the storage size of a cluster and monitors the PVC expansion",
+		Long: "Increases the size of the storage (and optionally of the WAL storage) of the cluster named " +
+			"CLUSTER and monitors the expansion of the PVCs of every instance, reporting the " +
+			"progress and the instances where the expansion is stuck.",
+		Example: "kubectl cnpg cluster resize-storage cluster-example --size 20Gi --wal-size 5Gi",
+		Args:    plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options := resizeStorageOptions{
+				clusterName:     args[0],
+				wait:            !noWait,
+				timeout:         timeout,
+				pollingInterval: pollingInterval,
+			}
+
+			if size == "" && walSize == "" {
+				return fmt.Errorf("at least one of --size or --wal-size must be specified")
+			}
+
+			if !noWait && pollingInterval <= 0 {
+				return fmt.Errorf("the polling interval must be positive")
+			}
+
+			if size != "" {
+				quantity, err := resource.ParseQuantity(size)
+				if err != nil {
+					return fmt.Errorf("invalid --size value %q: %w", size, err)
+				}
+				options.size = &quantity
+			}
+
+			if walSize != "" {
+				quantity, err := resource.ParseQuantity(walSize)
+				if err != nil {
+					return fmt.Errorf("invalid --wal-size value %q: %w", walSize, err)
+				}
+				options.walSize = &quantity
+			}
+
+			return resizeStorage(cmd.Context(), plugin.Client, plugin.Namespace, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&size, "size", "", "The new size of the PGDATA storage")
+	cmd.Flags().StringVar(&walSize, "wal-size", "", "The new size of the WAL storage")
+	cmd.Flags().BoolVar(&noWait, "no-wait", false,
+		"Don't wait for the PVCs to be expanded, just update the cluster definition")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute,
+		"The maximum time to wait for the PVC expansion to complete")
+	cmd.Flags().DurationVar(&pollingInterval, "polling-interval", 5*time.Second,
+		"How often the PVC expansion progress is checked")
+
+	return cmd
+}
@@ -0,0 +1,250 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	k8client "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func newTestPVC(name string, role utils.PVCRole, requested, capacity string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				utils.ClusterLabelName:      "cluster-example",
+				utils.InstanceNameLabelName: "cluster-example-1",
+				utils.PvcRoleLabelName:      string(role),
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: ptr.To("standard"),
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(requested),
+				},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse(capacity),
+			},
+		},
+	}
+}
+
+var _ = Describe("PVC expansion status", func() {
+	expectedSize := resource.MustParse("2Gi")
+
+	It("is completed when the capacity matches the expected size", func() {
+		pvc := newTestPVC("cluster-example-1", utils.PVCRolePgData, "2Gi", "2Gi")
+		status := getPVCExpansionStatus(*pvc, expectedSize)
+		Expect(status.phase).To(Equal(pvcExpansionPhaseCompleted))
+		Expect(status.instanceName).To(Equal("cluster-example-1"))
+	})
+
+	It("is pending when the operator didn't update the request yet", func() {
+		pvc := newTestPVC("cluster-example-1", utils.PVCRolePgData, "1Gi", "1Gi")
+		Expect(getPVCExpansionStatus(*pvc, expectedSize).phase).To(Equal(pvcExpansionPhasePending))
+	})
+
+	It("is resizing when the request has been updated", func() {
+		pvc := newTestPVC("cluster-example-1", utils.PVCRolePgData, "2Gi", "1Gi")
+		pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+			{Type: corev1.PersistentVolumeClaimResizing, Status: corev1.ConditionTrue},
+		}
+		status := getPVCExpansionStatus(*pvc, expectedSize)
+		Expect(status.phase).To(Equal(pvcExpansionPhaseResizing))
+		Expect(status.isStuck()).To(BeFalse())
+	})
+
+	It("is stuck when the file system resize is pending", func() {
+		pvc := newTestPVC("cluster-example-1", utils.PVCRolePgData, "2Gi", "1Gi")
+		pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+			{Type: corev1.PersistentVolumeClaimFileSystemResizePending, Status: corev1.ConditionTrue},
+		}
+		status := getPVCExpansionStatus(*pvc, expectedSize)
+		Expect(status.phase).To(Equal(pvcExpansionPhaseFileSystemResizePending))
+		Expect(status.isStuck()).To(BeTrue())
+	})
+
+	It("is failed when the controller reports an error", func() {
+		pvc := newTestPVC("cluster-example-1", utils.PVCRolePgData, "2Gi", "1Gi")
+		pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+			{
+				Type:    corev1.PersistentVolumeClaimControllerResizeError,
+				Status:  corev1.ConditionTrue,
+				Message: "quota exceeded",
+			},
+		}
+		status := getPVCExpansionStatus(*pvc, expectedSize)
+		Expect(status.phase).To(Equal(pvcExpansionPhaseFailed))
+		Expect(status.message).To(Equal("quota exceeded"))
+		Expect(describeStuckPVCs([]pvcExpansionStatus{status})).To(ContainSubstring("manual intervention"))
+	})
+
+	It("is failed when the expansion is infeasible", func() {
+		pvc := newTestPVC("cluster-example-1", utils.PVCRolePgData, "2Gi", "1Gi")
+		pvc.Status.AllocatedResourceStatuses = map[corev1.ResourceName]corev1.ClaimResourceStatus{
+			corev1.ResourceStorage: corev1.PersistentVolumeClaimNodeResizeInfeasible,
+		}
+		Expect(getPVCExpansionStatus(*pvc, expectedSize).phase).To(Equal(pvcExpansionPhaseFailed))
+	})
+})
+
+var _ = Describe("resize-storage", func() {
+	var (
+		cli          k8client.Client
+		cluster      *apiv1.Cluster
+		storageClass *storagev1.StorageClass
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				Instances: 1,
+				StorageConfiguration: apiv1.StorageConfiguration{
+					Size: "1Gi",
+				},
+			},
+		}
+		storageClass = &storagev1.StorageClass{
+			ObjectMeta:           metav1.ObjectMeta{Name: "standard"},
+			AllowVolumeExpansion: ptr.To(true),
+		}
+	})
+
+	buildClient := func(objects ...k8client.Object) k8client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(objects...).
+			Build()
+	}
+
+	It("rejects shrinking the storage", func(ctx SpecContext) {
+		cli = buildClient(cluster, storageClass)
+		err := resizeStorage(ctx, cli, "default", resizeStorageOptions{
+			clusterName: "cluster-example",
+			size:        ptr.To(resource.MustParse("512Mi")),
+		})
+		Expect(err).To(MatchError(ContainSubstring("must be greater than the current one")))
+	})
+
+	It("rejects resizing a WAL storage that doesn't exist", func(ctx SpecContext) {
+		cli = buildClient(cluster, storageClass)
+		err := resizeStorage(ctx, cli, "default", resizeStorageOptions{
+			clusterName: "cluster-example",
+			walSize:     ptr.To(resource.MustParse("2Gi")),
+		})
+		Expect(err).To(MatchError(ContainSubstring("no WAL storage")))
+	})
+
+	It("rejects resizing when the in-use volumes can't be resized", func(ctx SpecContext) {
+		cluster.Spec.StorageConfiguration.ResizeInUseVolumes = ptr.To(false)
+		cli = buildClient(cluster, storageClass)
+		err := resizeStorage(ctx, cli, "default", resizeStorageOptions{
+			clusterName: "cluster-example",
+			size:        ptr.To(resource.MustParse("2Gi")),
+		})
+		Expect(err).To(MatchError(ContainSubstring("resizeInUseVolumes")))
+	})
+
+	It("rejects resizing when the storage class doesn't allow expansion", func(ctx SpecContext) {
+		storageClass.AllowVolumeExpansion = ptr.To(false)
+		cli = buildClient(cluster, storageClass,
+			newTestPVC("cluster-example-1", utils.PVCRolePgData, "1Gi", "1Gi"))
+		err := resizeStorage(ctx, cli, "default", resizeStorageOptions{
+			clusterName: "cluster-example",
+			size:        ptr.To(resource.MustParse("2Gi")),
+		})
+		Expect(err).To(MatchError(ContainSubstring("doesn't allow volume expansion")))
+	})
+
+	It("updates the cluster storage size without waiting", func(ctx SpecContext) {
+		cli = buildClient(cluster, storageClass,
+			newTestPVC("cluster-example-1", utils.PVCRolePgData, "1Gi", "1Gi"))
+		Expect(resizeStorage(ctx, cli, "default", resizeStorageOptions{
+			clusterName: "cluster-example",
+			size:        ptr.To(resource.MustParse("2Gi")),
+		})).To(Succeed())
+
+		var updatedCluster apiv1.Cluster
+		Expect(cli.Get(ctx, k8client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Spec.StorageConfiguration.Size).To(Equal("2Gi"))
+	})
+
+	It("waits until the PVCs are expanded", func(ctx SpecContext) {
+		cli = buildClient(cluster, storageClass,
+			newTestPVC("cluster-example-1", utils.PVCRolePgData, "2Gi", "2Gi"))
+		Expect(resizeStorage(ctx, cli, "default", resizeStorageOptions{
+			clusterName:     "cluster-example",
+			size:            ptr.To(resource.MustParse("2Gi")),
+			wait:            true,
+			timeout:         time.Second,
+			pollingInterval: 10 * time.Millisecond,
+		})).To(Succeed())
+	})
+
+	It("reports the stuck PVCs when the timeout expires", func(ctx SpecContext) {
+		pvc := newTestPVC("cluster-example-1", utils.PVCRolePgData, "2Gi", "1Gi")
+		pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+			{Type: corev1.PersistentVolumeClaimFileSystemResizePending, Status: corev1.ConditionTrue},
+		}
+		cli = buildClient(cluster, storageClass, pvc)
+		err := resizeStorage(ctx, cli, "default", resizeStorageOptions{
+			clusterName:     "cluster-example",
+			size:            ptr.To(resource.MustParse("2Gi")),
+			wait:            true,
+			timeout:         50 * time.Millisecond,
+			pollingInterval: 10 * time.Millisecond,
+		})
+		Expect(err).To(MatchError(ContainSubstring("FileSystemResizePending")))
+	})
+})
+
+var _ = Describe("resize-storage command", func() {
+	DescribeTable("rejects non-positive polling intervals",
+		func(pollingInterval string) {
+			cmd := newResizeStorageCmd()
+			cmd.SetArgs([]string{"cluster-example", "--size", "2Gi", "--polling-interval", pollingInterval})
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			Expect(cmd.Execute()).To(MatchError(ContainSubstring("polling interval must be positive")))
+		},
+		Entry("zero", "0s"),
+		Entry("negative", "-1s"),
+	)
+})
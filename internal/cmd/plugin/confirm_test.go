@@ -0,0 +1,35 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = DescribeTable("AskToProceed",
+	func(answer string, expected bool) {
+		Expect(AskToProceed(strings.NewReader(answer))).To(Equal(expected))
+	},
+	Entry("short confirmation", "y\n", true),
+	Entry("long confirmation", " YES \n", true),
+	Entry("refusal", "n\n", false),
+	Entry("empty answer", "\n", false),
+	Entry("no answer", "", false),
+)
@@ -0,0 +1,50 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the new "debug" command
+func NewCmd() *cobra.Command {
+	debugCmd := &cobra.Command{
+		Use:     "debug",
+		Short:   "Inspect the configuration generated by the operator for troubleshooting",
+		GroupID: plugin.GroupIDTroubleshooting,
+	}
+
+	debugCmd.AddCommand(recoveryConfigCmd())
+
+	return debugCmd
+}
+
+func recoveryConfigCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "recovery-config CLUSTER",
+		Short: "Print the recovery configuration generated for a cluster bootstrapped from a backup",
+		Args:  plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RecoveryConfig(cmd.Context(), plugin.Client, plugin.Namespace, args[0])
+		},
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/external"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres"
+)
+
+// cloudProviderOptionsPlaceholder replaces, in the generated restore_command,
+// the options selecting the cloud provider
+const cloudProviderOptionsPlaceholder = "<cloud provider options>"
+
+// recoveryConfiguration is the recovery configuration that the operator
+// generates for a cluster bootstrapped from a backup
+type recoveryConfiguration struct {
+	// source describes where the recovery data is coming from
+	source string
+
+	// configuration contains the PostgreSQL settings driving the recovery
+	configuration string
+
+	// warnings contains the issues detected in the recovery configuration
+	warnings []string
+}
+
+// RecoveryConfig prints the recovery configuration generated for a cluster
+// bootstrapped from a backup, allowing the user to check it before the
+// instance is started
+func RecoveryConfig(ctx context.Context, cli client.Client, namespace, clusterName string) error {
+	var cluster apiv1.Cluster
+	err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, &cluster)
+	if err != nil {
+		return fmt.Errorf("cluster %s not found in namespace %s: %w", clusterName, namespace, err)
+	}
+
+	recoveryConfig, err := getRecoveryConfiguration(ctx, cli, &cluster)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("# Recovery source: %s\n", recoveryConfig.source)
+	for _, warning := range recoveryConfig.warnings {
+		fmt.Printf("# WARNING: %s\n", warning)
+	}
+	fmt.Print(recoveryConfig.configuration)
+	return nil
+}
+
+// getRecoveryConfiguration computes the recovery configuration of the cluster
+// in the same way the instance manager does when restoring it
+func getRecoveryConfiguration(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+) (*recoveryConfiguration, error) {
+	if cluster.Spec.Bootstrap == nil || cluster.Spec.Bootstrap.Recovery == nil {
+		return nil, fmt.Errorf("cluster %s is not bootstrapped from a backup", cluster.Name)
+	}
+	recovery := cluster.Spec.Bootstrap.Recovery
+
+	// Replica clusters follow their source once the data has been restored
+	if cluster.IsReplica() {
+		return getReplicaClusterRecoveryConfiguration(ctx, cli, cluster)
+	}
+
+	result := &recoveryConfiguration{
+		warnings: validateRecoveryTarget(recovery.RecoveryTarget),
+	}
+	recoveryTargetOptions := recovery.RecoveryTarget.BuildPostgresOptions()
+
+	if pluginConfiguration := cluster.GetRecoverySourcePlugin(); pluginConfiguration != nil {
+		result.source = fmt.Sprintf("external cluster %q, through the plugin %q",
+			recovery.Source, pluginConfiguration.Name)
+		result.warnings = append(result.warnings,
+			"the restore_command is provided by the plugin when the instance is restored")
+		result.configuration = recoveryTargetOptions
+		return result, nil
+	}
+
+	var backup *apiv1.Backup
+	switch {
+	case recovery.VolumeSnapshots != nil && recovery.Source == "":
+		result.source = "volume snapshots, without a WAL archive"
+		return result, nil
+
+	case recovery.Backup != nil && recovery.VolumeSnapshots == nil:
+		var referencedBackup apiv1.Backup
+		if err := cli.Get(
+			ctx,
+			client.ObjectKey{Namespace: cluster.Namespace, Name: recovery.Backup.Name},
+			&referencedBackup,
+		); err != nil {
+			return nil, fmt.Errorf("while getting backup %s: %w", recovery.Backup.Name, err)
+		}
+		backup = &referencedBackup
+		result.source = fmt.Sprintf("backup %q", backup.Name)
+		result.warnings = append(result.warnings, validateRecoveryBackup(backup, recovery.RecoveryTarget)...)
+
+	default:
+		server, found := cluster.ExternalCluster(recovery.Source)
+		if !found {
+			return nil, fmt.Errorf("missing external cluster: %v", recovery.Source)
+		}
+		if server.BarmanObjectStore == nil {
+			return nil, fmt.Errorf("missing barman object store configuration for source: %v", recovery.Source)
+		}
+
+		// The backup to restore is chosen from the catalog when the instance
+		// is restored, but the WAL archive location only depends on the
+		// external cluster definition
+		backup = &apiv1.Backup{
+			Status: apiv1.BackupStatus{
+				BarmanCredentials: server.BarmanObjectStore.BarmanCredentials,
+				EndpointCA:        server.BarmanObjectStore.EndpointCA,
+				EndpointURL:       server.BarmanObjectStore.EndpointURL,
+				DestinationPath:   server.BarmanObjectStore.DestinationPath,
+				ServerName:        server.GetServerName(),
+			},
+		}
+		result.source = fmt.Sprintf("external cluster %q", recovery.Source)
+	}
+
+	// The cloud provider options depend on the Barman Cloud version
+	// available in the operand image, which we cannot detect from here
+	var cloudProviderOptions []string
+	if credentials := backup.Status.BarmanCredentials; credentials.AWS != nil ||
+		credentials.Azure != nil || credentials.Google != nil {
+		cloudProviderOptions = []string{cloudProviderOptionsPlaceholder}
+	}
+
+	result.configuration = postgres.BuildRestoreWalConfig(backup, cloudProviderOptions) + recoveryTargetOptions
+	return result, nil
+}
+
+// getReplicaClusterRecoveryConfiguration computes the configuration used by
+// the instances of a replica cluster to follow their source
+func getReplicaClusterRecoveryConfiguration(
+	ctx context.Context,
+	cli client.Client,
+	cluster *apiv1.Cluster,
+) (*recoveryConfiguration, error) {
+	server, found := cluster.ExternalCluster(cluster.Spec.ReplicaCluster.Source)
+	if !found {
+		return nil, fmt.Errorf("missing external cluster: %v", cluster.Spec.ReplicaCluster.Source)
+	}
+
+	connectionString, err := external.GetServerConnectionString(ctx, cli, cluster.Namespace, &server, "")
+	if err != nil {
+		return nil, err
+	}
+
+	options := postgres.GetReplicaConfigurationOptions(connectionString, "")
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var configuration strings.Builder
+	for _, key := range keys {
+		configuration.WriteString(fmt.Sprintf("%s = '%s'\n", key, strings.ReplaceAll(options[key], "'", "''")))
+	}
+
+	return &recoveryConfiguration{
+		source:        fmt.Sprintf("replica cluster following %q", server.Name),
+		configuration: configuration.String(),
+	}, nil
+}
+
+// validateRecoveryTarget checks the recovery target for issues preventing
+// PostgreSQL from reaching it
+func validateRecoveryTarget(target *apiv1.RecoveryTarget) []string {
+	if target == nil || target.TargetTime == "" {
+		return nil
+	}
+
+	if _, err := types.ParseTargetTime(nil, target.TargetTime); err != nil {
+		return []string{fmt.Sprintf("invalid recovery target time %q: %v", target.TargetTime, err)}
+	}
+	return nil
+}
+
+// validateRecoveryBackup checks whether the backup can be used to reach the
+// recovery target
+func validateRecoveryBackup(backup *apiv1.Backup, target *apiv1.RecoveryTarget) []string {
+	if backup.Status.Phase != apiv1.BackupPhaseCompleted {
+		return []string{fmt.Sprintf("backup %q is not completed (phase: %q)", backup.Name, backup.Status.Phase)}
+	}
+
+	if target == nil || target.TargetTime == "" || backup.Status.StoppedAt == nil {
+		return nil
+	}
+
+	targetTime, err := types.ParseTargetTime(nil, target.TargetTime)
+	if err != nil {
+		return nil
+	}
+	if targetTime.Before(backup.Status.StoppedAt.Time) {
+		return []string{fmt.Sprintf(
+			"the recovery target time %q is before the end of backup %q (%s): the target can't be reached",
+			target.TargetTime, backup.Name, backup.Status.StoppedAt.Format(time.RFC3339))}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"time"
+
+	barmanApi "github.com/cloudnative-pg/barman-cloud/pkg/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	k8client "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("recovery configuration", func() {
+	const namespace = "default"
+
+	var cluster *apiv1.Cluster
+	var backup *apiv1.Backup
+	var client k8client.Client
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-restore",
+				Namespace: namespace,
+			},
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					Recovery: &apiv1.BootstrapRecovery{
+						Backup: &apiv1.BackupSource{
+							LocalObjectReference: apiv1.LocalObjectReference{Name: "backup-example"},
+						},
+						RecoveryTarget: &apiv1.RecoveryTarget{
+							TargetTime: "2024-01-01 12:00:00+00",
+						},
+					},
+				},
+				ExternalClusters: []apiv1.ExternalCluster{
+					{
+						Name: "origin",
+						BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+							DestinationPath: "s3://backups/",
+							BarmanCredentials: barmanApi.BarmanCredentials{
+								AWS: &barmanApi.S3Credentials{},
+							},
+						},
+						ConnectionParameters: map[string]string{
+							"host": "origin-rw",
+						},
+					},
+				},
+			},
+		}
+		backup = &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "backup-example",
+				Namespace: namespace,
+			},
+			Status: apiv1.BackupStatus{
+				Phase:           apiv1.BackupPhaseCompleted,
+				DestinationPath: "s3://backups/",
+				ServerName:      "cluster-example",
+				StoppedAt:       &metav1.Time{Time: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		client = fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(cluster, backup).Build()
+	})
+
+	It("fails when the cluster is not bootstrapped from a backup", func(ctx SpecContext) {
+		cluster.Spec.Bootstrap = nil
+		_, err := getRecoveryConfiguration(ctx, client, cluster)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("generates the configuration to recover from a Backup object", func(ctx SpecContext) {
+		recoveryConfig, err := getRecoveryConfiguration(ctx, client, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recoveryConfig.source).To(ContainSubstring("backup-example"))
+		Expect(recoveryConfig.warnings).To(BeEmpty())
+		Expect(recoveryConfig.configuration).To(ContainSubstring(
+			"restore_command = 'barman-cloud-wal-restore s3://backups/ cluster-example %f %p'"))
+		Expect(recoveryConfig.configuration).To(ContainSubstring(
+			"recovery_target_time = '2024-01-01 12:00:00+00'"))
+	})
+
+	It("warns when the recovery target is before the end of the backup", func(ctx SpecContext) {
+		cluster.Spec.Bootstrap.Recovery.RecoveryTarget.TargetTime = "2024-01-01 09:00:00+00"
+		recoveryConfig, err := getRecoveryConfiguration(ctx, client, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recoveryConfig.warnings).To(HaveLen(1))
+		Expect(recoveryConfig.warnings[0]).To(ContainSubstring("can't be reached"))
+	})
+
+	When("the backup is not completed", func() {
+		BeforeEach(func() {
+			backup.Status.Phase = apiv1.BackupPhaseFailed
+		})
+
+		It("warns about it", func(ctx SpecContext) {
+			recoveryConfig, err := getRecoveryConfiguration(ctx, client, cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recoveryConfig.warnings).To(HaveLen(1))
+			Expect(recoveryConfig.warnings[0]).To(ContainSubstring("not completed"))
+		})
+	})
+
+	It("generates the configuration to recover from an external cluster", func(ctx SpecContext) {
+		cluster.Spec.Bootstrap.Recovery.Backup = nil
+		cluster.Spec.Bootstrap.Recovery.Source = "origin"
+		recoveryConfig, err := getRecoveryConfiguration(ctx, client, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recoveryConfig.source).To(ContainSubstring("origin"))
+		Expect(recoveryConfig.configuration).To(ContainSubstring(
+			"restore_command = 'barman-cloud-wal-restore s3://backups/ origin <cloud provider options> %f %p'"))
+	})
+
+	It("doesn't generate a restore_command for volume snapshots without a WAL archive", func(ctx SpecContext) {
+		cluster.Spec.Bootstrap.Recovery.Backup = nil
+		cluster.Spec.Bootstrap.Recovery.VolumeSnapshots = &apiv1.DataSource{}
+		recoveryConfig, err := getRecoveryConfiguration(ctx, client, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recoveryConfig.configuration).To(BeEmpty())
+	})
+
+	It("generates the configuration of a replica cluster", func(ctx SpecContext) {
+		cluster.Spec.ReplicaCluster = &apiv1.ReplicaClusterConfiguration{
+			Enabled: ptr.To(true),
+			Source:  "origin",
+		}
+		recoveryConfig, err := getRecoveryConfiguration(ctx, client, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recoveryConfig.configuration).To(ContainSubstring("primary_conninfo = 'host=''origin-rw'''"))
+		Expect(recoveryConfig.configuration).To(ContainSubstring("recovery_target_timeline = 'latest'"))
+	})
+})
@@ -19,21 +19,37 @@ package destroy
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
+// keepPVCAll is the value of the --keep-pvc flag keeping every PVC
+const keepPVCAll = "all"
+
+// pvcRolesByName maps the values accepted by the --keep-pvc flag to the PVC roles
+var pvcRolesByName = map[string]utils.PVCRole{
+	"data":        utils.PVCRolePgData,
+	"wal":         utils.PVCRolePgWal,
+	"tablespaces": utils.PVCRolePgTablespace,
+}
+
 // NewCmd create the new "destroy" subcommand
 func NewCmd() *cobra.Command {
+	var keepPVC []string
+	var keepAll bool
+
 	destroyCmd := &cobra.Command{
 		Use:     "destroy CLUSTER INSTANCE",
 		Short:   "Destroy the instance named CLUSTER-INSTANCE with the associated PVC",
 		GroupID: plugin.GroupIDCluster,
 		Args:    plugin.RequiresArguments(2),
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: func(_ *cobra.Command, args []string) error {
 			ctx := context.Background()
 			clusterName := args[0]
 			node := args[1]
@@ -41,13 +57,49 @@ func NewCmd() *cobra.Command {
 				node = fmt.Sprintf("%s-%s", clusterName, node)
 			}
 
-			keepPVC, _ := cmd.Flags().GetBool("keep-pvc")
-			return Destroy(ctx, clusterName, node, keepPVC)
+			keptPVCRoles, err := parseKeptPVCRoles(keepPVC, keepAll)
+			if err != nil {
+				return err
+			}
+			return Destroy(ctx, clusterName, node, keptPVCRoles)
 		},
 	}
 
-	destroyCmd.Flags().BoolP("keep-pvc", "k", false,
-		"Keep the PVC but detach it from instance")
+	destroyCmd.Flags().StringSliceVarP(&keepPVC, "keep-pvc", "k", nil,
+		"Keep the PVCs having the passed roles (data, wal, tablespaces) but detach them from the instance. "+
+			"When no role is passed, or with 'all' or 'true', every PVC is kept")
+	destroyCmd.Flags().Lookup("keep-pvc").NoOptDefVal = keepPVCAll
+	destroyCmd.Flags().BoolVar(&keepAll, "keep-all", false,
+		"Keep every PVC of the instance but detach them from the instance")
+	destroyCmd.MarkFlagsMutuallyExclusive("keep-pvc", "keep-all")
 
 	return destroyCmd
 }
+
+// parseKeptPVCRoles returns the roles of the PVCs to be kept
+func parseKeptPVCRoles(keepPVC []string, keepAll bool) ([]utils.PVCRole, error) {
+	if keepAll {
+		return AllPVCRoles, nil
+	}
+
+	var result []utils.PVCRole
+	for _, name := range keepPVC {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case keepPVCAll, "true":
+			return AllPVCRoles, nil
+		case "false":
+			continue
+		}
+
+		role, ok := pvcRolesByName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown PVC role %q, expected one of: data, wal, tablespaces, all, true, false", name)
+		}
+		if !slices.Contains(result, role) {
+			result = append(result, role)
+		}
+	}
+
+	return result, nil
+}
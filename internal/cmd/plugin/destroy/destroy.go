@@ -20,7 +20,12 @@ package destroy
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"text/tabwriter"
 
+	"github.com/cheynewallace/tabby"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
@@ -33,105 +38,173 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
-// Destroy implements destroy subcommand
-func Destroy(ctx context.Context, clusterName, instanceName string, keepPVC bool) error {
-	if err := ensurePodIsDeleted(ctx, instanceName, clusterName); err != nil {
+// AllPVCRoles are the roles of all the PVCs an instance can have.
+// Passing them to Destroy keeps every PVC of the instance
+var AllPVCRoles = []utils.PVCRole{
+	utils.PVCRolePgData,
+	utils.PVCRolePgWal,
+	utils.PVCRolePgTablespace,
+}
+
+// objectOutcome is what happened to an object while destroying an instance
+type objectOutcome struct {
+	kind    string
+	name    string
+	outcome string
+}
+
+// report lists the objects that have been deleted or kept while destroying
+// an instance
+type report struct {
+	objects []objectOutcome
+}
+
+func (r *report) add(kind, name, outcome string) {
+	r.objects = append(r.objects, objectOutcome{kind: kind, name: name, outcome: outcome})
+}
+
+// print writes the report as a table
+func (r *report) print(writer io.Writer) {
+	table := tabby.NewCustom(tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0))
+	table.AddHeader("Kind", "Name", "Outcome")
+	for _, object := range r.objects {
+		table.AddLine(object.kind, object.name, object.outcome)
+	}
+	table.Print()
+}
+
+// Destroy implements destroy subcommand. The PVCs of the instance having
+// one of the passed roles are kept and detached from the instance, while
+// the other ones are deleted
+func Destroy(ctx context.Context, clusterName, instanceName string, keptPVCRoles []utils.PVCRole) error {
+	result, err := destroyInstance(ctx, plugin.Client, plugin.Namespace, clusterName, instanceName, keptPVCRoles)
+	if err != nil {
 		return err
 	}
 
+	fmt.Printf("Instance %s of cluster %s has been destroyed\n", instanceName, clusterName)
+	result.print(os.Stdout)
+	return nil
+}
+
+func destroyInstance(
+	ctx context.Context,
+	cli client.Client,
+	namespace, clusterName, instanceName string,
+	keptPVCRoles []utils.PVCRole,
+) (*report, error) {
+	var result report
+
+	podDeleted, err := ensurePodIsDeleted(ctx, cli, namespace, instanceName, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if podDeleted {
+		result.add("Pod", instanceName, "deleted")
+	}
+
 	var jobList batchv1.JobList
-	if err := plugin.Client.List(
+	if err := cli.List(
 		ctx,
 		&jobList,
-		client.InNamespace(plugin.Namespace),
+		client.InNamespace(namespace),
 		client.MatchingLabels{
 			utils.InstanceNameLabelName: instanceName,
 		},
 	); err != nil {
-		return err
+		return nil, err
 	}
+
 	for idx := range jobList.Items {
-		if err := plugin.Client.Delete(
+		if err := cli.Delete(
 			ctx,
 			&jobList.Items[idx],
 			client.PropagationPolicy(metav1.DeletePropagationBackground),
 		); err != nil && !apierrs.IsNotFound(err) {
-			return fmt.Errorf("deleting job %s: %w", jobList.Items[idx].Name, err)
+			return nil, fmt.Errorf("deleting job %s: %w", jobList.Items[idx].Name, err)
 		}
+		result.add("Job", jobList.Items[idx].Name, "deleted")
 	}
 
-	pvcs, err := persistentvolumeclaim.GetInstancePVCs(ctx, plugin.Client, instanceName, plugin.Namespace)
+	pvcs, err := persistentvolumeclaim.GetInstancePVCs(ctx, cli, instanceName, namespace)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if keepPVC {
-		// we remove the ownership from the pvcs if present
-		for i := range pvcs {
-			if _, isOwned := controller.IsOwnedByCluster(&pvcs[i]); !isOwned {
+	for i := range pvcs {
+		if pvcs[i].Labels == nil {
+			pvcs[i].Labels = map[string]string{}
+		}
+		if pvcs[i].Annotations == nil {
+			pvcs[i].Annotations = map[string]string{}
+		}
+		role := utils.PVCRole(pvcs[i].Labels[utils.PvcRoleLabelName])
+		_, isOwned := controller.IsOwnedByCluster(&pvcs[i])
+
+		if slices.Contains(keptPVCRoles, role) {
+			if !isOwned {
+				result.add("PersistentVolumeClaim", pvcs[i].Name, "kept")
 				continue
 			}
 
+			// we remove the ownership from the pvc
 			pvcs[i].OwnerReferences = removeOwnerReference(pvcs[i].OwnerReferences, clusterName)
 			pvcs[i].Annotations[utils.PVCStatusAnnotationName] = persistentvolumeclaim.StatusDetached
 			pvcs[i].Labels[utils.InstanceNameLabelName] = instanceName
-			err = plugin.Client.Update(ctx, &pvcs[i])
-			if err != nil {
-				return fmt.Errorf("error updating metadata for persistent volume claim %s: %v",
-					clusterName, err)
+			if err := cli.Update(ctx, &pvcs[i]); err != nil {
+				return nil, fmt.Errorf("error updating metadata for persistent volume claim %s: %v",
+					pvcs[i].Name, err)
 			}
+			result.add("PersistentVolumeClaim", pvcs[i].Name, "kept (detached)")
+			continue
 		}
-		fmt.Printf("Instance %s of cluster %s has been destroyed and the PVC was kept\n",
-			instanceName,
-			clusterName,
-		)
-		return nil
-	}
 
-	for i := range pvcs {
-		if pvcs[i].Labels == nil {
-			pvcs[i].Labels = map[string]string{}
-		}
-
-		_, isOwned := controller.IsOwnedByCluster(&pvcs[i])
 		// if it is requested for deletion and it is owned by the cluster, we delete it. If it is not owned by the cluster
 		// but it does have the instance label and the detached annotation then we can still delete it
 		// We will only skip the iteration and not delete the pvc if it is not owned by the cluster, and it does not have
 		// the annotation or label
-		if isOwned ||
-			(pvcs[i].Annotations[utils.PVCStatusAnnotationName] == persistentvolumeclaim.StatusDetached &&
-				pvcs[i].Labels[utils.InstanceNameLabelName] == instanceName) {
-			if err = plugin.Client.Delete(ctx, &pvcs[i]); err != nil {
-				return fmt.Errorf("error deleting pvc %s: %v", pvcs[i].Name, err)
-			}
+		if !isOwned &&
+			(pvcs[i].Annotations[utils.PVCStatusAnnotationName] != persistentvolumeclaim.StatusDetached ||
+				pvcs[i].Labels[utils.InstanceNameLabelName] != instanceName) {
+			result.add("PersistentVolumeClaim", pvcs[i].Name, "kept (not owned by the cluster)")
+			continue
 		}
-	}
 
-	fmt.Printf("Instance %s of cluster %s is destroyed\n", instanceName, clusterName)
+		if err := cli.Delete(ctx, &pvcs[i]); err != nil {
+			return nil, fmt.Errorf("error deleting pvc %s: %v", pvcs[i].Name, err)
+		}
+		result.add("PersistentVolumeClaim", pvcs[i].Name, "deleted")
+	}
 
-	return nil
+	return &result, nil
 }
 
-func ensurePodIsDeleted(ctx context.Context, instanceName, clusterName string) error {
+// ensurePodIsDeleted deletes the Pod of the instance, returning
+// whether it has been deleted
+func ensurePodIsDeleted(
+	ctx context.Context,
+	cli client.Client,
+	namespace, instanceName, clusterName string,
+) (bool, error) {
 	// Check if the Pod exist
 	var pod corev1.Pod
-	err := plugin.Client.Get(ctx, client.ObjectKey{
-		Namespace: plugin.Namespace,
+	err := cli.Get(ctx, client.ObjectKey{
+		Namespace: namespace,
 		Name:      instanceName,
 	}, &pod)
 	if apierrs.IsNotFound(err) {
 		// The Pod doesn't exist, so we already did our job
-		return nil
+		return false, nil
 	}
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if _, isOwned := controller.IsOwnedByCluster(&pod); !isOwned {
-		return fmt.Errorf("instance %s is not owned by cluster %s", pod.Name, clusterName)
+		return false, fmt.Errorf("instance %s is not owned by cluster %s", pod.Name, clusterName)
 	}
 
-	return plugin.Client.Delete(ctx, &pod)
+	return true, cli.Delete(ctx, &pod)
 }
 
 // removeOwnerReference removes the owner reference to the cluster
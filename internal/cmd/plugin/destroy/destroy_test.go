@@ -0,0 +1,211 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package destroy
+
+import (
+	"bytes"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("destroyInstance", func() {
+	const (
+		namespace    = "default"
+		clusterName  = "cluster-example"
+		instanceName = "cluster-example-2"
+	)
+
+	var cli client.Client
+
+	newPVC := func(cluster *apiv1.Cluster, name string, role utils.PVCRole) *corev1.PersistentVolumeClaim {
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					utils.InstanceNameLabelName: instanceName,
+					utils.PvcRoleLabelName:      string(role),
+				},
+				Annotations: map[string]string{
+					utils.PVCStatusAnnotationName: persistentvolumeclaim.StatusReady,
+				},
+			},
+		}
+		cluster.SetInheritedDataAndOwnership(&pvc.ObjectMeta)
+		return pvc
+	}
+
+	getPVC := func(ctx SpecContext, name string) (*corev1.PersistentVolumeClaim, error) {
+		var pvc corev1.PersistentVolumeClaim
+		err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &pvc)
+		return &pvc, err
+	}
+
+	BeforeEach(func() {
+		cluster := &apiv1.Cluster{
+			TypeMeta:   metav1.TypeMeta{APIVersion: apiv1.SchemeGroupVersion.String(), Kind: apiv1.ClusterKind},
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: namespace, UID: "uid"},
+		}
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: instanceName, Namespace: namespace}}
+		cluster.SetInheritedDataAndOwnership(&pod.ObjectMeta)
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      instanceName + "-join",
+				Namespace: namespace,
+				Labels:    map[string]string{utils.InstanceNameLabelName: instanceName},
+			},
+		}
+
+		cli = fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(
+				cluster,
+				pod,
+				job,
+				newPVC(cluster, instanceName, utils.PVCRolePgData),
+				newPVC(cluster, instanceName+"-wal", utils.PVCRolePgWal),
+				newPVC(cluster, instanceName+"-tbs-atablespace", utils.PVCRolePgTablespace),
+			).
+			Build()
+	})
+
+	It("deletes every PVC by default", func(ctx SpecContext) {
+		result, err := destroyInstance(ctx, cli, namespace, clusterName, instanceName, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.objects).To(ConsistOf(
+			objectOutcome{kind: "Pod", name: instanceName, outcome: "deleted"},
+			objectOutcome{kind: "Job", name: instanceName + "-join", outcome: "deleted"},
+			objectOutcome{kind: "PersistentVolumeClaim", name: instanceName, outcome: "deleted"},
+			objectOutcome{kind: "PersistentVolumeClaim", name: instanceName + "-wal", outcome: "deleted"},
+			objectOutcome{kind: "PersistentVolumeClaim", name: instanceName + "-tbs-atablespace", outcome: "deleted"},
+		))
+
+		_, err = getPVC(ctx, instanceName)
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("keeps and detaches only the PVCs with the requested roles", func(ctx SpecContext) {
+		result, err := destroyInstance(ctx, cli, namespace, clusterName, instanceName,
+			[]utils.PVCRole{utils.PVCRolePgData, utils.PVCRolePgWal})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.objects).To(ContainElements(
+			objectOutcome{kind: "PersistentVolumeClaim", name: instanceName, outcome: "kept (detached)"},
+			objectOutcome{kind: "PersistentVolumeClaim", name: instanceName + "-wal", outcome: "kept (detached)"},
+			objectOutcome{kind: "PersistentVolumeClaim", name: instanceName + "-tbs-atablespace", outcome: "deleted"},
+		))
+
+		pvc, err := getPVC(ctx, instanceName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pvc.OwnerReferences).To(BeEmpty())
+		Expect(pvc.Annotations).To(HaveKeyWithValue(utils.PVCStatusAnnotationName,
+			persistentvolumeclaim.StatusDetached))
+
+		_, err = getPVC(ctx, instanceName+"-tbs-atablespace")
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("deletes the detached PVCs when destroying the instance again", func(ctx SpecContext) {
+		_, err := destroyInstance(ctx, cli, namespace, clusterName, instanceName, AllPVCRoles)
+		Expect(err).ToNot(HaveOccurred())
+
+		result, err := destroyInstance(ctx, cli, namespace, clusterName, instanceName,
+			[]utils.PVCRole{utils.PVCRolePgData})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.objects).To(ConsistOf(
+			objectOutcome{kind: "PersistentVolumeClaim", name: instanceName, outcome: "kept"},
+			objectOutcome{kind: "PersistentVolumeClaim", name: instanceName + "-wal", outcome: "deleted"},
+			objectOutcome{kind: "PersistentVolumeClaim", name: instanceName + "-tbs-atablespace", outcome: "deleted"},
+		))
+	})
+
+	It("doesn't detach any PVC when none is requested to be kept", func(ctx SpecContext) {
+		foreign := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      instanceName + "-foreign",
+				Namespace: namespace,
+				Labels: map[string]string{
+					utils.InstanceNameLabelName: instanceName,
+					utils.PvcRoleLabelName:      string(utils.PVCRolePgTablespace),
+				},
+				Annotations: map[string]string{
+					utils.PVCStatusAnnotationName: persistentvolumeclaim.StatusReady,
+				},
+			},
+		}
+		Expect(cli.Create(ctx, foreign)).To(Succeed())
+
+		result, err := destroyInstance(ctx, cli, namespace, clusterName, instanceName, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.objects).To(ContainElements(
+			objectOutcome{kind: "PersistentVolumeClaim", name: instanceName, outcome: "deleted"},
+			objectOutcome{
+				kind:    "PersistentVolumeClaim",
+				name:    instanceName + "-foreign",
+				outcome: "kept (not owned by the cluster)",
+			},
+		))
+
+		pvc, err := getPVC(ctx, instanceName+"-foreign")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pvc.Annotations).To(HaveKeyWithValue(utils.PVCStatusAnnotationName,
+			persistentvolumeclaim.StatusReady))
+	})
+
+	It("prints the outcome of each object", func() {
+		var result report
+		result.add("Pod", instanceName, "deleted")
+		result.add("PersistentVolumeClaim", instanceName, "kept (detached)")
+
+		var buffer bytes.Buffer
+		result.print(&buffer)
+		Expect(buffer.String()).To(MatchRegexp(`Pod\s+cluster-example-2\s+deleted`))
+		Expect(buffer.String()).To(MatchRegexp(`PersistentVolumeClaim\s+cluster-example-2\s+kept \(detached\)`))
+	})
+})
+
+var _ = DescribeTable("parseKeptPVCRoles",
+	func(keepPVC []string, keepAll bool, expected []utils.PVCRole, expectError bool) {
+		roles, err := parseKeptPVCRoles(keepPVC, keepAll)
+		if expectError {
+			Expect(err).To(HaveOccurred())
+			return
+		}
+		Expect(err).ToNot(HaveOccurred())
+		Expect(roles).To(Equal(expected))
+	},
+	Entry("without flags", nil, false, nil, false),
+	Entry("with --keep-all", nil, true, AllPVCRoles, false),
+	Entry("with --keep-pvc without a value", []string{"all"}, false, AllPVCRoles, false),
+	Entry("with some roles", []string{"data", "WAL", "data"}, false,
+		[]utils.PVCRole{utils.PVCRolePgData, utils.PVCRolePgWal}, false),
+	Entry("with --keep-pvc=true", []string{"true"}, false, AllPVCRoles, false),
+	Entry("with --keep-pvc=false", []string{"false"}, false, nil, false),
+	Entry("with an unknown role", []string{"logs"}, false, nil, true),
+)
@@ -18,43 +18,96 @@ package fence
 
 import (
 	"fmt"
-	"strconv"
+	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
 )
 
-var (
-	fenceOnCmd = &cobra.Command{
-		Use:   "on CLUSTER INSTANCE",
-		Short: `Fence an instance named CLUSTER-INSTANCE`,
-		Args:  plugin.RequiresArguments(2),
+func newFenceOnCmd() *cobra.Command {
+	var all, skipConfirmation bool
+
+	cmd := &cobra.Command{
+		Use:   "on CLUSTER [INSTANCE]",
+		Short: `Fence an instance named CLUSTER-INSTANCE, or the whole cluster with --all`,
+		Args:  plugin.RequiresArguments(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clusterName := args[0]
-			node := args[1]
-			if _, err := strconv.Atoi(args[1]); err == nil {
-				node = fmt.Sprintf("%s-%s", clusterName, node)
+			node, err := getFencingTarget(args, all)
+			if err != nil {
+				return err
+			}
+
+			if all && !skipConfirmation {
+				fmt.Printf("Fencing every instance stops the database of cluster %s.\n", clusterName)
+				if !plugin.AskToProceed(os.Stdin) {
+					return nil
+				}
 			}
 
 			return fencingOn(cmd.Context(), clusterName, node)
 		},
 	}
 
-	fenceOffCmd = &cobra.Command{
-		Use:   "off CLUSTER INSTANCE",
-		Short: `Remove fence for an instance named CLUSTER-INSTANCE`,
-		Args:  plugin.RequiresArguments(2),
+	cmd.Flags().BoolVar(&all, "all", false, "Fence every instance of the cluster")
+	cmd.Flags().BoolVarP(&skipConfirmation, "yes", "y", false,
+		"Skip the confirmation prompt when fencing every instance of the cluster")
+
+	return cmd
+}
+
+func newFenceOffCmd() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "off CLUSTER [INSTANCE]",
+		Short: `Remove fence for an instance named CLUSTER-INSTANCE, or for the whole cluster with --all`,
+		Args:  plugin.RequiresArguments(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clusterName := args[0]
-			node := args[1]
-			if _, err := strconv.Atoi(args[1]); err == nil {
-				node = fmt.Sprintf("%s-%s", clusterName, node)
+			node, err := getFencingTarget(args, all)
+			if err != nil {
+				return err
 			}
+
 			return fencingOff(cmd.Context(), clusterName, node)
 		},
 	}
-)
+
+	cmd.Flags().BoolVar(&all, "all", false, "Remove the fence from every instance of the cluster")
+
+	return cmd
+}
+
+func newFenceListCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "list CLUSTER",
+		Short: `List the fenced instances of the cluster named CLUSTER`,
+		Long: "Lists the instances of the cluster that are currently fenced, " +
+			"or \"all\" when the whole cluster is fenced.",
+		Args: plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat := plugin.OutputFormat(output)
+			switch outputFormat {
+			case plugin.OutputFormatText, plugin.OutputFormatJSON, plugin.OutputFormatYAML:
+			default:
+				return fmt.Errorf("output: %s is not supported by the fencing list command", output)
+			}
+
+			return fencingList(cmd.Context(), args[0], outputFormat)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of text, json, or yaml")
+
+	return cmd
+}
 
 // NewCmd creates the new "fencing" command
 func NewCmd() *cobra.Command {
@@ -63,8 +116,9 @@ func NewCmd() *cobra.Command {
 		Short:   `Fencing related commands`,
 		GroupID: plugin.GroupIDCluster,
 	}
-	cmd.AddCommand(fenceOnCmd)
-	cmd.AddCommand(fenceOffCmd)
+	cmd.AddCommand(newFenceOnCmd())
+	cmd.AddCommand(newFenceOffCmd())
+	cmd.AddCommand(newFenceListCmd())
 
 	return cmd
 }
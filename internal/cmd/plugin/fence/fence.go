@@ -19,7 +19,9 @@ package fence
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 
 	"k8s.io/apimachinery/pkg/types"
 
@@ -59,3 +61,24 @@ func fencingOff(ctx context.Context, clusterName string, serverName string) erro
 	fmt.Printf("%s unfenced\n", serverName)
 	return nil
 }
+
+// getFencingTarget returns the name of the instance to be fenced or unfenced
+// given the command line arguments, or the wildcard fencing every instance
+// when all is set
+func getFencingTarget(args []string, all bool) (string, error) {
+	clusterName := args[0]
+	switch {
+	case all && len(args) > 1:
+		return "", errors.New("--all cannot be used together with an instance name")
+	case all:
+		return utils.FenceAllInstances, nil
+	case len(args) < 2:
+		return "", errors.New("an instance name is required, or use --all to target every instance")
+	}
+
+	node := args[1]
+	if _, err := strconv.Atoi(node); err == nil {
+		node = fmt.Sprintf("%s-%s", clusterName, node)
+	}
+	return node, nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fence
+
+import (
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getFencingTarget", func() {
+	It("expands the serial number of an instance", func() {
+		Expect(getFencingTarget([]string{"cluster-example", "2"}, false)).To(Equal("cluster-example-2"))
+	})
+
+	It("accepts the name of an instance", func() {
+		Expect(getFencingTarget([]string{"cluster-example", "cluster-example-2"}, false)).
+			To(Equal("cluster-example-2"))
+	})
+
+	It("targets every instance with --all", func() {
+		Expect(getFencingTarget([]string{"cluster-example"}, true)).To(Equal(utils.FenceAllInstances))
+	})
+
+	It("rejects --all together with an instance name", func() {
+		_, err := getFencingTarget([]string{"cluster-example", "2"}, true)
+		Expect(err).To(MatchError(ContainSubstring("--all cannot be used together")))
+	})
+
+	It("requires an instance name without --all", func() {
+		_, err := getFencingTarget([]string{"cluster-example"}, false)
+		Expect(err).To(MatchError(ContainSubstring("an instance name is required")))
+	})
+})
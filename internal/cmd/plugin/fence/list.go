@@ -0,0 +1,96 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fence
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// fencingStatus is the list of the fenced instances of a cluster
+type fencingStatus struct {
+	Cluster string `json:"cluster"`
+
+	// AllInstances is true when the whole cluster is fenced
+	AllInstances bool `json:"allInstances"`
+
+	// Instances is the sorted list of the fenced instances,
+	// empty when the whole cluster is fenced
+	Instances []string `json:"instances"`
+}
+
+// fencingList shows the instances of a cluster that are currently fenced
+func fencingList(ctx context.Context, clusterName string, format plugin.OutputFormat) error {
+	var cluster apiv1.Cluster
+	if err := plugin.Client.Get(
+		ctx,
+		client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName},
+		&cluster,
+	); err != nil {
+		return fmt.Errorf("while getting cluster %s: %w", clusterName, err)
+	}
+
+	status, err := buildFencingStatus(&cluster)
+	if err != nil {
+		return err
+	}
+
+	if format != plugin.OutputFormatText {
+		return plugin.Print(status, format, os.Stdout)
+	}
+
+	switch {
+	case status.AllInstances:
+		fmt.Println("all")
+	case len(status.Instances) == 0:
+		fmt.Printf("No fenced instances in cluster %s\n", clusterName)
+	default:
+		for _, instance := range status.Instances {
+			fmt.Println(instance)
+		}
+	}
+
+	return nil
+}
+
+// buildFencingStatus decodes the fencing annotation of the cluster
+func buildFencingStatus(cluster *apiv1.Cluster) (*fencingStatus, error) {
+	fencedInstances, err := utils.GetFencedInstances(cluster.Annotations)
+	if err != nil {
+		return nil, fmt.Errorf("while decoding the %s annotation: %w", utils.FencedInstanceAnnotation, err)
+	}
+
+	if fencedInstances.Has(utils.FenceAllInstances) {
+		return &fencingStatus{
+			Cluster:      cluster.Name,
+			AllInstances: true,
+			Instances:    []string{},
+		}, nil
+	}
+
+	return &fencingStatus{
+		Cluster:   cluster.Name,
+		Instances: fencedInstances.ToSortedList(),
+	}, nil
+}
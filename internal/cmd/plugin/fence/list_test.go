@@ -0,0 +1,71 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fence
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("buildFencingStatus", func() {
+	newCluster := func(annotations map[string]string) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "cluster-example",
+				Annotations: annotations,
+			},
+		}
+	}
+
+	It("reports no fenced instances when the annotation is missing", func() {
+		status, err := buildFencingStatus(newCluster(nil))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.Cluster).To(Equal("cluster-example"))
+		Expect(status.AllInstances).To(BeFalse())
+		Expect(status.Instances).To(BeEmpty())
+	})
+
+	It("reports the sorted list of the fenced instances", func() {
+		status, err := buildFencingStatus(newCluster(map[string]string{
+			utils.FencedInstanceAnnotation: `["cluster-example-3","cluster-example-1"]`,
+		}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.AllInstances).To(BeFalse())
+		Expect(status.Instances).To(Equal([]string{"cluster-example-1", "cluster-example-3"}))
+	})
+
+	It("reports when the whole cluster is fenced", func() {
+		status, err := buildFencingStatus(newCluster(map[string]string{
+			utils.FencedInstanceAnnotation: `["*"]`,
+		}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.AllInstances).To(BeTrue())
+		Expect(status.Instances).To(BeEmpty())
+	})
+
+	It("fails when the annotation cannot be decoded", func() {
+		_, err := buildFencingStatus(newCluster(map[string]string{
+			utils.FencedInstanceAnnotation: `cluster-example-1`,
+		}))
+		Expect(err).To(MatchError(utils.ErrorFencedInstancesSyntax))
+	})
+})
@@ -22,14 +22,16 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	storagev1 "k8s.io/api/storage/v1"
 
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
 )
 
 // NewCmd initializes the fio command
 func NewCmd() *cobra.Command {
-	var storageClassName, deploymentName, pvcSize string
+	var storageClassName, deploymentName, pvcSize, jobFile, jobDefinition string
 	var dryRun bool
+	var storageClass *storagev1.StorageClass
 
 	fioCmd := &cobra.Command{
 		Use:     "fio [name]",
@@ -42,32 +44,55 @@ func NewCmd() *cobra.Command {
 			ctx := context.Background()
 			fioArgs := args[1:]
 			deploymentName = args[0]
-			fioCommand := newFioCommand(deploymentName, storageClassName, pvcSize, dryRun, fioArgs)
-			return fioCommand.execute(ctx)
+			fioCommand := newFioCommand(deploymentName, storageClassName, pvcSize, dryRun, jobDefinition, fioArgs)
+			if err := fioCommand.execute(ctx); err != nil {
+				printCleanupInstructions(deploymentName)
+				return err
+			}
+
+			if !dryRun {
+				fmt.Printf("The PVC %v is provisioned using %s\n\n", deploymentName, describeStorageClass(storageClass))
+			}
+			return nil
 		},
-		PreRun: func(_ *cobra.Command, _ []string) {
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			var err error
+			if jobFile != "" {
+				if jobDefinition, err = readJobFile(jobFile); err != nil {
+					return err
+				}
+			}
+
 			if !dryRun {
+				if storageClass, err = resolveStorageClass(cmd.Context(), plugin.Client, storageClassName); err != nil {
+					return err
+				}
+
 				fmt.Println("Running this directly to the cluster may produce a disruption in the service, " +
 					"are you sure you want to proceed? (y/n)")
 				var input string
-				_, err := fmt.Scanln(&input)
-				if err != nil {
+				if _, err := fmt.Scanln(&input); err != nil {
 					os.Exit(1)
 				}
 				if input != "y" {
 					os.Exit(0)
 				}
 			}
+
+			return nil
 		},
 		PostRun: func(_ *cobra.Command, _ []string) {
 			if !dryRun {
-				fmt.Printf("To remove this test you need to delete the Deployment, ConfigMap "+
-					"and PVC with the name %v\n\nThe most simple way to do this is to re-run the command that was run"+
-					"to generate the deployment with the --dry-run flag and pipe that output to kubectl delete, e.g.:\n\n"+
-					"kubectl cnpg fio <fio-job-name> --dry-run | kubectl delete -f -\n", deploymentName)
+				printCleanupInstructions(deploymentName)
 			}
 		},
 	}
+	fioCmd.Flags().StringVar(
+		&storageClassName,
+		"storage-class",
+		"",
+		"The name of the storage class that will be used to provision the PVC, defaulting to the default one",
+	)
 	fioCmd.Flags().StringVar(
 		&storageClassName,
 		"storageClass",
@@ -86,6 +111,24 @@ func NewCmd() *cobra.Command {
 		false,
 		"When true prints the deployment manifest instead of creating it",
 	)
+	fioCmd.Flags().StringVar(
+		&jobFile,
+		"job-file",
+		"",
+		"The path of a fio job file to be used instead of the default read benchmark",
+	)
+
+	_ = fioCmd.Flags().MarkDeprecated("storageClass", "use storage-class instead")
 
 	return fioCmd
 }
+
+// printCleanupInstructions explains how to remove the objects created by the
+// fio command, relying on the labels they have been created with
+func printCleanupInstructions(name string) {
+	fmt.Printf("To remove this test you need to delete the Deployment, ConfigMap "+
+		"and PVC with the name %v\n\nAll of them are labeled with %s, so the most simple way "+
+		"to do this is:\n\n"+
+		"kubectl delete deployment,configmap,pvc -n %v -l %s\n",
+		name, getLabelSelector(name), plugin.Namespace, getLabelSelector(name))
+}
@@ -19,11 +19,15 @@ package fio
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,6 +42,10 @@ type fioCommand struct {
 	pvcSize          string
 	fioCommandArgs   []string
 	dryRun           bool
+
+	// jobDefinition is the content of the fio job file, defaulting
+	// to defaultJobDefinition when empty
+	jobDefinition string
 }
 
 const (
@@ -53,18 +61,54 @@ var jobExample = `
   kubectl-cnpg fio <fio-name>
 
   # Dry-run command with given values and clusterName "cluster-example"
-  kubectl-cnpg fio <fio-name> -n <namespace> --storageClass <name> --pvcSize <size> --dry-run
+  kubectl-cnpg fio <fio-name> -n <namespace> --storage-class <name> --pvcSize <size> --dry-run
 
   # Create a job with given values and clusterName "cluster-example"
-  kubectl-cnpg fio <fio-name> -n <namespace> --storageClass <name> --pvcSize <size>
+  kubectl-cnpg fio <fio-name> -n <namespace> --storage-class <name> --pvcSize <size>
+
+  # Create a job using a custom fio job definition
+  kubectl-cnpg fio <fio-name> -n <namespace> --job-file <path-to-job-file>
 `
 
+// defaultJobDefinition is the fio job used when no job file is given
+const defaultJobDefinition = `[read]
+    direct=1
+    bs=8k
+    size=1G
+    time_based=1
+    runtime=60
+    ioengine=libaio
+    iodepth=32
+    end_fsync=1
+    log_avg_msec=1000
+    directory=/data
+    rw=read
+    write_bw_log=read
+    write_lat_log=read
+    write_iops_log=read`
+
+// getLabels returns the labels set on every object created by the fio
+// command, allowing them to be cleaned up together
+func getLabels(name string) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":     fioKeyWord,
+		"app.kubernetes.io/instance": name,
+	}
+}
+
+// getLabelSelector returns the label selector matching the objects
+// created by the fio command
+func getLabelSelector(name string) string {
+	return labels.SelectorFromSet(getLabels(name)).String()
+}
+
 // newFioCommand initialize fio deployment options
 func newFioCommand(
 	name string,
 	storageClassName string,
 	pvcSize string,
 	dryRun bool,
+	jobDefinition string,
 	fioCommandArgs []string,
 ) *fioCommand {
 	fioArgs := &fioCommand{
@@ -73,6 +117,7 @@ func newFioCommand(
 		dryRun:           dryRun,
 		fioCommandArgs:   fioCommandArgs,
 		pvcSize:          pvcSize,
+		jobDefinition:    jobDefinition,
 	}
 	return fioArgs
 }
@@ -99,6 +144,7 @@ func (cmd *fioCommand) generatePVCObject() (*corev1.PersistentVolumeClaim, error
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cmd.name,
 			Namespace: plugin.Namespace,
+			Labels:    getLabels(cmd.name),
 		},
 		Spec: corev1.PersistentVolumeClaimSpec{
 			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
@@ -135,28 +181,38 @@ func (cmd *fioCommand) generateConfigMapObject() *corev1.ConfigMap {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cmd.name,
 			Namespace: plugin.Namespace,
+			Labels:    getLabels(cmd.name),
 		},
 		Data: map[string]string{
-			"job": `[read]
-    direct=1
-    bs=8k
-    size=1G
-    time_based=1
-    runtime=60
-    ioengine=libaio
-    iodepth=32
-    end_fsync=1
-    log_avg_msec=1000
-    directory=/data
-    rw=read
-    write_bw_log=read
-    write_lat_log=read
-    write_iops_log=read`,
+			"job": cmd.getJobDefinition(),
 		},
 	}
 	return result
 }
 
+func (cmd *fioCommand) getJobDefinition() string {
+	if cmd.jobDefinition == "" {
+		return defaultJobDefinition
+	}
+
+	return cmd.jobDefinition
+}
+
+// readJobFile reads a fio job definition from a local file,
+// ensuring it is not empty
+func readJobFile(path string) (string, error) {
+	content, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return "", fmt.Errorf("while reading the fio job file: %w", err)
+	}
+
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return "", fmt.Errorf("the fio job file %s is empty", path)
+	}
+
+	return string(content), nil
+}
+
 func getSecurityContext() *corev1.SecurityContext {
 	runAs := int64(10001)
 	sc := &corev1.SecurityContext{
@@ -203,21 +259,16 @@ func (cmd *fioCommand) generateFioDeployment(deploymentName string) *appsv1.Depl
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      deploymentName,
 			Namespace: plugin.Namespace,
+			Labels:    getLabels(deploymentName),
 		},
 
 		Spec: appsv1.DeploymentSpec{
 			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"app.kubernetes.io/name":     fioKeyWord,
-					"app.kubernetes.io/instance": deploymentName,
-				},
+				MatchLabels: getLabels(deploymentName),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"app.kubernetes.io/name":     fioKeyWord,
-						"app.kubernetes.io/instance": deploymentName,
-					},
+					Labels: getLabels(deploymentName),
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
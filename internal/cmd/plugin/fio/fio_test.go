@@ -0,0 +1,63 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fio
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("fio job definition", func() {
+	const customJob = "[write]\n    bs=4k\n    rw=randwrite\n    iodepth=16\n    directory=/data\n"
+
+	It("uses the default job when no job file is given", func() {
+		cmd := newFioCommand("fio-test", "", "2Gi", true, "", nil)
+		configMap := cmd.generateConfigMapObject()
+		Expect(configMap.Data).To(HaveKeyWithValue("job", defaultJobDefinition))
+	})
+
+	It("uses the content of the job file when given", func() {
+		cmd := newFioCommand("fio-test", "", "2Gi", true, customJob, nil)
+		configMap := cmd.generateConfigMapObject()
+		Expect(configMap.Data).To(HaveKeyWithValue("job", customJob))
+	})
+
+	It("reads a job file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "job.fio")
+		Expect(os.WriteFile(path, []byte(customJob), 0o600)).To(Succeed())
+
+		content, err := readJobFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(content).To(Equal(customJob))
+	})
+
+	It("rejects an empty job file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "job.fio")
+		Expect(os.WriteFile(path, []byte(" \n\t\n"), 0o600)).To(Succeed())
+
+		_, err := readJobFile(path)
+		Expect(err).To(MatchError(ContainSubstring("is empty")))
+	})
+
+	It("rejects a missing job file", func() {
+		_, err := readJobFile(filepath.Join(GinkgoT().TempDir(), "missing.fio"))
+		Expect(err).To(HaveOccurred())
+	})
+})
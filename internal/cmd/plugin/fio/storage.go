@@ -0,0 +1,69 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fio
+
+import (
+	"context"
+	"fmt"
+
+	storagev1 "k8s.io/api/storage/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultStorageClassAnnotation is the annotation marking the default storage class
+const defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// resolveStorageClass gets the storage class that will be used to provision
+// the benchmark PVC, failing if it doesn't exist. When no name is given the
+// default storage class is returned, or nil when none is defined
+func resolveStorageClass(ctx context.Context, cli client.Client, name string) (*storagev1.StorageClass, error) {
+	if name != "" {
+		var storageClass storagev1.StorageClass
+		err := cli.Get(ctx, client.ObjectKey{Name: name}, &storageClass)
+		if apierrs.IsNotFound(err) {
+			return nil, fmt.Errorf("storage class %q not found", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("while getting storage class %q: %w", name, err)
+		}
+		return &storageClass, nil
+	}
+
+	var storageClasses storagev1.StorageClassList
+	if err := cli.List(ctx, &storageClasses); err != nil {
+		return nil, fmt.Errorf("while listing the storage classes: %w", err)
+	}
+
+	for idx := range storageClasses.Items {
+		if storageClasses.Items[idx].Annotations[defaultStorageClassAnnotation] == "true" {
+			return &storageClasses.Items[idx], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// describeStorageClass returns a description of the storage class
+// used by the benchmark, including its provisioner
+func describeStorageClass(storageClass *storagev1.StorageClass) string {
+	if storageClass == nil {
+		return "no storage class, as no default one is defined"
+	}
+
+	return fmt.Sprintf("storage class %q (provisioner: %s)", storageClass.Name, storageClass.Provisioner)
+}
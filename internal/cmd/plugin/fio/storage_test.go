@@ -0,0 +1,95 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fio
+
+import (
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("resolveStorageClass", func() {
+	standard := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "standard",
+			Annotations: map[string]string{defaultStorageClassAnnotation: "true"},
+		},
+		Provisioner: "rancher.io/local-path",
+	}
+	fast := &storagev1.StorageClass{
+		ObjectMeta:  metav1.ObjectMeta{Name: "fast"},
+		Provisioner: "ebs.csi.aws.com",
+	}
+
+	It("gets the requested storage class", func(ctx SpecContext) {
+		cli := fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(standard, fast).Build()
+
+		storageClass, err := resolveStorageClass(ctx, cli, "fast")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(storageClass.Provisioner).To(Equal("ebs.csi.aws.com"))
+		Expect(describeStorageClass(storageClass)).To(ContainSubstring("ebs.csi.aws.com"))
+	})
+
+	It("fails when the requested storage class doesn't exist", func(ctx SpecContext) {
+		cli := fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(standard).Build()
+
+		_, err := resolveStorageClass(ctx, cli, "fast")
+		Expect(err).To(MatchError(ContainSubstring("not found")))
+	})
+
+	It("defaults to the default storage class", func(ctx SpecContext) {
+		cli := fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(standard, fast).Build()
+
+		storageClass, err := resolveStorageClass(ctx, cli, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(storageClass.Name).To(Equal("standard"))
+	})
+
+	It("returns nothing when there's no default storage class", func(ctx SpecContext) {
+		cli := fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(fast).Build()
+
+		storageClass, err := resolveStorageClass(ctx, cli, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(storageClass).To(BeNil())
+	})
+})
+
+var _ = Describe("fio objects labels", func() {
+	It("labels every created object", func() {
+		cmd := newFioCommand("fio-test", "fast", "2Gi", true, "", nil)
+		pvc, err := cmd.generatePVCObject()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*pvc.Spec.StorageClassName).To(Equal("fast"))
+
+		expectedLabels := getLabels("fio-test")
+		Expect(pvc.Labels).To(Equal(expectedLabels))
+		Expect(cmd.generateConfigMapObject().Labels).To(Equal(expectedLabels))
+		deployment := cmd.generateFioDeployment("fio-test")
+		Expect(deployment.Labels).To(Equal(expectedLabels))
+		Expect(deployment.Spec.Template.Labels).To(Equal(expectedLabels))
+		Expect(getLabelSelector("fio-test")).To(Equal("app.kubernetes.io/instance=fio-test,app.kubernetes.io/name=fio"))
+	})
+})
@@ -18,6 +18,7 @@ package hibernate
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -39,12 +40,25 @@ var (
 				return err
 			}
 
+			options, wait, err := getWaitOptions(cmd)
+			if err != nil {
+				return err
+			}
+
 			hibernateOn, err := newOnCommand(cmd.Context(), clusterName, force)
 			if err != nil {
 				return err
 			}
 
-			return hibernateOn.execute()
+			if err := hibernateOn.execute(); err != nil {
+				return err
+			}
+
+			if !wait {
+				return nil
+			}
+			return waitUntil(cmd.Context(), options,
+				newHibernationCheck(plugin.Client, plugin.Namespace, clusterName, hibernateOn.pvcs))
 		},
 	}
 
@@ -57,8 +71,21 @@ var (
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clusterName := args[0]
+			options, wait, err := getWaitOptions(cmd)
+			if err != nil {
+				return err
+			}
+
 			off := newOffCommand(cmd.Context(), clusterName)
-			return off.execute()
+			if err := off.execute(); err != nil {
+				return err
+			}
+
+			if !wait {
+				return nil
+			}
+			return waitUntil(cmd.Context(), options,
+				newReactivationCheck(plugin.Client, plugin.Namespace, clusterName))
 		},
 	}
 
@@ -89,6 +116,28 @@ var (
 	}
 )
 
+// defaultWaitTimeout is the default maximum time the hibernate
+// commands wait for the end of the operation
+const defaultWaitTimeout = 10 * time.Minute
+
+// getWaitOptions reads the --wait and --timeout flags of a command
+func getWaitOptions(cmd *cobra.Command) (waitOptions, bool, error) {
+	wait, err := cmd.Flags().GetBool("wait")
+	if err != nil {
+		return waitOptions{}, false, err
+	}
+
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return waitOptions{}, false, err
+	}
+	if timeout < 0 {
+		return waitOptions{}, false, fmt.Errorf("timeout cannot be negative: %s", timeout)
+	}
+
+	return waitOptions{timeout: timeout, pollingInterval: 2 * time.Second}, wait, nil
+}
+
 // NewCmd initializes the hibernate command
 func NewCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -105,6 +154,22 @@ func NewCmd() *cobra.Command {
 		"force",
 		false,
 		"Force the hibernation procedure even if the preconditions are not met")
+	hibernateOnCmd.Flags().Bool(
+		"wait",
+		false,
+		"Wait for all the instance pods to be deleted, while the PVCs are preserved")
+	hibernateOnCmd.Flags().Duration(
+		"timeout",
+		defaultWaitTimeout,
+		"The maximum time to wait when --wait is set, 0 means no limit")
+	hibernateOffCmd.Flags().Bool(
+		"wait",
+		false,
+		"Wait for the cluster to be healthy")
+	hibernateOffCmd.Flags().Duration(
+		"timeout",
+		defaultWaitTimeout,
+		"The maximum time to wait when --wait is set, 0 means no limit")
 	hibernateStatusCmd.Flags().
 		StringP(
 			"output",
@@ -110,6 +110,12 @@ func (off *offCommand) ensureClusterDoesNotExistStep() error {
 
 // ensurePVCsArePartOfAPVCGroupStep check if the passed PVCs are really part of the same group
 func (off *offCommand) ensurePVCsArePartOfAPVCGroupStep(pvcs []corev1.PersistentVolumeClaim) error {
+	return ensurePVCsArePartOfAPVCGroup(pvcs)
+}
+
+// ensurePVCsArePartOfAPVCGroup check if the passed PVCs are hibernated
+// and belong to the same instance
+func ensurePVCsArePartOfAPVCGroup(pvcs []corev1.PersistentVolumeClaim) error {
 	// ensure all the pvcs belong to the same node serial and are hibernated
 	var nodeSerial []string
 	for _, pvc := range pvcs {
@@ -272,7 +272,7 @@ func (on *onCommand) deleteResourcesStep() error {
 		on.ctx,
 		on.cluster.Name,
 		fmt.Sprintf("%s-%s", on.cluster.Name, strconv.Itoa(on.primaryInstanceSerial)),
-		true,
+		destroy.AllPVCRoles,
 	); err != nil {
 		return fmt.Errorf("error destroying primary instance: %w", err)
 	}
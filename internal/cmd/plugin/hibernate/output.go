@@ -45,6 +45,7 @@ type statusOutputManager interface {
 	addHibernationSummaryInformation(level statusLevel, statusMessage, clusterName string)
 	addClusterManifestInformation(cluster *apiv1.Cluster)
 	addPVCGroupInformation(pvcs []corev1.PersistentVolumeClaim)
+	addStorageInformation(storage hibernatedStorage)
 	// execute renders the output
 	execute() error
 }
@@ -123,6 +124,29 @@ func (t *textStatusOutputManager) addPVCGroupInformation(
 	t.textPrinter.AddLine(value)
 }
 
+func (t *textStatusOutputManager) addStorageInformation(storage hibernatedStorage) {
+	level := okLevel
+	consistent := "Yes"
+	if !storage.ConsistentForWake {
+		level = errorLevel
+		consistent = fmt.Sprintf("No, %s", storage.Inconsistency)
+	}
+
+	t.textPrinter.AddHeader(aurora.Colorize("Hibernated Storage", t.getColor(level)))
+	t.textPrinter.AddLine("PostgreSQL version", storage.PostgresVersion)
+	t.textPrinter.AddLine("Latest checkpoint location", storage.LatestCheckpointLocation)
+	t.textPrinter.AddLine("Database cluster state", storage.DatabaseClusterState)
+	t.textPrinter.AddLine("Consistent for wake", consistent)
+	t.textPrinter.AddLine("Reclaimable storage", storage.TotalSize)
+	t.textPrinter.AddLine()
+
+	t.textPrinter.AddHeader("PVC", "Role", "Size", "Persistent Volume")
+	for _, pvc := range storage.PVCs {
+		t.textPrinter.AddLine(pvc.Name, pvc.Role, pvc.Size, pvc.PersistentVolume)
+	}
+	t.textPrinter.AddLine()
+}
+
 func (t *textStatusOutputManager) execute() error {
 	// do not remove this is to flush the writer cache into the buffer
 	t.textPrinter.Print()
@@ -205,6 +229,10 @@ func (t *structuredStatusOutputManager) addPVCGroupInformation(
 	t.mapToSerialize["pgControlData"] = tmp
 }
 
+func (t *structuredStatusOutputManager) addStorageInformation(storage hibernatedStorage) {
+	t.mapToSerialize["storage"] = storage
+}
+
 func (t *structuredStatusOutputManager) execute() error {
 	return plugin.Print(t.mapToSerialize, t.format, os.Stdout)
 }
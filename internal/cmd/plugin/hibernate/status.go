@@ -92,6 +92,7 @@ func (cmd *statusCommand) clusterHibernatedOutput(pvcs []corev1.PersistentVolume
 
 	cmd.outputManager.addHibernationSummaryInformation(okLevel, "Cluster Hibernated", cmd.clusterName)
 	cmd.outputManager.addClusterManifestInformation(&clusterFromPVC)
+	cmd.outputManager.addStorageInformation(buildHibernatedStorage(cmd.ctx, &clusterFromPVC, pvcs))
 	cmd.outputManager.addPVCGroupInformation(pvcs)
 
 	return cmd.outputManager.execute()
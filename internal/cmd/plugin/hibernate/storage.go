@@ -0,0 +1,125 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// hibernatedStorage describes the storage preserved by a hibernated cluster
+type hibernatedStorage struct {
+	// PostgresVersion is the PostgreSQL version of the hibernated cluster,
+	// as resulting from its image
+	PostgresVersion string `json:"postgresVersion,omitempty"`
+
+	// LatestCheckpointLocation is the LSN of the latest checkpoint
+	// stored in the pg_controldata output captured at hibernation time
+	LatestCheckpointLocation string `json:"latestCheckpointLocation,omitempty"`
+
+	// DatabaseClusterState is the state of the data directory
+	// captured at hibernation time
+	DatabaseClusterState string `json:"databaseClusterState,omitempty"`
+
+	// ConsistentForWake is true when the data directory has been cleanly
+	// shut down and the PVCs can be used to bring the cluster back
+	ConsistentForWake bool `json:"consistentForWake"`
+
+	// Inconsistency explains why the storage is not consistent for wake
+	Inconsistency string `json:"inconsistency,omitempty"`
+
+	// TotalSize is the storage reserved by the preserved PVCs
+	TotalSize string `json:"totalSize"`
+
+	PVCs []hibernatedPVC `json:"pvcs"`
+}
+
+// hibernatedPVC describes a PVC preserved by a hibernated cluster
+type hibernatedPVC struct {
+	Name             string `json:"name"`
+	Role             string `json:"role,omitempty"`
+	Size             string `json:"size,omitempty"`
+	PersistentVolume string `json:"persistentVolume,omitempty"`
+}
+
+// buildHibernatedStorage describes the storage preserved by a hibernated
+// cluster, given its manifest and its PVCs
+func buildHibernatedStorage(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	pvcs []corev1.PersistentVolumeClaim,
+) hibernatedStorage {
+	storage := hibernatedStorage{
+		PVCs: make([]hibernatedPVC, 0, len(pvcs)),
+	}
+
+	if pgVersion, err := cluster.GetPostgresqlVersion(); err == nil {
+		storage.PostgresVersion = fmt.Sprintf("%d.%d", pgVersion.Major(), pgVersion.Minor())
+	}
+
+	totalSize := resource.Quantity{}
+	for _, pvc := range pvcs {
+		size := getPVCSize(pvc)
+		totalSize.Add(size)
+		storage.PVCs = append(storage.PVCs, hibernatedPVC{
+			Name:             pvc.Name,
+			Role:             pvc.Labels[utils.PvcRoleLabelName],
+			Size:             size.String(),
+			PersistentVolume: pvc.Spec.VolumeName,
+		})
+	}
+	slices.SortFunc(storage.PVCs, func(a, b hibernatedPVC) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	storage.TotalSize = totalSize.String()
+
+	if err := ensurePVCsArePartOfAPVCGroup(pvcs); err != nil {
+		storage.Inconsistency = err.Error()
+		return storage
+	}
+
+	// every PVC of the group is annotated with the same data
+	controlData := utils.ParsePgControldataOutput(pvcs[0].Annotations[utils.HibernatePgControlDataAnnotationName])
+	storage.LatestCheckpointLocation = controlData[utils.PgControlDataKeyLatestCheckpointLocation]
+	storage.DatabaseClusterState = controlData[utils.PgControlDataDatabaseClusterStateKey]
+
+	if !utils.PgDataState(storage.DatabaseClusterState).IsShutdown(ctx) {
+		storage.Inconsistency = fmt.Sprintf("the data directory was not cleanly shut down (state: %q)",
+			storage.DatabaseClusterState)
+		return storage
+	}
+
+	storage.ConsistentForWake = true
+	return storage
+}
+
+// getPVCSize returns the capacity of a PVC, or the requested
+// storage if it is not bound yet
+func getPVCSize(pvc corev1.PersistentVolumeClaim) resource.Quantity {
+	if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		return capacity
+	}
+	return pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+}
@@ -0,0 +1,125 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernate
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("buildHibernatedStorage", func() {
+	const controlData = "pg_control version number:            1300\n" +
+		"Database cluster state:               shut down\n" +
+		"Latest checkpoint location:           0/7000028\n"
+
+	cluster := &apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+		Spec: apiv1.ClusterSpec{
+			ImageName: "ghcr.io/cloudnative-pg/postgresql:16.4",
+		},
+	}
+
+	newPVC := func(name string, role utils.PVCRole, size string, bound bool) corev1.PersistentVolumeClaim {
+		pvc := corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{utils.PvcRoleLabelName: string(role)},
+				Annotations: map[string]string{
+					utils.HibernateClusterManifestAnnotationName: "{}",
+					utils.HibernatePgControlDataAnnotationName:   controlData,
+					utils.ClusterSerialAnnotationName:            "1",
+				},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+				},
+			},
+		}
+		if bound {
+			pvc.Spec.VolumeName = "pv-" + name
+			pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)}
+		}
+		return pvc
+	}
+
+	It("describes the preserved storage of a consistent hibernation", func(ctx SpecContext) {
+		storage := buildHibernatedStorage(ctx, cluster, []corev1.PersistentVolumeClaim{
+			newPVC("cluster-example-1-wal", utils.PVCRolePgWal, "1Gi", true),
+			newPVC("cluster-example-1", utils.PVCRolePgData, "2Gi", true),
+		})
+
+		Expect(storage.PostgresVersion).To(Equal("16.4"))
+		Expect(storage.LatestCheckpointLocation).To(Equal("0/7000028"))
+		Expect(storage.DatabaseClusterState).To(Equal("shut down"))
+		Expect(storage.ConsistentForWake).To(BeTrue())
+		Expect(storage.TotalSize).To(Equal("3Gi"))
+		Expect(storage.PVCs).To(Equal([]hibernatedPVC{
+			{
+				Name:             "cluster-example-1",
+				Role:             string(utils.PVCRolePgData),
+				Size:             "2Gi",
+				PersistentVolume: "pv-cluster-example-1",
+			},
+			{
+				Name:             "cluster-example-1-wal",
+				Role:             string(utils.PVCRolePgWal),
+				Size:             "1Gi",
+				PersistentVolume: "pv-cluster-example-1-wal",
+			},
+		}))
+	})
+
+	It("uses the requested size of the PVCs that are not bound", func(ctx SpecContext) {
+		storage := buildHibernatedStorage(ctx, cluster, []corev1.PersistentVolumeClaim{
+			newPVC("cluster-example-1", utils.PVCRolePgData, "2Gi", false),
+		})
+
+		Expect(storage.TotalSize).To(Equal("2Gi"))
+		Expect(storage.PVCs[0].PersistentVolume).To(BeEmpty())
+	})
+
+	It("reports PVCs belonging to different instances as not consistent", func(ctx SpecContext) {
+		pvc := newPVC("cluster-example-2", utils.PVCRolePgData, "2Gi", true)
+		pvc.Annotations[utils.ClusterSerialAnnotationName] = "2"
+
+		storage := buildHibernatedStorage(ctx, cluster, []corev1.PersistentVolumeClaim{
+			newPVC("cluster-example-1", utils.PVCRolePgData, "2Gi", true),
+			pvc,
+		})
+
+		Expect(storage.ConsistentForWake).To(BeFalse())
+		Expect(storage.Inconsistency).To(ContainSubstring("different instances"))
+	})
+
+	It("reports a data directory not cleanly shut down as not consistent", func(ctx SpecContext) {
+		pvc := newPVC("cluster-example-1", utils.PVCRolePgData, "2Gi", true)
+		pvc.Annotations[utils.HibernatePgControlDataAnnotationName] = "Database cluster state: in production\n"
+
+		storage := buildHibernatedStorage(ctx, cluster, []corev1.PersistentVolumeClaim{pvc})
+
+		Expect(storage.ConsistentForWake).To(BeFalse())
+		Expect(storage.Inconsistency).To(ContainSubstring("in production"))
+	})
+})
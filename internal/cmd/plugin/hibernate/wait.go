@@ -0,0 +1,176 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// waitOptions are the options controlling how the hibernate commands
+// wait for the end of the operation
+type waitOptions struct {
+	// timeout is the maximum time to wait, zero meaning no limit
+	timeout time.Duration
+
+	pollingInterval time.Duration
+}
+
+// waitCheck inspects the state of the operation, returning whether it is
+// completed and, if not, a description of the stage it is waiting for
+type waitCheck func(ctx context.Context) (done bool, stage string, err error)
+
+// waitUntil polls check until the operation is completed or the timeout
+// expires, printing every change of stage
+func waitUntil(ctx context.Context, options waitOptions, check waitCheck) error {
+	if options.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(options.pollingInterval)
+	defer ticker.Stop()
+
+	var lastStage string
+	for {
+		done, stage, err := check(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		if stage != lastStage {
+			fmt.Println(stage)
+			lastStage = stage
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timeout after %s: %s", options.timeout, lastStage)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// newHibernationCheck checks that every instance Pod of the cluster has
+// been deleted and that the hibernated PVCs are preserved with their
+// hibernation annotations
+func newHibernationCheck(
+	cli client.Client,
+	namespace, clusterName string,
+	pvcs []corev1.PersistentVolumeClaim,
+) waitCheck {
+	return func(ctx context.Context) (bool, string, error) {
+		var podList corev1.PodList
+		if err := cli.List(
+			ctx,
+			&podList,
+			client.InNamespace(namespace),
+			client.MatchingLabels{
+				utils.ClusterLabelName: clusterName,
+				utils.PodRoleLabelName: string(utils.PodRoleInstance),
+			},
+		); err != nil {
+			return false, "", fmt.Errorf("while listing the instance pods: %w", err)
+		}
+		if len(podList.Items) > 0 {
+			return false, describeRemainingPods(podList.Items), nil
+		}
+
+		for _, pvc := range pvcs {
+			var currentPVC corev1.PersistentVolumeClaim
+			err := cli.Get(ctx, client.ObjectKey{Namespace: pvc.Namespace, Name: pvc.Name}, &currentPVC)
+			if apierrs.IsNotFound(err) {
+				return false, "", fmt.Errorf("hibernated PVC %s has been deleted", pvc.Name)
+			}
+			if err != nil {
+				return false, "", fmt.Errorf("while getting PVC %s: %w", pvc.Name, err)
+			}
+			if err := ensureAnnotationsExists(
+				currentPVC,
+				utils.HibernateClusterManifestAnnotationName,
+				utils.HibernatePgControlDataAnnotationName,
+			); err != nil {
+				return false, "", err
+			}
+		}
+
+		fmt.Printf("all the instance pods have been deleted, %d PVC(s) preserved\n", len(pvcs))
+		return true, "", nil
+	}
+}
+
+// describeRemainingPods describes why the instance pods are not deleted yet
+func describeRemainingPods(pods []corev1.Pod) string {
+	descriptions := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		switch {
+		case pod.DeletionTimestamp == nil:
+			descriptions = append(descriptions, fmt.Sprintf("%s (not deleted yet)", pod.Name))
+		case len(pod.Finalizers) > 0:
+			descriptions = append(descriptions, fmt.Sprintf("%s (terminating, blocked by finalizers: %s)",
+				pod.Name, strings.Join(pod.Finalizers, ", ")))
+		default:
+			descriptions = append(descriptions, fmt.Sprintf("%s (terminating)", pod.Name))
+		}
+	}
+	slices.Sort(descriptions)
+
+	return fmt.Sprintf("waiting for the instance pods to be deleted: %s", strings.Join(descriptions, "; "))
+}
+
+// newReactivationCheck checks that the cluster has been recreated
+// and reached the healthy phase
+func newReactivationCheck(cli client.Client, namespace, clusterName string) waitCheck {
+	return func(ctx context.Context) (bool, string, error) {
+		var cluster apiv1.Cluster
+		err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, &cluster)
+		if apierrs.IsNotFound(err) {
+			return false, "waiting for the cluster to be created", nil
+		}
+		if err != nil {
+			return false, "", fmt.Errorf("while getting cluster %s: %w", clusterName, err)
+		}
+
+		if cluster.Status.Phase == apiv1.PhaseHealthy {
+			fmt.Println("cluster is healthy")
+			return true, "", nil
+		}
+
+		stage := fmt.Sprintf("waiting for the cluster to be healthy, current phase: %q", cluster.Status.Phase)
+		if cluster.Status.PhaseReason != "" {
+			stage += fmt.Sprintf(" (%s)", cluster.Status.PhaseReason)
+		}
+		return false, stage, nil
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernate
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("waitUntil", func() {
+	options := waitOptions{timeout: 50 * time.Millisecond, pollingInterval: time.Millisecond}
+
+	It("returns when the operation is completed", func(ctx SpecContext) {
+		calls := 0
+		err := waitUntil(ctx, options, func(context.Context) (bool, string, error) {
+			calls++
+			return calls == 3, "still working", nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal(3))
+	})
+
+	It("reports the stage the operation was stuck in on timeout", func(ctx SpecContext) {
+		err := waitUntil(ctx, options, func(context.Context) (bool, string, error) {
+			return false, "waiting for the instance pods to be deleted", nil
+		})
+		Expect(err).To(MatchError(ContainSubstring("timeout after 50ms: waiting for the instance pods to be deleted")))
+	})
+
+	It("stops on errors", func(ctx SpecContext) {
+		expectedErr := errors.New("boom")
+		err := waitUntil(ctx, options, func(context.Context) (bool, string, error) {
+			return false, "", expectedErr
+		})
+		Expect(err).To(MatchError(expectedErr))
+	})
+})
+
+var _ = Describe("hibernation checks", func() {
+	const (
+		namespace   = "default"
+		clusterName = "cluster-example"
+	)
+
+	newClient := func(objects ...client.Object) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(objects...).
+			Build()
+	}
+
+	hibernatedPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-example-1",
+			Namespace: namespace,
+			Annotations: map[string]string{
+				utils.HibernateClusterManifestAnnotationName: "{}",
+				utils.HibernatePgControlDataAnnotationName:   "pg_control version number: 1300",
+			},
+		},
+	}
+
+	instancePod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-example-2",
+			Namespace: namespace,
+			Labels: map[string]string{
+				utils.ClusterLabelName: clusterName,
+				utils.PodRoleLabelName: string(utils.PodRoleInstance),
+			},
+		},
+	}
+
+	poolerPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-example-pooler-rw-1",
+			Namespace: namespace,
+			Labels: map[string]string{
+				utils.ClusterLabelName: clusterName,
+				utils.PodRoleLabelName: string(utils.PodRolePooler),
+			},
+		},
+	}
+
+	It("waits for the instance pods to be deleted", func(ctx SpecContext) {
+		cli := newClient(hibernatedPVC.DeepCopy(), instancePod.DeepCopy())
+		check := newHibernationCheck(cli, namespace, clusterName, []corev1.PersistentVolumeClaim{*hibernatedPVC})
+
+		done, stage, err := check(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(done).To(BeFalse())
+		Expect(stage).To(ContainSubstring("cluster-example-2 (not deleted yet)"))
+	})
+
+	It("completes when the pods are gone and the PVCs are preserved", func(ctx SpecContext) {
+		cli := newClient(hibernatedPVC.DeepCopy(), poolerPod.DeepCopy())
+		check := newHibernationCheck(cli, namespace, clusterName, []corev1.PersistentVolumeClaim{*hibernatedPVC})
+
+		done, _, err := check(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(done).To(BeTrue())
+	})
+
+	It("fails when a hibernated PVC has been deleted", func(ctx SpecContext) {
+		cli := newClient()
+		check := newHibernationCheck(cli, namespace, clusterName, []corev1.PersistentVolumeClaim{*hibernatedPVC})
+
+		_, _, err := check(ctx)
+		Expect(err).To(MatchError(ContainSubstring("has been deleted")))
+	})
+
+	It("describes the pods blocked by finalizers", func() {
+		pod := instancePod.DeepCopy()
+		pod.DeletionTimestamp = ptr.To(metav1.Now())
+		pod.Finalizers = []string{"example.com/protection"}
+
+		Expect(describeRemainingPods([]corev1.Pod{*pod})).
+			To(ContainSubstring("cluster-example-2 (terminating, blocked by finalizers: example.com/protection)"))
+	})
+
+	It("waits for the reactivated cluster to be healthy", func(ctx SpecContext) {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: namespace},
+			Status: apiv1.ClusterStatus{
+				Phase:       apiv1.PhaseFirstPrimary,
+				PhaseReason: "Creating primary instance",
+			},
+		}
+		check := newReactivationCheck(newClient(cluster), namespace, clusterName)
+
+		done, stage, err := check(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(done).To(BeFalse())
+		Expect(stage).To(ContainSubstring("Creating primary instance"))
+
+		cluster.Status.Phase = apiv1.PhaseHealthy
+		check = newReactivationCheck(newClient(cluster), namespace, clusterName)
+		done, _, err = check(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(done).To(BeTrue())
+	})
+})
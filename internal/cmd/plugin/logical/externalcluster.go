@@ -54,5 +54,5 @@ func GetConnectionString(
 		return "", fmt.Errorf("external cluster not existent in the cluster definition")
 	}
 
-	return external.GetServerConnectionString(&externalCluster, databaseName), nil
+	return external.GetServerConnectionString(ctx, plugin.Client, plugin.Namespace, &externalCluster, databaseName)
 }
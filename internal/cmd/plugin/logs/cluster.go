@@ -34,6 +34,9 @@ func clusterCmd() *cobra.Command {
 			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateRole(cl.role); err != nil {
+				return err
+			}
 			cl.clusterName = args[0]
 			cl.namespace = plugin.Namespace
 			cl.ctx = cmd.Context()
@@ -53,6 +56,17 @@ func clusterCmd() *cobra.Command {
 		"Number of lines from the end of the logs to show for each pod. By default there is no limit")
 	cmd.Flags().BoolVarP(&cl.follow, "follow", "f", false,
 		"Follow cluster logs (watches for new and re-created pods)")
+	cmd.Flags().DurationVar(&cl.since, "since", 0,
+		"Only return logs newer than a relative duration like 5s, 2m, or 3h. "+
+			"Takes precedence over the starting time implied by --timestamps")
+	cmd.Flags().StringVar(&cl.role, "role", "",
+		"Only return the logs of the instances having the given role, as reported by the cluster status. "+
+			"Valid values are: primary, replica")
+	cmd.Flags().Var(&cl.level, "level",
+		"Only return the JSON log records having the given level. "+
+			"Valid values are: error, warning, info, debug, trace")
+	cmd.Flags().BoolVar(&cl.prefix, "prefix", false,
+		"Prepend the name of the instance to each log line")
 
 	return cmd
 }
@@ -31,9 +31,19 @@ import (
 
 	cnpgv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin/logs/pretty"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils/logs"
 )
 
+const (
+	// rolePrimary selects the logs of the current primary instance
+	rolePrimary = "primary"
+
+	// roleReplica selects the logs of the replica instances
+	roleReplica = "replica"
+)
+
 // clusterLogs contains the options and context to retrieve cluster logs
 type clusterLogs struct {
 	ctx         context.Context
@@ -43,6 +53,10 @@ type clusterLogs struct {
 	tailLines   int64
 	outputFile  string
 	follow      bool
+	since       time.Duration
+	role        string
+	level       pretty.LogLevel
+	prefix      bool
 	client      kubernetes.Interface
 }
 
@@ -57,8 +71,13 @@ func getCluster(cl clusterLogs) (*cnpgv1.Cluster, error) {
 
 func getStreamClusterLogs(cluster *cnpgv1.Cluster, cl clusterLogs) logs.ClusterStreamingRequest {
 	var sinceTime *metav1.Time
+	var sinceSeconds *int64
 	var tail *int64
-	if cl.timestamp {
+	switch {
+	case cl.since > 0:
+		seconds := int64(cl.since.Seconds())
+		sinceSeconds = &seconds
+	case cl.timestamp:
 		sinceTime = &metav1.Time{Time: time.Now().UTC()}
 	}
 	if cl.tailLines >= 0 {
@@ -67,12 +86,44 @@ func getStreamClusterLogs(cluster *cnpgv1.Cluster, cl clusterLogs) logs.ClusterS
 	return logs.ClusterStreamingRequest{
 		Cluster: cluster,
 		Options: &corev1.PodLogOptions{
-			Timestamps: cl.timestamp,
-			Follow:     cl.follow,
-			SinceTime:  sinceTime,
-			TailLines:  tail,
+			Timestamps:   cl.timestamp,
+			Follow:       cl.follow,
+			SinceTime:    sinceTime,
+			SinceSeconds: sinceSeconds,
+			TailLines:    tail,
 		},
-		Client: cl.client,
+		PodFilter:         getRolePodFilter(cluster, cl.role),
+		Level:             cl.level.String(),
+		PrefixWithPodName: cl.prefix,
+		Client:            cl.client,
+	}
+}
+
+// getRolePodFilter returns a filter accepting the instance pods having
+// the passed role, as resolved by the cluster status. When the role
+// is empty, every pod of the cluster is accepted
+func getRolePodFilter(cluster *cnpgv1.Cluster, role string) func(pod *corev1.Pod) bool {
+	if role == "" {
+		return nil
+	}
+
+	return func(pod *corev1.Pod) bool {
+		if pod.Labels[utils.PodRoleLabelName] != string(utils.PodRoleInstance) {
+			return false
+		}
+
+		isPrimary := pod.Name == cluster.Status.CurrentPrimary
+		return isPrimary == (role == rolePrimary)
+	}
+}
+
+// validateRole checks if the passed role is supported
+func validateRole(role string) error {
+	switch role {
+	case "", rolePrimary, roleReplica:
+		return nil
+	default:
+		return fmt.Errorf("invalid role %q, expected %q or %q", role, rolePrimary, roleReplica)
 	}
 }
 
@@ -18,6 +18,7 @@ package logs
 
 import (
 	"path"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -97,6 +98,51 @@ var _ = Describe("Get the logs", Ordered, func() {
 		Expect(*logsStream.Options.TailLines).To(BeEquivalentTo(5))
 	})
 
+	It("should prefer the relative duration to the timestamps starting time", func() {
+		cl.since = 5 * time.Minute
+		logsStream := getStreamClusterLogs(cluster, cl)
+		Expect(logsStream.Options.SinceTime).To(BeNil())
+		Expect(logsStream.Options.SinceSeconds).ToNot(BeNil())
+		Expect(*logsStream.Options.SinceSeconds).To(BeEquivalentTo(300))
+	})
+
+	It("should pass the level and the prefix options to the stream", func() {
+		cl.level = "error"
+		cl.prefix = true
+		logsStream := getStreamClusterLogs(cluster, cl)
+		Expect(logsStream.Level).To(Equal("error"))
+		Expect(logsStream.PrefixWithPodName).To(BeTrue())
+		Expect(logsStream.PodFilter).To(BeNil())
+	})
+
+	It("should filter the instances by role", func() {
+		roleCluster := cluster.DeepCopy()
+		roleCluster.Status.CurrentPrimary = clusterName + "-1"
+		newInstancePod := func(name string) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Labels: map[string]string{
+						utils.PodRoleLabelName: string(utils.PodRoleInstance),
+					},
+				},
+			}
+		}
+		primary := newInstancePod(clusterName + "-1")
+		replica := newInstancePod(clusterName + "-2")
+		job := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: clusterName + "-1-initdb"}}
+
+		primaryFilter := getRolePodFilter(roleCluster, rolePrimary)
+		Expect(primaryFilter(primary)).To(BeTrue())
+		Expect(primaryFilter(replica)).To(BeFalse())
+		Expect(primaryFilter(job)).To(BeFalse())
+
+		replicaFilter := getRolePodFilter(roleCluster, roleReplica)
+		Expect(replicaFilter(primary)).To(BeFalse())
+		Expect(replicaFilter(replica)).To(BeTrue())
+		Expect(replicaFilter(job)).To(BeFalse())
+	})
+
 	It("should get the proper stream for logs", func() {
 		PauseOutputInterception()
 		err := followCluster(cl)
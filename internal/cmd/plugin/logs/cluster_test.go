@@ -68,6 +68,24 @@ var _ = Describe("Test the command", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("should refuse an unknown role", func() {
+		cmd := clusterCmd()
+		cmd.SetArgs([]string{clusterName, "--role", "witness"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		err := cmd.Execute()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should refuse an unknown log level", func() {
+		cmd := clusterCmd()
+		cmd.SetArgs([]string{clusterName, "--level", "verbose"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		err := cmd.Execute()
+		Expect(err).To(HaveOccurred())
+	})
+
 	It("could follow the logs", func() {
 		cmd := clusterCmd()
 		cmd.SetArgs([]string{clusterName, "-f"})
@@ -58,8 +58,9 @@ func NewCmd() *cobra.Command {
 	})
 
 	maintenanceCmd.AddCommand(&cobra.Command{
-		Use:   "unset CLUSTER",
-		Short: "Removes maintenance mode",
+		Use:     "unset CLUSTER",
+		Aliases: []string{"clear"},
+		Short:   "Removes maintenance mode",
 		Long: "This command will unset maintenance mode on a single cluster or on all clusters " +
 			"in the current namespace if not specified differently through flags",
 		Args: cobra.MaximumNArgs(1),
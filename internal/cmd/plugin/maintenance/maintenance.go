@@ -18,17 +18,18 @@ limitations under the License.
 package maintenance
 
 import (
-	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
-	"strings"
+	"text/tabwriter"
 
 	"github.com/cheynewallace/tabby"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
 // Maintenance command implementation
@@ -74,7 +75,7 @@ func Maintenance(ctx context.Context,
 
 	clusters.Print()
 	if confirmationRequired {
-		proceed := askToProceed()
+		proceed := plugin.AskToProceed(os.Stdin)
 		if !proceed {
 			return nil
 		}
@@ -87,21 +88,59 @@ func Maintenance(ctx context.Context,
 		}
 	}
 
+	updatedClusters, err := refreshClusters(ctx, plugin.Client, clusterList.Items)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	printClustersState(os.Stdout, updatedClusters)
+
 	return nil
 }
 
-func askToProceed() bool {
-	fmt.Printf("Do you want to proceed? [y/n]: ")
-	reader := bufio.NewReader(os.Stdin)
-	answer, err := reader.ReadString('\n')
-	if err != nil {
-		return false
+// refreshClusters reads the current state of the passed clusters
+func refreshClusters(ctx context.Context, cli client.Client, clusters []v1.Cluster) ([]v1.Cluster, error) {
+	result := make([]v1.Cluster, 0, len(clusters))
+	for _, item := range clusters {
+		var cluster v1.Cluster
+		if err := cli.Get(ctx, client.ObjectKeyFromObject(&item), &cluster); err != nil {
+			return nil, fmt.Errorf("while reading cluster %v in namespace %v: %w", item.Name, item.Namespace, err)
+		}
+		result = append(result, cluster)
 	}
-	answer = strings.ToLower(strings.TrimSpace(answer))
-	if answer == "y" || answer == "yes" {
-		return true
+	return result, nil
+}
+
+// printClustersState prints the maintenance state of the passed clusters,
+// together with the state of the automated failover, which can be paused
+// independently of the maintenance window
+func printClustersState(writer io.Writer, clusters []v1.Cluster) {
+	table := tabby.NewCustom(tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0))
+	table.AddLine("The following is the resulting state of the clusters")
+	table.AddHeader(
+		"Namespace",
+		"Cluster Name",
+		"Maintenance",
+		"reusePVC",
+		"Failover",
+		"Phase")
+
+	for _, item := range clusters {
+		failover := "enabled"
+		if utils.IsFailoverDisabled(&item.ObjectMeta) {
+			failover = "paused"
+		}
+		table.AddLine(
+			item.Namespace,
+			item.Name,
+			item.IsNodeMaintenanceWindowInProgress(),
+			item.IsReusePVCEnabled(),
+			failover,
+			item.Status.Phase,
+		)
 	}
-	return false
+
+	table.Print()
 }
 
 func getClusters(ctx context.Context, allNamespaces bool) (v1.ClusterList, error) {
@@ -0,0 +1,92 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"bytes"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("resulting state of the clusters", func() {
+	var cli client.Client
+	clusterInMaintenance := v1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster-example",
+			Namespace:   "default",
+			Annotations: map[string]string{utils.FailoverAnnotationName: "disabled"},
+		},
+		Spec: v1.ClusterSpec{
+			NodeMaintenanceWindow: &v1.NodeMaintenanceWindow{
+				InProgress: true,
+				ReusePVC:   ptr.To(false),
+			},
+		},
+		Status: v1.ClusterStatus{Phase: v1.PhaseHealthy},
+	}
+	clusterWithoutMaintenance := v1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-other",
+			Namespace: "test",
+		},
+		Status: v1.ClusterStatus{Phase: v1.PhaseHealthy},
+	}
+
+	BeforeEach(func() {
+		cli = fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(clusterInMaintenance.DeepCopy(), clusterWithoutMaintenance.DeepCopy()).
+			Build()
+	})
+
+	It("reads the current state of the clusters", func(ctx SpecContext) {
+		stale := []v1.Cluster{
+			{ObjectMeta: clusterInMaintenance.ObjectMeta},
+			{ObjectMeta: clusterWithoutMaintenance.ObjectMeta},
+		}
+		clusters, err := refreshClusters(ctx, cli, stale)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(clusters).To(HaveLen(2))
+		Expect(clusters[0].IsNodeMaintenanceWindowInProgress()).To(BeTrue())
+		Expect(clusters[1].IsNodeMaintenanceWindowInProgress()).To(BeFalse())
+	})
+
+	It("fails when a cluster doesn't exist anymore", func(ctx SpecContext) {
+		_, err := refreshClusters(ctx, cli, []v1.Cluster{
+			{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "default"}},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("prints the maintenance and failover state of the clusters", func() {
+		var buffer bytes.Buffer
+		printClustersState(&buffer, []v1.Cluster{clusterInMaintenance, clusterWithoutMaintenance})
+
+		Expect(buffer.String()).To(MatchRegexp(`default\s+cluster-example\s+true\s+false\s+paused\s+Cluster in healthy state`))
+		Expect(buffer.String()).To(MatchRegexp(`test\s+cluster-other\s+false\s+true\s+enabled\s+Cluster in healthy state`))
+	})
+})
@@ -17,6 +17,7 @@ limitations under the License.
 package pgbench
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -36,6 +37,10 @@ func NewCmd() *cobra.Command {
 		GroupID: plugin.GroupIDMiscellaneous,
 		Example: jobExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateFlags(run); err != nil {
+				return err
+			}
+
 			run.clusterName = args[0]
 			run.pgBenchCommandArgs = args[1:]
 
@@ -71,6 +76,20 @@ func NewCmd() *cobra.Command {
 		"When true prints the job manifest instead of creating it",
 	)
 
+	pgBenchCmd.Flags().BoolVar(
+		&run.wait,
+		"wait",
+		false,
+		"Wait for the job to finish and print a summary of the results",
+	)
+
+	pgBenchCmd.Flags().StringVar(
+		&run.outputDir,
+		"output-dir",
+		"",
+		"Directory where the raw output and the summary of the results are saved. Requires --wait",
+	)
+
 	pgBenchCmd.Flags().StringSliceVar(
 		&run.nodeSelector,
 		"node-selector",
@@ -93,3 +112,15 @@ func validateCommandArgs(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func validateFlags(run *pgBenchRun) error {
+	if run.dryRun && run.wait {
+		return errors.New("the --wait and --dry-run options cannot be used together")
+	}
+
+	if run.outputDir != "" && !run.wait {
+		return errors.New("the --output-dir option requires --wait")
+	}
+
+	return nil
+}
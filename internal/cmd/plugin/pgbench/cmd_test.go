@@ -48,6 +48,21 @@ var _ = Describe("NewCmd", func() {
 		nodeSelectorFlag := cmd.Flag("node-selector")
 		Expect(nodeSelectorFlag).ToNot(BeNil())
 		Expect(nodeSelectorFlag.DefValue).To(Equal("[]"))
+
+		waitFlag := cmd.Flag("wait")
+		Expect(waitFlag).ToNot(BeNil())
+		Expect(waitFlag.DefValue).To(Equal("false"))
+
+		outputDirFlag := cmd.Flag("output-dir")
+		Expect(outputDirFlag).ToNot(BeNil())
+		Expect(outputDirFlag.DefValue).To(Equal(""))
+	})
+
+	It("should reject incompatible flags", func() {
+		Expect(validateFlags(&pgBenchRun{dryRun: true, wait: true})).ToNot(Succeed())
+		Expect(validateFlags(&pgBenchRun{outputDir: "results"})).ToNot(Succeed())
+		Expect(validateFlags(&pgBenchRun{outputDir: "results", wait: true})).To(Succeed())
+		Expect(validateFlags(&pgBenchRun{dryRun: true})).To(Succeed())
 	})
 
 	It("should correctly parse flags and arguments", func() {
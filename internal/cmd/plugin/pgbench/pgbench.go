@@ -40,6 +40,8 @@ type pgBenchRun struct {
 	nodeSelector       []string
 	pgBenchCommandArgs []string
 	dryRun             bool
+	wait               bool
+	outputDir          string
 }
 
 const (
@@ -59,7 +61,11 @@ var jobExample = `
 
   # Create a job with given values and [cluster] "cluster-example"
   kubectl-cnpg pgbench cluster-example --db-name pgbenchDBName --job-name job-name -- \
-    --time 30 --client 1 --jobs 1`
+    --time 30 --client 1 --jobs 1
+
+  # Create a job, wait for it to finish and save its results in the "results" directory
+  kubectl-cnpg pgbench cluster-example --wait --output-dir results -- \
+    --time 30 --client 1 --jobs 1 --report-per-command`
 
 func (cmd *pgBenchRun) execute(ctx context.Context) error {
 	cluster, err := cmd.getCluster(ctx)
@@ -78,7 +84,12 @@ func (cmd *pgBenchRun) execute(ctx context.Context) error {
 	}
 
 	fmt.Printf("job/%v created\n", job.Name)
-	return nil
+
+	if !cmd.wait {
+		return nil
+	}
+
+	return cmd.waitAndCollectResults(ctx, job)
 }
 
 func (cmd *pgBenchRun) getCluster(ctx context.Context) (*apiv1.Cluster, error) {
@@ -0,0 +1,335 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbench
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cheynewallace/tabby"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils/logs"
+)
+
+// jobPollingInterval is the interval between two checks of the pgbench job
+const jobPollingInterval = 2 * time.Second
+
+// errJobFailed is returned when the pgbench job has not been completed
+var errJobFailed = errors.New("pgbench job failed")
+
+var (
+	transactionsRegex  = regexp.MustCompile(`^number of transactions actually processed: (\d+)`)
+	failedRegex        = regexp.MustCompile(`^number of failed transactions: (\d+)`)
+	latencyAvgRegex    = regexp.MustCompile(`^latency average = ([0-9.]+) ms`)
+	latencyStddevRegex = regexp.MustCompile(`^latency stddev = ([0-9.]+) ms`)
+	tpsRegex           = regexp.MustCompile(`^tps = ([0-9.]+) \((.*)\)`)
+
+	// statementLatencyRegex matches a line of the per-statement report, where
+	// the latency is optionally followed by the number of failures and retries
+	statementLatencyRegex = regexp.MustCompile(`^\s*([0-9.]+)\s+(?:(\d+)\s+)?(?:(\d+)\s+)?(\S.*)$`)
+)
+
+// pgBenchResult is the summary of the output of a pgbench run
+type pgBenchResult struct {
+	TransactionsProcessed int64 `json:"transactionsProcessed"`
+	FailedTransactions    int64 `json:"failedTransactions"`
+
+	// TPS is the number of transactions per second, excluding the
+	// time needed to establish the initial connections when available
+	TPS float64 `json:"tps"`
+
+	LatencyAverageMs float64 `json:"latencyAverageMs"`
+	LatencyStddevMs  float64 `json:"latencyStddevMs,omitempty"`
+
+	// Statements is the per-statement latency report, only available
+	// when pgbench runs with the --report-per-command option
+	Statements []pgBenchStatementLatency `json:"statements,omitempty"`
+}
+
+// pgBenchStatementLatency is the average latency of a statement of the script
+type pgBenchStatementLatency struct {
+	LatencyMs float64 `json:"latencyMs"`
+	Failures  int64   `json:"failures,omitempty"`
+	Statement string  `json:"statement"`
+}
+
+// parsePgBenchOutput extracts the summary of a run from the pgbench output
+func parsePgBenchOutput(output string) (*pgBenchResult, error) {
+	result := &pgBenchResult{}
+	foundTPS := false
+	inStatements := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if inStatements {
+			if matches := statementLatencyRegex.FindStringSubmatch(line); matches != nil {
+				latency, _ := strconv.ParseFloat(matches[1], 64)
+				failures, _ := strconv.ParseInt(matches[2], 10, 64)
+				result.Statements = append(result.Statements, pgBenchStatementLatency{
+					LatencyMs: latency,
+					Failures:  failures,
+					Statement: strings.TrimSpace(matches[4]),
+				})
+				continue
+			}
+			inStatements = false
+		}
+
+		switch {
+		case strings.HasPrefix(line, "statement latencies in milliseconds"):
+			inStatements = true
+		case transactionsRegex.MatchString(line):
+			result.TransactionsProcessed, _ = strconv.ParseInt(transactionsRegex.FindStringSubmatch(line)[1], 10, 64)
+		case failedRegex.MatchString(line):
+			result.FailedTransactions, _ = strconv.ParseInt(failedRegex.FindStringSubmatch(line)[1], 10, 64)
+		case latencyAvgRegex.MatchString(line):
+			result.LatencyAverageMs, _ = strconv.ParseFloat(latencyAvgRegex.FindStringSubmatch(line)[1], 64)
+		case latencyStddevRegex.MatchString(line):
+			result.LatencyStddevMs, _ = strconv.ParseFloat(latencyStddevRegex.FindStringSubmatch(line)[1], 64)
+		case tpsRegex.MatchString(line):
+			matches := tpsRegex.FindStringSubmatch(line)
+			// PostgreSQL 13 and older report the TPS both including and
+			// excluding the connection time: we prefer the latter
+			if !foundTPS || strings.Contains(matches[2], "excluding") {
+				result.TPS, _ = strconv.ParseFloat(matches[1], 64)
+				foundTPS = true
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if !foundTPS {
+		return nil, fmt.Errorf("no pgbench summary found in the output")
+	}
+
+	return result, nil
+}
+
+// isInitializationRun checks whether the pgbench arguments request the
+// initialization of the database, which produces no summary to be parsed
+func isInitializationRun(args []string) bool {
+	for _, arg := range args {
+		switch {
+		case arg == "--":
+			return false
+		case arg == "--initialize":
+			return true
+		case strings.HasPrefix(arg, "--"):
+			continue
+		case strings.HasPrefix(arg, "-i"):
+			return true
+		}
+	}
+
+	return false
+}
+
+// printSummary prints the summary of a pgbench run
+func printSummary(result *pgBenchResult) {
+	summary := tabby.New()
+	summary.AddLine("Transactions processed:", result.TransactionsProcessed)
+	summary.AddLine("Failed transactions:", result.FailedTransactions)
+	summary.AddLine("TPS:", fmt.Sprintf("%.3f", result.TPS))
+	summary.AddLine("Latency average:", fmt.Sprintf("%.3f ms", result.LatencyAverageMs))
+	if result.LatencyStddevMs > 0 {
+		summary.AddLine("Latency stddev:", fmt.Sprintf("%.3f ms", result.LatencyStddevMs))
+	}
+	summary.Print()
+
+	if len(result.Statements) == 0 {
+		return
+	}
+
+	fmt.Println()
+	statements := tabby.New()
+	statements.AddHeader("Latency (ms)", "Failures", "Statement")
+	for _, statement := range result.Statements {
+		statements.AddLine(fmt.Sprintf("%.3f", statement.LatencyMs), statement.Failures, statement.Statement)
+	}
+	statements.Print()
+}
+
+// saveResults stores the raw output and the parsed summary of a pgbench
+// run in the output directory, naming the files after the job
+func saveResults(outputDir, jobName, rawOutput string, result *pgBenchResult) error {
+	if err := os.MkdirAll(outputDir, 0o750); err != nil {
+		return fmt.Errorf("while creating the output directory: %w", err)
+	}
+
+	rawPath := filepath.Join(outputDir, jobName+".log")
+	if err := os.WriteFile(rawPath, []byte(rawOutput), 0o600); err != nil {
+		return fmt.Errorf("while writing the pgbench output: %w", err)
+	}
+	fmt.Printf("pgbench output saved to %s\n", rawPath)
+
+	if result == nil {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	summaryPath := filepath.Join(outputDir, jobName+".json")
+	if err := os.WriteFile(summaryPath, data, 0o600); err != nil {
+		return fmt.Errorf("while writing the pgbench summary: %w", err)
+	}
+	fmt.Printf("pgbench summary saved to %s\n", summaryPath)
+
+	return nil
+}
+
+// isJobFinished checks whether a job has been completed or has failed
+func isJobFinished(job *batchv1.Job) (finished bool, failed bool) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, false
+		case batchv1.JobFailed:
+			return true, true
+		}
+	}
+	return false, false
+}
+
+// waitForJob waits for the pgbench job to finish, returning errJobFailed
+// if the job has not been completed successfully
+func waitForJob(ctx context.Context, cli client.Client, job *batchv1.Job) error {
+	ticker := time.NewTicker(jobPollingInterval)
+	defer ticker.Stop()
+
+	for {
+		var currentJob batchv1.Job
+		if err := cli.Get(ctx, client.ObjectKeyFromObject(job), &currentJob); err != nil {
+			return fmt.Errorf("while getting job %s: %w", job.Name, err)
+		}
+
+		if finished, failed := isJobFinished(&currentJob); finished {
+			if failed {
+				return errJobFailed
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// getJobPod gets the pod that run the pgbench job, preferring the one
+// that succeeded and falling back to the most recent one
+func getJobPod(ctx context.Context, cli client.Client, job *batchv1.Job) (*corev1.Pod, error) {
+	var pods corev1.PodList
+	if err := cli.List(
+		ctx,
+		&pods,
+		client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name},
+	); err != nil {
+		return nil, fmt.Errorf("while listing the pods of job %s: %w", job.Name, err)
+	}
+
+	var result *corev1.Pod
+	for idx := range pods.Items {
+		pod := &pods.Items[idx]
+		if pod.Status.Phase == corev1.PodSucceeded {
+			return pod, nil
+		}
+		if result == nil || result.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			result = pod
+		}
+	}
+
+	if result == nil {
+		return nil, fmt.Errorf("no pod found for job %s", job.Name)
+	}
+
+	return result, nil
+}
+
+// waitAndCollectResults waits for the pgbench job to finish, then prints
+// the summary of its output and optionally saves it in the output directory
+func (cmd *pgBenchRun) waitAndCollectResults(ctx context.Context, job *batchv1.Job) error {
+	fmt.Printf("waiting for job/%v to finish\n", job.Name)
+	jobErr := waitForJob(ctx, plugin.Client, job)
+	if jobErr != nil && !errors.Is(jobErr, errJobFailed) {
+		return jobErr
+	}
+
+	pod, err := getJobPod(ctx, plugin.Client, job)
+	if err != nil {
+		return err
+	}
+
+	var output bytes.Buffer
+	streamPodLogs := &logs.StreamingRequest{
+		Pod:     pod,
+		Options: &corev1.PodLogOptions{Container: pgBenchKeyWord},
+		Client:  plugin.ClientInterface,
+	}
+	if err := streamPodLogs.Stream(ctx, &output); err != nil {
+		return fmt.Errorf("while getting the output of job %s: %w", job.Name, err)
+	}
+
+	var result *pgBenchResult
+	switch {
+	case jobErr == nil && isInitializationRun(cmd.pgBenchCommandArgs):
+		fmt.Printf("job/%v: pgbench initialization completed\n", job.Name)
+	case jobErr == nil:
+		if result, err = parsePgBenchOutput(output.String()); err != nil {
+			return err
+		}
+		printSummary(result)
+	}
+
+	if cmd.outputDir != "" {
+		if err := saveResults(cmd.outputDir, job.Name, output.String(), result); err != nil {
+			return err
+		}
+	}
+
+	if jobErr != nil {
+		fmt.Print(output.String())
+		return fmt.Errorf("job/%v: %w", job.Name, jobErr)
+	}
+
+	return nil
+}
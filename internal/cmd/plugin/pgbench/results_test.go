@@ -0,0 +1,147 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbench
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const pgBenchOutput = `pgbench (16.2 (Debian 16.2-1.pgdg110+2))
+starting vacuum...end.
+transaction type: <builtin: TPC-B (sort of)>
+scaling factor: 1
+query mode: simple
+number of clients: 2
+number of threads: 1
+maximum number of tries: 1
+duration: 30 s
+number of transactions actually processed: 12345
+number of failed transactions: 0 (0.000%)
+latency average = 4.861 ms
+latency stddev = 1.234 ms
+initial connection time = 12.345 ms
+tps = 411.456789 (without initial connection time)
+statement latencies in milliseconds and failures:
+         0.002           0  \set aid random(1, 100000 * :scale)
+         0.001           0  \set bid random(1, 1 * :scale)
+         0.215           0  BEGIN;
+         2.030           0  UPDATE pgbench_branches SET bbalance = bbalance + :delta WHERE bid = :bid;
+         0.300           0  END;
+`
+
+const legacyPgBenchOutput = `number of transactions actually processed: 100
+latency average = 10.000 ms
+tps = 98.000000 (including connections establishing)
+tps = 99.500000 (excluding connections establishing)
+statement latencies in milliseconds:
+         0.250  BEGIN;
+         0.500  END;
+`
+
+var _ = Describe("parsePgBenchOutput", func() {
+	It("parses the summary and the statement latencies", func() {
+		result, err := parsePgBenchOutput(pgBenchOutput)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.TransactionsProcessed).To(BeEquivalentTo(12345))
+		Expect(result.FailedTransactions).To(BeZero())
+		Expect(result.TPS).To(BeNumerically("~", 411.456789))
+		Expect(result.LatencyAverageMs).To(BeNumerically("~", 4.861))
+		Expect(result.LatencyStddevMs).To(BeNumerically("~", 1.234))
+		Expect(result.Statements).To(HaveLen(5))
+		Expect(result.Statements[3].LatencyMs).To(BeNumerically("~", 2.030))
+		Expect(result.Statements[3].Statement).To(HavePrefix("UPDATE pgbench_branches"))
+		Expect(result.Statements[0].Statement).To(Equal(`\set aid random(1, 100000 * :scale)`))
+	})
+
+	It("prefers the TPS excluding the connection time in the legacy format", func() {
+		result, err := parsePgBenchOutput(legacyPgBenchOutput)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.TPS).To(BeNumerically("~", 99.5))
+		Expect(result.Statements).To(HaveLen(2))
+		Expect(result.Statements[1].Statement).To(Equal("END;"))
+	})
+
+	It("fails when the output has no summary", func() {
+		_, err := parsePgBenchOutput("pgbench: error: connection to server failed\n")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = DescribeTable("isInitializationRun",
+	func(args []string, expected bool) {
+		Expect(isInitializationRun(args)).To(Equal(expected))
+	},
+	Entry("no arguments", nil, false),
+	Entry("a benchmark run", []string{"--time", "30", "--client", "1"}, false),
+	Entry("the long option", []string{"--initialize", "--scale", "10"}, true),
+	Entry("the short option", []string{"-i", "-s", "10"}, true),
+	Entry("the short option with other flags", []string{"-iq"}, true),
+	Entry("the init steps option", []string{"--init-steps", "dtg"}, false),
+)
+
+var _ = Describe("isJobFinished", func() {
+	It("detects a running job", func() {
+		finished, _ := isJobFinished(&batchv1.Job{})
+		Expect(finished).To(BeFalse())
+	})
+
+	It("detects a completed job", func() {
+		job := &batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+			{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+		}}}
+		finished, failed := isJobFinished(job)
+		Expect(finished).To(BeTrue())
+		Expect(failed).To(BeFalse())
+	})
+
+	It("detects a failed job", func() {
+		job := &batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+			{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+		}}}
+		finished, failed := isJobFinished(job)
+		Expect(finished).To(BeTrue())
+		Expect(failed).To(BeTrue())
+	})
+})
+
+var _ = Describe("saveResults", func() {
+	It("writes the raw output and the parsed summary", func() {
+		outputDir := filepath.Join(GinkgoT().TempDir(), "results")
+		result, err := parsePgBenchOutput(pgBenchOutput)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(saveResults(outputDir, "test-job", pgBenchOutput, result)).To(Succeed())
+
+		raw, err := os.ReadFile(filepath.Join(outputDir, "test-job.log")) //nolint:gosec
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(raw)).To(Equal(pgBenchOutput))
+
+		data, err := os.ReadFile(filepath.Join(outputDir, "test-job.json")) //nolint:gosec
+		Expect(err).ToNot(HaveOccurred())
+		var saved pgBenchResult
+		Expect(json.Unmarshal(data, &saved)).To(Succeed())
+		Expect(saved).To(Equal(*result))
+	})
+})
@@ -0,0 +1,85 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbouncer
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd initializes the pgbouncer command
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "pgbouncer",
+		Short:   `PgBouncer related commands`,
+		GroupID: plugin.GroupIDTroubleshooting,
+	}
+
+	cmd.AddCommand(newStatusCmd())
+
+	return cmd
+}
+
+func newStatusCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "status POOLER",
+		Short: "Shows the status of the pools of the Pooler named POOLER",
+		Long: "Connects to the PgBouncer admin console of every Pod of the Pooler named POOLER " +
+			"and shows, for each pool, the active and waiting clients, the server connections " +
+			"and the average query time.",
+		Example: "kubectl cnpg pgbouncer status pooler-example-rw -o json",
+		Args:    plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completePoolers(cmd.Context(), plugin.Client, plugin.Namespace, args, toComplete),
+				cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat := plugin.OutputFormat(output)
+			switch outputFormat {
+			case plugin.OutputFormatText, plugin.OutputFormatJSON, plugin.OutputFormatYAML:
+			default:
+				return fmt.Errorf("output: %s is not supported by the pgbouncer status command", output)
+			}
+
+			status, err := getPoolerStatus(
+				cmd.Context(),
+				plugin.Client,
+				plugin.Namespace,
+				args[0],
+				newExecQueryRunner(),
+			)
+			if err != nil {
+				return err
+			}
+
+			if outputFormat == plugin.OutputFormatText {
+				return printPoolerStatus(cmd.OutOrStdout(), status)
+			}
+
+			return plugin.Print(status, outputFormat, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of text, json, or yaml")
+
+	return cmd
+}
@@ -0,0 +1,328 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbouncer
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cheynewallace/tabby"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// pgbouncerContainerName is the name of the container running PgBouncer
+// inside the Pods of a Pooler
+const pgbouncerContainerName = "pgbouncer"
+
+// queryRunner runs a query against the PgBouncer admin console of a Pod,
+// returning the result in CSV format
+type queryRunner func(ctx context.Context, pod corev1.Pod, query string) (string, error)
+
+// poolerStatus is the status of the pools of every Pod of a Pooler
+type poolerStatus struct {
+	Name    string      `json:"name"`
+	Cluster string      `json:"cluster"`
+	Type    string      `json:"type"`
+	Pods    []podStatus `json:"pods"`
+}
+
+// podStatus is the status of the pools of a single PgBouncer instance
+type podStatus struct {
+	Name    string       `json:"name"`
+	Clients int          `json:"clients"`
+	Pools   []poolStatus `json:"pools,omitempty"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// poolStatus is the status of a PgBouncer pool, as reported by
+// SHOW POOLS and SHOW STATS
+type poolStatus struct {
+	Database                 string `json:"database"`
+	User                     string `json:"user"`
+	PoolMode                 string `json:"poolMode"`
+	ClientsActive            int    `json:"clientsActive"`
+	ClientsWaiting           int    `json:"clientsWaiting"`
+	ServersActive            int    `json:"serversActive"`
+	ServersIdle              int    `json:"serversIdle"`
+	ServersUsed              int    `json:"serversUsed"`
+	MaxWaitSeconds           int    `json:"maxWaitSeconds"`
+	AvgQueryTimeMicroseconds int    `json:"avgQueryTimeMicroseconds"`
+}
+
+// newExecQueryRunner creates a queryRunner executing psql inside the
+// PgBouncer container. The container environment already points psql
+// to the admin console via the local socket
+func newExecQueryRunner() queryRunner {
+	return func(ctx context.Context, pod corev1.Pod, query string) (string, error) {
+		timeout := 10 * time.Second
+		stdout, stderr, err := utils.ExecCommand(
+			ctx,
+			plugin.ClientInterface,
+			plugin.Config,
+			pod,
+			pgbouncerContainerName,
+			&timeout,
+			"psql", "--csv", "-c", query,
+		)
+		if err != nil {
+			return "", fmt.Errorf("while executing %q: %w (%s)", query, err, strings.TrimSpace(stderr))
+		}
+
+		return stdout, nil
+	}
+}
+
+// getPoolerStatus collects the status of the pools of every Pod
+// of the Pooler named poolerName
+func getPoolerStatus(
+	ctx context.Context,
+	cli client.Client,
+	namespace string,
+	poolerName string,
+	runQuery queryRunner,
+) (*poolerStatus, error) {
+	var pooler apiv1.Pooler
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: poolerName}, &pooler); err != nil {
+		return nil, fmt.Errorf("while getting pooler %s: %w", poolerName, err)
+	}
+
+	var pods corev1.PodList
+	if err := cli.List(
+		ctx,
+		&pods,
+		client.InNamespace(namespace),
+		client.MatchingLabels{utils.PgbouncerNameLabel: poolerName},
+	); err != nil {
+		return nil, fmt.Errorf("while listing the pods of pooler %s: %w", poolerName, err)
+	}
+
+	slices.SortFunc(pods.Items, func(a, b corev1.Pod) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	result := &poolerStatus{
+		Name:    pooler.Name,
+		Cluster: pooler.Spec.Cluster.Name,
+		Type:    string(pooler.Spec.Type),
+		Pods:    make([]podStatus, 0, len(pods.Items)),
+	}
+	for _, pod := range pods.Items {
+		status, err := getPodStatus(ctx, pod, runQuery)
+		if err != nil {
+			status.Error = err.Error()
+		}
+		result.Pods = append(result.Pods, status)
+	}
+
+	return result, nil
+}
+
+// getPodStatus queries the admin console of a PgBouncer Pod and
+// aggregates the pools, stats and clients information
+func getPodStatus(ctx context.Context, pod corev1.Pod, runQuery queryRunner) (podStatus, error) {
+	result := podStatus{Name: pod.Name}
+
+	if pod.Status.Phase != corev1.PodRunning {
+		return result, fmt.Errorf("pod is not running (phase: %s)", pod.Status.Phase)
+	}
+
+	pools, err := queryAdminConsole(ctx, pod, runQuery, "SHOW POOLS")
+	if err != nil {
+		return result, err
+	}
+
+	stats, err := queryAdminConsole(ctx, pod, runQuery, "SHOW STATS")
+	if err != nil {
+		return result, err
+	}
+
+	clients, err := queryAdminConsole(ctx, pod, runQuery, "SHOW CLIENTS")
+	if err != nil {
+		return result, err
+	}
+
+	// SHOW STATS reports the statistics per database, while
+	// SHOW POOLS reports a row for each database/user pair
+	avgQueryTime := make(map[string]int, len(stats))
+	for _, row := range stats {
+		avgQueryTime[row["database"]] = getIntegerColumn(row, "avg_query_time")
+	}
+
+	result.Clients = len(clients)
+	result.Pools = make([]poolStatus, 0, len(pools))
+	for _, row := range pools {
+		result.Pools = append(result.Pools, poolStatus{
+			Database:                 row["database"],
+			User:                     row["user"],
+			PoolMode:                 row["pool_mode"],
+			ClientsActive:            getIntegerColumn(row, "cl_active"),
+			ClientsWaiting:           getIntegerColumn(row, "cl_waiting"),
+			ServersActive:            getIntegerColumn(row, "sv_active"),
+			ServersIdle:              getIntegerColumn(row, "sv_idle"),
+			ServersUsed:              getIntegerColumn(row, "sv_used"),
+			MaxWaitSeconds:           getIntegerColumn(row, "maxwait"),
+			AvgQueryTimeMicroseconds: avgQueryTime[row["database"]],
+		})
+	}
+
+	return result, nil
+}
+
+// queryAdminConsole runs a SHOW command on the admin console and returns
+// each row of the result as a map indexed by column name, as the set of
+// columns depends on the PgBouncer version
+func queryAdminConsole(
+	ctx context.Context,
+	pod corev1.Pod,
+	runQuery queryRunner,
+	query string,
+) ([]map[string]string, error) {
+	output, err := runQuery(ctx, pod, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := parseCSVOutput(output)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing the output of %q: %w", query, err)
+	}
+
+	return rows, nil
+}
+
+// parseCSVOutput parses the output of psql --csv, where the first
+// record is the header
+func parseCSVOutput(output string) ([]map[string]string, error) {
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for idx, column := range header {
+			if idx < len(record) {
+				row[column] = record[idx]
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// getIntegerColumn returns the integer value of a column, or zero
+// if the column is missing or not numeric
+func getIntegerColumn(row map[string]string, column string) int {
+	value, err := strconv.Atoi(row[column])
+	if err != nil {
+		return 0
+	}
+
+	return value
+}
+
+// printPoolerStatus prints the status of the pooler in a human-readable format
+func printPoolerStatus(writer io.Writer, status *poolerStatus) error {
+	if _, err := fmt.Fprintf(writer, "Pooler %s (cluster: %s, type: %s)\n",
+		status.Name, status.Cluster, status.Type); err != nil {
+		return err
+	}
+
+	if len(status.Pods) == 0 {
+		_, err := fmt.Fprintln(writer, "No pods found")
+		return err
+	}
+
+	for _, pod := range status.Pods {
+		if _, err := fmt.Fprintf(writer, "\nPod %s (clients: %d)\n", pod.Name, pod.Clients); err != nil {
+			return err
+		}
+
+		if pod.Error != "" {
+			if _, err := fmt.Fprintf(writer, "Error: %s\n", pod.Error); err != nil {
+				return err
+			}
+			continue
+		}
+
+		table := tabby.NewCustom(tabwriter.NewWriter(writer, 0, 0, 4, ' ', 0))
+		table.AddHeader(
+			"Database", "User", "Mode",
+			"Clients active", "Clients waiting",
+			"Servers active", "Servers idle", "Servers used",
+			"Max wait", "Avg query time",
+		)
+		for _, pool := range pod.Pools {
+			table.AddLine(
+				pool.Database, pool.User, pool.PoolMode,
+				pool.ClientsActive, pool.ClientsWaiting,
+				pool.ServersActive, pool.ServersIdle, pool.ServersUsed,
+				time.Duration(pool.MaxWaitSeconds)*time.Second,
+				time.Duration(pool.AvgQueryTimeMicroseconds)*time.Microsecond,
+			)
+		}
+		table.Print()
+	}
+
+	return nil
+}
+
+// completePoolers completes the name of the poolers in the current namespace
+func completePoolers(
+	ctx context.Context,
+	cli client.Client,
+	namespace string,
+	args []string,
+	toComplete string,
+) []string {
+	if len(args) == 1 {
+		return []string{}
+	}
+
+	var poolers apiv1.PoolerList
+	if err := cli.List(ctx, &poolers, client.InNamespace(namespace)); err != nil {
+		return []string{}
+	}
+
+	poolerNames := make([]string, 0, len(poolers.Items))
+	for _, pooler := range poolers.Items {
+		if strings.HasPrefix(pooler.Name, toComplete) {
+			poolerNames = append(poolerNames, pooler.Name)
+		}
+	}
+
+	return poolerNames
+}
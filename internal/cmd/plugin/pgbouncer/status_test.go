@@ -0,0 +1,182 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgbouncer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	showPoolsOutput = `database,user,cl_active,cl_waiting,sv_active,sv_idle,sv_used,sv_login,maxwait,pool_mode
+app,app,5,2,3,1,0,0,1,session
+pgbouncer,pgbouncer,1,0,0,0,0,0,0,statement
+`
+	showStatsOutput = `database,total_xact_count,total_query_count,avg_query_count,avg_query_time
+app,100,200,3,1500
+pgbouncer,1,1,0,0
+`
+	showClientsOutput = `type,user,database,state
+C,app,app,active
+C,app,app,active
+C,app,app,waiting
+`
+)
+
+func newTestPod(name string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				utils.PgbouncerNameLabel: "pooler-example-rw",
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+		},
+	}
+}
+
+func fakeQueryRunner(_ context.Context, pod corev1.Pod, query string) (string, error) {
+	if pod.Name == "pooler-example-rw-broken" {
+		return "", fmt.Errorf("connection refused")
+	}
+
+	switch query {
+	case "SHOW POOLS":
+		return showPoolsOutput, nil
+	case "SHOW STATS":
+		return showStatsOutput, nil
+	case "SHOW CLIENTS":
+		return showClientsOutput, nil
+	default:
+		return "", fmt.Errorf("unexpected query %q", query)
+	}
+}
+
+var _ = Describe("parseCSVOutput", func() {
+	It("maps every row by column name", func() {
+		rows, err := parseCSVOutput(showStatsOutput)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rows).To(HaveLen(2))
+		Expect(rows[0]).To(HaveKeyWithValue("database", "app"))
+		Expect(rows[0]).To(HaveKeyWithValue("avg_query_time", "1500"))
+	})
+
+	It("returns no rows for an empty output", func() {
+		rows, err := parseCSVOutput("")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rows).To(BeEmpty())
+	})
+
+	It("treats missing and non-numeric columns as zero", func() {
+		row := map[string]string{"cl_active": "n/a"}
+		Expect(getIntegerColumn(row, "cl_active")).To(BeZero())
+		Expect(getIntegerColumn(row, "cl_waiting")).To(BeZero())
+	})
+})
+
+var _ = Describe("getPoolerStatus", func() {
+	pooler := &apiv1.Pooler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pooler-example-rw",
+			Namespace: "default",
+		},
+		Spec: apiv1.PoolerSpec{
+			Cluster: apiv1.LocalObjectReference{Name: "cluster-example"},
+			Type:    apiv1.PoolerTypeRW,
+		},
+	}
+
+	It("aggregates the status of every pod", func(ctx SpecContext) {
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(
+				pooler,
+				newTestPod("pooler-example-rw-b", corev1.PodRunning),
+				newTestPod("pooler-example-rw-a", corev1.PodRunning),
+			).
+			Build()
+
+		status, err := getPoolerStatus(ctx, cli, "default", "pooler-example-rw", fakeQueryRunner)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.Cluster).To(Equal("cluster-example"))
+		Expect(status.Type).To(Equal("rw"))
+		Expect(status.Pods).To(HaveLen(2))
+		Expect(status.Pods[0].Name).To(Equal("pooler-example-rw-a"))
+
+		pod := status.Pods[0]
+		Expect(pod.Error).To(BeEmpty())
+		Expect(pod.Clients).To(Equal(3))
+		Expect(pod.Pools).To(HaveLen(2))
+		Expect(pod.Pools[0]).To(Equal(poolStatus{
+			Database:                 "app",
+			User:                     "app",
+			PoolMode:                 "session",
+			ClientsActive:            5,
+			ClientsWaiting:           2,
+			ServersActive:            3,
+			ServersIdle:              1,
+			ServersUsed:              0,
+			MaxWaitSeconds:           1,
+			AvgQueryTimeMicroseconds: 1500,
+		}))
+	})
+
+	It("reports the pods that can't be queried", func(ctx SpecContext) {
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(
+				pooler,
+				newTestPod("pooler-example-rw-broken", corev1.PodRunning),
+				newTestPod("pooler-example-rw-pending", corev1.PodPending),
+			).
+			Build()
+
+		status, err := getPoolerStatus(ctx, cli, "default", "pooler-example-rw", fakeQueryRunner)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.Pods).To(HaveLen(2))
+		Expect(status.Pods[0].Error).To(ContainSubstring("connection refused"))
+		Expect(status.Pods[1].Error).To(ContainSubstring("not running"))
+
+		var buffer bytes.Buffer
+		Expect(printPoolerStatus(&buffer, status)).To(Succeed())
+		Expect(buffer.String()).To(ContainSubstring("Error: connection refused"))
+	})
+
+	It("fails when the pooler doesn't exist", func(ctx SpecContext) {
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			Build()
+
+		_, err := getPoolerStatus(ctx, cli, "default", "pooler-example-rw", fakeQueryRunner)
+		Expect(err).To(HaveOccurred())
+	})
+})
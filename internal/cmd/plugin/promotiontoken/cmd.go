@@ -0,0 +1,61 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promotiontoken
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the promotion-token command
+func NewCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "promotion-token CLUSTER",
+		Short: "Generates the token needed to promote another cluster of a distributed topology",
+		Long: "Prints the promotion token of the replica cluster CLUSTER, to be set in the " +
+			".spec.replica.promotionToken field of the cluster being promoted. The demotion token " +
+			"published in the status of CLUSTER is used. Failing that, the token is read from the " +
+			"pg_controldata of the designated primary, as long as it was cleanly shut down " +
+			"and all its WAL files were archived.",
+		Example: "kubectl cnpg promotion-token cluster-eu-south -o json",
+		Args:    plugin.RequiresArguments(1),
+		GroupID: plugin.GroupIDCluster,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat := plugin.OutputFormat(output)
+			switch outputFormat {
+			case plugin.OutputFormatText, plugin.OutputFormatJSON, plugin.OutputFormatYAML:
+			default:
+				return fmt.Errorf("output: %s is not supported by the promotion-token command", output)
+			}
+
+			return PromotionToken(cmd.Context(), args[0], outputFormat)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "text",
+		"Output format. One of text, json, or yaml. The json and yaml formats include the decoded token")
+
+	return cmd
+}
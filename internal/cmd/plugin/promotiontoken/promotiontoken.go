@@ -0,0 +1,220 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promotiontoken
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// tokenSource is where the promotion token was read from
+type tokenSource string
+
+const (
+	// tokenSourceDemotionToken is the demotion token published in the
+	// status of the cluster once its former primary was cleanly demoted
+	tokenSourceDemotionToken tokenSource = "demotionToken"
+
+	// tokenSourcePgControlData is the pg_controldata of a designated
+	// primary that was cleanly shut down with all its WALs archived
+	tokenSourcePgControlData tokenSource = "pg_controldata"
+)
+
+// shutDownClusterStates are the states reported by pg_controldata
+// when PostgreSQL was cleanly shut down. A designated primary runs
+// in recovery, and reports "shut down in recovery" once stopped
+var shutDownClusterStates = []string{"shut down", "shut down in recovery"}
+
+// promotionToken is the promotion token of a demoted cluster
+type promotionToken struct {
+	Cluster  string      `json:"cluster"`
+	Instance string      `json:"instance,omitempty"`
+	Source   tokenSource `json:"source"`
+
+	// DatabaseClusterState is the state of PGDATA when the
+	// token was generated
+	DatabaseClusterState string `json:"databaseClusterState,omitempty"`
+
+	// Token is the base64-encoded value to be used as
+	// promotion token
+	Token string `json:"token"`
+
+	// Content is the decoded content of the token
+	Content *utils.PgControldataTokenContent `json:"content"`
+}
+
+// PromotionToken prints the promotion token of a replica cluster. The
+// demotion token published by the operator once the former primary
+// was cleanly demoted is preferred. Failing that, the token is generated
+// from the pg_controldata of the designated primary, as long as it was
+// cleanly shut down and all its WALs were archived
+func PromotionToken(ctx context.Context, clusterName string, format plugin.OutputFormat) error {
+	var cluster apiv1.Cluster
+	if err := plugin.Client.Get(
+		ctx,
+		client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName},
+		&cluster,
+	); err != nil {
+		return fmt.Errorf("while getting cluster %s: %w", clusterName, err)
+	}
+
+	if !cluster.IsReplica() {
+		return fmt.Errorf("cluster %s is not a replica cluster: demote it before generating the promotion token",
+			clusterName)
+	}
+
+	if cluster.Status.DemotionToken != "" {
+		token, err := newPromotionTokenFromDemotionToken(cluster.Name, cluster.Status.DemotionToken)
+		if err != nil {
+			return err
+		}
+		return printPromotionToken(token, format)
+	}
+
+	if cluster.Status.CurrentPrimary == "" {
+		return fmt.Errorf("cluster %s has no demotion token and no designated primary", clusterName)
+	}
+
+	var pod corev1.Pod
+	if err := plugin.Client.Get(
+		ctx,
+		client.ObjectKey{Namespace: plugin.Namespace, Name: cluster.Status.CurrentPrimary},
+		&pod,
+	); err != nil {
+		return fmt.Errorf("while getting the designated primary %s: %w", cluster.Status.CurrentPrimary, err)
+	}
+
+	rawPgControlData, err := plugin.GetPGControlData(ctx, pod)
+	if err != nil {
+		return fmt.Errorf("while reading pg_controldata from %s: %w", pod.Name, err)
+	}
+
+	pendingWALFiles, err := countWALFilesPendingArchive(ctx, pod)
+	if err != nil {
+		return fmt.Errorf("while checking the WAL archive status of %s: %w", pod.Name, err)
+	}
+
+	token, err := newPromotionToken(cluster.Name, pod.Name, rawPgControlData, pendingWALFiles)
+	if err != nil {
+		return err
+	}
+
+	return printPromotionToken(token, format)
+}
+
+// printPromotionToken prints the token in the requested format
+func printPromotionToken(token *promotionToken, format plugin.OutputFormat) error {
+	if format == plugin.OutputFormatText {
+		fmt.Println(token.Token)
+		return nil
+	}
+
+	return plugin.Print(token, format, os.Stdout)
+}
+
+// countWALFilesPendingArchive counts the WAL files of the instance
+// that are still waiting to be archived
+func countWALFilesPendingArchive(ctx context.Context, pod corev1.Pod) (int, error) {
+	timeout := time.Second * 10
+	stdout, _, err := utils.ExecCommand(
+		ctx,
+		plugin.ClientInterface,
+		plugin.Config,
+		pod,
+		specs.PostgresContainerName,
+		&timeout,
+		"find", specs.PgWalArchiveStatusPath, "-name", "*.ready")
+	if err != nil {
+		return 0, err
+	}
+
+	return len(strings.Fields(stdout)), nil
+}
+
+// newPromotionTokenFromDemotionToken validates the demotion token
+// published in the status of the cluster
+func newPromotionTokenFromDemotionToken(clusterName, demotionToken string) (*promotionToken, error) {
+	content, err := utils.ParsePgControldataToken(demotionToken)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing the demotion token of cluster %s: %w", clusterName, err)
+	}
+	if err := content.IsValid(); err != nil {
+		return nil, fmt.Errorf("while validating the demotion token of cluster %s: %w", clusterName, err)
+	}
+
+	return &promotionToken{
+		Cluster: clusterName,
+		Source:  tokenSourceDemotionToken,
+		Token:   demotionToken,
+		Content: content,
+	}, nil
+}
+
+// newPromotionToken generates the promotion token from the
+// output of pg_controldata, in the format expected by the webhook.
+// A token read from a running instance, or before the last WAL files
+// were archived, could point to a stale REDO location, so the instance
+// must have been cleanly shut down with no WAL file pending archive
+func newPromotionToken(
+	clusterName, instanceName, rawPgControlData string,
+	pendingWALFiles int,
+) (*promotionToken, error) {
+	pgControlData := utils.ParsePgControldataOutput(rawPgControlData)
+	state := pgControlData[utils.PgControlDataDatabaseClusterStateKey]
+	if !slices.Contains(shutDownClusterStates, state) {
+		return nil, fmt.Errorf(
+			"cannot generate the promotion token from %s: its state is %q instead of one of %q, "+
+				"wait for the demotion to publish the demotion token of cluster %s",
+			instanceName, state, shutDownClusterStates, clusterName)
+	}
+	if pendingWALFiles > 0 {
+		return nil, fmt.Errorf(
+			"cannot generate the promotion token from %s: %d WAL files are still waiting to be archived",
+			instanceName, pendingWALFiles)
+	}
+
+	content := utils.NewPgControldataTokenContent(pgControlData)
+	if err := content.IsValid(); err != nil {
+		return nil, fmt.Errorf("while generating the promotion token from %s: %w", instanceName, err)
+	}
+
+	token, err := content.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("while encoding the promotion token: %w", err)
+	}
+
+	return &promotionToken{
+		Cluster:              clusterName,
+		Instance:             instanceName,
+		Source:               tokenSourcePgControlData,
+		DatabaseClusterState: state,
+		Token:                token,
+		Content:              content,
+	}, nil
+}
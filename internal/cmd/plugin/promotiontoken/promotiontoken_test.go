@@ -0,0 +1,97 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promotiontoken
+
+import (
+	"strings"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const pgControlData = `pg_control version number:            1700
+Database cluster state:               shut down
+Database system identifier:           7384729304857234567
+Latest checkpoint location:           0/6000028
+Latest checkpoint's REDO location:    0/6000028
+Latest checkpoint's REDO WAL file:    000000020000000000000006
+Latest checkpoint's TimeLineID:       2
+Time of latest checkpoint:            Thu 16 Oct 2025 10:00:00 AM UTC`
+
+var _ = Describe("newPromotionToken", func() {
+	It("generates a token in the format expected by the webhook", func() {
+		token, err := newPromotionToken("cluster-eu-south", "cluster-eu-south-1", pgControlData, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token.Cluster).To(Equal("cluster-eu-south"))
+		Expect(token.Instance).To(Equal("cluster-eu-south-1"))
+		Expect(token.Source).To(Equal(tokenSourcePgControlData))
+		Expect(token.DatabaseClusterState).To(Equal("shut down"))
+
+		decoded, err := utils.ParsePgControldataToken(token.Token)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decoded.IsValid()).To(Succeed())
+		Expect(decoded).To(Equal(token.Content))
+		Expect(decoded.DatabaseSystemIdentifier).To(Equal("7384729304857234567"))
+		Expect(decoded.LatestCheckpointTimelineID).To(Equal("2"))
+		Expect(decoded.REDOWALFile).To(Equal("000000020000000000000006"))
+		Expect(decoded.LatestCheckpointREDOLocation).To(Equal("0/6000028"))
+	})
+
+	It("accepts a designated primary that was cleanly shut down in recovery", func() {
+		stopped := strings.Replace(pgControlData, "shut down", "shut down in recovery", 1)
+		token, err := newPromotionToken("cluster-eu-south", "cluster-eu-south-1", stopped, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token.DatabaseClusterState).To(Equal("shut down in recovery"))
+		Expect(token.Content.REDOWALFile).To(Equal("000000020000000000000006"))
+	})
+
+	It("fails when pg_controldata doesn't contain the required fields", func() {
+		_, err := newPromotionToken("cluster-eu-south", "cluster-eu-south-1", "Database cluster state: shut down", 0)
+		Expect(err).To(MatchError(utils.ErrEmptyLatestCheckpointTimelineID))
+	})
+
+	It("refuses an instance that was not cleanly shut down", func() {
+		running := strings.Replace(pgControlData, "shut down", "in archive recovery", 1)
+		_, err := newPromotionToken("cluster-eu-south", "cluster-eu-south-1", running, 0)
+		Expect(err).To(MatchError(ContainSubstring(`its state is "in archive recovery"`)))
+	})
+
+	It("refuses an instance with WAL files pending archive", func() {
+		_, err := newPromotionToken("cluster-eu-south", "cluster-eu-south-1", pgControlData, 2)
+		Expect(err).To(MatchError(ContainSubstring("2 WAL files are still waiting to be archived")))
+	})
+})
+
+var _ = Describe("newPromotionTokenFromDemotionToken", func() {
+	It("uses the demotion token published by the cluster", func() {
+		demotionToken, err := utils.CreatePromotionToken(utils.ParsePgControldataOutput(pgControlData))
+		Expect(err).ToNot(HaveOccurred())
+
+		token, err := newPromotionTokenFromDemotionToken("cluster-eu-south", demotionToken)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token.Source).To(Equal(tokenSourceDemotionToken))
+		Expect(token.Token).To(Equal(demotionToken))
+		Expect(token.Content.REDOWALFile).To(Equal("000000020000000000000006"))
+	})
+
+	It("rejects a demotion token that cannot be decoded", func() {
+		_, err := newPromotionTokenFromDemotionToken("cluster-eu-south", "not a token")
+		Expect(err).To(HaveOccurred())
+	})
+})
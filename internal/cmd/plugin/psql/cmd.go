@@ -27,6 +27,7 @@ import (
 // NewCmd creates the "psql" command
 func NewCmd() *cobra.Command {
 	var replica bool
+	var instance string
 	var allocateTTY bool
 	var passStdin bool
 
@@ -44,6 +45,7 @@ func NewCmd() *cobra.Command {
 			psqlArgs := args[1:]
 			psqlOptions := CommandOptions{
 				Replica:     replica,
+				Instance:    instance,
 				Namespace:   plugin.Namespace,
 				Context:     plugin.KubeContext,
 				AllocateTTY: allocateTTY,
@@ -65,9 +67,17 @@ func NewCmd() *cobra.Command {
 		&replica,
 		"replica",
 		false,
-		"Connects to the first replica on the pod list (by default connects to the primary)",
+		"Connects to a ready replica, as reported by the cluster status (by default connects to the primary)",
 	)
 
+	cmd.Flags().StringVar(
+		&instance,
+		"instance",
+		"",
+		"Connects to the instance with the given name (by default connects to the primary)",
+	)
+	cmd.MarkFlagsMutuallyExclusive("replica", "instance")
+
 	cmd.Flags().BoolVarP(
 		&allocateTTY,
 		"tty",
@@ -21,12 +21,14 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"syscall"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
@@ -44,6 +46,9 @@ type Command struct {
 	// The list of possible pods where to launch psql
 	podList []corev1.Pod
 
+	// The name of the current primary, as reported by the cluster status
+	currentPrimary string
+
 	// The path of kubectl
 	kubectlPath string
 }
@@ -53,6 +58,9 @@ type CommandOptions struct {
 	// Require a connection to a Replica
 	Replica bool
 
+	// Require a connection to the instance with this name
+	Instance string
+
 	// The cluster Name
 	Name string
 
@@ -92,6 +100,21 @@ func NewCommand(
 		return nil, fmt.Errorf("cluster does not exist or is not accessible")
 	}
 
+	// The roles of the instances are needed only when the
+	// instance is not explicitly chosen
+	var currentPrimary string
+	if options.Instance == "" {
+		var cluster apiv1.Cluster
+		if err := plugin.Client.Get(
+			ctx,
+			client.ObjectKey{Namespace: plugin.Namespace, Name: options.Name},
+			&cluster,
+		); err != nil {
+			return nil, fmt.Errorf("while getting cluster %s: %w", options.Name, err)
+		}
+		currentPrimary = cluster.Status.CurrentPrimary
+	}
+
 	kubectlPath, err := exec.LookPath(kubectlCommand)
 	if err != nil {
 		return nil, fmt.Errorf("while getting kubectl path: %w", err)
@@ -100,6 +123,7 @@ func NewCommand(
 	return &Command{
 		CommandOptions: options,
 		podList:        pods.Items,
+		currentPrimary: currentPrimary,
 		kubectlPath:    kubectlPath,
 	}, nil
 }
@@ -140,21 +164,46 @@ func (psql *Command) getKubectlInvocation() ([]string, error) {
 	return result, nil
 }
 
-// getPodName get the first Pod name with the required role
+// getPodName gets the name of the instance Pod where psql should be
+// started. The roles of the instances are resolved from the cluster status
 func (psql *Command) getPodName() (string, error) {
-	targetPodRole := specs.ClusterRoleLabelPrimary
-	if psql.Replica {
-		targetPodRole = specs.ClusterRoleLabelReplica
+	if psql.Instance != "" {
+		for i := range psql.podList {
+			if psql.podList[i].Name == psql.Instance && isInstancePod(psql.podList[i]) {
+				return psql.Instance, nil
+			}
+		}
+		return "", &ErrMissingInstance{name: psql.Instance, cluster: psql.Name}
+	}
+
+	if !psql.Replica {
+		for i := range psql.podList {
+			if psql.podList[i].Name == psql.currentPrimary && isInstancePod(psql.podList[i]) {
+				return psql.currentPrimary, nil
+			}
+		}
+		return "", &ErrMissingPod{role: specs.ClusterRoleLabelPrimary}
 	}
 
+	// Pick the first ready standby in name order, to have a stable choice
+	var replicas []string
 	for i := range psql.podList {
-		podRole, _ := utils.GetInstanceRole(psql.podList[i].Labels)
-		if podRole == targetPodRole {
-			return psql.podList[i].Name, nil
+		pod := psql.podList[i]
+		if pod.Name != psql.currentPrimary && isInstancePod(pod) && utils.IsPodReady(pod) {
+			replicas = append(replicas, pod.Name)
 		}
 	}
+	if len(replicas) == 0 {
+		return "", &ErrMissingPod{role: specs.ClusterRoleLabelReplica}
+	}
+	sort.Strings(replicas)
+
+	return replicas[0], nil
+}
 
-	return "", &ErrMissingPod{role: targetPodRole}
+// isInstancePod checks if the passed Pod is running a PostgreSQL instance
+func isInstancePod(pod corev1.Pod) bool {
+	return pod.Labels[utils.PodRoleLabelName] == string(utils.PodRoleInstance)
 }
 
 // Exec replaces the current process with a `kubectl Exec` invocation.
@@ -208,3 +257,15 @@ type ErrMissingPod struct {
 func (err *ErrMissingPod) Error() string {
 	return fmt.Sprintf("cannot find Pod with role \"%s\"", err.role)
 }
+
+// ErrMissingInstance is raised when the requested instance is not
+// part of the cluster
+type ErrMissingInstance struct {
+	name    string
+	cluster string
+}
+
+// Error implements the error interface
+func (err *ErrMissingInstance) Error() string {
+	return fmt.Sprintf("cannot find instance \"%s\" in cluster \"%s\"", err.name, err.cluster)
+}
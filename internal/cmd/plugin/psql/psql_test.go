@@ -39,7 +39,8 @@ var _ = Describe("psql launcher", func() {
 			CommandOptions: CommandOptions{
 				Replica: false,
 			},
-			podList: podList,
+			podList:        podList,
+			currentPrimary: "cluster-example-2",
 		}
 		Expect(cmd.getPodName()).To(Equal("cluster-example-2"))
 	})
@@ -49,11 +50,61 @@ var _ = Describe("psql launcher", func() {
 			CommandOptions: CommandOptions{
 				Replica: true,
 			},
-			podList: podList,
+			podList:        podList,
+			currentPrimary: "cluster-example-2",
 		}
 		Expect(cmd.getPodName()).To(Equal("cluster-example-1"))
 	})
 
+	It("skips the replicas which are not ready", func() {
+		unreadyReplica := fakePod("cluster-example-1", specs.ClusterRoleLabelReplica)
+		unreadyReplica.Status.Conditions = nil
+		cmd := Command{
+			CommandOptions: CommandOptions{
+				Replica: true,
+			},
+			podList: []corev1.Pod{
+				unreadyReplica,
+				fakePod("cluster-example-2", specs.ClusterRoleLabelPrimary),
+				fakePod("cluster-example-3", specs.ClusterRoleLabelReplica),
+			},
+			currentPrimary: "cluster-example-2",
+		}
+		Expect(cmd.getPodName()).To(Equal("cluster-example-3"))
+	})
+
+	It("resolves the primary from the cluster status", func() {
+		cmd := Command{
+			podList:        podList,
+			currentPrimary: "cluster-example-3",
+		}
+		Expect(cmd.getPodName()).To(Equal("cluster-example-3"))
+	})
+
+	It("selects the requested instance", func() {
+		cmd := Command{
+			CommandOptions: CommandOptions{
+				Instance: "cluster-example-3",
+			},
+			podList:        podList,
+			currentPrimary: "cluster-example-2",
+		}
+		Expect(cmd.getPodName()).To(Equal("cluster-example-3"))
+	})
+
+	It("raises an error when the requested instance doesn't exist", func() {
+		cmd := Command{
+			CommandOptions: CommandOptions{
+				Name:     "cluster-example",
+				Instance: "cluster-example-4",
+			},
+			podList:        podList,
+			currentPrimary: "cluster-example-2",
+		}
+		_, err := cmd.getPodName()
+		Expect(err).To(MatchError(`cannot find instance "cluster-example-4" in cluster "cluster-example"`))
+	})
+
 	It("raises an error when a Pod cannot be found", func() {
 		fakePodList := []corev1.Pod{
 			fakePod("cluster-example-1", "guitar"),
@@ -82,7 +133,8 @@ var _ = Describe("psql launcher", func() {
 				PassStdin:   true,
 				Namespace:   "default",
 			},
-			podList: podList,
+			podList:        podList,
+			currentPrimary: "cluster-example-2",
 		}
 		Expect(cmd.getKubectlInvocation()).To(ConsistOf(
 			"kubectl",
@@ -111,7 +163,8 @@ var _ = Describe("psql launcher", func() {
 					"select 1",
 				},
 			},
-			podList: podList,
+			podList:        podList,
+			currentPrimary: "cluster-example-2",
 		}
 		Expect(cmd.getKubectlInvocation()).To(ConsistOf(
 			"kubectl",
@@ -138,6 +191,15 @@ func fakePod(name, role string) corev1.Pod {
 			Namespace: "default",
 			Labels: map[string]string{
 				utils.ClusterInstanceRoleLabelName: role,
+				utils.PodRoleLabelName:             string(utils.PodRoleInstance),
+			},
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.ContainersReady,
+					Status: corev1.ConditionTrue,
+				},
 			},
 		},
 	}
@@ -18,6 +18,8 @@ package reload
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -26,10 +28,14 @@ import (
 
 // NewCmd creates the new "reset" command
 func NewCmd() *cobra.Command {
+	var instanceName string
+
 	restartCmd := &cobra.Command{
-		Use:     "reload CLUSTER",
-		Short:   `Reload a cluster`,
-		Long:    `Triggers a reconciliation loop for all the cluster's instances, rolling out new configurations if present.`,
+		Use:   "reload CLUSTER",
+		Short: `Reload a cluster`,
+		Long: `Triggers a reconciliation loop for all the cluster's instances, rolling out new configurations if present.
+If an instance is specified with --instance, only the configuration of that instance is reloaded,
+reporting whether the changed parameters require a restart.`,
 		GroupID: plugin.GroupIDCluster,
 		Args:    plugin.RequiresArguments(1),
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -38,9 +44,18 @@ func NewCmd() *cobra.Command {
 		RunE: func(_ *cobra.Command, args []string) error {
 			ctx := context.Background()
 			clusterName := args[0]
-			return Reload(ctx, clusterName)
+			if instanceName == "" {
+				return Reload(ctx, clusterName)
+			}
+			if _, err := strconv.Atoi(instanceName); err == nil {
+				instanceName = fmt.Sprintf("%s-%s", clusterName, instanceName)
+			}
+			return ReloadInstance(ctx, clusterName, instanceName)
 		},
 	}
 
+	restartCmd.Flags().StringVar(&instanceName, "instance", "",
+		"Reload only the configuration of the given instance")
+
 	return restartCmd
 }
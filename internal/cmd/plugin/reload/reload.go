@@ -19,13 +19,22 @@ package reload
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
+	corev1 "k8s.io/api/core/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/remote"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/url"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
@@ -54,3 +63,57 @@ func Reload(ctx context.Context, clusterName string) error {
 	fmt.Printf("%s will be reloaded\n", clusterRestarted.Name)
 	return nil
 }
+
+// ReloadInstance reloads the configuration of a single instance of the
+// cluster, reporting whether the changed parameters require a restart
+func ReloadInstance(ctx context.Context, clusterName, instanceName string) error {
+	var pod corev1.Pod
+	err := plugin.Client.Get(ctx, client.ObjectKey{Namespace: plugin.Namespace, Name: instanceName}, &pod)
+	if err != nil {
+		return err
+	}
+
+	if pod.Labels[utils.ClusterLabelName] != clusterName {
+		return fmt.Errorf("instance %s does not belong to cluster %s", instanceName, clusterName)
+	}
+
+	clientInterface := kubernetes.NewForConfigOrDie(plugin.Config)
+	body, err := clientInterface.CoreV1().RESTClient().
+		Post().
+		Namespace(pod.Namespace).
+		Resource("pods").
+		SubResource("proxy").
+		Name(utilnet.JoinSchemeNamePort(
+			remote.GetStatusSchemeFromPod(&pod).ToString(),
+			pod.Name,
+			strconv.Itoa(int(url.StatusPort)),
+		)).
+		Suffix(url.PathPgReload).
+		DoRaw(ctx)
+
+	var response webserver.Response[webserver.ReloadResult]
+	if jsonErr := json.Unmarshal(body, &response); jsonErr != nil && err == nil {
+		return fmt.Errorf("while decoding the reload result of %s: %w", instanceName, jsonErr)
+	}
+	if response.Error != nil {
+		return fmt.Errorf("failed to reload %s: %s (%s)", instanceName, response.Error.Message, response.Error.Code)
+	}
+	if err != nil {
+		return fmt.Errorf(
+			"failed to reload %s by proxying to the pod, you might lack permissions to create pods/proxy: %w",
+			instanceName, err)
+	}
+	if response.Data == nil {
+		return fmt.Errorf("empty reload result from %s", instanceName)
+	}
+
+	fmt.Printf("%s has been reloaded\n", instanceName)
+	if response.Data.PendingRestart {
+		fmt.Printf("A restart is required to apply the following parameters: %s\n",
+			strings.Join(response.Data.PendingRestartSettings, ", "))
+	} else {
+		fmt.Println("No restart is required")
+	}
+
+	return nil
+}
@@ -27,20 +27,27 @@ import (
 
 // NewCmd creates the new "reset" command
 func NewCmd() *cobra.Command {
+	var inPlace bool
+
 	restartCmd := &cobra.Command{
 		Use:   "restart CLUSTER [INSTANCE]",
 		Short: `Restart a cluster or a single instance in a cluster`,
 		Long: `If only the cluster name is specified, the whole cluster will be restarted, 
 rolling out new configurations if present.
 If a specific instance is specified, only that instance will be restarted, 
-in-place if it is a primary, deleting the pod if it is a replica.`,
+in-place if it is a primary, deleting the pod if it is a replica.
+Use --in-place to restart the primary of the cluster without a switchover.`,
 		Args:    cobra.RangeArgs(1, 2),
 		GroupID: plugin.GroupIDCluster,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			clusterName := args[0]
 			if len(args) == 1 {
-				return restart(ctx, clusterName)
+				return restart(ctx, clusterName, inPlace)
+			}
+			if inPlace {
+				return fmt.Errorf("the --in-place option can only be used when restarting a whole cluster, " +
+					"as the primary instance is always restarted in-place")
 			}
 			node := args[1]
 			if _, err := strconv.Atoi(args[1]); err == nil {
@@ -50,5 +57,8 @@ in-place if it is a primary, deleting the pod if it is a replica.`,
 		},
 	}
 
+	restartCmd.Flags().BoolVar(&inPlace, "in-place", false,
+		"Restart the primary instance in-place, without a switchover")
+
 	return restartCmd
 }
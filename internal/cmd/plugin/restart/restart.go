@@ -31,8 +31,9 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
-// restart marks the cluster as needing to restart
-func restart(ctx context.Context, clusterName string) error {
+// restart marks the cluster as needing to restart. When inPlace is true,
+// the primary instance is restarted without a switchover
+func restart(ctx context.Context, clusterName string, inPlace bool) error {
 	var cluster apiv1.Cluster
 
 	// Get the Cluster object
@@ -41,11 +42,24 @@ func restart(ctx context.Context, clusterName string) error {
 		return fmt.Errorf("while trying to get cluster %v: %w", clusterName, err)
 	}
 
+	if inPlace && cluster.GetPrimaryUpdateStrategy() == apiv1.PrimaryUpdateStrategySupervised {
+		return fmt.Errorf("cluster %v has a supervised primary update strategy: "+
+			"the primary instance is restarted only when requested by the user, "+
+			"use 'kubectl cnpg restart %v %v' to restart it in-place",
+			clusterName, clusterName, cluster.Status.CurrentPrimary)
+	}
+
 	clusterRestarted := cluster.DeepCopy()
 	if clusterRestarted.Annotations == nil {
 		clusterRestarted.Annotations = make(map[string]string)
 	}
-	clusterRestarted.Annotations[utils.ClusterRestartAnnotationName] = time.Now().Format(time.RFC3339)
+	restartTime := time.Now().Format(time.RFC3339)
+	clusterRestarted.Annotations[utils.ClusterRestartAnnotationName] = restartTime
+	if inPlace {
+		clusterRestarted.Annotations[utils.InPlaceRestartAnnotationName] = restartTime
+	} else {
+		delete(clusterRestarted.Annotations, utils.InPlaceRestartAnnotationName)
+	}
 	clusterRestarted.ManagedFields = nil
 
 	err = plugin.Client.Patch(ctx, clusterRestarted, client.MergeFrom(&cluster))
@@ -19,6 +19,7 @@ package status
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -44,6 +45,18 @@ func NewCmd() *cobra.Command {
 
 			verbose, _ := cmd.Flags().GetCount("verbose")
 			output, _ := cmd.Flags().GetString("output")
+			watch, _ := cmd.Flags().GetBool("watch")
+			watchInterval, _ := cmd.Flags().GetDuration("watch-interval")
+
+			if watch {
+				if plugin.OutputFormat(output) != plugin.OutputFormatText {
+					return fmt.Errorf("the watch mode is only supported with the text output format")
+				}
+				if watchInterval <= 0 {
+					return fmt.Errorf("the watch interval must be positive")
+				}
+				return Watch(ctx, clusterName, verbose, watchInterval)
+			}
 
 			return Status(ctx, clusterName, verbose, plugin.OutputFormat(output))
 		},
@@ -52,7 +65,11 @@ func NewCmd() *cobra.Command {
 	statusCmd.Flags().CountP(
 		"verbose", "v", "Increase verbosity to display more information")
 	statusCmd.Flags().StringP(
-		"output", "o", "text", "Output format. One of text|json")
+		"output", "o", "text", "Output format. One of text|json|prometheus")
+	statusCmd.Flags().BoolP(
+		"watch", "w", false, "Keep watching the cluster, redrawing only the parts of the status that changed")
+	statusCmd.Flags().Duration(
+		"watch-interval", 2*time.Second, "The interval between two refreshes of the status in watch mode")
 
 	return statusCmd
 }
@@ -0,0 +1,190 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"io"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// OutputFormatPrometheus means printing a snapshot of the cluster
+// metrics in the Prometheus text exposition format
+const OutputFormatPrometheus plugin.OutputFormat = "prometheus"
+
+// prometheusNamespace is the namespace of the metrics printed
+// by the status command
+const prometheusNamespace = "cnpg"
+
+// printPrometheusMetrics writes a snapshot of the key metrics of the
+// cluster and of its instances in the Prometheus text format
+func (fullStatus *PostgresqlStatus) printPrometheusMetrics(writer io.Writer) error {
+	registry := prometheus.NewRegistry()
+	cluster := fullStatus.Cluster
+	clusterLabels := prometheus.Labels{
+		"namespace": cluster.Namespace,
+		"cluster":   cluster.Name,
+	}
+
+	newClusterGauge := func(name, help string, labelNames ...string) *prometheus.GaugeVec {
+		gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   prometheusNamespace,
+			Subsystem:   "cluster",
+			Name:        name,
+			Help:        help,
+			ConstLabels: clusterLabels,
+		}, labelNames)
+		registry.MustRegister(gauge)
+		return gauge
+	}
+
+	newInstanceGauge := func(name, help string) *prometheus.GaugeVec {
+		gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   prometheusNamespace,
+			Subsystem:   "instance",
+			Name:        name,
+			Help:        help,
+			ConstLabels: clusterLabels,
+		}, []string{"pod"})
+		registry.MustRegister(gauge)
+		return gauge
+	}
+
+	newClusterGauge("info", "Information about the cluster, always 1",
+		"phase", "current_primary", "image").
+		WithLabelValues(cluster.Status.Phase, cluster.Status.CurrentPrimary, cluster.Status.Image).
+		Set(1)
+	newClusterGauge("instances", "Number of desired instances").
+		WithLabelValues().Set(float64(cluster.Spec.Instances))
+	newClusterGauge("ready_instances", "Number of ready instances").
+		WithLabelValues().Set(float64(cluster.Status.ReadyInstances))
+	newClusterGauge("timeline_id", "Current timeline of the cluster").
+		WithLabelValues().Set(float64(cluster.Status.TimelineID))
+
+	conditions := newClusterGauge("condition", "Status of the cluster conditions, 1 if true, 0 otherwise",
+		"type")
+	for _, condition := range cluster.Status.Conditions {
+		value := 0.0
+		if meta.IsStatusConditionTrue(cluster.Status.Conditions, condition.Type) {
+			value = 1
+		}
+		conditions.WithLabelValues(condition.Type).Set(value)
+	}
+
+	if timestamp, ok := parseStatusTimestamp(cluster.Status.LastSuccessfulBackup); ok {
+		newClusterGauge("last_successful_backup_timestamp_seconds",
+			"Time of the last successful backup, as a Unix timestamp").
+			WithLabelValues().Set(float64(timestamp.Unix()))
+	}
+	if timestamp, ok := parseStatusTimestamp(cluster.Status.FirstRecoverabilityPoint); ok {
+		newClusterGauge("first_recoverability_point_timestamp_seconds",
+			"Time of the first recoverability point, as a Unix timestamp").
+			WithLabelValues().Set(float64(timestamp.Unix()))
+	}
+
+	up := newInstanceGauge("up", "1 if the status of the instance could be retrieved, 0 otherwise")
+	isPrimary := newInstanceGauge("is_primary", "1 if the instance is the primary, 0 otherwise")
+	ready := newInstanceGauge("ready", "1 if the Pod of the instance is ready, 0 otherwise")
+	timelineID := newInstanceGauge("timeline_id", "Current timeline of the instance")
+	pendingRestart := newInstanceGauge("pending_restart",
+		"1 if the instance needs a restart to apply the configuration, 0 otherwise")
+	readyWALFiles := newInstanceGauge("ready_wal_files", "Number of WAL files waiting to be archived")
+	replayLag := newInstanceGauge("replay_lag_bytes",
+		"Amount of WAL the replica still has to replay compared to the primary")
+
+	if fullStatus.InstanceStatus == nil {
+		return writeMetrics(writer, registry)
+	}
+
+	var primaryLSN int64
+	if primary := fullStatus.tryGetPrimaryInstance(); primary != nil {
+		primaryLSN, _ = getCurrentLSN(*primary).Parse()
+	}
+
+	for _, instance := range fullStatus.InstanceStatus.Items {
+		if instance.Pod == nil {
+			continue
+		}
+		podName := instance.Pod.Name
+
+		ready.WithLabelValues(podName).Set(boolToFloat(instance.IsPodReady))
+		if instance.Error != nil {
+			up.WithLabelValues(podName).Set(0)
+			continue
+		}
+
+		up.WithLabelValues(podName).Set(1)
+		isPrimary.WithLabelValues(podName).Set(boolToFloat(instance.IsPrimary))
+		timelineID.WithLabelValues(podName).Set(float64(instance.TimeLineID))
+		pendingRestart.WithLabelValues(podName).Set(boolToFloat(instance.PendingRestart))
+		readyWALFiles.WithLabelValues(podName).Set(float64(instance.ReadyWALFiles))
+
+		if instance.IsPrimary || primaryLSN == 0 {
+			continue
+		}
+		if replayLSN, err := instance.ReplayLsn.Parse(); err == nil {
+			replayLag.WithLabelValues(podName).Set(float64(max(primaryLSN-replayLSN, 0)))
+		}
+	}
+
+	return writeMetrics(writer, registry)
+}
+
+// writeMetrics encodes the metrics gathered from the registry
+// in the Prometheus text format
+func writeMetrics(writer io.Writer, registry *prometheus.Registry) error {
+	metricFamilies, err := registry.Gather()
+	if err != nil {
+		return err
+	}
+
+	encoder := expfmt.NewEncoder(writer, expfmt.NewFormat(expfmt.TypeTextPlain))
+	for _, metricFamily := range metricFamilies {
+		if err := encoder.Encode(metricFamily); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseStatusTimestamp parses a timestamp stored in the cluster status
+func parseStatusTimestamp(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	timestamp, err := time.Parse(metav1.RFC3339Micro, value)
+	if err != nil {
+		timestamp, err = time.Parse(time.RFC3339, value)
+	}
+
+	return timestamp, err == nil
+}
+
+func boolToFloat(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,121 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"bytes"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("printPrometheusMetrics", func() {
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+
+	var fullStatus *PostgresqlStatus
+
+	BeforeEach(func() {
+		fullStatus = &PostgresqlStatus{
+			Cluster: &apiv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+				Spec:       apiv1.ClusterSpec{Instances: 3},
+				Status: apiv1.ClusterStatus{
+					Phase:                "Cluster in healthy state",
+					CurrentPrimary:       "cluster-example-1",
+					ReadyInstances:       2,
+					TimelineID:           1,
+					LastSuccessfulBackup: "2024-01-02T03:04:05Z",
+					Conditions: []metav1.Condition{
+						{Type: string(apiv1.ConditionClusterReady), Status: metav1.ConditionTrue},
+						{Type: string(apiv1.ConditionContinuousArchiving), Status: metav1.ConditionFalse},
+					},
+				},
+			},
+			InstanceStatus: &postgres.PostgresqlStatusList{
+				Items: []postgres.PostgresqlStatus{
+					{
+						Pod:           newPod("cluster-example-1"),
+						IsPrimary:     true,
+						IsPodReady:    true,
+						CurrentLsn:    "0/3000000",
+						TimeLineID:    1,
+						ReadyWALFiles: 2,
+					},
+					{
+						Pod:            newPod("cluster-example-2"),
+						IsPodReady:     true,
+						ReplayLsn:      "0/2000000",
+						TimeLineID:     1,
+						PendingRestart: true,
+					},
+					{
+						Pod:   newPod("cluster-example-3"),
+						Error: errors.New("connection refused"),
+					},
+				},
+			},
+		}
+	})
+
+	It("prints the cluster metrics", func() {
+		var buffer bytes.Buffer
+		Expect(fullStatus.printPrometheusMetrics(&buffer)).To(Succeed())
+
+		output := buffer.String()
+		Expect(output).To(ContainSubstring(
+			`cnpg_cluster_instances{cluster="cluster-example",namespace="default"} 3`))
+		Expect(output).To(ContainSubstring(
+			`cnpg_cluster_ready_instances{cluster="cluster-example",namespace="default"} 2`))
+		Expect(output).To(ContainSubstring(
+			`cnpg_cluster_condition{cluster="cluster-example",namespace="default",type="Ready"} 1`))
+		Expect(output).To(ContainSubstring(
+			`cnpg_cluster_condition{cluster="cluster-example",namespace="default",type="ContinuousArchiving"} 0`))
+		Expect(output).To(ContainSubstring(
+			`cnpg_cluster_last_successful_backup_timestamp_seconds{cluster="cluster-example",namespace="default"} ` +
+				`1.704164645e+09`))
+		Expect(output).ToNot(ContainSubstring("cnpg_cluster_first_recoverability_point_timestamp_seconds"))
+	})
+
+	It("prints the instance metrics", func() {
+		var buffer bytes.Buffer
+		Expect(fullStatus.printPrometheusMetrics(&buffer)).To(Succeed())
+
+		output := buffer.String()
+		Expect(output).To(ContainSubstring(
+			`cnpg_instance_is_primary{cluster="cluster-example",namespace="default",pod="cluster-example-1"} 1`))
+		Expect(output).To(ContainSubstring(
+			`cnpg_instance_ready_wal_files{cluster="cluster-example",namespace="default",pod="cluster-example-1"} 2`))
+		Expect(output).To(ContainSubstring(
+			`cnpg_instance_pending_restart{cluster="cluster-example",namespace="default",pod="cluster-example-2"} 1`))
+		Expect(output).To(ContainSubstring(
+			`cnpg_instance_replay_lag_bytes{cluster="cluster-example",namespace="default",pod="cluster-example-2"} ` +
+				`1.6777216e+07`))
+		Expect(output).To(ContainSubstring(
+			`cnpg_instance_up{cluster="cluster-example",namespace="default",pod="cluster-example-3"} 0`))
+		Expect(output).ToNot(ContainSubstring(
+			`cnpg_instance_replay_lag_bytes{cluster="cluster-example",namespace="default",pod="cluster-example-1"}`))
+	})
+})
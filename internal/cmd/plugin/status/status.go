@@ -35,6 +35,7 @@ import (
 	policyv1 "k8s.io/api/policy/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -70,6 +71,9 @@ type PostgresqlStatus struct {
 
 	// The size of the cluster
 	TotalClusterSize string
+
+	// LastBackup is the most recent completed backup of the cluster
+	LastBackup *apiv1.Backup `json:"lastBackup,omitempty"`
 }
 
 func (fullStatus *PostgresqlStatus) getReplicationSlotList() postgres.PgReplicationSlotList {
@@ -107,7 +111,6 @@ func Status(
 	format plugin.OutputFormat,
 ) error {
 	var cluster apiv1.Cluster
-	var errs []error
 
 	// Create a Kubernetes client suitable for calling the "Exec" subresource
 	clientInterface := kubernetes.NewForConfigOrDie(plugin.Config)
@@ -120,31 +123,51 @@ func Status(
 	}
 
 	status := extractPostgresqlStatus(ctx, cluster)
+	if format == OutputFormatPrometheus {
+		return status.printPrometheusMetrics(os.Stdout)
+	}
+
 	err = plugin.Print(status, format, os.Stdout)
 	if err != nil || format != plugin.OutputFormatText {
 		return err
 	}
-	errs = append(errs, status.ErrorList...)
 
-	status.printBasicInfo(ctx, clientInterface)
-	status.printHibernationInfo()
-	status.printDemotionTokenInfo()
-	status.printPromotionTokenInfo()
+	status.printText(ctx, clientInterface, verbosity)
+	return nil
+}
+
+// printText prints the status of the cluster in the human-readable format
+func (fullStatus *PostgresqlStatus) printText(
+	ctx context.Context,
+	clientInterface kubernetes.Interface,
+	verbosity int,
+) {
+	var errs []error
+	errs = append(errs, fullStatus.ErrorList...)
+
+	fullStatus.printBasicInfo(ctx, clientInterface)
+	fullStatus.printHibernationInfo()
+	fullStatus.printPrimaryUpdatePendingInfo()
+	fullStatus.printImportStatus()
+	fullStatus.printDemotionTokenInfo()
+	fullStatus.printPromotionTokenInfo()
 	if verbosity > 1 {
-		errs = append(errs, status.printPostgresConfiguration(ctx, clientInterface)...)
-		status.printCertificatesStatus()
-	}
-	status.printBackupStatus()
-	status.printBasebackupStatus(verbosity)
-	status.printReplicaStatus(verbosity)
+		errs = append(errs, fullStatus.printPostgresConfiguration(ctx, clientInterface)...)
+		fullStatus.printCertificatesStatus()
+	}
+	fullStatus.printBackupStatus()
+	fullStatus.printBasebackupStatus(verbosity)
+	fullStatus.printReplicaStatus(verbosity)
+	fullStatus.printDelayedReplicaStatus()
+	fullStatus.printParameterChangesStatus()
 	if verbosity > 0 {
-		status.printUnmanagedReplicationSlotStatus()
-		status.printRoleManagerStatus()
-		status.printTablespacesStatus()
-		status.printPodDisruptionBudgetStatus()
+		fullStatus.printUnmanagedReplicationSlotStatus()
+		fullStatus.printRoleManagerStatus()
+		fullStatus.printTablespacesStatus()
+		fullStatus.printPodDisruptionBudgetStatus()
 	}
-	status.printInstancesStatus()
-	status.printPluginStatus(verbosity)
+	fullStatus.printInstancesStatus()
+	fullStatus.printPluginStatus(verbosity)
 
 	if len(errs) > 0 {
 		fmt.Println()
@@ -155,8 +178,6 @@ func Status(
 			fmt.Printf("%s\n", err)
 		}
 	}
-
-	return nil
 }
 
 // extractPostgresqlStatus gets the PostgreSQL status using the Kubernetes API
@@ -187,6 +208,16 @@ func extractPostgresqlStatus(ctx context.Context, cluster apiv1.Cluster) *Postgr
 	); err != nil {
 		errs = append(errs, err)
 	}
+
+	var backupList apiv1.BackupList
+	if err := plugin.Client.List(
+		ctx,
+		&backupList,
+		client.InNamespace(plugin.Namespace),
+	); err != nil {
+		errs = append(errs, err)
+	}
+
 	// Extract the status from the instances
 	status := PostgresqlStatus{
 		Cluster:                 &cluster,
@@ -194,10 +225,29 @@ func extractPostgresqlStatus(ctx context.Context, cluster apiv1.Cluster) *Postgr
 		PrimaryPod:              primaryPod,
 		PodDisruptionBudgetList: pdbl,
 		ErrorList:               errs,
+		LastBackup:              getLastCompletedBackup(backupList.Items, cluster.Name),
 	}
 	return &status
 }
 
+// getLastCompletedBackup returns the completed backup of the cluster
+// that terminated last, or nil if there is none
+func getLastCompletedBackup(backups []apiv1.Backup, clusterName string) *apiv1.Backup {
+	var lastBackup *apiv1.Backup
+	for idx := range backups {
+		backup := &backups[idx]
+		if backup.Spec.Cluster.Name != clusterName ||
+			backup.Status.Phase != apiv1.BackupPhaseCompleted ||
+			backup.Status.StoppedAt == nil {
+			continue
+		}
+		if lastBackup == nil || lastBackup.Status.StoppedAt.Before(backup.Status.StoppedAt) {
+			lastBackup = backup
+		}
+	}
+	return lastBackup
+}
+
 func listFencedInstances(fencedInstances *stringset.Data) string {
 	if fencedInstances.Has(utils.FenceAllInstances) {
 		return "All Instances"
@@ -283,6 +333,10 @@ func (fullStatus *PostgresqlStatus) printBasicInfo(ctx context.Context, k8sClien
 		summary.AddLine("Ready instances:", aurora.Red(cluster.Status.ReadyInstances))
 	}
 
+	if maintenanceReason := getMaintenanceReason(cluster); maintenanceReason != "" {
+		summary.AddLine("Maintenance reason:", aurora.Yellow(maintenanceReason))
+	}
+
 	if fencedInstances != nil && fencedInstances.Len() > 0 {
 		if isPrimaryFenced {
 			summary.AddLine("Fenced instances:", aurora.Red(listFencedInstances(fencedInstances)))
@@ -345,6 +399,59 @@ func (fullStatus *PostgresqlStatus) printHibernationInfo() {
 	fmt.Println()
 }
 
+// printPrimaryUpdatePendingInfo explains why the primary instance
+// of a cluster using the supervised primary update strategy is not
+// being updated, and since when
+func (fullStatus *PostgresqlStatus) printPrimaryUpdatePendingInfo() {
+	condition := meta.FindStatusCondition(
+		fullStatus.Cluster.Status.Conditions,
+		string(apiv1.ConditionPrimaryUpdatePending),
+	)
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		return
+	}
+
+	status := tabby.New()
+	status.AddLine("Message", condition.Message)
+	status.AddLine("Pending since", getPrintablePendingTime(condition.LastTransitionTime.Time, time.Now()))
+
+	fmt.Println(aurora.Yellow("Primary update pending"))
+	status.Print()
+
+	fmt.Println()
+}
+
+// printImportStatus shows the progress of a running logical import
+func (fullStatus *PostgresqlStatus) printImportStatus() {
+	importStatus := fullStatus.Cluster.Status.Import
+	if importStatus == nil || importStatus.Phase == apiv1.ImportPhaseCompleted {
+		return
+	}
+
+	status := tabby.New()
+	status.AddLine("Phase", importStatus.Phase)
+	status.AddLine("Current database", importStatus.CurrentDatabase)
+	status.AddLine("Databases", fmt.Sprintf("%d/%d", importStatus.DatabasesDone, importStatus.DatabasesTotal))
+	if len(importStatus.CopyingTables) > 0 {
+		status.AddLine("Copying tables", strings.Join(importStatus.CopyingTables, ", "))
+		status.AddLine("Bytes processed",
+			resource.NewQuantity(importStatus.BytesProcessed, resource.BinarySI).String())
+	}
+	status.AddLine("Started at", importStatus.StartTime)
+	status.AddLine("Last update", importStatus.LastUpdateTime)
+
+	fmt.Println(aurora.Green("Logical import"))
+	status.Print()
+
+	fmt.Println()
+}
+
+func getPrintablePendingTime(since time.Time, currentTime time.Time) string {
+	return fmt.Sprintf("%s (%s)",
+		since.UTC().Format(time.RFC3339),
+		currentTime.Sub(since).Round(time.Second))
+}
+
 func (fullStatus *PostgresqlStatus) printTokenStatus(token string) {
 	primaryInstanceStatus := fullStatus.tryGetPrimaryInstance()
 
@@ -494,6 +601,15 @@ func (fullStatus *PostgresqlStatus) printBackupStatus() {
 		FPoR = "Not Available"
 	}
 	status.AddLine("First Point of Recoverability:", FPoR)
+	if retentionRun := cluster.Status.LastRetentionRun; retentionRun != nil {
+		status.AddLine("Last Retention Run:", getPrintableRetentionRun(retentionRun))
+	}
+
+	if lastBackup := fullStatus.LastBackup; lastBackup != nil {
+		status.AddLine("Last Backup:", lastBackup.Name, " @ ", lastBackup.Status.StoppedAt.Format(time.RFC3339))
+		status.AddLine("Last Backup Estimated Size:", getPrintableBackupSize(lastBackup.Status.BackupSize))
+		status.AddLine("Last Backup Duration:", getPrintableBackupDuration(lastBackup.Status.Duration))
+	}
 
 	primaryInstanceStatus := fullStatus.tryGetPrimaryInstance()
 	if primaryInstanceStatus == nil {
@@ -503,6 +619,8 @@ func (fullStatus *PostgresqlStatus) printBackupStatus() {
 	status.AddLine("Working WAL archiving:",
 		getWalArchivingStatus(primaryInstanceStatus.IsArchivingWAL, primaryInstanceStatus.LastFailedWAL))
 	status.AddLine("WALs waiting to be archived:", primaryInstanceStatus.ReadyWALFiles)
+	status.AddLine("Archived WALs:", primaryInstanceStatus.ArchivedCount)
+	status.AddLine("Failed WAL archiving attempts:", getPrintableFailedCount(primaryInstanceStatus.FailedCount))
 
 	if primaryInstanceStatus.LastArchivedWAL == "" {
 		status.AddLine("Last Archived WAL:", "-")
@@ -521,6 +639,36 @@ func (fullStatus *PostgresqlStatus) printBackupStatus() {
 	fmt.Println()
 }
 
+func getPrintableFailedCount(failedCount int64) string {
+	if failedCount > 0 {
+		return aurora.Red(failedCount).String()
+	}
+	return fmt.Sprint(failedCount)
+}
+
+func getPrintableRetentionRun(retentionRun *apiv1.RetentionRunStatus) string {
+	if retentionRun.Error != "" {
+		return fmt.Sprintf("%s (policy %s) %s", retentionRun.Time, retentionRun.RetentionPolicy,
+			aurora.Red(fmt.Sprintf("failed: %s", retentionRun.Error)))
+	}
+	return fmt.Sprintf("%s (policy %s, %d backups deleted)",
+		retentionRun.Time, retentionRun.RetentionPolicy, retentionRun.DeletedBackups)
+}
+
+func getPrintableBackupSize(size *resource.Quantity) string {
+	if size == nil {
+		return "-"
+	}
+	return size.String()
+}
+
+func getPrintableBackupDuration(duration *metav1.Duration) string {
+	if duration == nil {
+		return "-"
+	}
+	return duration.Round(time.Second).String()
+}
+
 func getWalArchivingStatus(isArchivingWAL bool, lastFailedWAL string) string {
 	switch {
 	case isArchivingWAL:
@@ -692,6 +840,109 @@ func (fullStatus *PostgresqlStatus) printReplicaStatus(verbosity int) {
 	fmt.Println()
 }
 
+// printDelayedReplicaStatus shows, for a delayed replica cluster, the
+// recovery_min_apply_delay in effect in each instance, as read from
+// PostgreSQL, together with the observed replay lag
+func (fullStatus *PostgresqlStatus) printDelayedReplicaStatus() {
+	cluster := fullStatus.Cluster
+	if !cluster.IsReplica() || cluster.Spec.ReplicaCluster.MinApplyDelay == nil {
+		return
+	}
+
+	fmt.Println(aurora.Green("Delayed replica status"))
+	fmt.Printf("Configured minimum apply delay: %s\n", cluster.Spec.ReplicaCluster.MinApplyDelay.Duration)
+
+	status := tabby.New()
+	status.AddHeader("Name", "Min apply delay", "Replay lag")
+	sort.Sort(fullStatus.InstanceStatus)
+	for _, instance := range fullStatus.InstanceStatus.Items {
+		if instance.Error != nil || instance.IsPrimary {
+			continue
+		}
+		status.AddLine(
+			instance.Pod.Name,
+			getPrintableMinApplyDelay(instance.MinApplyDelay),
+			getPrintableReplayLag(instance.ReplayLag),
+		)
+	}
+	status.Print()
+	fmt.Println()
+}
+
+// getPrintableMinApplyDelay highlights the instances where the
+// minimum apply delay is not in effect
+func getPrintableMinApplyDelay(minApplyDelay string) string {
+	if minApplyDelay == "" || minApplyDelay == "0" {
+		return aurora.Red("not in effect").String()
+	}
+	return aurora.Green(minApplyDelay).String()
+}
+
+// printParameterChangesStatus prints, for every instance, the parameters changed by
+// the latest configuration update, distinguishing the ones requiring a restart,
+// together with the ones still waiting for the instance to be restarted
+func (fullStatus *PostgresqlStatus) printParameterChangesStatus() {
+	hasChanges := false
+	for _, instance := range fullStatus.InstanceStatus.Items {
+		if instance.Error != nil {
+			continue
+		}
+		if len(instance.PendingRestartSettings) > 0 ||
+			(instance.LastParameterChanges != nil && !instance.LastParameterChanges.IsEmpty()) {
+			hasChanges = true
+			break
+		}
+	}
+	if !hasChanges {
+		return
+	}
+
+	fmt.Println(aurora.Green("Configuration changes"))
+	status := tabby.New()
+	status.AddHeader("Name", "Reload only", "Restart required", "Pending restart")
+	sort.Sort(fullStatus.InstanceStatus)
+	for _, instance := range fullStatus.InstanceStatus.Items {
+		if instance.Error != nil {
+			continue
+		}
+		var changes postgres.ParameterChanges
+		if instance.LastParameterChanges != nil {
+			changes = *instance.LastParameterChanges
+		}
+		status.AddLine(
+			instance.Pod.Name,
+			getPrintableParameterList(changes.ReloadOnly),
+			getPrintableParameterList(changes.RestartRequired),
+			getPrintablePendingRestart(instance.PendingRestartSettings),
+		)
+	}
+	status.Print()
+	fmt.Println()
+}
+
+func getPrintableParameterList(parameters []string) string {
+	if len(parameters) == 0 {
+		return "-"
+	}
+	return strings.Join(parameters, ", ")
+}
+
+// getPrintablePendingRestart highlights the parameters which
+// are waiting for the instance to be restarted
+func getPrintablePendingRestart(parameters []string) string {
+	if len(parameters) == 0 {
+		return "-"
+	}
+	return aurora.Red(strings.Join(parameters, ", ")).String()
+}
+
+func getPrintableReplayLag(replayLag string) string {
+	if replayLag == "" {
+		return "-"
+	}
+	return replayLag
+}
+
 func (fullStatus *PostgresqlStatus) printInstancesStatus() {
 	//  Column "Replication role"
 	//  If fenced, print "Fenced"
@@ -1196,20 +1447,52 @@ func (fullStatus *PostgresqlStatus) printPluginStatus(verbosity int) {
 	fmt.Println(aurora.Green(header))
 
 	status := tabby.New()
-	status.AddHeader("Name", "Version", "Status", "Reported Operator Capabilities")
+	status.AddHeader("Name", "Version", "Ready", "Status", "Reported Operator Capabilities")
 
 	for _, plg := range fullStatus.Cluster.Status.PluginStatus {
 		plgStatus := "N/A"
 		if plg.Status != "" {
 			plgStatus = plg.Status
 		}
-		status.AddLine(plg.Name, plg.Version, plgStatus, parseCapabilities(plg.Capabilities))
+		status.AddLine(plg.Name, plg.Version, fullStatus.getPrintablePluginReadiness(plg.Name), plgStatus,
+			parseCapabilities(plg.Capabilities))
 	}
 
 	status.Print()
 	fmt.Println()
 }
 
+// getMaintenanceReason gets the reason of the maintenance in progress on
+// the cluster, together with the time it was recorded
+func getMaintenanceReason(cluster *apiv1.Cluster) string {
+	reason := cluster.Annotations[utils.MaintenanceReasonAnnotationName]
+	if reason == "" {
+		return ""
+	}
+
+	if timestamp := cluster.Annotations[utils.MaintenanceReasonTimestampAnnotationName]; timestamp != "" {
+		return fmt.Sprintf("%s (since %s)", reason, timestamp)
+	}
+	return reason
+}
+
+// getPrintablePluginReadiness gets the readiness of the plugin, as reported
+// by the corresponding condition set by the operator
+func (fullStatus *PostgresqlStatus) getPrintablePluginReadiness(pluginName string) string {
+	condition := meta.FindStatusCondition(
+		fullStatus.Cluster.Status.Conditions,
+		string(apiv1.ConditionPluginReadyPrefix)+pluginName,
+	)
+	switch {
+	case condition == nil:
+		return "N/A"
+	case condition.Status == metav1.ConditionTrue:
+		return aurora.Green("Yes").String()
+	default:
+		return aurora.Red(fmt.Sprintf("No (%s)", condition.Message)).String()
+	}
+}
+
 func getPrimaryStartTime(cluster *apiv1.Cluster) string {
 	return getPrimaryStartTimeIdempotent(cluster, time.Now())
 }
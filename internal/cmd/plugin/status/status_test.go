@@ -17,12 +17,14 @@ limitations under the License.
 package status
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -76,3 +78,129 @@ var _ = Describe("getPrimaryStartTime", func() {
 		})
 	})
 })
+
+var _ = Describe("getLastCompletedBackup", func() {
+	newBackup := func(name, clusterName string, phase apiv1.BackupPhase, stoppedAt time.Time) apiv1.Backup {
+		return apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: apiv1.BackupSpec{
+				Cluster: apiv1.LocalObjectReference{Name: clusterName},
+			},
+			Status: apiv1.BackupStatus{
+				Phase:     phase,
+				StoppedAt: &metav1.Time{Time: stoppedAt},
+			},
+		}
+	}
+
+	It("should return the completed backup of the cluster that terminated last", func() {
+		now := time.Now()
+		backups := []apiv1.Backup{
+			newBackup("old", "cluster-example", apiv1.BackupPhaseCompleted, now.Add(-2*time.Hour)),
+			newBackup("recent", "cluster-example", apiv1.BackupPhaseCompleted, now.Add(-time.Hour)),
+			newBackup("failed", "cluster-example", apiv1.BackupPhaseFailed, now),
+			newBackup("other", "other-cluster", apiv1.BackupPhaseCompleted, now),
+		}
+		lastBackup := getLastCompletedBackup(backups, "cluster-example")
+		Expect(lastBackup).ToNot(BeNil())
+		Expect(lastBackup.Name).To(Equal("recent"))
+	})
+
+	It("should return nil when the cluster has no completed backup", func() {
+		backups := []apiv1.Backup{
+			newBackup("failed", "cluster-example", apiv1.BackupPhaseFailed, time.Now()),
+		}
+		Expect(getLastCompletedBackup(backups, "cluster-example")).To(BeNil())
+	})
+})
+
+var _ = Describe("getPrintablePluginReadiness", func() {
+	It("reports the readiness of the plugins as set by the operator", func() {
+		fullStatus := &PostgresqlStatus{
+			Cluster: &apiv1.Cluster{
+				Status: apiv1.ClusterStatus{
+					Conditions: []metav1.Condition{
+						apiv1.BuildPluginReadyCondition("ready.plugin", nil),
+						apiv1.BuildPluginReadyCondition("not-ready.plugin", errors.New("connection refused")),
+					},
+				},
+			},
+		}
+
+		Expect(fullStatus.getPrintablePluginReadiness("ready.plugin")).To(ContainSubstring("Yes"))
+		Expect(fullStatus.getPrintablePluginReadiness("not-ready.plugin")).To(ContainSubstring("No (connection refused)"))
+		Expect(fullStatus.getPrintablePluginReadiness("unknown.plugin")).To(Equal("N/A"))
+	})
+})
+
+var _ = Describe("getMaintenanceReason", func() {
+	It("reports the maintenance reason with its timestamp", func() {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					utils.MaintenanceReasonAnnotationName:          "Upgrading the storage class",
+					utils.MaintenanceReasonTimestampAnnotationName: "2025-01-02T03:04:05Z",
+				},
+			},
+		}
+		Expect(getMaintenanceReason(cluster)).To(Equal("Upgrading the storage class (since 2025-01-02T03:04:05Z)"))
+
+		delete(cluster.Annotations, utils.MaintenanceReasonTimestampAnnotationName)
+		Expect(getMaintenanceReason(cluster)).To(Equal("Upgrading the storage class"))
+	})
+
+	It("returns an empty string when no reason is recorded", func() {
+		Expect(getMaintenanceReason(&apiv1.Cluster{})).To(BeEmpty())
+	})
+})
+
+var _ = Describe("delayed replica status", func() {
+	It("highlights the instances where the minimum apply delay is not in effect", func() {
+		Expect(getPrintableMinApplyDelay("8h")).To(ContainSubstring("8h"))
+		Expect(getPrintableMinApplyDelay("0")).To(ContainSubstring("not in effect"))
+		Expect(getPrintableMinApplyDelay("")).To(ContainSubstring("not in effect"))
+	})
+
+	It("prints a placeholder when no transaction has been replayed yet", func() {
+		Expect(getPrintableReplayLag("")).To(Equal("-"))
+		Expect(getPrintableReplayLag("07:59:58")).To(Equal("07:59:58"))
+	})
+})
+
+var _ = Describe("parameter changes status", func() {
+	It("prints a placeholder when no parameter changed", func() {
+		Expect(getPrintableParameterList(nil)).To(Equal("-"))
+		Expect(getPrintablePendingRestart(nil)).To(Equal("-"))
+	})
+
+	It("lists the changed parameters", func() {
+		Expect(getPrintableParameterList([]string{"work_mem", "wal_keep_size"})).To(Equal("work_mem, wal_keep_size"))
+		Expect(getPrintablePendingRestart([]string{"max_connections"})).To(ContainSubstring("max_connections"))
+	})
+})
+
+var _ = Describe("getPrintablePendingTime", func() {
+	It("reports since when and for how long the update is pending", func() {
+		since := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+		currentTime := since.Add(26*time.Hour + 1500*time.Millisecond)
+		Expect(getPrintablePendingTime(since, currentTime)).To(Equal("2026-10-01T10:00:00Z (26h0m2s)"))
+	})
+})
+
+var _ = Describe("getPrintableRetentionRun", func() {
+	It("reports the number of deleted backups", func() {
+		Expect(getPrintableRetentionRun(&apiv1.RetentionRunStatus{
+			Time:            "2026-10-01T10:00:00Z",
+			RetentionPolicy: "30d",
+			DeletedBackups:  3,
+		})).To(Equal("2026-10-01T10:00:00Z (policy 30d, 3 backups deleted)"))
+	})
+
+	It("reports the error of a failed run", func() {
+		Expect(getPrintableRetentionRun(&apiv1.RetentionRunStatus{
+			Time:            "2026-10-01T10:00:00Z",
+			RetentionPolicy: "30d",
+			Error:           "exit status 1",
+		})).To(ContainSubstring("failed: exit status 1"))
+	})
+})
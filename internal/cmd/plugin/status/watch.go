@@ -0,0 +1,151 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+const (
+	// ansiCursorUp moves the cursor up by the given number of lines
+	ansiCursorUp = "\x1b[%dA"
+
+	// ansiCursorDown moves the cursor down by one line
+	ansiCursorDown = "\x1b[1B"
+
+	// ansiClearLine clears the line under the cursor
+	ansiClearLine = "\x1b[2K"
+
+	// ansiClearToEnd clears the screen from the cursor to the end
+	ansiClearToEnd = "\x1b[J"
+)
+
+// Watch implements the "status --watch" subcommand, periodically
+// rendering the status of the cluster and redrawing in place only the
+// lines that changed since the previous iteration
+func Watch(
+	ctx context.Context,
+	clusterName string,
+	verbosity int,
+	interval time.Duration,
+) error {
+	// Create a Kubernetes client suitable for calling the "Exec" subresource
+	clientInterface := kubernetes.NewForConfigOrDie(plugin.Config)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var previousLines []string
+	for {
+		var cluster apiv1.Cluster
+		err := plugin.Client.Get(ctx, client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName}, &cluster)
+		if err != nil {
+			return fmt.Errorf("while trying to get cluster %s in namespace %s: %w",
+				clusterName, plugin.Namespace, err)
+		}
+
+		status := extractPostgresqlStatus(ctx, cluster)
+		output, err := captureStdout(func() {
+			status.printText(ctx, clientInterface, verbosity)
+		})
+		if err != nil {
+			return err
+		}
+
+		currentLines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+		fmt.Print(renderDelta(previousLines, currentLines))
+		previousLines = currentLines
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// captureStdout returns what the passed function writes on the standard
+// output. The status rendering functions write directly on it, and this
+// allows them to be reused by the watch mode
+func captureStdout(render func()) (string, error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	outputChan := make(chan string)
+	go func() {
+		var buffer bytes.Buffer
+		_, _ = io.Copy(&buffer, reader)
+		outputChan <- buffer.String()
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = writer
+	render()
+	os.Stdout = stdout
+
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return <-outputChan, nil
+}
+
+// renderDelta returns the terminal output needed to transform the
+// previously rendered lines into the current ones, redrawing only the
+// lines that changed. The cursor is expected to be positioned right
+// after the previous rendering
+func renderDelta(previousLines, currentLines []string) string {
+	var output strings.Builder
+
+	if len(previousLines) > 0 {
+		output.WriteString("\r")
+		output.WriteString(fmt.Sprintf(ansiCursorUp, len(previousLines)))
+	}
+
+	for idx, line := range currentLines {
+		if idx < len(previousLines) && previousLines[idx] == line {
+			output.WriteString(ansiCursorDown)
+			continue
+		}
+
+		output.WriteString(ansiClearLine)
+		output.WriteString(line)
+		output.WriteString("\n")
+	}
+
+	if len(previousLines) > len(currentLines) {
+		output.WriteString(ansiClearToEnd)
+	}
+
+	return output.String()
+}
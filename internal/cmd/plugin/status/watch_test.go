@@ -0,0 +1,64 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("renderDelta", func() {
+	It("should print every line on the first rendering", func() {
+		Expect(renderDelta(nil, []string{"a", "b"})).To(Equal(
+			ansiClearLine + "a\n" + ansiClearLine + "b\n"))
+	})
+
+	It("should redraw only the changed lines", func() {
+		Expect(renderDelta([]string{"a", "b", "c"}, []string{"a", "x", "c"})).To(Equal(
+			"\r" + fmt.Sprintf(ansiCursorUp, 3) +
+				ansiCursorDown +
+				ansiClearLine + "x\n" +
+				ansiCursorDown))
+	})
+
+	It("should append the new lines", func() {
+		Expect(renderDelta([]string{"a"}, []string{"a", "b"})).To(Equal(
+			"\r" + fmt.Sprintf(ansiCursorUp, 1) +
+				ansiCursorDown +
+				ansiClearLine + "b\n"))
+	})
+
+	It("should clear the lines that are not there anymore", func() {
+		Expect(renderDelta([]string{"a", "b"}, []string{"a"})).To(Equal(
+			"\r" + fmt.Sprintf(ansiCursorUp, 2) +
+				ansiCursorDown +
+				ansiClearToEnd))
+	})
+})
+
+var _ = Describe("captureStdout", func() {
+	It("should return what has been written on the standard output", func() {
+		output, err := captureStdout(func() {
+			fmt.Println("hello")
+			fmt.Println("world")
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(output).To(Equal("hello\nworld\n"))
+	})
+})
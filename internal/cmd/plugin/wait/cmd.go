@@ -0,0 +1,75 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd initializes the wait command
+func NewCmd() *cobra.Command {
+	var (
+		forCondition    string
+		timeout         time.Duration
+		pollingInterval time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "wait CLUSTER --for condition=TYPE[=STATUS]",
+		Short: "Waits for a condition of the cluster to be met",
+		Long: "Waits for the condition of the cluster named CLUSTER to reach the requested " +
+			"status (True, if not specified), failing if this doesn't happen before the timeout.",
+		Example: "kubectl cnpg wait cluster-example --for condition=ContinuousArchiving=True --timeout 5m",
+		GroupID: plugin.GroupIDCluster,
+		Args:    plugin.RequiresArguments(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if pollingInterval <= 0 {
+				return fmt.Errorf("the polling interval must be positive")
+			}
+
+			condition, err := parseConditionSelector(forCondition)
+			if err != nil {
+				return err
+			}
+
+			return waitForCondition(cmd.Context(), plugin.Client, plugin.Namespace, waitOptions{
+				clusterName:     args[0],
+				condition:       condition,
+				timeout:         timeout,
+				pollingInterval: pollingInterval,
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&forCondition, "for", "",
+		"The condition to wait for, in the form condition=TYPE[=STATUS], e.g. condition=Ready")
+	_ = cmd.MarkFlagRequired("for")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute,
+		"The maximum time to wait for the condition to be met")
+	cmd.Flags().DurationVar(&pollingInterval, "polling-interval", 2*time.Second,
+		"How often the cluster status is checked")
+
+	return cmd
+}
@@ -0,0 +1,136 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// conditionSelector is the condition we are waiting for
+type conditionSelector struct {
+	conditionType string
+	status        metav1.ConditionStatus
+}
+
+func (s conditionSelector) String() string {
+	return fmt.Sprintf("%s=%s", s.conditionType, s.status)
+}
+
+// waitOptions are the options of the wait command
+type waitOptions struct {
+	clusterName     string
+	condition       conditionSelector
+	timeout         time.Duration
+	pollingInterval time.Duration
+}
+
+// parseConditionSelector parses the value of the --for flag, which has the
+// same syntax of the one of kubectl wait: condition=TYPE[=STATUS]
+func parseConditionSelector(value string) (conditionSelector, error) {
+	const conditionPrefix = "condition="
+
+	if !strings.HasPrefix(value, conditionPrefix) {
+		return conditionSelector{}, fmt.Errorf(
+			"invalid --for value %q: expected condition=TYPE[=STATUS]", value)
+	}
+
+	conditionType, rawStatus, hasStatus := strings.Cut(strings.TrimPrefix(value, conditionPrefix), "=")
+	if conditionType == "" {
+		return conditionSelector{}, fmt.Errorf("invalid --for value %q: missing condition type", value)
+	}
+
+	status := metav1.ConditionTrue
+	if hasStatus {
+		switch {
+		case strings.EqualFold(rawStatus, string(metav1.ConditionTrue)):
+			status = metav1.ConditionTrue
+		case strings.EqualFold(rawStatus, string(metav1.ConditionFalse)):
+			status = metav1.ConditionFalse
+		case strings.EqualFold(rawStatus, string(metav1.ConditionUnknown)):
+			status = metav1.ConditionUnknown
+		default:
+			return conditionSelector{}, fmt.Errorf(
+				"invalid --for value %q: status must be one of True, False or Unknown", value)
+		}
+	}
+
+	return conditionSelector{conditionType: conditionType, status: status}, nil
+}
+
+// waitForCondition polls the cluster until the requested condition is met
+// or the timeout expires
+func waitForCondition(ctx context.Context, cli client.Client, namespace string, options waitOptions) error {
+	timeout := time.NewTimer(options.timeout)
+	defer timeout.Stop()
+
+	ticker := time.NewTicker(options.pollingInterval)
+	defer ticker.Stop()
+
+	var condition *metav1.Condition
+	for {
+		var cluster apiv1.Cluster
+		if err := cli.Get(
+			ctx,
+			client.ObjectKey{Namespace: namespace, Name: options.clusterName},
+			&cluster,
+		); err != nil {
+			return fmt.Errorf("while getting cluster %s: %w", options.clusterName, err)
+		}
+
+		condition = meta.FindStatusCondition(cluster.Status.Conditions, options.condition.conditionType)
+		if condition != nil && condition.Status == options.condition.status {
+			fmt.Printf("cluster/%s condition met: %s\n", cluster.Name, options.condition)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return fmt.Errorf("timeout waiting for cluster/%s condition %s: %s",
+				options.clusterName, options.condition, describeCondition(condition))
+		case <-ticker.C:
+		}
+	}
+}
+
+// describeCondition returns a human-readable description of the last
+// observed state of a condition
+func describeCondition(condition *metav1.Condition) string {
+	if condition == nil {
+		return "the condition has not been set"
+	}
+
+	description := fmt.Sprintf("the condition status is %s", condition.Status)
+	if condition.Reason != "" {
+		description += fmt.Sprintf(" (reason: %s)", condition.Reason)
+	}
+	if condition.Message != "" {
+		description += fmt.Sprintf(": %s", condition.Message)
+	}
+
+	return description
+}
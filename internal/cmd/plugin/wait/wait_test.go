@@ -0,0 +1,134 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("parseConditionSelector", func() {
+	It("defaults the status to True", func() {
+		selector, err := parseConditionSelector("condition=Ready")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(selector).To(Equal(conditionSelector{conditionType: "Ready", status: metav1.ConditionTrue}))
+	})
+
+	It("parses the requested status case insensitively", func() {
+		selector, err := parseConditionSelector("condition=ContinuousArchiving=false")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(selector).To(Equal(conditionSelector{
+			conditionType: "ContinuousArchiving",
+			status:        metav1.ConditionFalse,
+		}))
+	})
+
+	DescribeTable("rejects invalid values",
+		func(value string) {
+			_, err := parseConditionSelector(value)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("without the condition prefix", "Ready"),
+		Entry("without a condition type", "condition="),
+		Entry("with an invalid status", "condition=Ready=Maybe"),
+	)
+})
+
+var _ = Describe("waitForCondition", func() {
+	newCluster := func(conditions ...metav1.Condition) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+			Status: apiv1.ClusterStatus{
+				Conditions: conditions,
+			},
+		}
+	}
+
+	options := waitOptions{
+		clusterName:     "cluster-example",
+		condition:       conditionSelector{conditionType: "Ready", status: metav1.ConditionTrue},
+		timeout:         50 * time.Millisecond,
+		pollingInterval: 10 * time.Millisecond,
+	}
+
+	It("returns as soon as the condition is met", func(ctx SpecContext) {
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(newCluster(metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue})).
+			Build()
+
+		Expect(waitForCondition(ctx, cli, "default", options)).To(Succeed())
+	})
+
+	It("times out reporting the last observed status", func(ctx SpecContext) {
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(newCluster(metav1.Condition{
+				Type:    "Ready",
+				Status:  metav1.ConditionFalse,
+				Reason:  "ClusterIsNotReady",
+				Message: "Cluster Is Not Ready",
+			})).
+			Build()
+
+		err := waitForCondition(ctx, cli, "default", options)
+		Expect(err).To(MatchError(ContainSubstring("reason: ClusterIsNotReady")))
+	})
+
+	It("times out when the condition is not set", func(ctx SpecContext) {
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(newCluster()).
+			Build()
+
+		err := waitForCondition(ctx, cli, "default", options)
+		Expect(err).To(MatchError(ContainSubstring("has not been set")))
+	})
+
+	It("fails when the cluster doesn't exist", func(ctx SpecContext) {
+		cli := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			Build()
+
+		Expect(waitForCondition(ctx, cli, "default", options)).ToNot(Succeed())
+	})
+})
+
+var _ = Describe("NewCmd", func() {
+	DescribeTable("rejects non-positive polling intervals",
+		func(pollingInterval string) {
+			cmd := NewCmd()
+			cmd.SetArgs([]string{"cluster-example", "--for", "condition=Ready", "--polling-interval", pollingInterval})
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			Expect(cmd.Execute()).To(MatchError(ContainSubstring("polling interval must be positive")))
+		},
+		Entry("zero", "0s"),
+		Entry("negative", "-1s"),
+	)
+})
@@ -0,0 +1,57 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package walstatus
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+)
+
+// NewCmd creates the wal-status command
+func NewCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "wal-status CLUSTER",
+		Short: "Shows the WAL position and the timeline of each instance",
+		Long: "Shows, for each instance of the cluster, the timeline and the current, last received " +
+			"and last replayed LSN, together with the replay lag of the standbys in respect to the primary.",
+		Example: "kubectl cnpg wal-status cluster-example -o json",
+		Args:    plugin.RequiresArguments(1),
+		GroupID: plugin.GroupIDTroubleshooting,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.CompleteClusters(cmd.Context(), args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat := plugin.OutputFormat(output)
+			switch outputFormat {
+			case plugin.OutputFormatText, plugin.OutputFormatJSON, plugin.OutputFormatYAML:
+			default:
+				return fmt.Errorf("output: %s is not supported by the wal-status command", output)
+			}
+
+			return WALStatus(cmd.Context(), args[0], outputFormat)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format. One of text, json, or yaml")
+
+	return cmd
+}
@@ -0,0 +1,216 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package walstatus
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/cheynewallace/tabby"
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	"github.com/logrusorgru/aurora/v4"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cmd/plugin"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/plugin/resources"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+// clusterWALStatus is the WAL position of every instance of a cluster
+type clusterWALStatus struct {
+	Cluster   string              `json:"cluster"`
+	Instances []instanceWALStatus `json:"instances"`
+}
+
+// instanceWALStatus is the WAL position of a single instance
+type instanceWALStatus struct {
+	Name        string    `json:"name"`
+	Role        string    `json:"role"`
+	TimelineID  int       `json:"timelineID,omitempty"`
+	CurrentLSN  types.LSN `json:"currentLSN,omitempty"`
+	ReceivedLSN types.LSN `json:"receivedLSN,omitempty"`
+	ReplayLSN   types.LSN `json:"replayLSN,omitempty"`
+
+	// ReplayLagBytes is the distance between the current LSN of the
+	// primary and the last LSN replayed by a standby
+	ReplayLagBytes *int64 `json:"replayLagBytes,omitempty"`
+
+	// DivergentTimeline is true when a standby is not on the
+	// same timeline as the primary
+	DivergentTimeline bool `json:"divergentTimeline,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// WALStatus shows the WAL position and the timeline of each instance
+// of the cluster
+func WALStatus(ctx context.Context, clusterName string, format plugin.OutputFormat) error {
+	var cluster apiv1.Cluster
+	if err := plugin.Client.Get(
+		ctx,
+		client.ObjectKey{Namespace: plugin.Namespace, Name: clusterName},
+		&cluster,
+	); err != nil {
+		return fmt.Errorf("while getting cluster %s: %w", clusterName, err)
+	}
+
+	managedPods, _, err := resources.GetInstancePods(ctx, cluster.Name)
+	if err != nil {
+		return fmt.Errorf("while getting the instances of cluster %s: %w", clusterName, err)
+	}
+
+	// The errors are reported instance by instance
+	instancesStatus, _ := resources.ExtractInstancesStatus(ctx, plugin.Config, managedPods)
+	status := buildClusterWALStatus(cluster.Name, instancesStatus)
+
+	if format != plugin.OutputFormatText {
+		return plugin.Print(status, format, os.Stdout)
+	}
+
+	printClusterWALStatus(status)
+	return nil
+}
+
+// buildClusterWALStatus extracts the WAL position of every instance
+// from their status, comparing the standbys with the primary
+func buildClusterWALStatus(clusterName string, instancesStatus postgres.PostgresqlStatusList) clusterWALStatus {
+	sort.Sort(&instancesStatus)
+
+	var primary *postgres.PostgresqlStatus
+	for idx := range instancesStatus.Items {
+		if instancesStatus.Items[idx].Error == nil && instancesStatus.Items[idx].IsPrimary {
+			primary = &instancesStatus.Items[idx]
+			break
+		}
+	}
+
+	result := clusterWALStatus{
+		Cluster:   clusterName,
+		Instances: make([]instanceWALStatus, 0, len(instancesStatus.Items)),
+	}
+	for _, instance := range instancesStatus.Items {
+		instanceStatus := instanceWALStatus{
+			Name: instance.Pod.Name,
+		}
+
+		switch {
+		case instance.Error != nil:
+			instanceStatus.Role = "Unknown"
+			instanceStatus.Error = instance.Error.Error()
+			result.Instances = append(result.Instances, instanceStatus)
+			continue
+		case instance.IsPrimary:
+			instanceStatus.Role = "Primary"
+		default:
+			instanceStatus.Role = "Standby"
+		}
+
+		instanceStatus.TimelineID = instance.TimeLineID
+		instanceStatus.CurrentLSN = instance.CurrentLsn
+		instanceStatus.ReceivedLSN = instance.ReceivedLsn
+		instanceStatus.ReplayLSN = instance.ReplayLsn
+
+		// The checkpoint timeline of a standby only changes with the next
+		// restartpoint, so we rely on the timeline of the received WAL,
+		// which is known only when the standby is streaming
+		if !instance.IsPrimary && instance.ReceivedTimeLineID != 0 {
+			instanceStatus.TimelineID = instance.ReceivedTimeLineID
+		}
+
+		if primary != nil && !instance.IsPrimary {
+			instanceStatus.ReplayLagBytes = getReplayLagBytes(primary.CurrentLsn, instance.ReplayLsn)
+			instanceStatus.DivergentTimeline = instance.ReceivedTimeLineID != 0 &&
+				instance.ReceivedTimeLineID != primary.TimeLineID
+		}
+
+		result.Instances = append(result.Instances, instanceStatus)
+	}
+
+	return result
+}
+
+// getReplayLagBytes computes the distance, in bytes, between the current
+// LSN of the primary and the LSN replayed by a standby. It returns nil
+// when any of the two positions is not known
+func getReplayLagBytes(primaryLSN, replayLSN types.LSN) *int64 {
+	if primaryLSN == "" || replayLSN == "" {
+		return nil
+	}
+
+	primaryPosition, err := primaryLSN.Parse()
+	if err != nil {
+		return nil
+	}
+	replayPosition, err := replayLSN.Parse()
+	if err != nil {
+		return nil
+	}
+
+	// The standby could have replayed WAL records generated after the
+	// primary status was collected
+	lag := max(primaryPosition-replayPosition, 0)
+	return &lag
+}
+
+func printClusterWALStatus(status clusterWALStatus) {
+	table := tabby.New()
+	table.AddHeader("Name", "Role", "Timeline", "Current LSN", "Received LSN", "Replay LSN", "Replay Lag")
+
+	for _, instance := range status.Instances {
+		if instance.Error != "" {
+			table.AddLine(instance.Name, instance.Role, "-", "-", "-", "-", aurora.Red(instance.Error))
+			continue
+		}
+
+		timeline := "-"
+		if instance.TimelineID != 0 {
+			timeline = strconv.Itoa(instance.TimelineID)
+		}
+		if instance.DivergentTimeline {
+			timeline = aurora.Red(timeline + " (diverged)").String()
+		}
+
+		replayLag := "-"
+		if instance.ReplayLagBytes != nil {
+			replayLag = resource.NewQuantity(*instance.ReplayLagBytes, resource.BinarySI).String()
+		}
+
+		table.AddLine(
+			instance.Name,
+			instance.Role,
+			timeline,
+			getPrintableLSN(instance.CurrentLSN),
+			getPrintableLSN(instance.ReceivedLSN),
+			getPrintableLSN(instance.ReplayLSN),
+			replayLag,
+		)
+	}
+
+	table.Print()
+}
+
+func getPrintableLSN(lsn types.LSN) string {
+	if lsn == "" {
+		return "-"
+	}
+	return string(lsn)
+}
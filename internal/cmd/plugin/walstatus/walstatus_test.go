@@ -0,0 +1,127 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package walstatus
+
+import (
+	"errors"
+
+	"github.com/cloudnative-pg/machinery/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("buildClusterWALStatus", func() {
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	It("compares the WAL position of the standbys with the primary", func() {
+		status := buildClusterWALStatus("cluster-example", postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{
+					Pod:                newPod("cluster-example-2"),
+					TimeLineID:         1,
+					ReceivedTimeLineID: 2,
+					ReceivedLsn:        types.LSN("0/3000100"),
+					ReplayLsn:          types.LSN("0/3000000"),
+				},
+				{
+					Pod:        newPod("cluster-example-1"),
+					IsPrimary:  true,
+					TimeLineID: 2,
+					CurrentLsn: types.LSN("0/3000400"),
+				},
+				{
+					Pod:                newPod("cluster-example-3"),
+					TimeLineID:         1,
+					ReceivedTimeLineID: 1,
+					ReceivedLsn:        types.LSN("0/2000000"),
+					ReplayLsn:          types.LSN("0/2000000"),
+				},
+				{
+					Pod:        newPod("cluster-example-4"),
+					TimeLineID: 1,
+					ReplayLsn:  types.LSN("0/3000000"),
+				},
+			},
+		})
+
+		Expect(status.Cluster).To(Equal("cluster-example"))
+		Expect(status.Instances).To(HaveLen(4))
+
+		primary := status.Instances[0]
+		Expect(primary.Name).To(Equal("cluster-example-1"))
+		Expect(primary.Role).To(Equal("Primary"))
+		Expect(primary.ReplayLagBytes).To(BeNil())
+
+		Expect(status.Instances[1].Name).To(Equal("cluster-example-2"))
+		Expect(status.Instances[1].Role).To(Equal("Standby"))
+		Expect(status.Instances[1].TimelineID).To(Equal(2))
+		Expect(status.Instances[1].ReplayLagBytes).To(HaveValue(BeEquivalentTo(0x400)))
+		Expect(status.Instances[1].DivergentTimeline).To(BeFalse())
+
+		Expect(status.Instances[2].Name).To(Equal("cluster-example-3"))
+		Expect(status.Instances[2].ReplayLagBytes).To(HaveValue(BeEquivalentTo(0x1000400)))
+		Expect(status.Instances[2].DivergentTimeline).To(BeTrue())
+
+		// The timeline of a standby which is not streaming is unknown
+		Expect(status.Instances[3].Name).To(Equal("cluster-example-4"))
+		Expect(status.Instances[3].DivergentTimeline).To(BeFalse())
+	})
+
+	It("reports the instances whose status cannot be extracted", func() {
+		status := buildClusterWALStatus("cluster-example", postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{
+					Pod:   newPod("cluster-example-1"),
+					Error: errors.New("connection refused"),
+				},
+				{
+					Pod:       newPod("cluster-example-2"),
+					ReplayLsn: types.LSN("0/3000000"),
+				},
+			},
+		})
+
+		Expect(status.Instances).To(HaveLen(2))
+		for _, instance := range status.Instances {
+			if instance.Name == "cluster-example-1" {
+				Expect(instance.Error).To(Equal("connection refused"))
+				continue
+			}
+			// Without a primary there's nothing to compare with
+			Expect(instance.ReplayLagBytes).To(BeNil())
+		}
+	})
+})
+
+var _ = Describe("getReplayLagBytes", func() {
+	It("doesn't compute the lag when a position is unknown", func() {
+		Expect(getReplayLagBytes("", "0/3000000")).To(BeNil())
+		Expect(getReplayLagBytes("0/3000000", "")).To(BeNil())
+		Expect(getReplayLagBytes("invalid", "0/3000000")).To(BeNil())
+	})
+
+	It("never reports a negative lag", func() {
+		Expect(getReplayLagBytes("0/3000000", "0/3000100")).To(HaveValue(BeEquivalentTo(0)))
+	})
+})
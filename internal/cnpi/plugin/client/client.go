@@ -52,6 +52,15 @@ func (data *data) MetadataList() []connection.Metadata {
 	return result
 }
 
+func (data *data) ProbePlugins(ctx context.Context) map[string]error {
+	result := make(map[string]error, len(data.plugins))
+	for i := range data.plugins {
+		result[data.plugins[i].Name()] = data.plugins[i].Probe(ctx)
+	}
+
+	return result
+}
+
 func (data *data) Close(ctx context.Context) {
 	contextLogger := log.FromContext(ctx)
 	for i := range data.plugins {
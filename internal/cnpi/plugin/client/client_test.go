@@ -0,0 +1,45 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/cloudnative-pg/cloudnative-pg/internal/cnpi/plugin/connection"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProbePlugins", func() {
+	It("reports the readiness of every loaded plugin", func(ctx SpecContext) {
+		d := data{
+			plugins: []connection.Interface{
+				&fakeConnection{name: "ready-plugin"},
+				&fakeConnection{name: "not-ready-plugin", probeError: connection.ErrPluginNotReady},
+			},
+		}
+
+		results := d.ProbePlugins(ctx)
+		Expect(results).To(HaveLen(2))
+		Expect(results).To(HaveKeyWithValue("ready-plugin", BeNil()))
+		Expect(results["not-ready-plugin"]).To(MatchError(connection.ErrPluginNotReady))
+	})
+
+	It("returns an empty map when no plugin is loaded", func(ctx SpecContext) {
+		d := data{}
+		Expect(d.ProbePlugins(ctx)).To(BeEmpty())
+	})
+})
@@ -47,6 +47,11 @@ type Connection interface {
 
 	// MetadataList exposes the metadata of the loaded plugins
 	MetadataList() []connection.Metadata
+
+	// ProbePlugins calls the Probe endpoint of every loaded plugin,
+	// returning a map of [pluginName]: error, where a nil error means
+	// that the plugin is ready
+	ProbePlugins(ctx context.Context) map[string]error
 }
 
 // ClusterCapabilities describes a set of behaviour needed to implement the Cluster capabilities
@@ -102,6 +102,7 @@ type fakeConnection struct {
 	lifecycleCapabilities []*lifecycle.OperatorLifecycleCapabilities
 	name                  string
 	operatorClient        *fakeOperatorClient
+	probeError            error
 }
 
 func (f *fakeConnection) RestoreJobHooksClient() restore.RestoreJobHooksClient {
@@ -182,6 +183,10 @@ func (f *fakeConnection) Ping(_ context.Context) error {
 	panic("not implemented") // TODO: Implement
 }
 
+func (f *fakeConnection) Probe(_ context.Context) error {
+	return f.probeError
+}
+
 func (f *fakeConnection) Close() error {
 	panic("not implemented") // TODO: Implement
 }
@@ -18,6 +18,7 @@ package connection
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"slices"
@@ -36,6 +37,10 @@ import (
 // defaultTimeout is the timeout applied by default to every GRPC call
 const defaultTimeout = 30 * time.Second
 
+// ErrPluginNotReady is returned when the plugin reports, through its
+// Probe endpoint, that it is not ready
+var ErrPluginNotReady = errors.New("plugin is not ready")
+
 // Protocol represents a way to connect to a plugin
 type Protocol interface {
 	Dial(ctx context.Context) (Handler, error)
@@ -69,6 +74,7 @@ type Interface interface {
 	RestoreJobHooksCapabilities() []restore.RestoreJobHooksCapability_Kind
 
 	Ping(ctx context.Context) error
+	Probe(ctx context.Context) error
 	Close() error
 }
 
@@ -362,6 +368,21 @@ func (pluginData *data) Ping(ctx context.Context) error {
 	return err
 }
 
+// Probe calls the Probe endpoint of the plugin, returning an error when
+// the plugin cannot be reached or reports not being ready
+func (pluginData *data) Probe(ctx context.Context) error {
+	response, err := pluginData.identityClient.Probe(ctx, &identity.ProbeRequest{})
+	if err != nil {
+		return err
+	}
+
+	if !response.GetReady() {
+		return ErrPluginNotReady
+	}
+
+	return nil
+}
+
 // LoadPlugin loads the plugin connected over a certain collections,
 // queries the metadata and prepares an active plugin connection interface
 func LoadPlugin(ctx context.Context, handler Handler) (Interface, error) {
@@ -62,6 +62,10 @@ const backupPhase = ".status.phase"
 // where the name of the cluster is written
 const clusterName = ".spec.cluster.name"
 
+// errBackupInstanceNotFound is raised when the instance requested
+// for a backup is not part of the cluster
+var errBackupInstanceNotFound = errors.New("the requested instance is not part of the cluster")
+
 // BackupReconciler reconciles a Backup object
 type BackupReconciler struct {
 	client.Client
@@ -312,10 +316,12 @@ func (r *BackupReconciler) isValidBackupRunning(
 	}
 
 	var isCorrectPodElected bool
-	switch backup.Spec.Target {
-	case apiv1.BackupTargetPrimary:
+	switch {
+	case backup.Spec.Instance != "":
+		isCorrectPodElected = backup.Status.InstanceID.PodName == backup.Spec.Instance
+	case backup.Spec.Target == apiv1.BackupTargetPrimary:
 		isCorrectPodElected = backup.Status.InstanceID.PodName == cluster.Status.TargetPrimary
-	case apiv1.BackupTargetStandby, "":
+	case backup.Spec.Target == apiv1.BackupTargetStandby, backup.Spec.Target == "":
 		// we don't really care for this type
 		isCorrectPodElected = true
 	default:
@@ -569,6 +575,18 @@ func (r *BackupReconciler) getBackupTargetPod(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+
+	if backup.Spec.Instance != "" {
+		for idx := range pods.Items {
+			if pods.Items[idx].Name == backup.Spec.Instance {
+				contextLogger.Debug("Requested instance is elected as backup target",
+					"instance", pods.Items[idx].Name)
+				return &pods.Items[idx], nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", errBackupInstanceNotFound, backup.Spec.Instance)
+	}
+
 	var backupTarget apiv1.BackupTarget
 	if cluster.Spec.Backup != nil {
 		backupTarget = cluster.Spec.Backup.Target
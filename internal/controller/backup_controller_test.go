@@ -150,6 +150,20 @@ var _ = Describe("backup_controller barmanObjectStore unit tests", func() {
 			Expect(res).To(BeFalse())
 		})
 
+		It("returning true when a backup is running on the requested instance", func(ctx context.Context) {
+			backup.Spec.Instance = clusterPrimary
+			res, err := env.backupReconciler.isValidBackupRunning(ctx, backup, cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(BeTrue())
+		})
+
+		It("returning false when a backup is running on another instance", func(ctx context.Context) {
+			backup.Spec.Instance = "cluster-example-2"
+			res, err := env.backupReconciler.isValidBackupRunning(ctx, backup, cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(BeFalse())
+		})
+
 		It("returning an error when the backup target is wrong", func(ctx context.Context) {
 			backup.Spec.Target = "fakeTarget"
 			res, err := env.backupReconciler.isValidBackupRunning(ctx, backup, cluster)
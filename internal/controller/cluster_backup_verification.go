@@ -0,0 +1,240 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/robfig/cron"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+)
+
+// backupVerificationCheckInterval is the interval between two checks
+// of a running backup verification job
+const backupVerificationCheckInterval = 30 * time.Second
+
+// reconcileBackupVerification starts the verification of the latest base
+// backup of the cluster when requested through the corresponding annotation
+// or by the verification schedule, and reports the outcome of the
+// verification job once it is completed
+func (r *ClusterReconciler) reconcileBackupVerification(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (ctrl.Result, error) {
+	var job batchv1.Job
+	err := r.Get(
+		ctx,
+		client.ObjectKey{Namespace: cluster.Namespace, Name: specs.GetBackupVerificationJobName(cluster.Name)},
+		&job,
+	)
+	if err == nil {
+		return r.reportBackupVerification(ctx, cluster, &job)
+	}
+	if !apierrs.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+
+	_, requested := cluster.Annotations[utils.VerifyBackupAnnotationName]
+	nextVerification, err := getNextBackupVerificationTime(cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !requested {
+		if nextVerification.IsZero() {
+			return ctrl.Result{}, nil
+		}
+		if now := time.Now(); now.Before(nextVerification) {
+			return ctrl.Result{RequeueAfter: nextVerification.Sub(now)}, nil
+		}
+	}
+
+	return ctrl.Result{}, r.startBackupVerification(ctx, cluster, requested)
+}
+
+// getNextBackupVerificationTime gets the time when the next scheduled
+// verification should start, or the zero time when no schedule is defined
+func getNextBackupVerificationTime(cluster *apiv1.Cluster) (time.Time, error) {
+	if cluster.Spec.Backup == nil || cluster.Spec.Backup.Verification == nil {
+		return time.Time{}, nil
+	}
+
+	schedule, err := cron.Parse(cluster.Spec.Backup.Verification.Schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("while parsing the backup verification schedule: %w", err)
+	}
+
+	lastVerification := cluster.CreationTimestamp.Time
+	if cluster.Status.LastBackupVerification != nil && cluster.Status.LastBackupVerification.StartedAt != nil {
+		lastVerification = cluster.Status.LastBackupVerification.StartedAt.Time
+	}
+
+	return schedule.Next(lastVerification), nil
+}
+
+// startBackupVerification creates the job verifying the latest completed
+// base backup of the cluster
+func (r *ClusterReconciler) startBackupVerification(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	requested bool,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	backup, err := r.getLatestVerifiableBackup(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	if backup == nil {
+		contextLogger.Info("No completed base backup to be verified")
+	} else {
+		job := specs.CreateBackupVerificationJob(*cluster, backup)
+		if err := r.Create(ctx, job); err != nil && !apierrs.IsAlreadyExists(err) {
+			return fmt.Errorf("while creating the backup verification job: %w", err)
+		}
+
+		contextLogger.Info("Verifying the latest base backup", "backupName", backup.Name, "jobName", job.Name)
+		r.Recorder.Eventf(cluster, "Normal", "VerifyingBackup",
+			"Verifying backup %s in job %s", backup.Name, job.Name)
+
+		if err := status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+			cluster.Status.LastBackupVerification = &apiv1.BackupVerificationStatus{
+				BackupName: backup.Name,
+				JobName:    job.Name,
+				StartedAt:  ptr.To(metav1.Now()),
+			}
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !requested {
+		return nil
+	}
+
+	origCluster := cluster.DeepCopy()
+	delete(cluster.Annotations, utils.VerifyBackupAnnotationName)
+	return r.Patch(ctx, cluster, client.MergeFrom(origCluster))
+}
+
+// getLatestVerifiableBackup gets the most recent completed base backup
+// of the cluster stored in the object store, if any
+func (r *ClusterReconciler) getLatestVerifiableBackup(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (*apiv1.Backup, error) {
+	var backups apiv1.BackupList
+	if err := r.List(ctx, &backups, client.InNamespace(cluster.Namespace)); err != nil {
+		return nil, fmt.Errorf("while listing backups: %w", err)
+	}
+
+	var latest *apiv1.Backup
+	for idx := range backups.Items {
+		backup := &backups.Items[idx]
+		if backup.Spec.Cluster.Name != cluster.Name ||
+			backup.Status.Phase != apiv1.BackupPhaseCompleted ||
+			backup.Status.Method != apiv1.BackupMethodBarmanObjectStore ||
+			backup.Status.StoppedAt == nil {
+			continue
+		}
+
+		if latest == nil || latest.Status.StoppedAt.Before(backup.Status.StoppedAt) {
+			latest = backup
+		}
+	}
+
+	return latest, nil
+}
+
+// reportBackupVerification updates the cluster status with the outcome of
+// the verification job, deleting it once it is completed
+func (r *ClusterReconciler) reportBackupVerification(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	job *batchv1.Job,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	if !job.DeletionTimestamp.IsZero() {
+		return ctrl.Result{RequeueAfter: time.Second}, nil
+	}
+
+	succeeded := utils.JobHasOneCompletion(*job)
+	if !succeeded && !utils.JobHasFailed(*job) {
+		return ctrl.Result{RequeueAfter: backupVerificationCheckInterval}, nil
+	}
+
+	backupName := ""
+	if cluster.Status.LastBackupVerification != nil {
+		backupName = cluster.Status.LastBackupVerification.BackupName
+	}
+
+	condition := metav1.Condition{
+		Type:    string(apiv1.ConditionBackupVerified),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(apiv1.ConditionReasonBackupVerificationSucceeded),
+		Message: fmt.Sprintf("Backup %s has been restored and reached a consistent state", backupName),
+	}
+	if !succeeded {
+		condition = metav1.Condition{
+			Type:   string(apiv1.ConditionBackupVerified),
+			Status: metav1.ConditionFalse,
+			Reason: string(apiv1.ConditionReasonBackupVerificationFailed),
+			Message: fmt.Sprintf("Backup %s could not be restored, the logs of job %s contain the details",
+				backupName, job.Name),
+		}
+		r.Recorder.Eventf(cluster, "Warning", "BackupVerificationFailed",
+			"Backup %s could not be restored", backupName)
+	}
+
+	contextLogger.Info("Backup verification completed",
+		"backupName", backupName, "succeeded", succeeded)
+
+	if err := status.PatchWithOptimisticLock(ctx, r.Client, cluster, func(cluster *apiv1.Cluster) {
+		if cluster.Status.LastBackupVerification != nil {
+			cluster.Status.LastBackupVerification.StoppedAt = ptr.To(metav1.Now())
+		}
+		meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The job is deleted only after the status has been updated, so that
+	// the outcome of the verification is never lost
+	background := metav1.DeletePropagationBackground
+	if err := r.Delete(ctx, job, &client.DeleteOptions{
+		PropagationPolicy: &background,
+	}); err != nil && !apierrs.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
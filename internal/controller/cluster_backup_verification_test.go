@@ -0,0 +1,186 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getNextBackupVerificationTime", func() {
+	It("returns the zero time when no schedule is defined", func() {
+		next, err := getNextBackupVerificationTime(&apiv1.Cluster{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(next.IsZero()).To(BeTrue())
+	})
+
+	It("schedules the next verification after the last one", func() {
+		lastVerification := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{
+					Verification: &apiv1.BackupVerificationConfiguration{Schedule: "0 0 3 * * *"},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				LastBackupVerification: &apiv1.BackupVerificationStatus{
+					StartedAt: ptr.To(metav1.NewTime(lastVerification)),
+				},
+			},
+		}
+
+		next, err := getNextBackupVerificationTime(cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(next.UTC()).To(Equal(time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC)))
+	})
+})
+
+var _ = Describe("reconcileBackupVerification", func() {
+	const namespace = "default"
+
+	var (
+		cluster    *apiv1.Cluster
+		cli        client.Client
+		reconciler *ClusterReconciler
+	)
+
+	newBackup := func(name string, stoppedAt time.Time, phase apiv1.BackupPhase) *apiv1.Backup {
+		return &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: apiv1.BackupSpec{
+				Cluster: apiv1.LocalObjectReference{Name: "cluster-example"},
+			},
+			Status: apiv1.BackupStatus{
+				Phase:     phase,
+				Method:    apiv1.BackupMethodBarmanObjectStore,
+				StoppedAt: ptr.To(metav1.NewTime(stoppedAt)),
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "cluster-example",
+				Namespace:   namespace,
+				Annotations: map[string]string{utils.VerifyBackupAnnotationName: "true"},
+			},
+			Spec: apiv1.ClusterSpec{Instances: 1},
+		}
+
+		now := time.Now()
+		cli = fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithStatusSubresource(&apiv1.Cluster{}).
+			WithObjects(
+				cluster,
+				newBackup("older", now.Add(-2*time.Hour), apiv1.BackupPhaseCompleted),
+				newBackup("latest", now.Add(-time.Hour), apiv1.BackupPhaseCompleted),
+				newBackup("failed", now, apiv1.BackupPhaseFailed),
+			).
+			Build()
+		reconciler = &ClusterReconciler{
+			Client:   cli,
+			Recorder: record.NewFakeRecorder(10),
+		}
+	})
+
+	It("verifies the latest completed backup when requested", func(ctx SpecContext) {
+		result, err := reconciler.reconcileBackupVerification(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.IsZero()).To(BeTrue())
+
+		var job batchv1.Job
+		Expect(cli.Get(ctx, client.ObjectKey{
+			Namespace: namespace,
+			Name:      specs.GetBackupVerificationJobName(cluster.Name),
+		}, &job)).To(Succeed())
+		Expect(job.Spec.Template.Spec.Containers[0].Command).To(ContainElements("verify-backup", "latest"))
+
+		var updatedCluster apiv1.Cluster
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Annotations).ToNot(HaveKey(utils.VerifyBackupAnnotationName))
+		Expect(updatedCluster.Status.LastBackupVerification).ToNot(BeNil())
+		Expect(updatedCluster.Status.LastBackupVerification.BackupName).To(Equal("latest"))
+		Expect(updatedCluster.Status.LastBackupVerification.StartedAt).ToNot(BeNil())
+	})
+
+	It("waits for a running verification job", func(ctx SpecContext) {
+		job := specs.CreateBackupVerificationJob(*cluster, newBackup("latest", time.Now(), apiv1.BackupPhaseCompleted))
+		Expect(cli.Create(ctx, job)).To(Succeed())
+
+		result, err := reconciler.reconcileBackupVerification(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(backupVerificationCheckInterval))
+	})
+
+	It("reports a failed verification and removes the job", func(ctx SpecContext) {
+		job := specs.CreateBackupVerificationJob(*cluster, newBackup("latest", time.Now(), apiv1.BackupPhaseCompleted))
+		job.Status.Conditions = []batchv1.JobCondition{
+			{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+		}
+		Expect(cli.Create(ctx, job)).To(Succeed())
+		cluster.Status.LastBackupVerification = &apiv1.BackupVerificationStatus{
+			BackupName: "latest",
+			JobName:    job.Name,
+			StartedAt:  ptr.To(metav1.Now()),
+		}
+		Expect(cli.Status().Update(ctx, cluster)).To(Succeed())
+
+		_, err := reconciler.reconcileBackupVerification(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		var updatedCluster apiv1.Cluster
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Status.LastBackupVerification.StoppedAt).ToNot(BeNil())
+		condition := meta.FindStatusCondition(updatedCluster.Status.Conditions, string(apiv1.ConditionBackupVerified))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(apiv1.ConditionReasonBackupVerificationFailed)))
+
+		err = cli.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{})
+		Expect(apierrs.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("doesn't start a verification when not requested nor scheduled", func(ctx SpecContext) {
+		cluster.Annotations = nil
+		result, err := reconciler.reconcileBackupVerification(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.IsZero()).To(BeTrue())
+
+		var jobs batchv1.JobList
+		Expect(cli.List(ctx, &jobs)).To(Succeed())
+		Expect(jobs.Items).To(BeEmpty())
+	})
+})
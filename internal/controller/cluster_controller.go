@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -34,12 +35,14 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -87,6 +90,14 @@ type ClusterReconciler struct {
 	Plugins         repository.Interface
 
 	rolloutManager *rolloutManager.Manager
+
+	// evictionEvents tracks, for each cluster, the last event raised
+	// about the switchover from its evicted primary
+	evictionEvents sync.Map
+
+	// clock is used to check the validity of the certificates,
+	// defaulting to the real clock when nil
+	clock clock.PassiveClock
 }
 
 // NewClusterReconciler creates a new ClusterReconciler initializing it
@@ -95,6 +106,9 @@ func NewClusterReconciler(
 	discoveryClient *discovery.DiscoveryClient,
 	plugins repository.Interface,
 ) *ClusterReconciler {
+	rolloutMetrics := rolloutManager.NewMetrics()
+	ctrlmetrics.Registry.MustRegister(rolloutMetrics.Collectors()...)
+
 	return &ClusterReconciler{
 		InstanceClient:  remote.NewClient().Instance(),
 		DiscoveryClient: discoveryClient,
@@ -105,6 +119,7 @@ func NewClusterReconciler(
 		rolloutManager: rolloutManager.New(
 			configuration.Current.GetClustersRolloutDelay(),
 			configuration.Current.GetInstancesRolloutDelay(),
+			rolloutManager.WithMetrics(rolloutMetrics),
 		),
 	}
 }
@@ -392,6 +407,7 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		r.Client,
 		cluster,
 		resources.instances.Items,
+		instancesStatus,
 	); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -511,6 +527,12 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return res, err
 	}
 
+	// Verifies the base backups, when requested
+	verificationResult, err := r.reconcileBackupVerification(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("while verifying the backups: %w", err)
+	}
+
 	// Calls post-reconcile hooks
 	if hookResult := postReconcilePluginHooks(ctx, cluster, cluster); hookResult.Err != nil ||
 		!hookResult.Result.IsZero() {
@@ -519,7 +541,12 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *apiv1.Cluste
 		return hookResult.Result, hookResult.Err
 	}
 
-	return setStatusPluginHook(ctx, r.Client, getPluginClientFromContext(ctx), cluster)
+	res, err = setStatusPluginHook(ctx, r.Client, getPluginClientFromContext(ctx), cluster)
+	if err != nil || !res.IsZero() {
+		return res, err
+	}
+
+	return verificationResult, nil
 }
 
 func (r *ClusterReconciler) ensureNoFailoverOnFullDisk(
@@ -585,6 +612,15 @@ func (r *ClusterReconciler) handleSwitchover(
 	// Update the target primary name from the Pods status.
 	// This means issuing a failover or switchover when needed.
 	selectedPrimary, err := r.reconcileTargetPrimaryFromPods(ctx, cluster, instancesStatus, resources)
+	if errors.Is(err, ErrFailoverPaused) {
+		// The rest of the reconciliation loop can proceed, the
+		// failover will be evaluated again in the next one
+		contextLogger.Info("Current primary isn't healthy, but the automatic failover is paused")
+		return nil, nil
+	}
+	if resumeErr := r.resumeFailover(ctx, cluster); resumeErr != nil {
+		return nil, resumeErr
+	}
 	if err != nil {
 		if errors.Is(err, ErrWaitingOnFailOverDelay) {
 			contextLogger.Info("Waiting for the failover delay to expire")
@@ -594,6 +630,10 @@ func (r *ClusterReconciler) handleSwitchover(
 			contextLogger.Info("Waiting for all WAL receivers to be down to elect a new primary")
 			return &ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 		}
+		if errors.Is(err, errRolloutDelayed) {
+			contextLogger.Info("Waiting for the rollout slot to switch over from the evicted primary")
+			return &ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+		}
 		contextLogger.Info("Cannot update target primary: operation cannot be fulfilled. "+
 			"An immediate retry will be scheduled",
 			"error", err)
@@ -979,6 +1019,15 @@ func (r *ClusterReconciler) handleRollingUpdate(
 				"not connected via streaming replication, waiting for 5 seconds",
 		)
 		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	case errors.Is(err, errNoSwitchoverTarget):
+		contextLogger.Warning(
+			"The primary needs to be restarted, but no replica can be elected " +
+				"as the new primary, waiting for 5 seconds",
+		)
+		if err := r.reportNoSwitchoverTarget(ctx, cluster); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 	case errors.Is(err, errRolloutDelayed):
 		contextLogger.Warning(
 			"A Pod need to be rolled out, but the rollout is being delayed",
@@ -1001,6 +1050,12 @@ func (r *ClusterReconciler) handleRollingUpdate(
 		return ctrl.Result{}, ErrNextLoop
 	}
 
+	// No instance needs to be rolled out: the primary, if it was waiting
+	// for a supervised update, is not anymore
+	if err := r.clearPrimaryUpdatePending(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if instancesStatus.ArePodsWaitingForDecreasedSettings() {
 		// requeue and wait for the pods to be ready to be restarted,
 		// which will be handled by rolloutDueToCondition
@@ -1351,9 +1406,10 @@ func filterClustersUsingConfigMap(
 func (r *ClusterReconciler) mapNodeToClusters() handler.MapFunc {
 	return func(ctx context.Context, obj client.Object) []reconcile.Request {
 		node := obj.(*corev1.Node)
-		// exit if the node is schedulable (e.g. not cordoned)
+		// exit if the node is schedulable (e.g. not cordoned) and is not
+		// evicting its Pods (e.g. not drained through NoExecute taints)
 		// could be expanded here with other conditions (e.g. pressure or issues)
-		if !node.Spec.Unschedulable {
+		if !node.Spec.Unschedulable && len(getNoExecuteTaints(node)) == 0 {
 			return nil
 		}
 		var childPods corev1.PodList
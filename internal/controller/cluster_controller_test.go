@@ -22,6 +22,8 @@ import (
 	cnpgTypes "github.com/cloudnative-pg/machinery/pkg/types"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -29,6 +31,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -66,6 +69,100 @@ var _ = Describe("Filtering cluster", func() {
 	})
 })
 
+var _ = Describe("ConfigMap included in the PostgreSQL configuration", func() {
+	var env *testingEnvironment
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+	})
+
+	It("tracks the resource version of the ConfigMap", func(ctx SpecContext) {
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Spec.PostgresConfiguration.IncludeConfigMap = &apiv1.ConfigMapKeySelector{
+				LocalObjectReference: apiv1.LocalObjectReference{Name: "custom-parameters"},
+				Key:                  "custom.conf",
+			}
+		})
+
+		By("ignoring the ConfigMap while it doesn't exist", func() {
+			Expect(env.clusterReconciler.refreshConfigMapResourceVersions(ctx, cluster)).To(Succeed())
+			Expect(cluster.Status.ConfigMapResourceVersion.PostgresqlInclude).To(BeEmpty())
+		})
+
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom-parameters", Namespace: namespace},
+			Data:       map[string]string{"custom.conf": "work_mem = '8MB'"},
+		}
+		Expect(env.client.Create(ctx, configMap)).To(Succeed())
+
+		By("storing the resource version of the ConfigMap", func() {
+			Expect(env.clusterReconciler.refreshConfigMapResourceVersions(ctx, cluster)).To(Succeed())
+			Expect(cluster.Status.ConfigMapResourceVersion.PostgresqlInclude).To(Equal(configMap.ResourceVersion))
+		})
+
+		By("reconciling the cluster when the ConfigMap changes", func() {
+			Expect(filterClustersUsingConfigMap(apiv1.ClusterList{Items: []apiv1.Cluster{*cluster}}, configMap)).
+				To(HaveLen(1))
+		})
+	})
+
+	It("reports the problems of the ConfigMap in the status", func(ctx SpecContext) {
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Spec.PostgresConfiguration.IncludeConfigMap = &apiv1.ConfigMapKeySelector{
+				LocalObjectReference: apiv1.LocalObjectReference{Name: "custom-parameters"},
+				Key:                  "custom.conf",
+			}
+		})
+		conditionReason := func() string {
+			condition := meta.FindStatusCondition(cluster.Status.Conditions,
+				string(apiv1.ConditionIncludeConfigMapReady))
+			Expect(condition).ToNot(BeNil())
+			return condition.Reason
+		}
+
+		By("reporting a missing ConfigMap", func() {
+			Expect(env.clusterReconciler.setIncludeConfigMapReadyCondition(ctx, cluster)).To(Succeed())
+			Expect(conditionReason()).To(Equal(string(apiv1.ConditionReasonIncludeConfigMapNotFound)))
+		})
+
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom-parameters", Namespace: namespace},
+			Data:       map[string]string{"other.conf": "work_mem = '8MB'"},
+		}
+		Expect(env.client.Create(ctx, configMap)).To(Succeed())
+
+		By("reporting a missing key", func() {
+			Expect(env.clusterReconciler.setIncludeConfigMapReadyCondition(ctx, cluster)).To(Succeed())
+			Expect(conditionReason()).To(Equal(string(apiv1.ConditionReasonIncludeConfigMapKeyNotFound)))
+		})
+
+		configMap.Data["custom.conf"] = "work_mem = '8MB'"
+		Expect(env.client.Update(ctx, configMap)).To(Succeed())
+
+		By("reporting a ConfigMap without the reload label", func() {
+			Expect(env.clusterReconciler.setIncludeConfigMapReadyCondition(ctx, cluster)).To(Succeed())
+			Expect(conditionReason()).To(Equal(string(apiv1.ConditionReasonIncludeConfigMapNotWatched)))
+		})
+
+		configMap.Labels = map[string]string{utils.WatchedLabelName: "true"}
+		Expect(env.client.Update(ctx, configMap)).To(Succeed())
+
+		By("reporting a ConfigMap ready to be included", func() {
+			Expect(env.clusterReconciler.setIncludeConfigMapReadyCondition(ctx, cluster)).To(Succeed())
+			Expect(conditionReason()).To(Equal(string(apiv1.ConditionReasonIncludeConfigMapReady)))
+			Expect(meta.IsStatusConditionTrue(cluster.Status.Conditions,
+				string(apiv1.ConditionIncludeConfigMapReady))).To(BeTrue())
+		})
+
+		By("removing the condition when no ConfigMap is included", func() {
+			cluster.Spec.PostgresConfiguration.IncludeConfigMap = nil
+			Expect(env.clusterReconciler.setIncludeConfigMapReadyCondition(ctx, cluster)).To(Succeed())
+			Expect(cluster.Status.Conditions).To(BeEmpty())
+		})
+	})
+})
+
 var _ = Describe("Updating target primary", func() {
 	var env *testingEnvironment
 	BeforeEach(func() {
@@ -207,6 +304,77 @@ var _ = Describe("Updating target primary", func() {
 		})
 	})
 
+	It("doesn't select a new target primary while the failover is paused", func(ctx SpecContext) {
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Annotations = map[string]string{
+				utils.FailoverAnnotationName: "disabled",
+			}
+		})
+
+		By("creating the cluster resources")
+		jobs := generateFakeInitDBJobs(env.client, cluster)
+		instances := generateFakeClusterPods(env.client, cluster, true)
+		pvc := generateClusterPVC(env.client, cluster, persistentvolumeclaim.StatusReady)
+
+		managedResources := &managedResources{
+			nodes:     nil,
+			instances: corev1.PodList{Items: instances},
+			pvcs:      corev1.PersistentVolumeClaimList{Items: pvc},
+			jobs:      batchv1.JobList{Items: jobs},
+		}
+		statusList := postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{
+					CurrentLsn:  cnpgTypes.LSN("0/0"),
+					ReceivedLsn: cnpgTypes.LSN("0/0"),
+					ReplayLsn:   cnpgTypes.LSN("0/0"),
+					IsPodReady:  true,
+					Pod:         &instances[1],
+				},
+				{
+					CurrentLsn:  cnpgTypes.LSN("0/0"),
+					ReceivedLsn: cnpgTypes.LSN("0/0"),
+					ReplayLsn:   cnpgTypes.LSN("0/0"),
+					IsPodReady:  false,
+					Pod:         &instances[0],
+				},
+			},
+		}
+
+		cluster.Status.TargetPrimary = instances[0].Name
+		cluster.Status.CurrentPrimary = instances[0].Name
+
+		By("withholding the failover and recording the condition", func() {
+			selectedPrimary, err := env.clusterReconciler.reconcileTargetPrimaryForNonReplicaCluster(
+				ctx,
+				cluster,
+				statusList,
+				managedResources,
+			)
+			Expect(err).To(MatchError(ErrFailoverPaused))
+			Expect(selectedPrimary).To(BeEmpty())
+			Expect(meta.IsStatusConditionTrue(cluster.Status.Conditions, string(apiv1.ConditionFailoverPaused))).
+				To(BeTrue())
+		})
+
+		By("failing over once the failover is resumed", func() {
+			delete(cluster.Annotations, utils.FailoverAnnotationName)
+			Expect(env.clusterReconciler.resumeFailover(ctx, cluster)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(cluster.Status.Conditions, string(apiv1.ConditionFailoverPaused))).
+				To(BeTrue())
+
+			selectedPrimary, err := env.clusterReconciler.reconcileTargetPrimaryForNonReplicaCluster(
+				ctx,
+				cluster,
+				statusList,
+				managedResources,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selectedPrimary).To(Equal(instances[1].Name))
+		})
+	})
+
 	It("Issue #1783: ensure that the scale-down behaviour remain consistent", func(ctx SpecContext) {
 		namespace := newFakeNamespace(env.client)
 		cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
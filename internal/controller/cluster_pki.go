@@ -20,10 +20,13 @@ import (
 	"context"
 	"crypto/x509"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
@@ -254,7 +257,68 @@ func (r *ClusterReconciler) ensureServerLeafCertificate(
 	}
 
 	opts := &x509.VerifyOptions{KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}}
-	return validateLeafCertificate(caSecret, &serverSecret, opts)
+	if err := validateLeafCertificate(caSecret, &serverSecret, opts); err != nil {
+		return err
+	}
+
+	r.checkServerCertificateAltDNSNames(ctx, cluster, &serverSecret)
+	return nil
+}
+
+// checkServerCertificateAltDNSNames warns when the user-provided server
+// certificate doesn't cover every name of the cluster services, which would
+// make the clients using sslmode=verify-full fail. This is not fatal because
+// the operator and the instances verify the server certificate against the
+// CA only, disregarding the DNS names
+func (r *ClusterReconciler) checkServerCertificateAltDNSNames(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	serverSecret *v1.Secret,
+) {
+	contextLogger := log.FromContext(ctx)
+
+	missingNames, err := getMissingAltDNSNames(serverSecret, cluster.GetClusterAltDNSNames())
+	if err != nil {
+		contextLogger.Error(err, "while checking the names covered by the server certificate",
+			"secret", serverSecret.Name)
+		return
+	}
+	if len(missingNames) == 0 {
+		return
+	}
+
+	fieldErr := field.Invalid(
+		field.NewPath("spec", "certificates", "serverTLSSecret"),
+		serverSecret.Name,
+		fmt.Sprintf("the server certificate doesn't cover the following names: %s",
+			strings.Join(missingNames, ", ")),
+	)
+	contextLogger.Info("The server certificate doesn't cover all the names of the cluster services",
+		"secret", serverSecret.Name, "missingNames", missingNames)
+	r.Recorder.Event(cluster, "Warning", "ServerCertificateMissingNames", fieldErr.Error())
+}
+
+// getMissingAltDNSNames returns the names that are not covered by the
+// certificate contained in the server secret, taking wildcards into account
+func getMissingAltDNSNames(serverSecret *v1.Secret, altDNSNames []string) ([]string, error) {
+	serverPair, err := certs.ParseServerSecret(serverSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := serverPair.ParseCertificate()
+	if err != nil {
+		return nil, err
+	}
+
+	var missingNames []string
+	for _, name := range altDNSNames {
+		if err := certificate.VerifyHostname(name); err != nil {
+			missingNames = append(missingNames, name)
+		}
+	}
+
+	return missingNames, nil
 }
 
 // ensureServerLeafCertificate checks if we have a client certificate for the
@@ -326,6 +390,8 @@ func (r *ClusterReconciler) ensureLeafCertificate(
 	var secret v1.Secret
 	err := r.Get(ctx, secretName, &secret)
 	switch {
+	case err == nil && usage == certs.CertTypeClient:
+		return r.renewAndUpdateClientCertificate(ctx, cluster, caSecret, &secret)
 	case err == nil:
 		return r.renewAndUpdateCertificate(ctx, caSecret, &secret, altDNSNames)
 	case apierrors.IsNotFound(err):
@@ -388,3 +454,41 @@ func (r *ClusterReconciler) renewAndUpdateCertificate(
 
 	return nil
 }
+
+// now returns the current time according to the clock of the reconciler
+func (r *ClusterReconciler) now() time.Time {
+	if r.clock == nil {
+		return time.Now()
+	}
+
+	return r.clock.Now()
+}
+
+// renewAndUpdateClientCertificate renews a client certificate when less than
+// the configured percentage of its lifetime is left, and updates the secret.
+// The instances reload the new certificate once the secret is changed
+func (r *ClusterReconciler) renewAndUpdateClientCertificate(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	caSecret *v1.Secret,
+	secret *v1.Secret,
+) error {
+	origSecret := secret.DeepCopy()
+	hasBeenRenewed, err := certs.RenewClientLeafCertificate(
+		caSecret,
+		secret,
+		r.now(),
+		cluster.Spec.Certificates.GetClientCertificatesRenewalThreshold(),
+	)
+	if err != nil {
+		return err
+	}
+	if !hasBeenRenewed {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Renewing client certificate", "secret", secret.Name)
+	r.Recorder.Event(cluster, "Normal", "ClientCertificateRenewed",
+		"Renewed the client certificate in secret "+secret.Name)
+	return r.Patch(ctx, secret, client.MergeFrom(origSecret))
+}
@@ -0,0 +1,169 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getMissingAltDNSNames", func() {
+	altDNSNames := []string{
+		"cluster-example-rw",
+		"cluster-example-rw.default",
+		"cluster-example-rw.default.svc",
+		"cluster-example-ro.default.svc",
+	}
+
+	signServerCertificate := func(names []string) *certs.KeyPair {
+		ca, err := certs.CreateRootCA("cluster-example", "default")
+		Expect(err).ToNot(HaveOccurred())
+		pair, err := ca.CreateAndSignPair("cluster-example-rw", certs.CertTypeServer, names)
+		Expect(err).ToNot(HaveOccurred())
+		return pair
+	}
+
+	It("returns nothing when every name is covered", func() {
+		pair := signServerCertificate(altDNSNames)
+		missingNames, err := getMissingAltDNSNames(pair.GenerateCertificateSecret("default", "tls"), altDNSNames)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(missingNames).To(BeEmpty())
+	})
+
+	It("takes wildcard names into account", func() {
+		pair := signServerCertificate([]string{"cluster-example-rw", "cluster-example-rw.default", "*.default.svc"})
+		missingNames, err := getMissingAltDNSNames(pair.GenerateCertificateSecret("default", "tls"), altDNSNames)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(missingNames).To(BeEmpty())
+	})
+
+	It("returns the names that are not covered", func() {
+		pair := signServerCertificate([]string{"cluster-example-rw", "cluster-example-rw.default.svc"})
+		missingNames, err := getMissingAltDNSNames(pair.GenerateCertificateSecret("default", "tls"), altDNSNames)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(missingNames).To(ConsistOf("cluster-example-rw.default", "cluster-example-ro.default.svc"))
+	})
+})
+
+var _ = Describe("client certificates rotation", func() {
+	const namespace = "default"
+
+	var (
+		r               *ClusterReconciler
+		cluster         *apiv1.Cluster
+		caSecret        *corev1.Secret
+		secretKey       client.ObjectKey
+		fakeClock       *testingclock.FakePassiveClock
+		recorder        *record.FakeRecorder
+		notBefore       time.Time
+		lifetime        time.Duration
+		getSerialNumber func(ctx context.Context) string
+		rotateOnce      func(ctx context.Context)
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: namespace},
+		}
+
+		ca, err := certs.CreateRootCA(cluster.Name, namespace)
+		Expect(err).ToNot(HaveOccurred())
+		caSecret = ca.GenerateCASecret(namespace, cluster.GetClientCASecretName())
+
+		fakeClock = testingclock.NewFakePassiveClock(time.Now())
+		recorder = record.NewFakeRecorder(10)
+		r = &ClusterReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+				WithObjects(cluster, caSecret).
+				Build(),
+			Recorder: recorder,
+			clock:    fakeClock,
+		}
+		secretKey = client.ObjectKey{Namespace: namespace, Name: cluster.GetReplicationSecretName()}
+
+		getSerialNumber = func(ctx context.Context) string {
+			var secret corev1.Secret
+			Expect(r.Get(ctx, secretKey, &secret)).To(Succeed())
+			pair, err := certs.ParseServerSecret(&secret)
+			Expect(err).ToNot(HaveOccurred())
+			cert, err := pair.ParseCertificate()
+			Expect(err).ToNot(HaveOccurred())
+			notBefore = cert.NotBefore
+			lifetime = cert.NotAfter.Sub(cert.NotBefore)
+			return cert.SerialNumber.String()
+		}
+		rotateOnce = func(ctx context.Context) {
+			Expect(r.ensureReplicationClientLeafCertificate(ctx, cluster, caSecret)).To(Succeed())
+		}
+
+		rotateOnce(ctx)
+	})
+
+	It("rotates the certificate exactly once when it enters the renewal window", func(ctx SpecContext) {
+		originalSN := getSerialNumber(ctx)
+
+		By("not rotating the certificate while enough of its lifetime is left", func() {
+			fakeClock.SetTime(notBefore.Add(lifetime / 2))
+			rotateOnce(ctx)
+			Expect(getSerialNumber(ctx)).To(Equal(originalSN))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		By("rotating the certificate when less than a third of its lifetime is left", func() {
+			fakeClock.SetTime(notBefore.Add(lifetime * 3 / 4))
+			rotateOnce(ctx)
+			Expect(getSerialNumber(ctx)).ToNot(Equal(originalSN))
+			Expect(recorder.Events).To(Receive(ContainSubstring("ClientCertificateRenewed")))
+		})
+
+		By("being idempotent after the rotation", func() {
+			rotatedSN := getSerialNumber(ctx)
+			rotateOnce(ctx)
+			rotateOnce(ctx)
+			Expect(getSerialNumber(ctx)).To(Equal(rotatedSN))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+
+	It("honors the configured renewal threshold", func(ctx SpecContext) {
+		cluster.Spec.Certificates = &apiv1.CertificatesConfiguration{ClientCertificatesRenewalThreshold: 10}
+		originalSN := getSerialNumber(ctx)
+
+		fakeClock.SetTime(notBefore.Add(lifetime * 3 / 4))
+		rotateOnce(ctx)
+		Expect(getSerialNumber(ctx)).To(Equal(originalSN))
+
+		fakeClock.SetTime(notBefore.Add(lifetime * 19 / 20))
+		rotateOnce(ctx)
+		Expect(getSerialNumber(ctx)).ToNot(Equal(originalSN))
+	})
+})
@@ -19,8 +19,13 @@ package controller
 
 import (
 	"context"
+	"maps"
 	"reflect"
+	"slices"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
@@ -46,10 +51,34 @@ func (r *ClusterReconciler) updatePluginsStatus(ctx context.Context, cluster *ap
 		cluster.Status.PluginStatus[i].RestoreJobHookCapabilities = entry.RestoreJobHookCapabilities
 	}
 
+	// Probe the plugins and report their readiness as conditions
+	setPluginReadyConditions(cluster, pluginClient.ProbePlugins(ctx))
+
 	// If nothing changes, there's no need to hit the API server
-	if reflect.DeepEqual(oldCluster.Status.PluginStatus, cluster.Status.PluginStatus) {
+	if reflect.DeepEqual(oldCluster.Status.PluginStatus, cluster.Status.PluginStatus) &&
+		reflect.DeepEqual(oldCluster.Status.Conditions, cluster.Status.Conditions) {
 		return nil
 	}
 
 	return r.Client.Status().Patch(ctx, cluster, client.MergeFrom(oldCluster))
 }
+
+// setPluginReadyConditions sets a condition for each probed plugin, removing
+// the ones referring to plugins that are not loaded anymore
+func setPluginReadyConditions(cluster *apiv1.Cluster, probeResults map[string]error) {
+	prefix := string(apiv1.ConditionPluginReadyPrefix)
+	cluster.Status.Conditions = slices.DeleteFunc(cluster.Status.Conditions, func(condition metav1.Condition) bool {
+		if !strings.HasPrefix(condition.Type, prefix) {
+			return false
+		}
+		_, found := probeResults[strings.TrimPrefix(condition.Type, prefix)]
+		return !found
+	})
+
+	for _, pluginName := range slices.Sorted(maps.Keys(probeResults)) {
+		meta.SetStatusCondition(
+			&cluster.Status.Conditions,
+			apiv1.BuildPluginReadyCondition(pluginName, probeResults[pluginName]),
+		)
+	}
+}
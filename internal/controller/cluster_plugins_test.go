@@ -0,0 +1,75 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("setPluginReadyConditions", func() {
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{}
+	})
+
+	It("sets a condition for each probed plugin", func() {
+		setPluginReadyConditions(cluster, map[string]error{
+			"ready.plugin":     nil,
+			"not-ready.plugin": errors.New("connection refused"),
+		})
+
+		Expect(cluster.Status.Conditions).To(HaveLen(2))
+
+		readyCondition := meta.FindStatusCondition(cluster.Status.Conditions, "PluginReady-ready.plugin")
+		Expect(readyCondition).ToNot(BeNil())
+		Expect(readyCondition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(readyCondition.Reason).To(Equal(string(apiv1.ConditionReasonPluginReady)))
+
+		notReadyCondition := meta.FindStatusCondition(cluster.Status.Conditions, "PluginReady-not-ready.plugin")
+		Expect(notReadyCondition).ToNot(BeNil())
+		Expect(notReadyCondition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(notReadyCondition.Reason).To(Equal(string(apiv1.ConditionReasonPluginNotReady)))
+		Expect(notReadyCondition.Message).To(Equal("connection refused"))
+	})
+
+	It("removes the conditions of the plugins that are not loaded anymore", func() {
+		cluster.Status.Conditions = []metav1.Condition{
+			{
+				Type:   string(apiv1.ConditionClusterReady),
+				Status: metav1.ConditionTrue,
+				Reason: string(apiv1.ClusterReady),
+			},
+			apiv1.BuildPluginReadyCondition("removed.plugin", nil),
+		}
+
+		setPluginReadyConditions(cluster, map[string]error{"ready.plugin": nil})
+
+		Expect(cluster.Status.Conditions).To(HaveLen(2))
+		Expect(meta.FindStatusCondition(cluster.Status.Conditions, "PluginReady-removed.plugin")).To(BeNil())
+		Expect(meta.FindStatusCondition(cluster.Status.Conditions, string(apiv1.ConditionClusterReady))).ToNot(BeNil())
+		Expect(meta.FindStatusCondition(cluster.Status.Conditions, "PluginReady-ready.plugin")).ToNot(BeNil())
+	})
+})
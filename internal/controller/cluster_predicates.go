@@ -17,6 +17,8 @@ limitations under the License.
 package controller
 
 import (
+	"reflect"
+
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -25,6 +27,10 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
+// certManagerCertificateNameAnnotation is the annotation set by cert-manager
+// on the secrets containing the certificates it issues
+const certManagerCertificateNameAnnotation = "cert-manager.io/certificate-name"
+
 var (
 	isUsefulConfigMap = func(object client.Object) bool {
 		return isOwnedByClusterOrSatisfiesPredicate(object, func(object client.Object) bool {
@@ -35,8 +41,8 @@ var (
 
 	isUsefulClusterSecret = func(object client.Object) bool {
 		return isOwnedByClusterOrSatisfiesPredicate(object, func(object client.Object) bool {
-			_, ok := object.(*corev1.Secret)
-			return ok && hasReloadLabelSet(object)
+			secret, ok := object.(*corev1.Secret)
+			return ok && (hasReloadLabelSet(object) || isCertificateSecret(secret))
 		})
 	}
 
@@ -74,7 +80,8 @@ var (
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			oldNode, oldOk := e.ObjectOld.(*corev1.Node)
 			newNode, newOk := e.ObjectNew.(*corev1.Node)
-			return oldOk && newOk && oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable
+			return oldOk && newOk && (oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable ||
+				!reflect.DeepEqual(getNoExecuteTaints(oldNode), getNoExecuteTaints(newNode)))
 		},
 		CreateFunc: func(_ event.CreateEvent) bool {
 			return false
@@ -100,3 +107,27 @@ func hasReloadLabelSet(obj client.Object) bool {
 	_, hasLabel := obj.GetLabels()[utils.WatchedLabelName]
 	return hasLabel
 }
+
+// isCertificateSecret checks if the secret contains a certificate that
+// could be used by a cluster, such as the ones issued and renewed in place
+// by cert-manager. The clusters actually using it are filtered later
+func isCertificateSecret(secret *corev1.Secret) bool {
+	if secret.Type == corev1.SecretTypeTLS {
+		return true
+	}
+
+	_, isIssuedByCertManager := secret.Annotations[certManagerCertificateNameAnnotation]
+	return isIssuedByCertManager
+}
+
+// getNoExecuteTaints returns the taints of the node evicting the Pods
+// that don't tolerate them
+func getNoExecuteTaints(node *corev1.Node) []corev1.Taint {
+	var result []corev1.Taint
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectNoExecute {
+			result = append(result, corev1.Taint{Key: taint.Key, Value: taint.Value, Effect: taint.Effect})
+		}
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("isUsefulClusterSecret", func() {
+	It("ignores the secrets that are not related to a cluster", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated"},
+			Type:       corev1.SecretTypeOpaque,
+		}
+		Expect(isUsefulClusterSecret(secret)).To(BeFalse())
+	})
+
+	It("accepts the secrets having the reload label", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "reloaded",
+				Labels: map[string]string{utils.WatchedLabelName: "true"},
+			},
+		}
+		Expect(isUsefulClusterSecret(secret)).To(BeTrue())
+	})
+
+	It("accepts the TLS secrets", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "server-tls"},
+			Type:       corev1.SecretTypeTLS,
+		}
+		Expect(isUsefulClusterSecret(secret)).To(BeTrue())
+	})
+
+	It("accepts the secrets issued by cert-manager", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "server-ca",
+				Annotations: map[string]string{certManagerCertificateNameAnnotation: "server-ca"},
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+		Expect(isUsefulClusterSecret(secret)).To(BeTrue())
+	})
+})
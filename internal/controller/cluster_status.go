@@ -20,14 +20,17 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"regexp"
 	"runtime"
 	"sort"
+	"strconv"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -208,6 +211,17 @@ func (r *ClusterReconciler) getManagedJobs(
 		return batchv1.JobList{}, err
 	}
 
+	// The backup verification job doesn't involve any instance of the
+	// cluster and is managed separately
+	verificationJobName := specs.GetBackupVerificationJobName(cluster.Name)
+	instanceJobs := childJobs.Items[:0]
+	for _, job := range childJobs.Items {
+		if job.Name != verificationJobName {
+			instanceJobs = append(instanceJobs, job)
+		}
+	}
+	childJobs.Items = instanceJobs
+
 	sort.Slice(childJobs.Items, func(i, j int) bool {
 		return childJobs.Items[i].Name < childJobs.Items[j].Name
 	})
@@ -355,6 +369,10 @@ func (r *ClusterReconciler) updateResourceStatus(
 		return err
 	}
 
+	if err := r.setIncludeConfigMapReadyCondition(ctx, cluster); err != nil {
+		return err
+	}
+
 	if cluster.Spec.ReplicaCluster != nil && len(cluster.Spec.ReplicaCluster.PromotionToken) == 0 {
 		cluster.Status.LastPromotionToken = ""
 	}
@@ -578,11 +596,71 @@ func (r *ClusterReconciler) refreshConfigMapResourceVersions(ctx context.Context
 		}
 	}
 
+	if includeConfigMap := cluster.Spec.PostgresConfiguration.IncludeConfigMap; includeConfigMap != nil {
+		version, err := r.getConfigMapResourceVersion(ctx, cluster, includeConfigMap.Name)
+		if err != nil {
+			return err
+		}
+		versions.PostgresqlInclude = version
+	}
+
 	cluster.Status.ConfigMapResourceVersion = versions
 
 	return nil
 }
 
+// setIncludeConfigMapReadyCondition reports whether the ConfigMap included
+// in the PostgreSQL configuration exists, contains the referenced key, and
+// is watched by the operator. As the ConfigMap can be created or changed
+// independently of the cluster, this is checked at every reconciliation
+// rather than by the webhook
+func (r *ClusterReconciler) setIncludeConfigMapReadyCondition(ctx context.Context, cluster *apiv1.Cluster) error {
+	conditionType := string(apiv1.ConditionIncludeConfigMapReady)
+
+	reference := cluster.Spec.PostgresConfiguration.IncludeConfigMap
+	if reference == nil {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, conditionType)
+		return nil
+	}
+
+	var configMap corev1.ConfigMap
+	err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: reference.Name}, &configMap)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+
+	condition := metav1.Condition{
+		Type:   conditionType,
+		Status: metav1.ConditionTrue,
+		Reason: string(apiv1.ConditionReasonIncludeConfigMapReady),
+		Message: fmt.Sprintf("The key %q of the ConfigMap %q is included in the PostgreSQL configuration",
+			reference.Key, reference.Name),
+	}
+	_, hasKey := configMap.Data[reference.Key]
+	_, hasLabel := configMap.Labels[utils.WatchedLabelName]
+	switch {
+	case apierrs.IsNotFound(err):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(apiv1.ConditionReasonIncludeConfigMapNotFound)
+		condition.Message = fmt.Sprintf("The ConfigMap %q included in the PostgreSQL configuration doesn't exist: "+
+			"its parameters will be applied once it is created", reference.Name)
+	case !hasKey:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(apiv1.ConditionReasonIncludeConfigMapKeyNotFound)
+		condition.Message = fmt.Sprintf("The ConfigMap %q included in the PostgreSQL configuration "+
+			"doesn't contain the key %q", reference.Name, reference.Key)
+	case !hasLabel:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(apiv1.ConditionReasonIncludeConfigMapNotWatched)
+		condition.Message = fmt.Sprintf("The ConfigMap %q included in the PostgreSQL configuration "+
+			"doesn't have the %q label: its changes are not applied until the cluster is reconciled again",
+			reference.Name, utils.WatchedLabelName)
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	return nil
+}
+
 // refreshSecretResourceVersions set the resource version of the secrets
 func (r *ClusterReconciler) refreshSecretResourceVersions(ctx context.Context, cluster *apiv1.Cluster) error {
 	versions := apiv1.SecretsResourceVersion{}
@@ -747,7 +825,7 @@ func (r *ClusterReconciler) updateClusterStatusThatRequiresInstancesState(
 	cluster *apiv1.Cluster,
 	statuses postgres.PostgresqlStatusList,
 ) error {
-	existingClusterStatus := cluster.Status
+	existingClusterStatus := *cluster.Status.DeepCopy()
 	cluster.Status.InstancesReportedState = make(map[apiv1.PodName]apiv1.InstanceReportedState, len(statuses.Items))
 
 	// we extract the instances reported state
@@ -767,12 +845,141 @@ func (r *ClusterReconciler) updateClusterStatusThatRequiresInstancesState(
 		}
 	}
 
+	setStandbyAvailableForBackupCondition(cluster, statuses)
+	setRecoveryWindowCoveredCondition(cluster)
+
 	if !reflect.DeepEqual(existingClusterStatus, cluster.Status) {
 		return r.Status().Update(ctx, cluster)
 	}
 	return nil
 }
 
+// setStandbyAvailableForBackupCondition reports whether the backups targeting
+// a standby can run on a standby instance. The condition is only set on
+// clusters with more than one instance having backups configured to target
+// a standby, where the lack of a ready standby moves the load of the backups
+// to the primary
+func setStandbyAvailableForBackupCondition(cluster *apiv1.Cluster, statuses postgres.PostgresqlStatusList) {
+	conditionType := string(apiv1.ConditionStandbyAvailableForBackup)
+
+	if cluster.Spec.Backup == nil ||
+		cluster.Spec.Backup.Target == apiv1.BackupTargetPrimary ||
+		cluster.Spec.Instances < 2 {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, conditionType)
+		return
+	}
+
+	availableStandbys := 0
+	for _, item := range statuses.Items {
+		if !item.IsPrimary && item.IsPodReady && item.Error == nil {
+			availableStandbys++
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:   conditionType,
+		Status: metav1.ConditionTrue,
+		Reason: string(apiv1.ConditionReasonStandbyAvailable),
+		Message: fmt.Sprintf("%d standby instance(s) available to run the backups targeting a standby",
+			availableStandbys),
+	}
+	if availableStandbys == 0 {
+		condition = metav1.Condition{
+			Type:   conditionType,
+			Status: metav1.ConditionFalse,
+			Reason: string(apiv1.ConditionReasonNoStandbyAvailable),
+			Message: "No standby instance is available to run the backups targeting a standby, " +
+				"they will be taken on the primary",
+		}
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+}
+
+// setRecoveryWindowCoveredCondition warns the user when the backup retention
+// policy is shorter than the minimum recovery window declared through the
+// corresponding annotation, as a point-in-time recovery beyond the retention
+// policy will fail because the required backups and WAL files have already
+// been removed. The condition is advisory and doesn't block any operation
+func setRecoveryWindowCoveredCondition(cluster *apiv1.Cluster) {
+	conditionType := string(apiv1.ConditionRecoveryWindowCovered)
+
+	minimumRecoveryWindow := cluster.Annotations[utils.MinimumRecoveryWindowAnnotationName]
+	if minimumRecoveryWindow == "" || cluster.Spec.Backup == nil || cluster.Spec.Backup.RetentionPolicy == "" {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, conditionType)
+		return
+	}
+	retentionPolicy := cluster.Spec.Backup.RetentionPolicy
+
+	recoveryWindowDays, err := parseRecoveryWindowDays(minimumRecoveryWindow)
+	if err != nil {
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:   conditionType,
+			Status: metav1.ConditionFalse,
+			Reason: string(apiv1.ConditionReasonInvalidRecoveryWindow),
+			Message: fmt.Sprintf("Invalid value %q for the %s annotation: %s",
+				minimumRecoveryWindow, utils.MinimumRecoveryWindowAnnotationName, err),
+		})
+		return
+	}
+
+	retentionPolicyDays, err := parseRecoveryWindowDays(retentionPolicy)
+	if err != nil {
+		// The retention policy is validated by the webhook, we don't
+		// have anything useful to report here
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, conditionType)
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:   conditionType,
+		Status: metav1.ConditionTrue,
+		Reason: string(apiv1.ConditionReasonRecoveryWindowCovered),
+		Message: fmt.Sprintf("The retention policy (%s) covers the minimum recovery window (%s)",
+			retentionPolicy, minimumRecoveryWindow),
+	}
+	if retentionPolicyDays < recoveryWindowDays {
+		condition = metav1.Condition{
+			Type:   conditionType,
+			Status: metav1.ConditionFalse,
+			Reason: string(apiv1.ConditionReasonRetentionPolicyTooShort),
+			Message: fmt.Sprintf("The retention policy (%s) is shorter than the minimum recovery window (%s): "+
+				"a point-in-time recovery older than %s will fail, as the required backups and WAL files "+
+				"are removed by the retention policy",
+				retentionPolicy, minimumRecoveryWindow, retentionPolicy),
+		}
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+}
+
+// recoveryWindowRegex matches the format of the backup retention policy
+var recoveryWindowRegex = regexp.MustCompile(`^([1-9][0-9]*)([dwm])$`)
+
+// parseRecoveryWindowDays converts a recovery window expressed in the format
+// of the backup retention policy (i.e. '30d', '4w', '3m') to an approximate
+// number of days, counting a month as 30 days
+func parseRecoveryWindowDays(window string) (int, error) {
+	matches := recoveryWindowRegex.FindStringSubmatch(window)
+	if matches == nil {
+		return 0, fmt.Errorf("expected a positive integer followed by one of 'd', 'w', 'm'")
+	}
+
+	value, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, err
+	}
+
+	switch matches[2] {
+	case "w":
+		return value * 7, nil
+	case "m":
+		return value * 30, nil
+	default:
+		return value, nil
+	}
+}
+
 // getPodsTopology returns a map with all the information about the pods topology
 func getPodsTopology(
 	ctx context.Context,
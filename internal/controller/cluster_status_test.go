@@ -18,15 +18,20 @@ package controller
 
 import (
 	"context"
+	"errors"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/rand"
 
 	v1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/certs"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -171,3 +176,174 @@ var _ = Describe("cluster_status unit tests", func() {
 		})
 	})
 })
+
+var _ = Describe("setStandbyAvailableForBackupCondition", func() {
+	newStatus := func(name string, isPrimary, isReady bool) postgres.PostgresqlStatus {
+		return postgres.PostgresqlStatus{
+			Pod:        &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}},
+			IsPrimary:  isPrimary,
+			IsPodReady: isReady,
+		}
+	}
+
+	newCluster := func(instances int, target v1.BackupTarget) *v1.Cluster {
+		return &v1.Cluster{
+			Spec: v1.ClusterSpec{
+				Instances: instances,
+				Backup: &v1.BackupConfiguration{
+					Target: target,
+				},
+			},
+		}
+	}
+
+	getCondition := func(cluster *v1.Cluster) *metav1.Condition {
+		return meta.FindStatusCondition(cluster.Status.Conditions,
+			string(v1.ConditionStandbyAvailableForBackup))
+	}
+
+	It("reports a standby is available", func() {
+		cluster := newCluster(3, v1.BackupTargetStandby)
+		setStandbyAvailableForBackupCondition(cluster, postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				newStatus("cluster-example-1", true, true),
+				newStatus("cluster-example-2", false, true),
+				newStatus("cluster-example-3", false, false),
+			},
+		})
+
+		condition := getCondition(cluster)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(v1.ConditionReasonStandbyAvailable)))
+	})
+
+	It("reports no standby is available when all of them are down", func() {
+		cluster := newCluster(3, "")
+		setStandbyAvailableForBackupCondition(cluster, postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				newStatus("cluster-example-1", true, true),
+				newStatus("cluster-example-2", false, false),
+			},
+		})
+
+		condition := getCondition(cluster)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(v1.ConditionReasonNoStandbyAvailable)))
+	})
+
+	It("doesn't count the standbys whose status can't be retrieved", func() {
+		cluster := newCluster(2, v1.BackupTargetStandby)
+		standby := newStatus("cluster-example-2", false, true)
+		standby.Error = errors.New("connection refused")
+		setStandbyAvailableForBackupCondition(cluster, postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				newStatus("cluster-example-1", true, true),
+				standby,
+			},
+		})
+
+		Expect(getCondition(cluster).Status).To(Equal(metav1.ConditionFalse))
+	})
+
+	It("removes the condition when backups target the primary", func() {
+		cluster := newCluster(3, v1.BackupTargetPrimary)
+		cluster.Status.Conditions = []metav1.Condition{
+			{Type: string(v1.ConditionStandbyAvailableForBackup), Status: metav1.ConditionFalse},
+		}
+		setStandbyAvailableForBackupCondition(cluster, postgres.PostgresqlStatusList{})
+
+		Expect(getCondition(cluster)).To(BeNil())
+	})
+
+	It("doesn't set the condition on single instance clusters", func() {
+		cluster := newCluster(1, v1.BackupTargetStandby)
+		setStandbyAvailableForBackupCondition(cluster, postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				newStatus("cluster-example-1", true, true),
+			},
+		})
+
+		Expect(getCondition(cluster)).To(BeNil())
+	})
+
+	It("doesn't set the condition without a backup configuration", func() {
+		cluster := &v1.Cluster{Spec: v1.ClusterSpec{Instances: 3}}
+		setStandbyAvailableForBackupCondition(cluster, postgres.PostgresqlStatusList{})
+
+		Expect(getCondition(cluster)).To(BeNil())
+	})
+})
+
+var _ = Describe("setRecoveryWindowCoveredCondition", func() {
+	newCluster := func(retentionPolicy, minimumRecoveryWindow string) *v1.Cluster {
+		cluster := &v1.Cluster{
+			Spec: v1.ClusterSpec{
+				Backup: &v1.BackupConfiguration{
+					RetentionPolicy: retentionPolicy,
+				},
+			},
+		}
+		if minimumRecoveryWindow != "" {
+			cluster.Annotations = map[string]string{
+				utils.MinimumRecoveryWindowAnnotationName: minimumRecoveryWindow,
+			}
+		}
+		return cluster
+	}
+
+	getCondition := func(cluster *v1.Cluster) *metav1.Condition {
+		return meta.FindStatusCondition(cluster.Status.Conditions,
+			string(v1.ConditionRecoveryWindowCovered))
+	}
+
+	It("warns when the retention policy is shorter than the recovery window", func() {
+		cluster := newCluster("7d", "2w")
+		setRecoveryWindowCoveredCondition(cluster)
+
+		condition := getCondition(cluster)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(v1.ConditionReasonRetentionPolicyTooShort)))
+		Expect(condition.Message).To(ContainSubstring("(7d)"))
+		Expect(condition.Message).To(ContainSubstring("(2w)"))
+	})
+
+	It("reports when the retention policy covers the recovery window", func() {
+		cluster := newCluster("1m", "30d")
+		setRecoveryWindowCoveredCondition(cluster)
+
+		condition := getCondition(cluster)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(v1.ConditionReasonRecoveryWindowCovered)))
+	})
+
+	It("reports an invalid recovery window", func() {
+		cluster := newCluster("30d", "two weeks")
+		setRecoveryWindowCoveredCondition(cluster)
+
+		condition := getCondition(cluster)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(v1.ConditionReasonInvalidRecoveryWindow)))
+	})
+
+	It("removes the condition when the annotation is not set", func() {
+		cluster := newCluster("7d", "")
+		cluster.Status.Conditions = []metav1.Condition{
+			{Type: string(v1.ConditionRecoveryWindowCovered), Status: metav1.ConditionFalse},
+		}
+		setRecoveryWindowCoveredCondition(cluster)
+
+		Expect(getCondition(cluster)).To(BeNil())
+	})
+
+	It("doesn't set the condition without a retention policy", func() {
+		cluster := newCluster("", "7d")
+		setRecoveryWindowCoveredCondition(cluster)
+
+		Expect(getCondition(cluster)).To(BeNil())
+	})
+})
@@ -26,6 +26,8 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
@@ -33,6 +35,7 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/webserver/client/remote"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/reconciler/persistentvolumeclaim"
+	resourcestatus "github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
@@ -42,6 +45,16 @@ import (
 // instance is not connected via streaming replication
 var errLogShippingReplicaElected = errors.New("log shipping replica elected as a new post-switchover primary")
 
+// errNoSwitchoverTarget is raised when the pod update process need to
+// select a new primary before upgrading the old primary, but no replica
+// is ready, up to date, and not excluded from the rollout
+var errNoSwitchoverTarget = errors.New("no replica can be elected as a new post-switchover primary")
+
+// noSwitchoverTargetReason is the phase reason of a cluster whose primary
+// can't be updated because no replica can be promoted in its place
+const noSwitchoverTargetReason = "The primary needs to be updated, but no replica is ready, " +
+	"up to date, and not excluded from the rollout to be promoted"
+
 // errRolloutDelayed is raised the a pod rollout have been delayed because
 // of the operator configuration
 var errRolloutDelayed = errors.New("pod rollout delayed")
@@ -53,6 +66,8 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 	cluster *apiv1.Cluster,
 	podList *postgres.PostgresqlStatusList,
 ) (bool, error) {
+	contextLogger := log.FromContext(ctx)
+
 	// The following code works under the assumption that podList.Items list is ordered
 	// by lag (primary first)
 
@@ -76,6 +91,15 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 			continue
 		}
 
+		// Skipped instances are excluded before asking the rollout manager,
+		// so they never claim the rollout slot
+		if isInstanceRolloutSkipped(cluster, postgresqlStatus.Pod) {
+			contextLogger.Info("Skipping the rollout of the instance as requested",
+				"instance", postgresqlStatus.Pod.Name,
+				"reason", podRollout.reason)
+			continue
+		}
+
 		managerResult := r.rolloutManager.CoordinateRollout(client.ObjectKeyFromObject(cluster), postgresqlStatus.Pod.Name)
 		if !managerResult.RolloutAllowed {
 			r.Recorder.Eventf(
@@ -116,6 +140,13 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 		return false, nil
 	}
 
+	if isInstanceRolloutSkipped(cluster, primaryPostgresqlStatus.Pod) {
+		contextLogger.Info("Skipping the rollout of the primary instance as requested",
+			"instance", primaryPostgresqlStatus.Pod.Name,
+			"reason", podRollout.reason)
+		return false, nil
+	}
+
 	// if the primary instance is marked for restart due to hot standby sensitive parameter decrease,
 	// it should be restarted by the instance manager itself
 	if primaryPostgresqlStatus.PendingRestartForDecrease {
@@ -141,6 +172,14 @@ func (r *ClusterReconciler) rolloutRequiredInstances(
 		podRollout.canBeInPlace, podRollout.primaryForceRecreate, podRollout.reason)
 }
 
+// isInstanceRolloutSkipped checks whether the user asked to exclude the
+// instance from the rolling updates, either annotating its Pod or listing
+// it in the cluster annotations. Skipped instances are still part of the
+// cluster and are counted as such
+func isInstanceRolloutSkipped(cluster *apiv1.Cluster, pod *corev1.Pod) bool {
+	return utils.IsRolloutSkipped(&pod.ObjectMeta) || cluster.IsInstanceRolloutSkipped(pod.Name)
+}
+
 func (r *ClusterReconciler) updatePrimaryPod(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
@@ -161,11 +200,15 @@ func (r *ClusterReconciler) updatePrimaryPod(
 		if err != nil {
 			return false, err
 		}
+		if err := r.setPrimaryUpdatePending(ctx, cluster, primaryPod.Name, reason); err != nil {
+			return false, err
+		}
 
 		return true, nil
 	}
 
-	if cluster.GetPrimaryUpdateMethod() == apiv1.PrimaryUpdateMethodRestart || forceRecreate {
+	if cluster.GetPrimaryUpdateMethod() == apiv1.PrimaryUpdateMethodRestart || forceRecreate ||
+		isInPlaceRestartRequested(cluster, &primaryPod) {
 		if inPlacePossible {
 			// In-place restart is possible
 			if err := r.updateRestartAnnotation(ctx, cluster, primaryPod); err != nil {
@@ -189,17 +232,15 @@ func (r *ClusterReconciler) updatePrimaryPod(
 
 	// if the cluster has more than one instance, we should trigger a switchover before upgrading
 	if cluster.Status.Instances > 1 && len(podList.Items) > 1 {
-		// If this is not a replica cluster, podList.Items[1] is the first replica,
-		// as the pod list is sorted in the same order we use for switchover / failover.
-		// This may not be true for replica clusters, where every instance is a replica
-		// from the PostgreSQL point-of-view.
-		targetInstance := podList.Items[1]
-
-		// If this is a replica cluster, the target primary we chose may be
-		// the one we're trying to upgrade, as the list isn't sorted. In
-		// this case, we promote the first instance of the list
-		if targetInstance.Pod.Name == primaryPod.Name {
-			targetInstance = podList.Items[0]
+		targetInstance := getSwitchoverTarget(ctx, cluster, podList, primaryPod.Name)
+		if targetInstance == nil {
+			contextLogger.Info(
+				"no replica is ready, up to date, and not excluded from the rollout, "+
+					"interrupting the primaryPodUpdate",
+				"updateReason", reason,
+				"currentPrimary", primaryPod.Name,
+			)
+			return false, errNoSwitchoverTarget
 		}
 
 		// Before promoting a replica, the instance manager will wait for the WAL receiver
@@ -239,6 +280,90 @@ func (r *ClusterReconciler) updatePrimaryPod(
 	return true, r.upgradePod(ctx, cluster, &primaryPod, reason)
 }
 
+// getSwitchoverTarget chooses the replica to promote before updating the
+// primary. If this is not a replica cluster, the pod list is sorted in the
+// same order we use for switchover / failover, so the first eligible replica
+// is the best one. This may not be true for replica clusters, where every
+// instance is a replica from the PostgreSQL point-of-view.
+// A replica is eligible when it is ready, not fenced, not excluded from the
+// rollout, and doesn't need to be rolled out itself
+func getSwitchoverTarget(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	podList *postgres.PostgresqlStatusList,
+	primaryName string,
+) *postgres.PostgresqlStatus {
+	for idx := range podList.Items {
+		instance := &podList.Items[idx]
+		switch {
+		case instance.Pod == nil || instance.Pod.Name == primaryName:
+		case instance.Error != nil || !instance.IsPodReady:
+		case cluster.IsInstanceFenced(instance.Pod.Name):
+		case isInstanceRolloutSkipped(cluster, instance.Pod):
+		case isInstanceNeedingRollout(ctx, *instance, cluster).required:
+		default:
+			return instance
+		}
+	}
+
+	return nil
+}
+
+// isInPlaceRestartRequested checks whether the user requested to restart
+// the primary instance without a switchover, and the primary instance
+// has not been restarted yet
+func isInPlaceRestartRequested(cluster *apiv1.Cluster, primaryPod *corev1.Pod) bool {
+	clusterRestart := cluster.Annotations[utils.ClusterRestartAnnotationName]
+	if clusterRestart == "" || cluster.Annotations[utils.InPlaceRestartAnnotationName] != clusterRestart {
+		return false
+	}
+
+	return primaryPod.Annotations[utils.ClusterRestartAnnotationName] != clusterRestart
+}
+
+// setPrimaryUpdatePending records in the cluster status that the update of
+// the primary instance is waiting for a user-initiated switchover or restart.
+// The condition transition time tells how long the update has been pending
+func (r *ClusterReconciler) setPrimaryUpdatePending(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	primaryName string,
+	reason rolloutReason,
+) error {
+	if !meta.IsStatusConditionTrue(cluster.Status.Conditions, string(apiv1.ConditionPrimaryUpdatePending)) {
+		r.Recorder.Eventf(cluster, "Normal", "PrimaryUpdatePending",
+			"The primary instance %s needs to be updated (%s), waiting for a supervised switchover",
+			primaryName, reason)
+	}
+
+	condition := metav1.Condition{
+		Type:   string(apiv1.ConditionPrimaryUpdatePending),
+		Status: metav1.ConditionTrue,
+		Reason: string(apiv1.ConditionReasonWaitingForSupervisedUpdate),
+		Message: fmt.Sprintf("The primary instance %s needs to be updated "+
+			"(%s): the primary update strategy is supervised, "+
+			"a switchover or a restart of the primary must be requested by the user",
+			primaryName, reason),
+	}
+	return resourcestatus.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster, condition)
+}
+
+// clearPrimaryUpdatePending records in the cluster status that the primary
+// instance doesn't need to be updated anymore
+func (r *ClusterReconciler) clearPrimaryUpdatePending(ctx context.Context, cluster *apiv1.Cluster) error {
+	if !meta.IsStatusConditionTrue(cluster.Status.Conditions, string(apiv1.ConditionPrimaryUpdatePending)) {
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:    string(apiv1.ConditionPrimaryUpdatePending),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(apiv1.ConditionReasonPrimaryUpdated),
+		Message: "The primary instance is up to date",
+	}
+	return resourcestatus.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster, condition)
+}
+
 func (r *ClusterReconciler) updateRestartAnnotation(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
@@ -789,3 +914,16 @@ func (r *ClusterReconciler) upgradeInstanceManager(
 
 	return nil
 }
+
+// reportNoSwitchoverTarget tells the user that the update of the primary is
+// postponed because no replica can be promoted, by setting the phase of the
+// cluster. The event is raised only when the cluster enters this state, and
+// not at every retry
+func (r *ClusterReconciler) reportNoSwitchoverTarget(ctx context.Context, cluster *apiv1.Cluster) error {
+	if cluster.Status.Phase == apiv1.PhaseUpgradeDelayed && cluster.Status.PhaseReason == noSwitchoverTargetReason {
+		return nil
+	}
+
+	r.Recorder.Event(cluster, "Warning", "NoSwitchoverTarget", noSwitchoverTargetReason)
+	return r.RegisterPhase(ctx, cluster, apiv1.PhaseUpgradeDelayed, noSwitchoverTargetReason)
+}
@@ -18,13 +18,18 @@ package controller
 
 import (
 	"encoding/json"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	rolloutManager "github.com/cloudnative-pg/cloudnative-pg/internal/controller/rollout"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
@@ -799,3 +804,215 @@ var _ = Describe("Cluster upgrade with podSpec reconciliation disabled", func()
 		Expect(rollout.reason).To(BeEmpty())
 	})
 })
+
+var _ = Describe("Skipping instances during the rollout", func() {
+	var cluster *apiv1.Cluster
+	var podList *postgres.PostgresqlStatusList
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "cluster-example",
+				Namespace:   "default",
+				Annotations: map[string]string{},
+			},
+			Spec: apiv1.ClusterSpec{
+				SchedulerName: "default-scheduler",
+			},
+			Status: apiv1.ClusterStatus{
+				CurrentPrimary: "cluster-example-1",
+			},
+		}
+
+		podList = &postgres.PostgresqlStatusList{}
+		for idx := 1; idx <= 3; idx++ {
+			pod, err := specs.PodWithExistingStorage(*cluster, idx)
+			Expect(err).ToNot(HaveOccurred())
+			podList.Items = append(podList.Items, postgres.PostgresqlStatus{
+				Pod:            pod,
+				IsPodReady:     true,
+				ExecutableHash: "test_hash",
+			})
+		}
+
+		// Every instance now needs to be rolled out
+		cluster.Spec.SchedulerName = "new-scheduler"
+		configuration.Current = configuration.NewConfiguration()
+	})
+
+	It("detects the instances skipped via the Pod or the Cluster annotations", func() {
+		podList.Items[1].Pod.Annotations[utils.SkipRolloutAnnotationName] = "enabled"
+		cluster.Annotations[utils.SkipRolloutInstancesAnnotationName] = "cluster-example-3"
+
+		Expect(isInstanceRolloutSkipped(cluster, podList.Items[0].Pod)).To(BeFalse())
+		Expect(isInstanceRolloutSkipped(cluster, podList.Items[1].Pod)).To(BeTrue())
+		Expect(isInstanceRolloutSkipped(cluster, podList.Items[2].Pod)).To(BeTrue())
+	})
+
+	It("never claims the rollout slot for skipped instances", func(ctx SpecContext) {
+		cluster.Annotations[utils.SkipRolloutInstancesAnnotationName] = "cluster-example-1, cluster-example-2"
+		podList.Items[2].Pod.Annotations[utils.SkipRolloutAnnotationName] = "enabled"
+
+		r := &ClusterReconciler{
+			rolloutManager: rolloutManager.New(time.Hour, time.Hour),
+		}
+		done, err := r.rolloutRequiredInstances(ctx, cluster, podList)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(done).To(BeFalse())
+
+		// The slot is still free, so the rollout of another cluster is
+		// allowed even with a long delay between clusters
+		result := r.rolloutManager.CoordinateRollout(
+			client.ObjectKey{Namespace: "default", Name: "another-cluster"}, "another-cluster-1")
+		Expect(result.RolloutAllowed).To(BeTrue())
+	})
+
+	It("never switches over to a skipped, not ready, or outdated replica", func(ctx SpecContext) {
+		// The replicas are up to date
+		cluster.Spec.SchedulerName = "default-scheduler"
+		Expect(getSwitchoverTarget(ctx, cluster, podList, "cluster-example-1").Pod.Name).
+			To(Equal("cluster-example-2"))
+
+		podList.Items[1].Pod.Annotations[utils.SkipRolloutAnnotationName] = "enabled"
+		Expect(getSwitchoverTarget(ctx, cluster, podList, "cluster-example-1").Pod.Name).
+			To(Equal("cluster-example-3"))
+
+		podList.Items[2].PendingRestart = true
+		Expect(getSwitchoverTarget(ctx, cluster, podList, "cluster-example-1")).To(BeNil())
+
+		podList.Items[2].PendingRestart = false
+		podList.Items[2].IsPodReady = false
+		Expect(getSwitchoverTarget(ctx, cluster, podList, "cluster-example-1")).To(BeNil())
+	})
+
+	It("requeues the update of the primary when no replica can be promoted", func(ctx SpecContext) {
+		cluster.Status.Instances = 3
+		cluster.Spec.PrimaryUpdateMethod = apiv1.PrimaryUpdateMethodSwitchover
+
+		r := &ClusterReconciler{}
+		done, err := r.updatePrimaryPod(ctx, cluster, podList, *podList.Items[0].Pod,
+			false, false, "the scheduler changed")
+		Expect(err).To(MatchError(errNoSwitchoverTarget))
+		Expect(done).To(BeFalse())
+	})
+
+	It("reports only once that no replica can be promoted", func(ctx SpecContext) {
+		env := buildTestEnvironment()
+		recorder := record.NewFakeRecorder(10)
+		env.clusterReconciler.Recorder = recorder
+		fakeCluster := newFakeCNPGCluster(env.client, newFakeNamespace(env.client))
+
+		for range 3 {
+			Expect(env.clusterReconciler.reportNoSwitchoverTarget(ctx, fakeCluster)).To(Succeed())
+		}
+
+		var updatedCluster apiv1.Cluster
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(fakeCluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Status.Phase).To(Equal(apiv1.PhaseUpgradeDelayed))
+		Expect(updatedCluster.Status.PhaseReason).To(Equal(noSwitchoverTargetReason))
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring("NoSwitchoverTarget"))
+	})
+})
+
+var _ = Describe("Supervised primary update", func() {
+	var env *testingEnvironment
+
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+	})
+
+	It("records the pending update of the primary until it is completed", func(ctx SpecContext) {
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Spec.PrimaryUpdateStrategy = apiv1.PrimaryUpdateStrategySupervised
+			cluster.Status.Image = "postgres:18.1"
+		})
+		instances := generateFakeClusterPods(env.client, cluster, true)
+		podList := &postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{Pod: &instances[0], IsPodReady: true},
+				{Pod: &instances[1], IsPodReady: true},
+			},
+		}
+
+		By("waiting for the user to update the primary", func() {
+			done, err := env.clusterReconciler.updatePrimaryPod(ctx, cluster, podList, instances[0],
+				false, false, "the image name changed")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(done).To(BeTrue())
+			Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseWaitingForUser))
+
+			condition := meta.FindStatusCondition(cluster.Status.Conditions,
+				string(apiv1.ConditionPrimaryUpdatePending))
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("the image name changed"))
+		})
+
+		By("clearing the condition once the primary is up to date", func() {
+			Expect(env.clusterReconciler.clearPrimaryUpdatePending(ctx, cluster)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(cluster.Status.Conditions,
+				string(apiv1.ConditionPrimaryUpdatePending))).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("In-place restart of the primary", func() {
+	var env *testingEnvironment
+
+	BeforeEach(func() {
+		env = buildTestEnvironment()
+	})
+
+	It("detects when an in-place restart is requested", func() {
+		cluster := &apiv1.Cluster{}
+		pod := &corev1.Pod{}
+		Expect(isInPlaceRestartRequested(cluster, pod)).To(BeFalse())
+
+		cluster.Annotations = map[string]string{
+			utils.ClusterRestartAnnotationName: "2024-01-01T00:00:00Z",
+		}
+		Expect(isInPlaceRestartRequested(cluster, pod)).To(BeFalse())
+
+		cluster.Annotations[utils.InPlaceRestartAnnotationName] = "2023-01-01T00:00:00Z"
+		Expect(isInPlaceRestartRequested(cluster, pod)).To(BeFalse())
+
+		cluster.Annotations[utils.InPlaceRestartAnnotationName] = "2024-01-01T00:00:00Z"
+		Expect(isInPlaceRestartRequested(cluster, pod)).To(BeTrue())
+
+		pod.Annotations = map[string]string{
+			utils.ClusterRestartAnnotationName: "2024-01-01T00:00:00Z",
+		}
+		Expect(isInPlaceRestartRequested(cluster, pod)).To(BeFalse())
+	})
+
+	It("restarts the primary without a switchover", func(ctx SpecContext) {
+		namespace := newFakeNamespace(env.client)
+		cluster := newFakeCNPGCluster(env.client, namespace, func(cluster *apiv1.Cluster) {
+			cluster.Annotations = map[string]string{
+				utils.ClusterRestartAnnotationName: "2024-01-01T00:00:00Z",
+				utils.InPlaceRestartAnnotationName: "2024-01-01T00:00:00Z",
+			}
+		})
+		instances := generateFakeClusterPods(env.client, cluster, true)
+		podList := &postgres.PostgresqlStatusList{
+			Items: []postgres.PostgresqlStatus{
+				{Pod: &instances[0], IsPodReady: true, IsPrimary: true},
+				{Pod: &instances[1], IsPodReady: true, IsWalReceiverActive: true},
+			},
+		}
+
+		done, err := env.clusterReconciler.updatePrimaryPod(ctx, cluster, podList, instances[0],
+			true, false, "the deployment has been restarted")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(done).To(BeTrue())
+		Expect(cluster.Status.Phase).To(Equal(apiv1.PhaseInplacePrimaryRestart))
+		Expect(cluster.Status.TargetPrimary).ToNot(Equal(instances[1].Name))
+
+		var primaryPod corev1.Pod
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(&instances[0]), &primaryPod)).To(Succeed())
+		Expect(primaryPod.Annotations).To(HaveKeyWithValue(utils.ClusterRestartAnnotationName,
+			"2024-01-01T00:00:00Z"))
+	})
+})
@@ -25,10 +25,13 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+	resourcestatus "github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
@@ -41,6 +44,14 @@ var ErrWalReceiversRunning = fmt.Errorf("wal receivers are still running")
 // elapsed yet
 var ErrWaitingOnFailOverDelay = fmt.Errorf("current primary isn't healthy, waiting for the delay before triggering a failover") //nolint: lll
 
+// evictedPrimarySwitchoverTimeout is the maximum time the switchover from an
+// evicted primary waits for the rollout slot, since the beginning of the eviction
+const evictedPrimarySwitchoverTimeout = 30 * time.Second
+
+// ErrFailoverPaused is raised when the primary server isn't healthy, but a new one
+// can't be elected because the automatic failover has been paused by the user
+var ErrFailoverPaused = fmt.Errorf("current primary isn't healthy, but the automatic failover is paused")
+
 // reconcileTargetPrimaryFromPods sets the name of the target primary from the Pods status if needed
 // this function will return the name of the new primary selected for promotion.
 // Returns the name of the primary if any changes was made and any error encountered.
@@ -59,11 +70,25 @@ func (r *ClusterReconciler) reconcileTargetPrimaryFromPods(
 		return "", nil
 	}
 
-	// First step: check if the current primary is running in an unschedulable node
-	// and issue a switchover if that's the case
+	// First step: check if the current primary is being evicted or is running
+	// in an unschedulable node and issue a switchover if that's the case
 	if primary := status.Items[0]; (primary.IsPrimary || (cluster.IsReplica() && primary.IsPodReady)) &&
 		primary.Pod.Name == cluster.Status.CurrentPrimary &&
 		cluster.Status.TargetPrimary == cluster.Status.CurrentPrimary {
+		evictionReason, evictedSince, err := r.getInstanceEvictionReason(ctx, primary.Pod)
+		if err != nil {
+			contextLogger.Error(err, "while checking if current primary is being evicted")
+			// in case of error it's better to proceed with the normal target primary reconciliation
+		} else if evictionReason != "" {
+			selectedPrimary, err := r.switchoverFromEvictedPrimary(
+				ctx, cluster, status, &primary, evictionReason, evictedSince)
+			if selectedPrimary != "" || err != nil {
+				return selectedPrimary, err
+			}
+		} else {
+			r.evictionEvents.Delete(client.ObjectKeyFromObject(cluster))
+		}
+
 		isPrimaryOnUnschedulableNode, err := r.isNodeUnschedulable(ctx, primary.Node)
 		if err != nil {
 			contextLogger.Error(err, "while checking if current primary is on an unschedulable node")
@@ -103,6 +128,12 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForNonReplicaCluster(
 		return "", nil
 	}
 
+	// A failover that is already in progress can't be paused, as the former
+	// primary may have already been requested to shut down
+	if cluster.Status.TargetPrimary == cluster.Status.CurrentPrimary && utils.IsFailoverDisabled(&cluster.ObjectMeta) {
+		return "", r.pauseFailover(ctx, cluster)
+	}
+
 	if err := r.enforceFailoverDelay(ctx, cluster); err != nil {
 		return "", err
 	}
@@ -257,6 +288,157 @@ func (r *ClusterReconciler) setPrimaryOnSchedulableNode(
 	return "", nil
 }
 
+// getInstanceEvictionReason checks whether the Pod of an instance is going to
+// be evicted, returning the reason and the time the eviction started if that's
+// the case. This happens when the eviction of the Pod has been accepted, or when
+// its node has a NoExecute taint the Pod doesn't tolerate, e.g. while the node
+// is drained. The returned time is zero when Kubernetes didn't record it
+func (r *ClusterReconciler) getInstanceEvictionReason(
+	ctx context.Context,
+	pod *corev1.Pod,
+) (string, time.Time, error) {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.DisruptionTarget && condition.Status == corev1.ConditionTrue {
+			return fmt.Sprintf("pod is a disruption target: %s", condition.Reason),
+				condition.LastTransitionTime.Time, nil
+		}
+	}
+
+	if pod.Spec.NodeName == "" {
+		return "", time.Time{}, nil
+	}
+
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, &node); err != nil {
+		return "", time.Time{}, err
+	}
+
+	for idx := range node.Spec.Taints {
+		taint := &node.Spec.Taints[idx]
+		if taint.Effect != corev1.TaintEffectNoExecute {
+			continue
+		}
+		tolerated := false
+		for _, toleration := range pod.Spec.Tolerations {
+			if toleration.ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			var taintedSince time.Time
+			if taint.TimeAdded != nil {
+				taintedSince = taint.TimeAdded.Time
+			}
+			return fmt.Sprintf("node %s has the %s:%s taint", node.Name, taint.Key, taint.Effect),
+				taintedSince, nil
+		}
+	}
+
+	return "", time.Time{}, nil
+}
+
+// recordEvictionEvent raises an event about the switchover from the evicted
+// primary of a cluster, unless it was already raised for the same primary.
+// This avoids raising the same event at every reconciliation loop while
+// waiting for the eviction to complete
+func (r *ClusterReconciler) recordEvictionEvent(
+	cluster *apiv1.Cluster,
+	primaryName, eventType, reason, messageFmt string,
+	args ...interface{},
+) {
+	state := primaryName + "/" + reason
+	if previousState, loaded := r.evictionEvents.Swap(client.ObjectKeyFromObject(cluster), state); loaded &&
+		previousState == state {
+		return
+	}
+
+	r.Recorder.Eventf(cluster, eventType, reason, messageFmt, args...)
+}
+
+// switchoverFromEvictedPrimary proactively switches over to a healthy standby
+// when the Pod of the primary is going to be evicted, instead of waiting for
+// the primary to go down and react with a failover. Unlike the switchover away
+// from an unschedulable node, it doesn't wait for the other instances to be
+// moved. The switchover claims the rollout slot, as any other planned restart
+// of the primary, to avoid contention with the rolling updates, but it doesn't
+// wait for it longer than evictedPrimarySwitchoverTimeout since the beginning
+// of the eviction, as the primary is going down anyway.
+// An empty string is returned when there's no safe target for the switchover
+func (r *ClusterReconciler) switchoverFromEvictedPrimary(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+	status postgres.PostgresqlStatusList,
+	primary *postgres.PostgresqlStatus,
+	evictionReason string,
+	evictedSince time.Time,
+) (string, error) {
+	contextLogger := log.FromContext(ctx).WithValues(
+		"currentPrimary", primary.Pod.Name,
+		"currentPrimaryNode", primary.Node,
+		"evictionReason", evictionReason)
+
+	// The instances are sorted by their status, so the first valid
+	// candidate is the most advanced one
+	var target *postgres.PostgresqlStatus
+	for idx := range status.Items {
+		candidate := &status.Items[idx]
+		if candidate.Pod.Name == primary.Pod.Name || candidate.Node == primary.Node ||
+			!utils.IsPodReady(*candidate.Pod) || !candidate.IsWalReceiverActive ||
+			cluster.IsInstanceFenced(candidate.Pod.Name) {
+			continue
+		}
+
+		if unschedulable, err := r.isNodeUnschedulable(ctx, candidate.Node); err != nil || unschedulable {
+			continue
+		}
+
+		if reason, _, err := r.getInstanceEvictionReason(ctx, candidate.Pod); err != nil || reason != "" {
+			continue
+		}
+
+		target = candidate
+		break
+	}
+
+	if target == nil {
+		contextLogger.Info("Current primary is being evicted, but there is no safe target for a switchover")
+		status.LogStatus(ctx)
+		r.recordEvictionEvent(cluster, primary.Pod.Name, "Warning", "NoSwitchoverTarget",
+			"Current primary %v is being evicted, but there is no healthy standby to switch over to",
+			primary.Pod.Name)
+		return "", nil
+	}
+
+	if r.rolloutManager != nil {
+		managerResult := r.rolloutManager.CoordinateRollout(client.ObjectKeyFromObject(cluster), primary.Pod.Name)
+		switch {
+		case managerResult.RolloutAllowed:
+		case r.now().Sub(evictedSince) < evictedPrimarySwitchoverTimeout:
+			r.recordEvictionEvent(cluster, primary.Pod.Name, "Normal", "SwitchoverDelayed",
+				"Switchover from the evicted primary %v have been delayed for %s",
+				primary.Pod.Name, managerResult.TimeToWait.String())
+			return "", errRolloutDelayed
+		default:
+			contextLogger.Info("Stopped waiting for the rollout slot, as the primary is being evicted since too long",
+				"evictedSince", evictedSince, "timeout", evictedPrimarySwitchoverTimeout)
+		}
+	}
+
+	contextLogger.Info("Current primary is being evicted, triggering a switchover",
+		"targetPrimary", target.Pod.Name, "targetPrimaryNode", target.Node)
+	status.LogStatus(ctx)
+	r.Recorder.Eventf(cluster, "Normal", "SwitchingOver",
+		"Current primary %v is being evicted, switching over to %v",
+		primary.Pod.Name, target.Pod.Name)
+	if err := r.RegisterPhase(ctx, cluster, apiv1.PhaseSwitchover,
+		fmt.Sprintf("Switching over to %v, because primary instance is being evicted (%s)",
+			target.Pod.Name, evictionReason)); err != nil {
+		return "", err
+	}
+	return target.Pod.Name, r.setPrimaryInstance(ctx, cluster, target.Pod.Name)
+}
+
 // reconcileTargetPrimaryForReplicaCluster sets the name of the target designated
 // primary from the Pods status if needed this function will return the name of the
 // new primary selected for promotion
@@ -278,6 +460,10 @@ func (r *ClusterReconciler) reconcileTargetPrimaryForReplicaCluster(
 		}
 	}
 
+	if utils.IsFailoverDisabled(&cluster.ObjectMeta) {
+		return "", r.pauseFailover(ctx, cluster)
+	}
+
 	if err := r.enforceFailoverDelay(ctx, cluster); err != nil {
 		return "", err
 	}
@@ -371,6 +557,46 @@ func (r *ClusterReconciler) evaluateFailoverDelay(
 	return nil
 }
 
+// pauseFailover records, in the status of the cluster, that a failover
+// has been withheld because the automatic failover is paused, and returns
+// ErrFailoverPaused
+func (r *ClusterReconciler) pauseFailover(ctx context.Context, cluster *apiv1.Cluster) error {
+	if !meta.IsStatusConditionTrue(cluster.Status.Conditions, string(apiv1.ConditionFailoverPaused)) {
+		r.Recorder.Eventf(cluster, "Warning", "FailoverPaused",
+			"Current primary %v isn't healthy, but the automatic failover is paused",
+			cluster.Status.CurrentPrimary)
+	}
+
+	condition := metav1.Condition{
+		Type:   string(apiv1.ConditionFailoverPaused),
+		Status: metav1.ConditionTrue,
+		Reason: string(apiv1.ConditionReasonFailoverPaused),
+		Message: fmt.Sprintf("Current primary %v isn't healthy, but the automatic failover is paused "+
+			"by the %v annotation", cluster.Status.CurrentPrimary, utils.FailoverAnnotationName),
+	}
+	if err := resourcestatus.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster, condition); err != nil {
+		return err
+	}
+
+	return ErrFailoverPaused
+}
+
+// resumeFailover clears the condition recording a failover withheld
+// because the automatic failover was paused
+func (r *ClusterReconciler) resumeFailover(ctx context.Context, cluster *apiv1.Cluster) error {
+	if !meta.IsStatusConditionTrue(cluster.Status.Conditions, string(apiv1.ConditionFailoverPaused)) {
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:    string(apiv1.ConditionFailoverPaused),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(apiv1.ConditionReasonFailoverResumed),
+		Message: "The automatic failover is not paused",
+	}
+	return resourcestatus.PatchConditionsWithOptimisticLock(ctx, r.Client, cluster, condition)
+}
+
 // findDeletableInstance get the Pod who is supposed to be deleted when the cluster is scaled down
 func findDeletableInstance(cluster *apiv1.Cluster, instances []corev1.Pod) string {
 	resultIdx := -1
@@ -17,10 +17,16 @@ limitations under the License.
 package controller
 
 import (
+	"fmt"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	rolloutManager "github.com/cloudnative-pg/cloudnative-pg/internal/controller/rollout"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
@@ -151,3 +157,146 @@ var _ = Describe("Check pods not on primary node", func() {
 		Expect(GetPodsNotOnPrimaryNode(statusList2, &statusList2.Items[0]).Items).ToNot(BeEmpty())
 	})
 })
+
+var _ = Describe("Switchover from an evicted primary", func() {
+	var env *testingEnvironment
+	var cluster *apiv1.Cluster
+	var instances []corev1.Pod
+	var statusList postgres.PostgresqlStatusList
+
+	noExecuteTaint := corev1.Taint{Key: "example.com/drain", Effect: corev1.TaintEffectNoExecute}
+
+	createNode := func(ctx SpecContext, name string, mutators ...func(*corev1.Node)) {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		for _, mutator := range mutators {
+			mutator(node)
+		}
+		Expect(env.client.Create(ctx, node)).To(Succeed())
+	}
+
+	BeforeEach(func(ctx SpecContext) {
+		env = buildTestEnvironment()
+		env.clusterReconciler.rolloutManager = rolloutManager.New(0, 0)
+
+		namespace := newFakeNamespace(env.client)
+		cluster = newFakeCNPGCluster(env.client, namespace)
+		instances = generateFakeClusterPods(env.client, cluster, true)
+
+		statusList = postgres.PostgresqlStatusList{}
+		for idx := range instances {
+			nodeName := fmt.Sprintf("%s-node-%d", namespace, idx+1)
+			instances[idx].Spec.NodeName = nodeName
+			statusList.Items = append(statusList.Items, postgres.PostgresqlStatus{
+				Pod:                 &instances[idx],
+				Node:                nodeName,
+				IsPodReady:          true,
+				IsPrimary:           idx == 0,
+				IsWalReceiverActive: idx != 0,
+			})
+		}
+		createNode(ctx, instances[0].Spec.NodeName, func(node *corev1.Node) {
+			node.Spec.Taints = []corev1.Taint{noExecuteTaint}
+		})
+		createNode(ctx, instances[1].Spec.NodeName, func(node *corev1.Node) {
+			node.Spec.Unschedulable = true
+		})
+		createNode(ctx, instances[2].Spec.NodeName)
+
+		cluster.Status.CurrentPrimary = instances[0].Name
+		cluster.Status.TargetPrimary = instances[0].Name
+	})
+
+	Context("detecting the eviction of an instance", func() {
+		It("detects the Pods that are disruption targets", func(ctx SpecContext) {
+			evictedSince := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+			pod := instances[2].DeepCopy()
+			pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+				Type:               corev1.DisruptionTarget,
+				Status:             corev1.ConditionTrue,
+				Reason:             "EvictionByEvictionAPI",
+				LastTransitionTime: evictedSince,
+			})
+			reason, since, err := env.clusterReconciler.getInstanceEvictionReason(ctx, pod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reason).To(ContainSubstring("EvictionByEvictionAPI"))
+			Expect(since).To(Equal(evictedSince.Time))
+		})
+
+		It("detects the Pods on a node with a NoExecute taint", func(ctx SpecContext) {
+			Expect(env.clusterReconciler.getInstanceEvictionReason(ctx, &instances[0])).
+				To(ContainSubstring("example.com/drain"))
+		})
+
+		It("ignores the NoExecute taints tolerated by the Pod", func(ctx SpecContext) {
+			pod := instances[0].DeepCopy()
+			pod.Spec.Tolerations = []corev1.Toleration{
+				{Key: noExecuteTaint.Key, Operator: corev1.TolerationOpExists},
+			}
+			Expect(env.clusterReconciler.getInstanceEvictionReason(ctx, pod)).To(BeEmpty())
+		})
+
+		It("ignores the cordoned nodes", func(ctx SpecContext) {
+			Expect(env.clusterReconciler.getInstanceEvictionReason(ctx, &instances[1])).To(BeEmpty())
+		})
+	})
+
+	It("switches over to the first healthy standby on a schedulable node", func(ctx SpecContext) {
+		selectedPrimary, err := env.clusterReconciler.reconcileTargetPrimaryFromPods(
+			ctx, cluster, statusList, &managedResources{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(selectedPrimary).To(Equal(instances[2].Name))
+
+		var updatedCluster apiv1.Cluster
+		Expect(env.client.Get(ctx, client.ObjectKeyFromObject(cluster), &updatedCluster)).To(Succeed())
+		Expect(updatedCluster.Status.TargetPrimary).To(Equal(instances[2].Name))
+		Expect(updatedCluster.Status.Phase).To(Equal(apiv1.PhaseSwitchover))
+	})
+
+	It("doesn't switch over when there is no safe target", func(ctx SpecContext) {
+		statusList.Items[2].IsWalReceiverActive = false
+
+		selectedPrimary, err := env.clusterReconciler.switchoverFromEvictedPrimary(
+			ctx, cluster, statusList, &statusList.Items[0], "testing", time.Now())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(selectedPrimary).To(BeEmpty())
+		Expect(cluster.Status.TargetPrimary).To(Equal(instances[0].Name))
+	})
+
+	It("raises the NoSwitchoverTarget event only once for the same primary", func(ctx SpecContext) {
+		recorder := record.NewFakeRecorder(10)
+		env.clusterReconciler.Recorder = recorder
+		statusList.Items[2].IsWalReceiverActive = false
+
+		for range 3 {
+			_, err := env.clusterReconciler.switchoverFromEvictedPrimary(
+				ctx, cluster, statusList, &statusList.Items[0], "testing", time.Now())
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring("NoSwitchoverTarget"))
+	})
+
+	It("waits for the rollout slot before switching over", func(ctx SpecContext) {
+		env.clusterReconciler.rolloutManager = rolloutManager.New(time.Hour, time.Hour)
+		Expect(env.clusterReconciler.rolloutManager.CoordinateRollout(
+			client.ObjectKey{Namespace: "other", Name: "other"}, "other-1").RolloutAllowed).To(BeTrue())
+
+		selectedPrimary, err := env.clusterReconciler.switchoverFromEvictedPrimary(
+			ctx, cluster, statusList, &statusList.Items[0], "testing", time.Now())
+		Expect(err).To(MatchError(errRolloutDelayed))
+		Expect(selectedPrimary).To(BeEmpty())
+		Expect(cluster.Status.TargetPrimary).To(Equal(instances[0].Name))
+	})
+
+	It("stops waiting for the rollout slot when the eviction started too long ago", func(ctx SpecContext) {
+		env.clusterReconciler.rolloutManager = rolloutManager.New(time.Hour, time.Hour)
+		Expect(env.clusterReconciler.rolloutManager.CoordinateRollout(
+			client.ObjectKey{Namespace: "other", Name: "other"}, "other-1").RolloutAllowed).To(BeTrue())
+
+		selectedPrimary, err := env.clusterReconciler.switchoverFromEvictedPrimary(
+			ctx, cluster, statusList, &statusList.Items[0], "testing",
+			time.Now().Add(-2*evictedPrimarySwitchoverTimeout))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(selectedPrimary).To(Equal(instances[2].Name))
+	})
+})
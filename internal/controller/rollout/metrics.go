@@ -0,0 +1,122 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// deniedReasonClusterDelay is the reason used when a rollout is denied
+	// because another cluster has been rolled out recently
+	deniedReasonClusterDelay = "cluster_delay"
+
+	// deniedReasonInstanceDelay is the reason used when a rollout is denied
+	// because another instance of the same cluster has been rolled out recently
+	deniedReasonInstanceDelay = "instance_delay"
+
+	// waiterExpiration is how long a cluster whose rollout has been denied
+	// is still considered waiting, in addition to the rollout delays, without
+	// asking again for the slot. The operator asks again when the time to wait
+	// has elapsed, so this only prevents clusters that are not rolling out
+	// anymore from being counted forever
+	waiterExpiration = time.Minute
+)
+
+// Metrics are the Prometheus metrics describing the contention on
+// the rollout slot
+type Metrics struct {
+	slotHeldSeconds prometheus.Gauge
+	denied          *prometheus.CounterVec
+	waiters         prometheus.Gauge
+}
+
+// NewMetrics creates the metrics to be updated by the rollout manager
+func NewMetrics() *Metrics {
+	const subsystem = "rollout"
+	return &Metrics{
+		slotHeldSeconds: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "cnpg",
+			Subsystem: subsystem,
+			Name:      "slot_held_seconds",
+			Help:      "Seconds elapsed since the rollout slot has been granted to the latest instance",
+		}),
+		denied: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "cnpg",
+			Subsystem: subsystem,
+			Name:      "denied_total",
+			Help:      "Number of rollouts denied by the rollout manager, by reason",
+		}, []string{"reason"}),
+		waiters: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "cnpg",
+			Subsystem: subsystem,
+			Name:      "waiters",
+			Help:      "Number of clusters waiting for the rollout slot",
+		}),
+	}
+}
+
+// Collectors returns the collectors to be registered in a
+// Prometheus registry
+func (metrics *Metrics) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		metrics.slotHeldSeconds,
+		metrics.denied,
+		metrics.waiters,
+	}
+}
+
+// Option is a configuration option for the rollout manager
+type Option func(manager *Manager)
+
+// WithMetrics makes the rollout manager update the passed metrics
+// every time a rollout is coordinated
+func WithMetrics(metrics *Metrics) Option {
+	return func(manager *Manager) {
+		manager.metrics = metrics
+		manager.waiters = make(map[client.ObjectKey]time.Time)
+	}
+}
+
+// recordRollout updates the metrics with the result of a rollout
+// coordination. It must be called with the lock held
+func (manager *Manager) recordRollout(cluster client.ObjectKey, result Result, deniedReason string) {
+	if manager.metrics == nil {
+		return
+	}
+
+	now := manager.timeProvider()
+	if result.RolloutAllowed {
+		delete(manager.waiters, cluster)
+	} else {
+		manager.metrics.denied.WithLabelValues(deniedReason).Inc()
+		manager.waiters[cluster] = now
+	}
+
+	expiration := max(manager.clusterRolloutDelay, manager.instanceRolloutDelay) + waiterExpiration
+	for waitingCluster, lastDenied := range manager.waiters {
+		if now.Sub(lastDenied) > expiration {
+			delete(manager.waiters, waitingCluster)
+		}
+	}
+
+	manager.metrics.waiters.Set(float64(len(manager.waiters)))
+	manager.metrics.slotHeldSeconds.Set(now.Sub(manager.lastUpdate).Seconds())
+}
@@ -0,0 +1,89 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rollout manager metrics", func() {
+	const (
+		clustersRolloutDelay  = 10 * time.Minute
+		instancesRolloutDelay = 5 * time.Minute
+	)
+
+	var (
+		currentTime time.Time
+		metrics     *Metrics
+		m           *Manager
+	)
+
+	clusterExample := client.ObjectKey{Namespace: "default", Name: "cluster-example"}
+	clusterBis := client.ObjectKey{Namespace: "default", Name: "cluster-bis"}
+
+	BeforeEach(func() {
+		currentTime = time.Now()
+		metrics = NewMetrics()
+		m = New(clustersRolloutDelay, instancesRolloutDelay, WithMetrics(metrics))
+		m.timeProvider = func() time.Time {
+			return currentTime
+		}
+	})
+
+	It("counts the denied rollouts by reason and the waiting clusters", func() {
+		Expect(m.CoordinateRollout(clusterExample, "cluster-example-1").RolloutAllowed).To(BeTrue())
+		Expect(testutil.ToFloat64(metrics.slotHeldSeconds)).To(BeZero())
+		Expect(testutil.ToFloat64(metrics.waiters)).To(BeZero())
+
+		currentTime = currentTime.Add(time.Minute)
+		Expect(m.CoordinateRollout(clusterExample, "cluster-example-2").RolloutAllowed).To(BeFalse())
+		Expect(m.CoordinateRollout(clusterBis, "cluster-bis-1").RolloutAllowed).To(BeFalse())
+		Expect(m.CoordinateRollout(clusterBis, "cluster-bis-1").RolloutAllowed).To(BeFalse())
+
+		Expect(testutil.ToFloat64(metrics.denied.WithLabelValues(deniedReasonInstanceDelay))).To(BeEquivalentTo(1))
+		Expect(testutil.ToFloat64(metrics.denied.WithLabelValues(deniedReasonClusterDelay))).To(BeEquivalentTo(2))
+		Expect(testutil.ToFloat64(metrics.waiters)).To(BeEquivalentTo(2))
+		Expect(testutil.ToFloat64(metrics.slotHeldSeconds)).To(BeEquivalentTo(60))
+
+		currentTime = currentTime.Add(5 * time.Minute)
+		Expect(m.CoordinateRollout(clusterExample, "cluster-example-2").RolloutAllowed).To(BeTrue())
+		Expect(testutil.ToFloat64(metrics.waiters)).To(BeEquivalentTo(1))
+		Expect(testutil.ToFloat64(metrics.slotHeldSeconds)).To(BeZero())
+	})
+
+	It("stops counting the clusters that don't ask for the slot anymore", func() {
+		Expect(m.CoordinateRollout(clusterExample, "cluster-example-1").RolloutAllowed).To(BeTrue())
+		Expect(m.CoordinateRollout(clusterBis, "cluster-bis-1").RolloutAllowed).To(BeFalse())
+		Expect(testutil.ToFloat64(metrics.waiters)).To(BeEquivalentTo(1))
+
+		currentTime = currentTime.Add(clustersRolloutDelay + waiterExpiration + time.Second)
+		Expect(m.CoordinateRollout(clusterExample, "cluster-example-2").RolloutAllowed).To(BeTrue())
+		Expect(testutil.ToFloat64(metrics.waiters)).To(BeZero())
+	})
+
+	It("works without metrics", func() {
+		m := New(clustersRolloutDelay, instancesRolloutDelay)
+		Expect(m.CoordinateRollout(clusterExample, "cluster-example-1").RolloutAllowed).To(BeTrue())
+		Expect(m.CoordinateRollout(clusterBis, "cluster-bis-1").RolloutAllowed).To(BeFalse())
+	})
+})
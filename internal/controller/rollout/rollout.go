@@ -48,6 +48,11 @@ type Manager struct {
 	lastInstance string
 	lastCluster  client.ObjectKey
 	lastUpdate   time.Time
+
+	// The metrics describing the contention on the rollout
+	// slot, and the clusters that are waiting for it
+	metrics *Metrics
+	waiters map[client.ObjectKey]time.Time
 }
 
 // Result is the output of the rollout manager, telling the
@@ -62,12 +67,16 @@ type Result struct {
 }
 
 // New creates a new rollout manager with the passed configuration
-func New(clusterRolloutDelay, instancesRolloutDelay time.Duration) *Manager {
-	return &Manager{
+func New(clusterRolloutDelay, instancesRolloutDelay time.Duration, options ...Option) *Manager {
+	manager := &Manager{
 		timeProvider:         time.Now,
 		clusterRolloutDelay:  clusterRolloutDelay,
 		instanceRolloutDelay: instancesRolloutDelay,
 	}
+	for _, option := range options {
+		option(manager)
+	}
+	return manager
 }
 
 // CoordinateRollout is called to check whether this rollout is allowed or not
@@ -79,10 +88,18 @@ func (manager *Manager) CoordinateRollout(
 	manager.m.Lock()
 	defer manager.m.Unlock()
 
+	var result Result
+	var deniedReason string
 	if manager.lastCluster == cluster {
-		return manager.coordinateRolloutWithTime(cluster, instanceName, manager.instanceRolloutDelay)
+		result = manager.coordinateRolloutWithTime(cluster, instanceName, manager.instanceRolloutDelay)
+		deniedReason = deniedReasonInstanceDelay
+	} else {
+		result = manager.coordinateRolloutWithTime(cluster, instanceName, manager.clusterRolloutDelay)
+		deniedReason = deniedReasonClusterDelay
 	}
-	return manager.coordinateRolloutWithTime(cluster, instanceName, manager.clusterRolloutDelay)
+
+	manager.recordRollout(cluster, result, deniedReason)
+	return result
 }
 
 func (manager *Manager) coordinateRolloutWithTime(
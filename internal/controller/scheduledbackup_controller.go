@@ -132,6 +132,10 @@ func ReconcileScheduledBackup(
 ) (ctrl.Result, error) {
 	contextLogger := log.FromContext(ctx)
 
+	if _, requested := scheduledBackup.Annotations[utils.RunOnceAnnotationName]; requested {
+		return runBackupOnce(ctx, event, cli, scheduledBackup, time.Now())
+	}
+
 	// Let's check
 	schedule, err := cron.Parse(scheduledBackup.GetSchedule())
 	if err != nil {
@@ -219,28 +223,35 @@ func ReconcileScheduledBackup(
 		return ctrl.Result{RequeueAfter: nextTime.Sub(now)}, nil
 	}
 
+	if scheduledBackup.IsSkippedWhenUnavailable() {
+		var cluster apiv1.Cluster
+		err := cli.Get(ctx, client.ObjectKey{
+			Namespace: scheduledBackup.Namespace,
+			Name:      scheduledBackup.Spec.Cluster.Name,
+		}, &cluster)
+		if err != nil && !apierrs.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+
+		// A missing cluster is reported by the backup itself
+		if err == nil {
+			if reason := getScheduledBackupSkipReason(&cluster); reason != "" {
+				return skipBackup(ctx, event, cli, scheduledBackup, now, schedule, reason)
+			}
+		}
+	}
+
 	return createBackup(ctx, event, cli, scheduledBackup, nextTime, now, schedule, false)
 }
 
-// createBackup creates a scheduled backup for a backuptime, updating the ScheduledBackup accordingly
-func createBackup(
+// buildBackup prepares a Backup object from the passed scheduled backup
+func buildBackup(
 	ctx context.Context,
-	event record.EventRecorder,
 	cli client.Client,
 	scheduledBackup *apiv1.ScheduledBackup,
-	backupTime time.Time,
-	now time.Time,
-	schedule cron.Schedule,
+	name string,
 	immediate bool,
-) (ctrl.Result, error) {
-	contextLogger := log.FromContext(ctx)
-
-	origScheduled := scheduledBackup.DeepCopy()
-
-	// So we have no backup running, let's create a backup.
-	// Let's have deterministic names to avoid creating the job two
-	// times
-	name := fmt.Sprintf("%s-%s", scheduledBackup.GetName(), pgTime.ToCompactISO8601(backupTime))
+) (*apiv1.Backup, error) {
 	backup := scheduledBackup.CreateBackup(name)
 	metadata := &backup.ObjectMeta
 	if metadata.Labels == nil {
@@ -258,7 +269,7 @@ func createBackup(
 			types.NamespacedName{Name: scheduledBackup.Spec.Cluster.Name, Namespace: scheduledBackup.Namespace},
 			&cluster,
 		); err != nil {
-			return ctrl.Result{}, err
+			return nil, err
 		}
 		cluster.SetInheritedDataAndOwnership(&backup.ObjectMeta)
 	case "self":
@@ -268,6 +279,134 @@ func createBackup(
 		break
 	}
 
+	// The run-once trigger must not be propagated to the backup
+	delete(metadata.Annotations, utils.RunOnceAnnotationName)
+
+	return backup, nil
+}
+
+// runBackupOnce creates a single backup as requested by the run-once
+// annotation, and then removes the annotation. The status of the
+// scheduled backup is not touched, to preserve the regular cadence
+func runBackupOnce(
+	ctx context.Context,
+	event record.EventRecorder,
+	cli client.Client,
+	scheduledBackup *apiv1.ScheduledBackup,
+	now time.Time,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	name := fmt.Sprintf("%s-%s", scheduledBackup.GetName(), pgTime.ToCompactISO8601(now))
+	backup, err := buildBackup(ctx, cli, scheduledBackup, name, true)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	contextLogger.Info("Creating run-once backup", "backupName", backup.Name)
+	if err := cli.Create(ctx, backup); err != nil && !apierrs.IsAlreadyExists(err) {
+		contextLogger.Error(
+			err, "Error while creating run-once backup object",
+			"backupName", backup.GetName())
+		event.Event(scheduledBackup, "Warning", "BackupCreation", "Error while creating run-once backup object")
+		return ctrl.Result{}, err
+	}
+
+	origScheduled := scheduledBackup.DeepCopy()
+	delete(scheduledBackup.Annotations, utils.RunOnceAnnotationName)
+	if err := cli.Patch(ctx, scheduledBackup, client.MergeFrom(origScheduled)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	event.Eventf(scheduledBackup, "Normal", "BackupRunOnce", "Created run-once backup %s", backup.Name)
+
+	// Removing the annotation triggers a new reconciliation,
+	// which will resume the regular schedule
+	return ctrl.Result{}, nil
+}
+
+// getScheduledBackupSkipReason returns the reason why a scheduled backup
+// should not be taken on the passed cluster, or an empty string if the
+// cluster is available for backups
+func getScheduledBackupSkipReason(cluster *apiv1.Cluster) string {
+	switch {
+	case cluster.IsNodeMaintenanceWindowInProgress():
+		return "cluster is in a node maintenance window"
+	case cluster.Annotations[utils.MaintenanceReasonAnnotationName] != "":
+		return fmt.Sprintf("cluster is under maintenance: %s",
+			cluster.Annotations[utils.MaintenanceReasonAnnotationName])
+	case cluster.Status.Phase != apiv1.PhaseHealthy:
+		return fmt.Sprintf("cluster is not healthy, phase is %q", cluster.Status.Phase)
+	default:
+		return ""
+	}
+}
+
+// skipBackup records a skipped scheduled backup, moving on to the next schedule
+func skipBackup(
+	ctx context.Context,
+	event record.EventRecorder,
+	cli client.Client,
+	scheduledBackup *apiv1.ScheduledBackup,
+	now time.Time,
+	schedule cron.Schedule,
+	reason string,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	origScheduled := scheduledBackup.DeepCopy()
+
+	scheduledBackup.Status.LastCheckTime = &metav1.Time{
+		Time: now,
+	}
+	scheduledBackup.Status.LastSkippedTime = &metav1.Time{
+		Time: now,
+	}
+	scheduledBackup.Status.LastSkippedReason = reason
+	nextBackupTime := schedule.Next(now)
+	scheduledBackup.Status.NextScheduleTime = &metav1.Time{
+		Time: nextBackupTime,
+	}
+
+	if err := cli.Status().Patch(ctx, scheduledBackup, client.MergeFrom(origScheduled)); err != nil {
+		if apierrs.IsConflict(err) {
+			// Retry later, the cache is stale
+			contextLogger.Debug("Conflict while updating scheduled backup", "error", err)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	contextLogger.Info("Skipping scheduled backup", "reason", reason, "next", nextBackupTime)
+	event.Eventf(scheduledBackup, "Warning", "BackupSkipped",
+		"Scheduled backup skipped (%s), next backup scheduled by %v", reason, nextBackupTime)
+	return ctrl.Result{RequeueAfter: nextBackupTime.Sub(now)}, nil
+}
+
+// createBackup creates a scheduled backup for a backuptime, updating the ScheduledBackup accordingly
+func createBackup(
+	ctx context.Context,
+	event record.EventRecorder,
+	cli client.Client,
+	scheduledBackup *apiv1.ScheduledBackup,
+	backupTime time.Time,
+	now time.Time,
+	schedule cron.Schedule,
+	immediate bool,
+) (ctrl.Result, error) {
+	contextLogger := log.FromContext(ctx)
+
+	origScheduled := scheduledBackup.DeepCopy()
+
+	// So we have no backup running, let's create a backup.
+	// Let's have deterministic names to avoid creating the job two
+	// times
+	name := fmt.Sprintf("%s-%s", scheduledBackup.GetName(), pgTime.ToCompactISO8601(backupTime))
+	backup, err := buildBackup(ctx, cli, scheduledBackup, name, immediate)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	contextLogger.Info("Creating backup", "backupName", backup.Name)
 	if err := cli.Create(ctx, backup); err != nil {
 		if apierrs.IsConflict(err) {
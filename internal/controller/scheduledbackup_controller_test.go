@@ -0,0 +1,189 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	schemeBuilder "github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getScheduledBackupSkipReason", func() {
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster-example",
+			},
+			Status: apiv1.ClusterStatus{
+				Phase: apiv1.PhaseHealthy,
+			},
+		}
+	})
+
+	It("doesn't skip the backups of a healthy cluster", func() {
+		Expect(getScheduledBackupSkipReason(cluster)).To(BeEmpty())
+	})
+
+	It("skips the backups during a node maintenance window", func() {
+		cluster.Spec.NodeMaintenanceWindow = &apiv1.NodeMaintenanceWindow{InProgress: true}
+		Expect(getScheduledBackupSkipReason(cluster)).To(ContainSubstring("node maintenance"))
+	})
+
+	It("skips the backups when the cluster has a maintenance reason", func() {
+		cluster.Annotations = map[string]string{
+			utils.MaintenanceReasonAnnotationName: "storage migration",
+		}
+		Expect(getScheduledBackupSkipReason(cluster)).To(ContainSubstring("storage migration"))
+	})
+
+	It("skips the backups of an unhealthy cluster", func() {
+		cluster.Status.Phase = apiv1.PhaseFailOver
+		Expect(getScheduledBackupSkipReason(cluster)).To(ContainSubstring(apiv1.PhaseFailOver))
+	})
+})
+
+var _ = Describe("ReconcileScheduledBackup", func() {
+	const namespace = "default"
+
+	var (
+		cluster         *apiv1.Cluster
+		scheduledBackup *apiv1.ScheduledBackup
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: namespace,
+			},
+			Status: apiv1.ClusterStatus{
+				Phase: apiv1.PhaseUpgrade,
+			},
+		}
+		scheduledBackup = &apiv1.ScheduledBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "scheduled-backup",
+				Namespace: namespace,
+			},
+			Spec: apiv1.ScheduledBackupSpec{
+				Schedule: "0 0 0 * * *",
+				Cluster:  apiv1.LocalObjectReference{Name: cluster.Name},
+			},
+			Status: apiv1.ScheduledBackupStatus{
+				LastCheckTime: &metav1.Time{Time: time.Now().Add(-48 * time.Hour)},
+			},
+		}
+	})
+
+	reconcile := func(ctx SpecContext) (client.Client, *apiv1.BackupList) {
+		cli := fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(cluster, scheduledBackup).
+			WithStatusSubresource(cluster, scheduledBackup).
+			Build()
+
+		result, err := ReconcileScheduledBackup(ctx, record.NewFakeRecorder(10), cli, scheduledBackup)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+		var backups apiv1.BackupList
+		Expect(cli.List(ctx, &backups, client.InNamespace(namespace))).To(Succeed())
+		return cli, &backups
+	}
+
+	It("creates the backup of an unhealthy cluster by default", func(ctx SpecContext) {
+		_, backups := reconcile(ctx)
+		Expect(backups.Items).To(HaveLen(1))
+		Expect(scheduledBackup.Status.LastSkippedTime).To(BeNil())
+	})
+
+	It("skips the backup of an unhealthy cluster when requested", func(ctx SpecContext) {
+		scheduledBackup.Spec.SkipWhenUnavailable = ptr.To(true)
+
+		cli, backups := reconcile(ctx)
+		Expect(backups.Items).To(BeEmpty())
+
+		var updated apiv1.ScheduledBackup
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(scheduledBackup), &updated)).To(Succeed())
+		Expect(updated.Status.LastSkippedTime).ToNot(BeNil())
+		Expect(updated.Status.LastSkippedReason).To(ContainSubstring("not healthy"))
+		Expect(updated.Status.NextScheduleTime.Time).To(BeTemporally(">", time.Now()))
+		Expect(updated.Status.LastCheckTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+	})
+
+	It("creates a single backup when the run-once trigger is set, and then clears it", func(ctx SpecContext) {
+		scheduledBackup.Annotations = map[string]string{
+			utils.RunOnceAnnotationName: "true",
+		}
+		scheduledBackup.Spec.Target = apiv1.BackupTargetPrimary
+		scheduledBackup.Spec.Method = apiv1.BackupMethodVolumeSnapshot
+		lastCheckTime := scheduledBackup.Status.LastCheckTime.DeepCopy()
+
+		cli := fake.NewClientBuilder().
+			WithScheme(schemeBuilder.BuildWithAllKnownScheme()).
+			WithObjects(cluster, scheduledBackup).
+			WithStatusSubresource(cluster, scheduledBackup).
+			Build()
+
+		_, err := ReconcileScheduledBackup(ctx, record.NewFakeRecorder(10), cli, scheduledBackup)
+		Expect(err).ToNot(HaveOccurred())
+
+		var backups apiv1.BackupList
+		Expect(cli.List(ctx, &backups, client.InNamespace(namespace))).To(Succeed())
+		Expect(backups.Items).To(HaveLen(1))
+		backup := backups.Items[0]
+		Expect(backup.Spec.Target).To(Equal(apiv1.BackupTargetPrimary))
+		Expect(backup.Spec.Method).To(Equal(apiv1.BackupMethodVolumeSnapshot))
+		Expect(backup.Labels).To(HaveKeyWithValue(utils.ImmediateBackupLabelName, "true"))
+		Expect(backup.Labels).To(HaveKeyWithValue(utils.ParentScheduledBackupLabelName, scheduledBackup.Name))
+		Expect(backup.Annotations).ToNot(HaveKey(utils.RunOnceAnnotationName))
+
+		var updated apiv1.ScheduledBackup
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(scheduledBackup), &updated)).To(Succeed())
+		Expect(updated.Annotations).ToNot(HaveKey(utils.RunOnceAnnotationName))
+		Expect(updated.Status.LastCheckTime.Time).To(BeTemporally("~", lastCheckTime.Time, time.Second))
+		Expect(updated.Status.LastScheduleTime).To(BeNil())
+
+		// The next reconciliation resumes the regular schedule
+		_, err = ReconcileScheduledBackup(ctx, record.NewFakeRecorder(10), cli, &updated)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cli.List(ctx, &backups, client.InNamespace(namespace))).To(Succeed())
+		Expect(backups.Items).To(HaveLen(2))
+		Expect(updated.Status.LastScheduleTime).ToNot(BeNil())
+	})
+
+	It("creates the backup of a healthy cluster when skipping is requested", func(ctx SpecContext) {
+		scheduledBackup.Spec.SkipWhenUnavailable = ptr.To(true)
+		cluster.Status.Phase = apiv1.PhaseHealthy
+
+		_, backups := reconcile(ctx)
+		Expect(backups.Items).To(HaveLen(1))
+	})
+})
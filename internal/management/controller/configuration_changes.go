@@ -0,0 +1,43 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+// configurationFilesChanges tracks the files updated by a reconciliation
+// loop that need the instance to be reloaded
+type configurationFilesChanges struct {
+	// secrets is true when the certificates or the secrets used by the
+	// instance changed
+	secrets bool
+
+	// hba is true when the pg_hba.conf or the pg_ident.conf file changed
+	hba bool
+
+	// postgresql is true when the PostgreSQL configuration changed
+	postgresql bool
+}
+
+// reloadNeeded is true when at least one of the files changed
+func (changes configurationFilesChanges) reloadNeeded() bool {
+	return changes.secrets || changes.hba || changes.postgresql
+}
+
+// isHBAOnly is true when only the host-based authentication rules changed.
+// A change of these rules is applied by a reload and never requires a restart
+// of the instance, so there's no need to check for pending restarts.
+func (changes configurationFilesChanges) isHBAOnly() bool {
+	return changes.hba && !changes.secrets && !changes.postgresql
+}
@@ -0,0 +1,54 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("configuration files changes", func() {
+	It("doesn't require a reload when nothing changed", func() {
+		changes := configurationFilesChanges{}
+		Expect(changes.reloadNeeded()).To(BeFalse())
+		Expect(changes.isHBAOnly()).To(BeFalse())
+	})
+
+	It("applies an HBA-only change without checking for pending restarts", func() {
+		changes := configurationFilesChanges{hba: true}
+		Expect(changes.reloadNeeded()).To(BeTrue())
+		Expect(changes.isHBAOnly()).To(BeTrue())
+	})
+
+	It("checks for pending restarts when the PostgreSQL configuration changed too", func() {
+		changes := configurationFilesChanges{hba: true, postgresql: true}
+		Expect(changes.reloadNeeded()).To(BeTrue())
+		Expect(changes.isHBAOnly()).To(BeFalse())
+	})
+
+	It("checks for pending restarts when the secrets changed", func() {
+		changes := configurationFilesChanges{hba: true, secrets: true}
+		Expect(changes.reloadNeeded()).To(BeTrue())
+		Expect(changes.isHBAOnly()).To(BeFalse())
+	})
+
+	It("checks for pending restarts when only the PostgreSQL configuration changed", func() {
+		changes := configurationFilesChanges{postgresql: true}
+		Expect(changes.reloadNeeded()).To(BeTrue())
+		Expect(changes.isHBAOnly()).To(BeFalse())
+	})
+})
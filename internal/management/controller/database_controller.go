@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -41,6 +42,7 @@ type DatabaseReconciler struct {
 	instance            instanceInterface
 	finalizerReconciler *finalizerReconciler[*apiv1.Database]
 	getSuperUserDB      func() (*sql.DB, error)
+	getTargetDB         func(name string) (*sql.DB, error)
 }
 
 // databaseReconciliationInterval is the time between the
@@ -75,10 +77,9 @@ func (r *DatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, nil
 	}
 
-	// If everything is reconciled, we're done here
-	if database.Generation == database.Status.ObservedGeneration {
-		return ctrl.Result{}, nil
-	}
+	// If everything is reconciled, we only need to check for drifts
+	checkDriftOnly := database.Generation == database.Status.ObservedGeneration &&
+		database.GetDeletionTimestamp().IsZero()
 
 	// Fetch the Cluster from the cache
 	cluster, err := r.GetCluster(ctx)
@@ -109,6 +110,17 @@ func (r *DatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{RequeueAfter: databaseReconciliationInterval}, nil
 	}
 
+	if checkDriftOnly {
+		drift, err := r.detectDatabaseDrift(ctx, &database)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("while detecting drifts of the database: %w", err)
+		}
+		if drift == "" {
+			return ctrl.Result{RequeueAfter: databaseReconciliationInterval}, nil
+		}
+		contextLogger.Info("Database drifted from its specification, reconciling it", "drift", drift)
+	}
+
 	if err := r.finalizerReconciler.reconcile(ctx, &database); err != nil {
 		return ctrl.Result{}, fmt.Errorf("while reconciling the finalizer: %w", err)
 	}
@@ -164,6 +176,9 @@ func NewDatabaseReconciler(
 		getSuperUserDB: func() (*sql.DB, error) {
 			return instance.GetSuperUserDB()
 		},
+		getTargetDB: func(name string) (*sql.DB, error) {
+			return instance.ConnectionPool().Connection(name)
+		},
 	}
 
 	dr.finalizerReconciler = newFinalizerReconciler(
@@ -204,8 +219,132 @@ func (r *DatabaseReconciler) reconcileDatabase(ctx context.Context, obj *apiv1.D
 	}
 
 	if dbExists {
-		return updateDatabase(ctx, db, obj)
+		err = updateDatabase(ctx, db, obj)
+	} else {
+		err = createDatabase(ctx, db, obj)
+		if isDuplicateDatabaseError(err) {
+			// The database has been created after being detected as missing,
+			// i.e. by a previous attempt that timed out. Let's align it
+			err = updateDatabase(ctx, db, obj)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := r.reconcileDatabaseExtensions(ctx, obj); err != nil {
+		return err
+	}
+
+	return r.reconcileDatabaseDefaultPrivileges(ctx, obj)
+}
+
+// detectDatabaseDrift checks if the database has been changed in PostgreSQL
+// after being reconciled, returning a description of the drift if it has.
+// Only the existence and the owner of the database are checked.
+func (r *DatabaseReconciler) detectDatabaseDrift(ctx context.Context, obj *apiv1.Database) (string, error) {
+	db, err := r.getSuperUserDB()
+	if err != nil {
+		return "", fmt.Errorf("while getting DB connection: %w", err)
+	}
+
+	owner, dbExists, err := getDatabaseOwner(ctx, db, obj)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case obj.Spec.Ensure == apiv1.EnsureAbsent && dbExists:
+		return "the database exists but it should be absent", nil
+	case obj.Spec.Ensure == apiv1.EnsureAbsent:
+		return "", nil
+	case !dbExists:
+		return "the database is missing", nil
+	case len(obj.Spec.Owner) > 0 && owner != obj.Spec.Owner:
+		return fmt.Sprintf("the database is owned by %q instead of %q", owner, obj.Spec.Owner), nil
+	default:
+		return r.detectDatabaseObjectsDrift(ctx, obj)
+	}
+}
+
+// detectDatabaseObjectsDrift checks if the extensions and the default
+// privileges managed in the database have been changed in PostgreSQL,
+// returning a description of the first drift found
+func (r *DatabaseReconciler) detectDatabaseObjectsDrift(ctx context.Context, obj *apiv1.Database) (string, error) {
+	if len(obj.Spec.Extensions) == 0 && len(obj.Spec.DefaultPrivileges) == 0 {
+		return "", nil
+	}
+
+	db, err := r.getTargetDB(obj.Spec.Name)
+	if err != nil {
+		return "", fmt.Errorf("while connecting to the database %q: %w", obj.Spec.Name, err)
+	}
+
+	for _, extension := range obj.Spec.Extensions {
+		info, err := getDatabaseExtensionInfo(ctx, db, extension)
+		if err != nil {
+			return "", err
+		}
+		if drift := getDatabaseExtensionDrift(extension, info); drift != "" {
+			return drift, nil
+		}
+	}
+
+	for _, defaultPrivileges := range obj.Spec.DefaultPrivileges {
+		privilegesInDB, err := getDatabaseDefaultPrivileges(ctx, db, defaultPrivileges)
+		if err != nil {
+			return "", err
+		}
+		if toGrant, toRevoke := getDefaultPrivilegesDiff(defaultPrivileges, privilegesInDB); len(toGrant) > 0 ||
+			len(toRevoke) > 0 {
+			return fmt.Sprintf("the default privileges of %q on %s granted by %q have changed",
+				defaultPrivileges.Grantee, defaultPrivileges.ObjectType, defaultPrivileges.Grantor), nil
+		}
+	}
+
+	return "", nil
+}
+
+// reconcileDatabaseExtensions reconciles the extensions managed in the
+// database, reporting all the extensions that couldn't be reconciled
+func (r *DatabaseReconciler) reconcileDatabaseExtensions(ctx context.Context, obj *apiv1.Database) error {
+	if len(obj.Spec.Extensions) == 0 {
+		return nil
+	}
+
+	db, err := r.getTargetDB(obj.Spec.Name)
+	if err != nil {
+		return fmt.Errorf("while connecting to the database %q: %w", obj.Spec.Name, err)
+	}
+
+	var errs []error
+	for _, extension := range obj.Spec.Extensions {
+		if err := reconcileDatabaseExtension(ctx, db, extension); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reconcileDatabaseDefaultPrivileges reconciles the default privileges
+// managed in the database, reporting all the ones that couldn't be reconciled
+func (r *DatabaseReconciler) reconcileDatabaseDefaultPrivileges(ctx context.Context, obj *apiv1.Database) error {
+	if len(obj.Spec.DefaultPrivileges) == 0 {
+		return nil
+	}
+
+	db, err := r.getTargetDB(obj.Spec.Name)
+	if err != nil {
+		return fmt.Errorf("while connecting to the database %q: %w", obj.Spec.Name, err)
+	}
+
+	var errs []error
+	for _, defaultPrivileges := range obj.Spec.DefaultPrivileges {
+		if err := reconcileDatabaseDefaultPrivileges(ctx, db, defaultPrivileges); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	return createDatabase(ctx, db, obj)
+	return errors.Join(errs...)
 }
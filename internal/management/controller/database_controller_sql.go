@@ -19,11 +19,14 @@ package controller
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 )
@@ -53,6 +56,42 @@ func detectDatabase(
 	return count > 0, nil
 }
 
+func getDatabaseOwner(
+	ctx context.Context,
+	db *sql.DB,
+	obj *apiv1.Database,
+) (string, bool, error) {
+	row := db.QueryRowContext(
+		ctx,
+		`
+		SELECT pg_catalog.pg_get_userbyid(datdba)
+		FROM pg_catalog.pg_database
+		WHERE datname = $1
+		`,
+		obj.Spec.Name)
+	if row.Err() != nil {
+		return "", false, fmt.Errorf("while getting the owner of database %q: %w", obj.Spec.Name, row.Err())
+	}
+
+	var owner string
+	if err := row.Scan(&owner); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("while scanning the owner of database %q: %w", obj.Spec.Name, err)
+	}
+
+	return owner, true, nil
+}
+
+// isDuplicateDatabaseError checks if the error has been raised
+// by PostgreSQL while creating a database that already exists
+func isDuplicateDatabaseError(err error) bool {
+	var errPGX *pgconn.PgError
+	// 42P04 -> duplicate_database
+	return errors.As(err, &errPGX) && errPGX.Code == "42P04"
+}
+
 func createDatabase(
 	ctx context.Context,
 	db *sql.DB,
@@ -213,3 +252,311 @@ func dropDatabase(
 
 	return nil
 }
+
+// extensionInfo is the status of an extension, as reported by PostgreSQL
+type extensionInfo struct {
+	Name    string
+	Version string
+	Schema  string
+}
+
+func getDatabaseExtensionInfo(
+	ctx context.Context,
+	db *sql.DB,
+	extension apiv1.ExtensionSpec,
+) (*extensionInfo, error) {
+	row := db.QueryRowContext(
+		ctx,
+		`
+		SELECT e.extname, e.extversion, n.nspname
+		FROM pg_catalog.pg_extension e
+		JOIN pg_catalog.pg_namespace n ON e.extnamespace = n.oid
+		WHERE e.extname = $1
+		`,
+		extension.Name)
+	if row.Err() != nil {
+		return nil, fmt.Errorf("while checking if extension %q exists: %w", extension.Name, row.Err())
+	}
+
+	var result extensionInfo
+	if err := row.Scan(&result.Name, &result.Version, &result.Schema); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("while scanning if extension %q exists: %w", extension.Name, err)
+	}
+
+	return &result, nil
+}
+
+func createDatabaseExtension(
+	ctx context.Context,
+	db *sql.DB,
+	extension apiv1.ExtensionSpec,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	var sqlCreateExtension strings.Builder
+	sqlCreateExtension.WriteString(fmt.Sprintf("CREATE EXTENSION %s", pgx.Identifier{extension.Name}.Sanitize()))
+	if len(extension.Schema) > 0 {
+		sqlCreateExtension.WriteString(fmt.Sprintf(" SCHEMA %s", pgx.Identifier{extension.Schema}.Sanitize()))
+	}
+	if len(extension.Version) > 0 {
+		sqlCreateExtension.WriteString(fmt.Sprintf(" VERSION %s", pgx.Identifier{extension.Version}.Sanitize()))
+	}
+
+	if _, err := db.ExecContext(ctx, sqlCreateExtension.String()); err != nil {
+		contextLogger.Error(err, "while creating extension", "query", sqlCreateExtension.String())
+		return fmt.Errorf("while creating extension %q: %w", extension.Name, err)
+	}
+	contextLogger.Info("created extension", "name", extension.Name)
+
+	return nil
+}
+
+func updateDatabaseExtension(
+	ctx context.Context,
+	db *sql.DB,
+	extension apiv1.ExtensionSpec,
+	info *extensionInfo,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	if len(extension.Version) > 0 && extension.Version != info.Version {
+		updateVersionSQL := fmt.Sprintf(
+			"ALTER EXTENSION %s UPDATE TO %s",
+			pgx.Identifier{extension.Name}.Sanitize(),
+			pgx.Identifier{extension.Version}.Sanitize())
+
+		if _, err := db.ExecContext(ctx, updateVersionSQL); err != nil {
+			contextLogger.Error(err, "while altering extension", "query", updateVersionSQL)
+			return fmt.Errorf("while updating extension %q from version %s to %s: %w",
+				extension.Name, info.Version, extension.Version, err)
+		}
+		contextLogger.Info("updated extension", "name", extension.Name,
+			"oldVersion", info.Version, "newVersion", extension.Version)
+	}
+
+	if len(extension.Schema) > 0 && extension.Schema != info.Schema {
+		changeSchemaSQL := fmt.Sprintf(
+			"ALTER EXTENSION %s SET SCHEMA %s",
+			pgx.Identifier{extension.Name}.Sanitize(),
+			pgx.Identifier{extension.Schema}.Sanitize())
+
+		if _, err := db.ExecContext(ctx, changeSchemaSQL); err != nil {
+			contextLogger.Error(err, "while altering extension", "query", changeSchemaSQL)
+			return fmt.Errorf("while altering extension %q schema to %s: %w",
+				extension.Name, extension.Schema, err)
+		}
+	}
+
+	return nil
+}
+
+func dropDatabaseExtension(
+	ctx context.Context,
+	db *sql.DB,
+	extension apiv1.ExtensionSpec,
+) error {
+	contextLogger := log.FromContext(ctx)
+	query := fmt.Sprintf("DROP EXTENSION IF EXISTS %s", pgx.Identifier{extension.Name}.Sanitize())
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		contextLogger.Error(err, "while dropping extension", "query", query)
+		return fmt.Errorf("while dropping extension %q: %w", extension.Name, err)
+	}
+	contextLogger.Info("dropped extension", "name", extension.Name)
+
+	return nil
+}
+
+// reconcileDatabaseExtension makes the extension in the database match its
+// specification, comparing it with the content of the pg_extension catalog
+func reconcileDatabaseExtension(
+	ctx context.Context,
+	db *sql.DB,
+	extension apiv1.ExtensionSpec,
+) error {
+	info, err := getDatabaseExtensionInfo(ctx, db, extension)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case extension.Ensure == apiv1.EnsureAbsent && info != nil:
+		return dropDatabaseExtension(ctx, db, extension)
+	case extension.Ensure == apiv1.EnsureAbsent:
+		return nil
+	case info == nil:
+		return createDatabaseExtension(ctx, db, extension)
+	default:
+		return updateDatabaseExtension(ctx, db, extension, info)
+	}
+}
+
+// getDatabaseExtensionDrift compares an extension with its status in the
+// pg_extension catalog, returning a description of the difference, if any
+func getDatabaseExtensionDrift(extension apiv1.ExtensionSpec, info *extensionInfo) string {
+	switch {
+	case extension.Ensure == apiv1.EnsureAbsent && info != nil:
+		return fmt.Sprintf("the extension %q exists but it should be absent", extension.Name)
+	case extension.Ensure == apiv1.EnsureAbsent:
+		return ""
+	case info == nil:
+		return fmt.Sprintf("the extension %q is missing", extension.Name)
+	case len(extension.Version) > 0 && extension.Version != info.Version:
+		return fmt.Sprintf("the extension %q has version %q instead of %q",
+			extension.Name, info.Version, extension.Version)
+	case len(extension.Schema) > 0 && extension.Schema != info.Schema:
+		return fmt.Sprintf("the extension %q is in schema %q instead of %q",
+			extension.Name, info.Schema, extension.Schema)
+	default:
+		return ""
+	}
+}
+
+// getDatabaseDefaultPrivileges returns the privileges the grantee is
+// granted by default on the objects created by the grantor, as stored
+// in the pg_default_acl catalog
+func getDatabaseDefaultPrivileges(
+	ctx context.Context,
+	db *sql.DB,
+	defaultPrivileges apiv1.DefaultPrivilegesConfiguration,
+) ([]apiv1.GrantPrivilege, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`
+		SELECT acl.privilege_type
+		FROM pg_catalog.pg_default_acl d
+		LEFT JOIN pg_catalog.pg_namespace n ON d.defaclnamespace = n.oid
+		CROSS JOIN LATERAL pg_catalog.aclexplode(d.defaclacl) acl
+		WHERE d.defaclrole = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $1)
+		AND d.defaclobjtype = $2
+		AND COALESCE(n.nspname, '') = $3
+		AND acl.grantee = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $4)
+		`,
+		defaultPrivileges.Grantor,
+		defaultPrivileges.ObjectType.GetCatalogObjectType(),
+		defaultPrivileges.Schema,
+		defaultPrivileges.Grantee)
+	if err != nil {
+		return nil, fmt.Errorf("while listing the default privileges of %q: %w", defaultPrivileges.Grantee, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var privileges []apiv1.GrantPrivilege
+	for rows.Next() {
+		var privilege apiv1.GrantPrivilege
+		if err := rows.Scan(&privilege); err != nil {
+			return nil, fmt.Errorf("while scanning the default privileges of %q: %w", defaultPrivileges.Grantee, err)
+		}
+		privileges = append(privileges, privilege)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("while listing the default privileges of %q: %w", defaultPrivileges.Grantee, err)
+	}
+
+	return privileges, nil
+}
+
+// getDefaultPrivilegesDiff returns the privileges to be granted and revoked
+// to make the default privileges in the database match the specification
+func getDefaultPrivilegesDiff(
+	defaultPrivileges apiv1.DefaultPrivilegesConfiguration,
+	privilegesInDB []apiv1.GrantPrivilege,
+) (toGrant []apiv1.GrantPrivilege, toRevoke []apiv1.GrantPrivilege) {
+	for _, privilege := range defaultPrivileges.ObjectType.GetSupportedPrivileges() {
+		inSpec := slices.Contains(defaultPrivileges.Privileges, privilege)
+		inDB := slices.Contains(privilegesInDB, privilege)
+		switch {
+		case inSpec && !inDB:
+			toGrant = append(toGrant, privilege)
+		case !inSpec && inDB:
+			toRevoke = append(toRevoke, privilege)
+		}
+	}
+
+	return toGrant, toRevoke
+}
+
+// alterDefaultPrivileges grants or revokes the passed default privileges,
+// depending on the action ("GRANT" or "REVOKE")
+func alterDefaultPrivileges(
+	ctx context.Context,
+	db *sql.DB,
+	defaultPrivileges apiv1.DefaultPrivilegesConfiguration,
+	action string,
+	privileges []apiv1.GrantPrivilege,
+) error {
+	contextLogger := log.FromContext(ctx)
+
+	names := make([]string, len(privileges))
+	for i, privilege := range privileges {
+		names[i] = string(privilege)
+	}
+
+	var query strings.Builder
+	query.WriteString(fmt.Sprintf(
+		"ALTER DEFAULT PRIVILEGES FOR ROLE %s",
+		pgx.Identifier{defaultPrivileges.Grantor}.Sanitize()))
+	if len(defaultPrivileges.Schema) > 0 {
+		query.WriteString(fmt.Sprintf(" IN SCHEMA %s", pgx.Identifier{defaultPrivileges.Schema}.Sanitize()))
+	}
+
+	preposition := "TO"
+	if action == "REVOKE" {
+		preposition = "FROM"
+	}
+	query.WriteString(fmt.Sprintf(
+		" %s %s ON %s %s %s",
+		action,
+		strings.Join(names, ", "),
+		strings.ToUpper(string(defaultPrivileges.ObjectType)),
+		preposition,
+		pgx.Identifier{defaultPrivileges.Grantee}.Sanitize()))
+
+	if _, err := db.ExecContext(ctx, query.String()); err != nil {
+		contextLogger.Error(err, "while altering default privileges", "query", query.String())
+		return fmt.Errorf("while altering the default privileges of %q on %s: %w",
+			defaultPrivileges.Grantee, defaultPrivileges.ObjectType, err)
+	}
+	contextLogger.Info("altered default privileges",
+		"action", action,
+		"privileges", names,
+		"objectType", defaultPrivileges.ObjectType,
+		"grantor", defaultPrivileges.Grantor,
+		"grantee", defaultPrivileges.Grantee,
+		"schema", defaultPrivileges.Schema)
+
+	return nil
+}
+
+// reconcileDatabaseDefaultPrivileges makes the default privileges in the
+// database match their specification, comparing them with the content of
+// the pg_default_acl catalog and applying only the needed changes
+func reconcileDatabaseDefaultPrivileges(
+	ctx context.Context,
+	db *sql.DB,
+	defaultPrivileges apiv1.DefaultPrivilegesConfiguration,
+) error {
+	privilegesInDB, err := getDatabaseDefaultPrivileges(ctx, db, defaultPrivileges)
+	if err != nil {
+		return err
+	}
+
+	toGrant, toRevoke := getDefaultPrivilegesDiff(defaultPrivileges, privilegesInDB)
+	if len(toGrant) > 0 {
+		if err := alterDefaultPrivileges(ctx, db, defaultPrivileges, "GRANT", toGrant); err != nil {
+			return err
+		}
+	}
+	if len(toRevoke) > 0 {
+		if err := alterDefaultPrivileges(ctx, db, defaultPrivileges, "REVOKE", toRevoke); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -222,4 +223,217 @@ var _ = Describe("Managed Database SQL", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
+
+	Context("extensions", func() {
+		const detectionQuery = `
+		SELECT e.extname, e.extversion, n.nspname
+		FROM pg_catalog.pg_extension e
+		JOIN pg_catalog.pg_namespace n ON e.extnamespace = n.oid
+		WHERE e.extname = $1
+		`
+		var extension apiv1.ExtensionSpec
+
+		BeforeEach(func() {
+			extension = apiv1.ExtensionSpec{
+				Name:   "postgis",
+				Ensure: apiv1.EnsurePresent,
+			}
+		})
+
+		It("detects a missing extension", func(ctx SpecContext) {
+			dbMock.ExpectQuery(detectionQuery).WithArgs(extension.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}))
+
+			info, err := getDatabaseExtensionInfo(ctx, db, extension)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info).To(BeNil())
+		})
+
+		It("detects an existing extension", func(ctx SpecContext) {
+			dbMock.ExpectQuery(detectionQuery).WithArgs(extension.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}).
+					AddRow("postgis", "3.4.2", "public"))
+
+			info, err := getDatabaseExtensionInfo(ctx, db, extension)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info).To(Equal(&extensionInfo{Name: "postgis", Version: "3.4.2", Schema: "public"}))
+		})
+
+		It("creates an extension with its schema and version", func(ctx SpecContext) {
+			extension.Schema = "gis"
+			extension.Version = "3.4.2"
+			dbMock.ExpectQuery(detectionQuery).WithArgs(extension.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}))
+			dbMock.ExpectExec(`CREATE EXTENSION "postgis" SCHEMA "gis" VERSION "3.4.2"`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			Expect(reconcileDatabaseExtension(ctx, db, extension)).To(Succeed())
+		})
+
+		It("updates an extension to the requested version and schema", func(ctx SpecContext) {
+			extension.Schema = "gis"
+			extension.Version = "3.5.0"
+			dbMock.ExpectQuery(detectionQuery).WithArgs(extension.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}).
+					AddRow("postgis", "3.4.2", "public"))
+			dbMock.ExpectExec(`ALTER EXTENSION "postgis" UPDATE TO "3.5.0"`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			dbMock.ExpectExec(`ALTER EXTENSION "postgis" SET SCHEMA "gis"`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			Expect(reconcileDatabaseExtension(ctx, db, extension)).To(Succeed())
+		})
+
+		It("doesn't alter an extension matching its specification", func(ctx SpecContext) {
+			extension.Version = "3.4.2"
+			dbMock.ExpectQuery(detectionQuery).WithArgs(extension.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}).
+					AddRow("postgis", "3.4.2", "public"))
+
+			Expect(reconcileDatabaseExtension(ctx, db, extension)).To(Succeed())
+		})
+
+		It("drops an extension that should be absent", func(ctx SpecContext) {
+			extension.Ensure = apiv1.EnsureAbsent
+			dbMock.ExpectQuery(detectionQuery).WithArgs(extension.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}).
+					AddRow("postgis", "3.4.2", "public"))
+			dbMock.ExpectExec(`DROP EXTENSION IF EXISTS "postgis"`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			Expect(reconcileDatabaseExtension(ctx, db, extension)).To(Succeed())
+		})
+
+		It("doesn't drop an extension that is already absent", func(ctx SpecContext) {
+			extension.Ensure = apiv1.EnsureAbsent
+			dbMock.ExpectQuery(detectionQuery).WithArgs(extension.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}))
+
+			Expect(reconcileDatabaseExtension(ctx, db, extension)).To(Succeed())
+		})
+	})
+
+	DescribeTable("getDatabaseExtensionDrift",
+		func(extension apiv1.ExtensionSpec, info *extensionInfo, expected string) {
+			if expected == "" {
+				Expect(getDatabaseExtensionDrift(extension, info)).To(BeEmpty())
+			} else {
+				Expect(getDatabaseExtensionDrift(extension, info)).To(ContainSubstring(expected))
+			}
+		},
+		Entry("matching extension",
+			apiv1.ExtensionSpec{Name: "pg_trgm", Ensure: apiv1.EnsurePresent, Version: "1.6", Schema: "public"},
+			&extensionInfo{Name: "pg_trgm", Version: "1.6", Schema: "public"}, ""),
+		Entry("missing extension",
+			apiv1.ExtensionSpec{Name: "pg_trgm", Ensure: apiv1.EnsurePresent}, nil, "is missing"),
+		Entry("extension to be dropped",
+			apiv1.ExtensionSpec{Name: "pg_trgm", Ensure: apiv1.EnsureAbsent},
+			&extensionInfo{Name: "pg_trgm", Version: "1.6", Schema: "public"}, "should be absent"),
+		Entry("dropped extension",
+			apiv1.ExtensionSpec{Name: "pg_trgm", Ensure: apiv1.EnsureAbsent}, nil, ""),
+		Entry("different version",
+			apiv1.ExtensionSpec{Name: "pg_trgm", Ensure: apiv1.EnsurePresent, Version: "1.6"},
+			&extensionInfo{Name: "pg_trgm", Version: "1.5", Schema: "public"}, `version "1.5"`),
+		Entry("different schema",
+			apiv1.ExtensionSpec{Name: "pg_trgm", Ensure: apiv1.EnsurePresent, Schema: "ext"},
+			&extensionInfo{Name: "pg_trgm", Version: "1.6", Schema: "public"}, `schema "public"`),
+		Entry("unspecified version and schema",
+			apiv1.ExtensionSpec{Name: "pg_trgm", Ensure: apiv1.EnsurePresent},
+			&extensionInfo{Name: "pg_trgm", Version: "1.6", Schema: "public"}, ""),
+	)
+
+	Context("default privileges", func() {
+		const listQuery = `
+		SELECT acl.privilege_type
+		FROM pg_catalog.pg_default_acl d
+		LEFT JOIN pg_catalog.pg_namespace n ON d.defaclnamespace = n.oid
+		CROSS JOIN LATERAL pg_catalog.aclexplode(d.defaclacl) acl
+		WHERE d.defaclrole = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $1)
+		AND d.defaclobjtype = $2
+		AND COALESCE(n.nspname, '') = $3
+		AND acl.grantee = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $4)
+		`
+		var defaultPrivileges apiv1.DefaultPrivilegesConfiguration
+
+		BeforeEach(func() {
+			defaultPrivileges = apiv1.DefaultPrivilegesConfiguration{
+				Grantor:    "app",
+				Grantee:    "reader",
+				ObjectType: apiv1.DefaultPrivilegesObjectTypeTables,
+				Schema:     "sales",
+				Privileges: []apiv1.GrantPrivilege{apiv1.GrantPrivilegeSelect},
+			}
+		})
+
+		expectPrivileges := func(privileges ...string) {
+			rows := sqlmock.NewRows([]string{"privilege_type"})
+			for _, privilege := range privileges {
+				rows.AddRow(privilege)
+			}
+			dbMock.ExpectQuery(listQuery).
+				WithArgs(defaultPrivileges.Grantor, defaultPrivileges.ObjectType.GetCatalogObjectType(),
+					defaultPrivileges.Schema, defaultPrivileges.Grantee).
+				WillReturnRows(rows)
+		}
+
+		It("grants the missing default privileges", func(ctx SpecContext) {
+			expectPrivileges()
+			dbMock.ExpectExec(
+				`ALTER DEFAULT PRIVILEGES FOR ROLE "app" IN SCHEMA "sales" GRANT SELECT ON TABLES TO "reader"`).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			Expect(reconcileDatabaseDefaultPrivileges(ctx, db, defaultPrivileges)).To(Succeed())
+		})
+
+		It("doesn't alter the default privileges matching their specification", func(ctx SpecContext) {
+			expectPrivileges("SELECT")
+
+			Expect(reconcileDatabaseDefaultPrivileges(ctx, db, defaultPrivileges)).To(Succeed())
+		})
+
+		It("grants and revokes default privileges in the same cycle", func(ctx SpecContext) {
+			defaultPrivileges.Privileges = []apiv1.GrantPrivilege{apiv1.GrantPrivilegeSelect, apiv1.GrantPrivilegeInsert}
+			expectPrivileges("SELECT", "DELETE", "UPDATE")
+			dbMock.ExpectExec(
+				`ALTER DEFAULT PRIVILEGES FOR ROLE "app" IN SCHEMA "sales" GRANT INSERT ON TABLES TO "reader"`).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			dbMock.ExpectExec(
+				`ALTER DEFAULT PRIVILEGES FOR ROLE "app" IN SCHEMA "sales" REVOKE UPDATE, DELETE ON TABLES FROM "reader"`).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			Expect(reconcileDatabaseDefaultPrivileges(ctx, db, defaultPrivileges)).To(Succeed())
+		})
+
+		It("revokes every default privilege when none is requested", func(ctx SpecContext) {
+			defaultPrivileges.Privileges = nil
+			expectPrivileges("SELECT")
+			dbMock.ExpectExec(
+				`ALTER DEFAULT PRIVILEGES FOR ROLE "app" IN SCHEMA "sales" REVOKE SELECT ON TABLES FROM "reader"`).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			Expect(reconcileDatabaseDefaultPrivileges(ctx, db, defaultPrivileges)).To(Succeed())
+		})
+
+		It("manages the default privileges of the objects created in any schema", func(ctx SpecContext) {
+			defaultPrivileges.Schema = ""
+			defaultPrivileges.ObjectType = apiv1.DefaultPrivilegesObjectTypeFunctions
+			defaultPrivileges.Privileges = []apiv1.GrantPrivilege{apiv1.GrantPrivilegeExecute}
+			expectPrivileges()
+			dbMock.ExpectExec(
+				`ALTER DEFAULT PRIVILEGES FOR ROLE "app" GRANT EXECUTE ON FUNCTIONS TO "reader"`).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			Expect(reconcileDatabaseDefaultPrivileges(ctx, db, defaultPrivileges)).To(Succeed())
+		})
+
+		It("reports the errors while altering the default privileges", func(ctx SpecContext) {
+			expectPrivileges()
+			dbMock.ExpectExec(
+				`ALTER DEFAULT PRIVILEGES FOR ROLE "app" IN SCHEMA "sales" GRANT SELECT ON TABLES TO "reader"`).
+				WillReturnError(errors.New("role \"reader\" does not exist"))
+
+			Expect(reconcileDatabaseDefaultPrivileges(ctx, db, defaultPrivileges)).
+				To(MatchError(ContainSubstring("role \"reader\" does not exist")))
+		})
+	})
 })
@@ -23,6 +23,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -45,6 +46,15 @@ const databaseDetectionQuery = `SELECT count(*)
 			FROM pg_database
 			WHERE datname = $1`
 
+const databaseOwnerQuery = `SELECT pg_catalog.pg_get_userbyid(datdba)
+		FROM pg_catalog.pg_database
+		WHERE datname = $1`
+
+const extensionDetectionQuery = `SELECT e.extname, e.extversion, n.nspname
+		FROM pg_catalog.pg_extension e
+		JOIN pg_catalog.pg_namespace n ON e.extnamespace = n.oid
+		WHERE e.extname = $1`
+
 var _ = Describe("Managed Database status", func() {
 	var (
 		dbMock     sqlmock.Sqlmock
@@ -103,6 +113,9 @@ var _ = Describe("Managed Database status", func() {
 			getSuperUserDB: func() (*sql.DB, error) {
 				return db, nil
 			},
+			getTargetDB: func(_ string) (*sql.DB, error) {
+				return db, nil
+			},
 		}
 		r.finalizerReconciler = newFinalizerReconciler(
 			fakeClient,
@@ -155,6 +168,172 @@ var _ = Describe("Managed Database status", func() {
 		Expect(database.GetStatusMessage()).Should(ContainSubstring(expectedError.Error()))
 	})
 
+	It("creates the managed extensions in the database", func(ctx SpecContext) {
+		database.Spec.Extensions = []apiv1.ExtensionSpec{
+			{Name: "pg_trgm", Ensure: apiv1.EnsurePresent},
+			{Name: "hstore", Ensure: apiv1.EnsureAbsent},
+		}
+		Expect(fakeClient.Update(ctx, database)).To(Succeed())
+
+		dbMock.ExpectQuery(databaseDetectionQuery).WithArgs(database.Spec.Name).
+			WillReturnRows(sqlmock.NewRows([]string{""}).AddRow("1"))
+		dbMock.ExpectExec(fmt.Sprintf("ALTER DATABASE %s OWNER TO %s",
+			pgx.Identifier{database.Spec.Name}.Sanitize(),
+			pgx.Identifier{database.Spec.Owner}.Sanitize(),
+		)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectQuery(extensionDetectionQuery).WithArgs("pg_trgm").
+			WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}))
+		dbMock.ExpectExec(`CREATE EXTENSION "pg_trgm"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		dbMock.ExpectQuery(extensionDetectionQuery).WithArgs("hstore").
+			WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}).
+				AddRow("hstore", "1.8", "public"))
+		dbMock.ExpectExec(`DROP EXTENSION IF EXISTS "hstore"`).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := reconcileDatabase(ctx, fakeClient, r, database)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(database.Status.Applied).Should(HaveValue(BeTrue()))
+		Expect(database.GetStatusMessage()).Should(BeEmpty())
+	})
+
+	It("marks as failed when a managed extension cannot be created", func(ctx SpecContext) {
+		database.Spec.Extensions = []apiv1.ExtensionSpec{
+			{Name: "missing_extension", Ensure: apiv1.EnsurePresent},
+			{Name: "pg_trgm", Ensure: apiv1.EnsurePresent},
+		}
+		Expect(fakeClient.Update(ctx, database)).To(Succeed())
+
+		dbMock.ExpectQuery(databaseDetectionQuery).WithArgs(database.Spec.Name).
+			WillReturnRows(sqlmock.NewRows([]string{""}).AddRow("1"))
+		dbMock.ExpectExec(fmt.Sprintf("ALTER DATABASE %s OWNER TO %s",
+			pgx.Identifier{database.Spec.Name}.Sanitize(),
+			pgx.Identifier{database.Spec.Owner}.Sanitize(),
+		)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		dbMock.ExpectQuery(extensionDetectionQuery).WithArgs("missing_extension").
+			WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}))
+		dbMock.ExpectExec(`CREATE EXTENSION "missing_extension"`).
+			WillReturnError(fmt.Errorf("extension is not available"))
+		dbMock.ExpectQuery(extensionDetectionQuery).WithArgs("pg_trgm").
+			WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}).
+				AddRow("pg_trgm", "1.6", "public"))
+
+		err := reconcileDatabase(ctx, fakeClient, r, database)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(database.Status.Applied).Should(HaveValue(BeFalse()))
+		Expect(database.GetStatusMessage()).Should(ContainSubstring(`while creating extension "missing_extension"`))
+	})
+
+	Context("drift detection", func() {
+		BeforeEach(func(ctx SpecContext) {
+			database.Status.ObservedGeneration = database.Generation
+			database.Status.Applied = ptr.To(true)
+			Expect(fakeClient.Status().Update(ctx, database)).To(Succeed())
+		})
+
+		It("doesn't alter a reconciled database matching its specification", func(ctx SpecContext) {
+			dbMock.ExpectQuery(databaseOwnerQuery).WithArgs(database.Spec.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"owner"}).AddRow(database.Spec.Owner))
+
+			err := reconcileDatabase(ctx, fakeClient, r, database)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(database.Status.Applied).Should(HaveValue(BeTrue()))
+		})
+
+		It("restores the owner of a reconciled database", func(ctx SpecContext) {
+			dbMock.ExpectQuery(databaseOwnerQuery).WithArgs(database.Spec.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"owner"}).AddRow("postgres"))
+			dbMock.ExpectQuery(databaseDetectionQuery).WithArgs(database.Spec.Name).
+				WillReturnRows(sqlmock.NewRows([]string{""}).AddRow("1"))
+			dbMock.ExpectExec(fmt.Sprintf("ALTER DATABASE %s OWNER TO %s",
+				pgx.Identifier{database.Spec.Name}.Sanitize(),
+				pgx.Identifier{database.Spec.Owner}.Sanitize(),
+			)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			err := reconcileDatabase(ctx, fakeClient, r, database)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(database.Status.Applied).Should(HaveValue(BeTrue()))
+		})
+
+		Context("with managed extensions", func() {
+			BeforeEach(func(ctx SpecContext) {
+				database.Spec.Extensions = []apiv1.ExtensionSpec{
+					{Name: "pg_trgm", Ensure: apiv1.EnsurePresent, Version: "1.6"},
+				}
+				Expect(fakeClient.Update(ctx, database)).To(Succeed())
+				database.Status.ObservedGeneration = database.Generation
+				Expect(fakeClient.Status().Update(ctx, database)).To(Succeed())
+
+				dbMock.ExpectQuery(databaseOwnerQuery).WithArgs(database.Spec.Name).
+					WillReturnRows(sqlmock.NewRows([]string{"owner"}).AddRow(database.Spec.Owner))
+			})
+
+			It("doesn't alter the extensions matching their specification", func(ctx SpecContext) {
+				dbMock.ExpectQuery(extensionDetectionQuery).WithArgs("pg_trgm").
+					WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}).
+						AddRow("pg_trgm", "1.6", "public"))
+
+				err := reconcileDatabase(ctx, fakeClient, r, database)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(database.Status.Applied).Should(HaveValue(BeTrue()))
+			})
+
+			It("recreates an extension that has been dropped", func(ctx SpecContext) {
+				dbMock.ExpectQuery(extensionDetectionQuery).WithArgs("pg_trgm").
+					WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}))
+
+				dbMock.ExpectQuery(databaseDetectionQuery).WithArgs(database.Spec.Name).
+					WillReturnRows(sqlmock.NewRows([]string{""}).AddRow("1"))
+				dbMock.ExpectExec(fmt.Sprintf("ALTER DATABASE %s OWNER TO %s",
+					pgx.Identifier{database.Spec.Name}.Sanitize(),
+					pgx.Identifier{database.Spec.Owner}.Sanitize(),
+				)).WillReturnResult(sqlmock.NewResult(0, 1))
+				dbMock.ExpectQuery(extensionDetectionQuery).WithArgs("pg_trgm").
+					WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion", "nspname"}))
+				dbMock.ExpectExec(`CREATE EXTENSION "pg_trgm" VERSION "1.6"`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				err := reconcileDatabase(ctx, fakeClient, r, database)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(database.Status.Applied).Should(HaveValue(BeTrue()))
+			})
+		})
+
+		It("recreates a reconciled database that has been dropped", func(ctx SpecContext) {
+			dbMock.ExpectQuery(databaseOwnerQuery).WithArgs(database.Spec.Name).
+				WillReturnRows(sqlmock.NewRows([]string{"owner"}))
+			dbMock.ExpectQuery(databaseDetectionQuery).WithArgs(database.Spec.Name).
+				WillReturnRows(sqlmock.NewRows([]string{""}).AddRow("0"))
+			dbMock.ExpectExec(fmt.Sprintf("CREATE DATABASE %s OWNER %s",
+				pgx.Identifier{database.Spec.Name}.Sanitize(),
+				pgx.Identifier{database.Spec.Owner}.Sanitize(),
+			)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+			err := reconcileDatabase(ctx, fakeClient, r, database)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(database.Status.Applied).Should(HaveValue(BeTrue()))
+		})
+	})
+
+	It("aligns a database created after being detected as missing", func(ctx SpecContext) {
+		dbMock.ExpectQuery(databaseDetectionQuery).WithArgs(database.Spec.Name).
+			WillReturnRows(sqlmock.NewRows([]string{""}).AddRow("0"))
+		dbMock.ExpectExec(fmt.Sprintf("CREATE DATABASE %s OWNER %s",
+			pgx.Identifier{database.Spec.Name}.Sanitize(),
+			pgx.Identifier{database.Spec.Owner}.Sanitize(),
+		)).WillReturnError(&pgconn.PgError{Code: "42P04", Message: "database already exists"})
+		dbMock.ExpectExec(fmt.Sprintf("ALTER DATABASE %s OWNER TO %s",
+			pgx.Identifier{database.Spec.Name}.Sanitize(),
+			pgx.Identifier{database.Spec.Owner}.Sanitize(),
+		)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := reconcileDatabase(ctx, fakeClient, r, database)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(database.Status.Applied).Should(HaveValue(BeTrue()))
+	})
+
 	When("reclaim policy is delete", func() {
 		It("on deletion it removes finalizers and drops DB", func(ctx SpecContext) {
 			// Mocking DetectDB
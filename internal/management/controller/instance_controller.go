@@ -151,13 +151,11 @@ func (r *InstanceReconciler) Reconcile(
 
 	// Reconcile secrets and cryptographic material
 	// This doesn't need the PG connection, but it needs to reload it in case of changes
-	reloadNeeded := r.RefreshSecrets(ctx, cluster)
+	changes := configurationFilesChanges{secrets: r.RefreshSecrets(ctx, cluster)}
 
-	reloadConfigNeeded, err := r.refreshConfigurationFiles(ctx, cluster)
-	if err != nil {
+	if err := r.refreshConfigurationFiles(ctx, cluster, &changes); err != nil {
 		return reconcile.Result{}, err
 	}
-	reloadNeeded = reloadNeeded || reloadConfigNeeded
 
 	// here we execute initialization tasks that need to be executed only on the first reconciliation loop
 	if !r.firstReconcileDone.Load() {
@@ -172,7 +170,7 @@ func (r *InstanceReconciler) Reconcile(
 	if err != nil {
 		return reconcile.Result{}, err
 	}
-	reloadNeeded = reloadNeeded || reloadClusterRoleConfig
+	changes.postgresql = changes.postgresql || reloadClusterRoleConfig
 
 	r.systemInitialization.Broadcast()
 
@@ -221,7 +219,11 @@ func (r *InstanceReconciler) Reconcile(
 	}
 	restarted = restarted || restartedInplace
 
-	if reloadNeeded && !restarted {
+	if changes.isHBAOnly() && !restarted {
+		if err = r.reloadHBA(ctx); err != nil {
+			return reconcile.Result{}, err
+		}
+	} else if changes.reloadNeeded() && !restarted {
 		contextLogger.Info("reloading the instance")
 		if err = r.instance.Reload(ctx); err != nil {
 			return reconcile.Result{}, fmt.Errorf("while reloading the instance: %w", err)
@@ -338,35 +340,153 @@ func (r *InstanceReconciler) restartPrimaryInplaceIfRequested(
 	return false, nil
 }
 
+// refreshConfigurationFiles writes the host-based authentication rules and the
+// PostgreSQL configuration, tracking the changed files in the passed structure
 func (r *InstanceReconciler) refreshConfigurationFiles(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
-) (reloadNeeded bool, err error) {
-	reloadNeeded, err = r.refreshPGHBA(ctx, cluster)
+	changes *configurationFilesChanges,
+) error {
+	reloadHBA, err := r.refreshPGHBA(ctx, cluster)
 	if err != nil {
-		return false, err
+		return err
 	}
+	changes.hba = changes.hba || reloadHBA
 
-	reloadIdent, err := r.instance.RefreshPGIdent(ctx, cluster.Spec.PostgresConfiguration.PgIdent)
+	reloadIdent, err := r.instance.RefreshPGIdent(ctx, postgresManagement.GetIdentLines(cluster))
 	if err != nil {
-		return false, err
+		return err
+	}
+	changes.hba = changes.hba || reloadIdent
+
+	reloadInclude, err := r.refreshIncludedConfiguration(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	changes.postgresql = changes.postgresql || reloadInclude
+
+	previousConfiguration, err := r.instance.GetCustomConfiguration()
+	if err != nil {
+		return err
 	}
-	reloadNeeded = reloadNeeded || reloadIdent
 
 	// Reconcile PostgreSQL configuration
 	// This doesn't need the PG connection, but it needs to reload it in case of changes
 	reloadConfig, err := r.instance.RefreshConfigurationFilesFromCluster(ctx, cluster, false)
 	if err != nil {
-		return false, err
+		return err
+	}
+	changes.postgresql = changes.postgresql || reloadConfig
+	if reloadConfig {
+		r.classifyParameterChanges(ctx, previousConfiguration)
 	}
-	reloadNeeded = reloadNeeded || reloadConfig
 
 	reloadReplicaConfig, err := r.instance.RefreshReplicaConfiguration(ctx, cluster, r.client)
+	if err != nil {
+		return err
+	}
+	changes.postgresql = changes.postgresql || reloadReplicaConfig
+	return nil
+}
+
+// reloadHBA reloads the instance to apply new host-based authentication
+// rules and records whether PostgreSQL accepted them. These rules never
+// require a restart, so the pending restart detection is skipped.
+func (r *InstanceReconciler) reloadHBA(ctx context.Context) error {
+	contextLogger := log.FromContext(ctx)
+
+	requestTime := time.Now()
+	contextLogger.Info("reloading the instance to apply the host-based authentication rules")
+	if err := r.instance.Reload(ctx); err != nil {
+		return fmt.Errorf("while reloading the instance: %w", err)
+	}
+
+	status, err := r.instance.WaitForHBAReload(ctx, requestTime)
+	if err != nil {
+		return err
+	}
+	r.instance.SetLastHBAReload(status)
+
+	if !status.Succeeded {
+		contextLogger.Warning("PostgreSQL rejected the new host-based authentication rules, "+
+			"the previous ones are still in use", "errors", status.Errors)
+		return nil
+	}
+	contextLogger.Info("Host-based authentication rules reloaded", "reloadTime", status.ReloadTime)
+	return nil
+}
+
+// refreshIncludedConfiguration writes the content of the ConfigMap referenced
+// by the cluster in the file included in the PostgreSQL configuration
+func (r *InstanceReconciler) refreshIncludedConfiguration(
+	ctx context.Context,
+	cluster *apiv1.Cluster,
+) (bool, error) {
+	contextLogger := log.FromContext(ctx)
+
+	reference := cluster.Spec.PostgresConfiguration.IncludeConfigMap
+	if reference == nil {
+		return r.instance.RefreshIncludedConfiguration(ctx, "")
+	}
+
+	var configMap corev1.ConfigMap
+	err := r.GetClient().Get(
+		ctx,
+		client.ObjectKey{Namespace: r.instance.GetNamespaceName(), Name: reference.Name},
+		&configMap)
+	if apierrors.IsNotFound(err) {
+		contextLogger.Warning("Unable to find the ConfigMap included in the PostgreSQL configuration",
+			"reference", reference)
+		return false, nil
+	}
 	if err != nil {
 		return false, err
 	}
-	reloadNeeded = reloadNeeded || reloadReplicaConfig
-	return reloadNeeded, nil
+
+	content, ok := configMap.Data[reference.Key]
+	if !ok {
+		contextLogger.Warning("Missing key in the ConfigMap included in the PostgreSQL configuration",
+			"reference", reference)
+		return false, nil
+	}
+
+	return r.instance.RefreshIncludedConfiguration(ctx, content)
+}
+
+// classifyParameterChanges detects the parameters changed by the latest update
+// of the configuration and classifies them between the ones applied by a reload
+// and the ones requiring a restart, using the context reported by PostgreSQL
+func (r *InstanceReconciler) classifyParameterChanges(ctx context.Context, previousConfiguration string) {
+	contextLogger := log.FromContext(ctx)
+
+	currentConfiguration, err := r.instance.GetCustomConfiguration()
+	if err != nil {
+		contextLogger.Warning("Cannot read the PostgreSQL configuration", "err", err)
+		return
+	}
+
+	changed := postgres.GetChangedParameters(previousConfiguration, currentConfiguration)
+	if len(changed) == 0 {
+		return
+	}
+
+	superUserDB, err := r.instance.GetSuperUserDB()
+	if err != nil {
+		contextLogger.Info("Cannot classify the changed parameters", "changed", changed, "err", err)
+		return
+	}
+
+	contexts, err := r.instance.GetSettingsContext(superUserDB)
+	if err != nil {
+		contextLogger.Info("Cannot classify the changed parameters", "changed", changed, "err", err)
+		return
+	}
+
+	changes := postgres.ClassifyParameterChanges(changed, contexts)
+	r.instance.SetLastParameterChanges(&changes)
+	contextLogger.Info("PostgreSQL parameters changed",
+		"reloadOnly", changes.ReloadOnly,
+		"restartRequired", changes.RestartRequired)
 }
 
 func (r *InstanceReconciler) reconcileFencing(ctx context.Context, cluster *apiv1.Cluster) *reconcile.Result {
@@ -977,6 +1097,12 @@ func (r *InstanceReconciler) reconcileInstance(cluster *apiv1.Cluster) {
 	r.instance.MaxStopDelay = cluster.GetMaxStopDelay()
 	r.instance.SmartStopDelay = cluster.GetSmartShutdownTimeout()
 	r.instance.RequiresDesignatedPrimaryTransition = detectRequiresDesignatedPrimaryTransition()
+	r.instance.SetStreamingRequiredForReadiness(cluster.IsStreamingRequiredForReadiness())
+
+	// The maximum lag has been validated by the webhook, and an invalid
+	// value just disables the check
+	maxLagBytes, maxLagDuration, _ := cluster.GetMaxStandbyLagForReady()
+	r.instance.SetMaxStandbyLagForReady(maxLagBytes, maxLagDuration)
 }
 
 // PostgreSQLAutoConfWritable reconciles the permissions bit of `postgresql.auto.conf`
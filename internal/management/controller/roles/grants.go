@@ -0,0 +1,189 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package roles
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/jackc/pgx/v5"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// listTablePrivilegesQuery lists the privileges held by a role on a table
+const listTablePrivilegesQuery = `SELECT privilege_type
+FROM information_schema.role_table_grants
+WHERE grantee = $1 AND table_schema = $2 AND table_name = $3`
+
+// listSchemaPrivilegesQuery lists the privileges held by a role on a schema.
+// The information schema doesn't expose the privileges on schemas,
+// so they are extracted from the access control list in the catalog
+const listSchemaPrivilegesQuery = `SELECT acl.privilege_type
+FROM pg_catalog.pg_namespace AS n, pg_catalog.aclexplode(n.nspacl) AS acl
+WHERE n.nspname = $2
+	AND acl.grantee = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $1)`
+
+// listSequencePrivilegesQuery lists the privileges held by a role on a sequence.
+// The information schema only exposes the USAGE privilege on sequences,
+// so they are extracted from the access control list in the catalog
+const listSequencePrivilegesQuery = `SELECT acl.privilege_type
+FROM pg_catalog.pg_class AS c
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace,
+	pg_catalog.aclexplode(c.relacl) AS acl
+WHERE c.relkind = 'S' AND n.nspname = $2 AND c.relname = $3
+	AND acl.grantee = (SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $1)`
+
+// ListPrivileges lists the privileges the role of the grant configuration
+// holds on its object
+func ListPrivileges(
+	ctx context.Context,
+	db *sql.DB,
+	grant apiv1.GrantConfiguration,
+) ([]apiv1.GrantPrivilege, error) {
+	logger := log.FromContext(ctx).WithName("roles_reconciler")
+	wrapErr := func(err error) error {
+		return fmt.Errorf("while listing the privileges of role %s on %s %s: %w",
+			grant.Role, grant.ObjectType, getGrantObjectName(grant), err)
+	}
+
+	var rows *sql.Rows
+	var err error
+	switch grant.ObjectType {
+	case apiv1.GrantObjectTypeSchema:
+		rows, err = db.QueryContext(ctx, listSchemaPrivilegesQuery, grant.Role, grant.Schema)
+	case apiv1.GrantObjectTypeTable:
+		rows, err = db.QueryContext(ctx, listTablePrivilegesQuery, grant.Role, grant.Schema, grant.Name)
+	case apiv1.GrantObjectTypeSequence:
+		rows, err = db.QueryContext(ctx, listSequencePrivilegesQuery, grant.Role, grant.Schema, grant.Name)
+	default:
+		return nil, wrapErr(fmt.Errorf("unknown object type %q", grant.ObjectType))
+	}
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Info("Ignorable error while listing privileges", "err", err)
+		}
+	}()
+
+	var privileges []apiv1.GrantPrivilege
+	for rows.Next() {
+		var privilege string
+		if err := rows.Scan(&privilege); err != nil {
+			return nil, wrapErr(err)
+		}
+		privileges = append(privileges, apiv1.GrantPrivilege(privilege))
+	}
+
+	if rows.Err() != nil {
+		return nil, wrapErr(rows.Err())
+	}
+
+	return privileges, nil
+}
+
+// GrantPrivileges grants the passed privileges to the role of the
+// grant configuration
+func GrantPrivileges(
+	ctx context.Context,
+	db *sql.DB,
+	grant apiv1.GrantConfiguration,
+	privileges []apiv1.GrantPrivilege,
+) error {
+	if len(privileges) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("GRANT %s ON %s TO %s",
+		joinPrivileges(privileges), getGrantObjectClause(grant), pgx.Identifier{grant.Role}.Sanitize())
+	log.FromContext(ctx).WithName("roles_reconciler").Debug("Granting privileges", "query", query)
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("while granting privileges to role %s: %w", grant.Role, err)
+	}
+
+	return nil
+}
+
+// RevokePrivileges revokes the passed privileges from the role of the
+// grant configuration
+func RevokePrivileges(
+	ctx context.Context,
+	db *sql.DB,
+	grant apiv1.GrantConfiguration,
+	privileges []apiv1.GrantPrivilege,
+) error {
+	if len(privileges) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("REVOKE %s ON %s FROM %s",
+		joinPrivileges(privileges), getGrantObjectClause(grant), pgx.Identifier{grant.Role}.Sanitize())
+	log.FromContext(ctx).WithName("roles_reconciler").Debug("Revoking privileges", "query", query)
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("while revoking privileges from role %s: %w", grant.Role, err)
+	}
+
+	return nil
+}
+
+// getPrivilegesDiff returns the privileges in the spec missing from the
+// database, and the supported privileges in the database missing from the spec
+func getPrivilegesDiff(
+	grant apiv1.GrantConfiguration,
+	privilegesInDB []apiv1.GrantPrivilege,
+) (toGrant []apiv1.GrantPrivilege, toRevoke []apiv1.GrantPrivilege) {
+	for _, privilege := range grant.ObjectType.GetSupportedPrivileges() {
+		inSpec := slices.Contains(grant.Privileges, privilege)
+		inDB := slices.Contains(privilegesInDB, privilege)
+		switch {
+		case inSpec && !inDB:
+			toGrant = append(toGrant, privilege)
+		case !inSpec && inDB:
+			toRevoke = append(toRevoke, privilege)
+		}
+	}
+
+	return toGrant, toRevoke
+}
+
+func joinPrivileges(privileges []apiv1.GrantPrivilege) string {
+	names := make([]string, len(privileges))
+	for i, privilege := range privileges {
+		names[i] = string(privilege)
+	}
+	return strings.Join(names, ", ")
+}
+
+// getGrantObjectName returns the quoted name of the object of a grant configuration
+func getGrantObjectName(grant apiv1.GrantConfiguration) string {
+	if grant.ObjectType == apiv1.GrantObjectTypeSchema {
+		return pgx.Identifier{grant.Schema}.Sanitize()
+	}
+	return pgx.Identifier{grant.Schema, grant.Name}.Sanitize()
+}
+
+// getGrantObjectClause returns the object clause of the GRANT and
+// REVOKE statements for a grant configuration
+func getGrantObjectClause(grant apiv1.GrantConfiguration) string {
+	return fmt.Sprintf("%s %s", strings.ToUpper(string(grant.ObjectType)), getGrantObjectName(grant))
+}
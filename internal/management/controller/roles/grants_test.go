@@ -0,0 +1,157 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package roles
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Managed grants", func() {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+	)
+
+	tableGrant := apiv1.GrantConfiguration{
+		Role:       "app_reader",
+		ObjectType: apiv1.GrantObjectTypeTable,
+		Schema:     "public",
+		Name:       "orders",
+		Privileges: []apiv1.GrantPrivilege{apiv1.GrantPrivilegeSelect, apiv1.GrantPrivilegeInsert},
+	}
+	schemaGrant := apiv1.GrantConfiguration{
+		Role:       "app_reader",
+		ObjectType: apiv1.GrantObjectTypeSchema,
+		Schema:     "sales",
+		Privileges: []apiv1.GrantPrivilege{apiv1.GrantPrivilegeUsage},
+	}
+	sequenceGrant := apiv1.GrantConfiguration{
+		Role:       "app_reader",
+		ObjectType: apiv1.GrantObjectTypeSequence,
+		Schema:     "public",
+		Name:       "orders_id_seq",
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, mock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	It("lists the privileges on a table from the information schema", func(ctx context.Context) {
+		mock.ExpectQuery(listTablePrivilegesQuery).
+			WithArgs("app_reader", "public", "orders").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}).AddRow("SELECT").AddRow("DELETE"))
+
+		privileges, err := ListPrivileges(ctx, db, tableGrant)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(privileges).To(ConsistOf(apiv1.GrantPrivilegeSelect, apiv1.GrantPrivilegeDelete))
+	})
+
+	It("lists the privileges on a schema from the catalog", func(ctx context.Context) {
+		mock.ExpectQuery(listSchemaPrivilegesQuery).
+			WithArgs("app_reader", "sales").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}))
+
+		privileges, err := ListPrivileges(ctx, db, schemaGrant)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(privileges).To(BeEmpty())
+	})
+
+	It("computes the privileges to grant and to revoke", func() {
+		toGrant, toRevoke := getPrivilegesDiff(tableGrant, []apiv1.GrantPrivilege{
+			apiv1.GrantPrivilegeSelect,
+			apiv1.GrantPrivilegeDelete,
+			// not supported by the object type, never revoked
+			apiv1.GrantPrivilegeUsage,
+		})
+		Expect(toGrant).To(ConsistOf(apiv1.GrantPrivilegeInsert))
+		Expect(toRevoke).To(ConsistOf(apiv1.GrantPrivilegeDelete))
+	})
+
+	It("quotes the identifiers in the GRANT and REVOKE statements", func(ctx context.Context) {
+		grant := apiv1.GrantConfiguration{
+			Role:       `bad"role`,
+			ObjectType: apiv1.GrantObjectTypeTable,
+			Schema:     "public",
+			Name:       "orders; DROP TABLE orders",
+		}
+		mock.ExpectExec(`GRANT SELECT, INSERT ON TABLE "public"."orders; DROP TABLE orders" TO "bad""role"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`REVOKE USAGE ON TABLE "public"."orders; DROP TABLE orders" FROM "bad""role"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		Expect(GrantPrivileges(ctx, db, grant,
+			[]apiv1.GrantPrivilege{apiv1.GrantPrivilegeSelect, apiv1.GrantPrivilegeInsert})).To(Succeed())
+		Expect(RevokePrivileges(ctx, db, grant, []apiv1.GrantPrivilege{apiv1.GrantPrivilegeUsage})).To(Succeed())
+	})
+
+	It("doesn't issue any statement when there are no privileges to change", func(ctx context.Context) {
+		Expect(GrantPrivileges(ctx, db, tableGrant, nil)).To(Succeed())
+		Expect(RevokePrivileges(ctx, db, tableGrant, nil)).To(Succeed())
+	})
+
+	It("synchronizes the grants issuing only the needed statements", func(ctx context.Context) {
+		mock.ExpectQuery(listTablePrivilegesQuery).
+			WithArgs("app_reader", "public", "orders").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}).AddRow("SELECT").AddRow("INSERT"))
+		mock.ExpectQuery(listSchemaPrivilegesQuery).
+			WithArgs("app_reader", "sales").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}).AddRow("USAGE").AddRow("CREATE"))
+		mock.ExpectExec(`REVOKE CREATE ON SCHEMA "sales" FROM "app_reader"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(listSequencePrivilegesQuery).
+			WithArgs("app_reader", "public", "orders_id_seq").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}))
+
+		roleSynchronizer := RoleSynchronizer{}
+		Expect(roleSynchronizer.synchronizeGrants(ctx, db,
+			[]apiv1.GrantConfiguration{tableGrant, schemaGrant, sequenceGrant})).To(Succeed())
+	})
+
+	It("carries on after an error on a grant", func(ctx context.Context) {
+		missingTableGrant := tableGrant
+		missingTableGrant.Name = "missing"
+		mock.ExpectQuery(listTablePrivilegesQuery).
+			WithArgs("app_reader", "public", "missing").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}))
+		mock.ExpectExec(`GRANT SELECT, INSERT ON TABLE "public"."missing" TO "app_reader"`).
+			WillReturnError(errors.New(`relation "public.missing" does not exist`))
+		mock.ExpectQuery(listSchemaPrivilegesQuery).
+			WithArgs("app_reader", "sales").
+			WillReturnRows(sqlmock.NewRows([]string{"privilege_type"}))
+		mock.ExpectExec(`GRANT USAGE ON SCHEMA "sales" TO "app_reader"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		roleSynchronizer := RoleSynchronizer{}
+		err := roleSynchronizer.synchronizeGrants(ctx, db,
+			[]apiv1.GrantConfiguration{missingTableGrant, schemaGrant})
+		Expect(err).To(MatchError(ContainSubstring(`relation "public.missing" does not exist`)))
+	})
+})
@@ -35,8 +35,17 @@ func Reconcile(
 	cluster *apiv1.Cluster,
 	c client.Client,
 ) (reconcile.Result, error) {
-	if cluster.Spec.Managed == nil ||
-		len(cluster.Spec.Managed.Roles) == 0 {
+	if cluster.Spec.Managed == nil {
+		return reconcile.Result{}, nil
+	}
+
+	if len(cluster.Spec.Managed.Grants) > 0 {
+		// the runnable compares the grants with the database,
+		// issuing only the needed statements
+		instance.TriggerRoleSynchronizer(cluster.Spec.Managed)
+	}
+
+	if len(cluster.Spec.Managed.Roles) == 0 {
 		return reconcile.Result{}, nil
 	}
 
@@ -19,6 +19,7 @@ package roles
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -51,6 +52,7 @@ const (
 
 type instanceInterface interface {
 	GetSuperUserDB() (*sql.DB, error)
+	GetNamedDB(name string) (*sql.DB, error)
 	IsPrimary() (bool, error)
 	RoleSynchronizerChan() <-chan *apiv1.ManagedConfiguration
 	IsServerHealthy() error
@@ -103,9 +105,9 @@ func (sr *RoleSynchronizer) Start(ctx context.Context) error {
 			}
 			contextLog.Debug("RoleSynchronizer loop triggered")
 
-			// If the spec contains no roles to manage, stop the timer,
+			// If the spec contains no roles or grants to manage, stop the timer,
 			// the process will resume through the wakeUp channel if necessary
-			if config == nil || len(config.Roles) == 0 {
+			if config == nil || (len(config.Roles) == 0 && len(config.Grants) == 0) {
 				continue
 			}
 
@@ -147,6 +149,36 @@ func (sr *RoleSynchronizer) reconcile(ctx context.Context, config *apiv1.Managed
 		return err
 	}
 
+	if len(config.Roles) > 0 {
+		if err = sr.reconcileRoles(ctx, config, &remoteCluster); err != nil {
+			return err
+		}
+	}
+
+	if len(config.Grants) == 0 {
+		return nil
+	}
+
+	applicationDatabase := remoteCluster.GetApplicationDatabaseName()
+	if applicationDatabase == "" {
+		contextLog.Info("no application database defined, skipping grants reconciling")
+		return nil
+	}
+
+	appDB, err := sr.instance.GetNamedDB(applicationDatabase)
+	if err != nil {
+		return fmt.Errorf("while getting application database connection: %w", err)
+	}
+	return sr.synchronizeGrants(ctx, appDB, config.Grants)
+}
+
+// reconcileRoles synchronizes the managed roles and updates the cluster Status
+// with the latest applied changes
+func (sr *RoleSynchronizer) reconcileRoles(
+	ctx context.Context,
+	config *apiv1.ManagedConfiguration,
+	remoteCluster *apiv1.Cluster,
+) error {
 	rolePasswords := remoteCluster.Status.ManagedRolesStatus.PasswordStatus
 	if rolePasswords == nil {
 		rolePasswords = map[string]apiv1.PasswordState{}
@@ -163,13 +195,47 @@ func (sr *RoleSynchronizer) reconcile(ctx context.Context, config *apiv1.Managed
 	if err = sr.client.Get(ctx, types.NamespacedName{
 		Name:      sr.instance.GetClusterName(),
 		Namespace: sr.instance.GetNamespaceName(),
-	}, &remoteCluster); err != nil {
+	}, remoteCluster); err != nil {
 		return err
 	}
 	updatedCluster := remoteCluster.DeepCopy()
 	updatedCluster.Status.ManagedRolesStatus.PasswordStatus = appliedState
 	updatedCluster.Status.ManagedRolesStatus.CannotReconcile = irreconcilableRoles
-	return sr.client.Status().Patch(ctx, updatedCluster, client.MergeFrom(&remoteCluster))
+	return sr.client.Status().Patch(ctx, updatedCluster, client.MergeFrom(remoteCluster))
+}
+
+// synchronizeGrants aligns the privileges in the application database to the spec,
+// issuing only the needed GRANT and REVOKE statements.
+//
+// NOTE: synchronizeGrants will carry on after an error on a grant, so that
+// the other grants are not blocked by a user error, e.g. a missing table.
+// The collected errors are returned at the end.
+func (sr *RoleSynchronizer) synchronizeGrants(
+	ctx context.Context,
+	db *sql.DB,
+	grants []apiv1.GrantConfiguration,
+) error {
+	contextLog := log.FromContext(ctx).WithName("roles_reconciler")
+	contextLog.Debug("synchronizing managed grants")
+
+	var errs []error
+	for _, grant := range grants {
+		privilegesInDB, err := ListPrivileges(ctx, db, grant)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		toGrant, toRevoke := getPrivilegesDiff(grant, privilegesInDB)
+		if err := GrantPrivileges(ctx, db, grant, toGrant); err != nil {
+			errs = append(errs, err)
+		}
+		if err := RevokePrivileges(ctx, db, grant, toRevoke); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 func getRoleNames(roles []roleConfigurationAdapter) []string {
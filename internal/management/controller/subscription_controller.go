@@ -119,6 +119,8 @@ func (r *SubscriptionReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	// Let's get the connection string
 	connString, err := getSubscriptionConnectionString(
+		ctx,
+		r.Client,
 		cluster,
 		subscription.Spec.ExternalClusterName,
 		subscription.Spec.PublicationDBName,
@@ -213,6 +215,8 @@ func (r *SubscriptionReconciler) GetCluster(ctx context.Context) (*apiv1.Cluster
 // getSubscriptionConnectionString gets the connection string to be used to connect to
 // the specified external cluster, while connected to a pod of the specified cluster
 func getSubscriptionConnectionString(
+	ctx context.Context,
+	cli client.Client,
 	cluster *apiv1.Cluster,
 	externalClusterName string,
 	databaseName string,
@@ -222,5 +226,5 @@ func getSubscriptionConnectionString(
 		return "", fmt.Errorf("externalCluster '%s' not declared in cluster %s", externalClusterName, cluster.Name)
 	}
 
-	return external.GetServerConnectionString(&externalCluster, databaseName), nil
+	return external.GetServerConnectionString(ctx, cli, cluster.Namespace, &externalCluster, databaseName)
 }
@@ -58,7 +58,7 @@ var _ = Describe("Managed subscription controller tests", func() {
 		err          error
 	)
 
-	BeforeEach(func() {
+	BeforeEach(func(ctx SpecContext) {
 		cluster = &apiv1.Cluster{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "cluster-example",
@@ -97,9 +97,6 @@ var _ = Describe("Managed subscription controller tests", func() {
 				ExternalClusterName: "cluster-other",
 			},
 		}
-		connString, err = getSubscriptionConnectionString(cluster, "cluster-other", "app")
-		Expect(err).ToNot(HaveOccurred())
-
 		db, dbMock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		Expect(err).ToNot(HaveOccurred())
 
@@ -113,6 +110,9 @@ var _ = Describe("Managed subscription controller tests", func() {
 			WithStatusSubresource(&apiv1.Cluster{}, &apiv1.Subscription{}).
 			Build()
 
+		connString, err = getSubscriptionConnectionString(ctx, fakeClient, cluster, "cluster-other", "app")
+		Expect(err).ToNot(HaveOccurred())
+
 		r = &SubscriptionReconciler{
 			Client:   fakeClient,
 			Scheme:   schemeBuilder.BuildWithAllKnownScheme(),
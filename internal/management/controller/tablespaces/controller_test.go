@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -67,6 +68,14 @@ func (f fakeInstance) CanCheckReadiness() bool {
 	return true
 }
 
+func (f fakeInstance) IsStreamingRequiredForReadiness() bool {
+	return false
+}
+
+func (f fakeInstance) GetMaxStandbyLagForReady() (int64, time.Duration) {
+	return 0, 0
+}
+
 func (f fakeInstance) IsPrimary() (bool, error) {
 	return true, nil
 }
@@ -19,6 +19,7 @@ package tablespaces
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -36,6 +37,8 @@ type instanceInterface interface {
 	GetSuperUserDB() (*sql.DB, error)
 	IsPrimary() (bool, error)
 	CanCheckReadiness() bool
+	IsStreamingRequiredForReadiness() bool
+	GetMaxStandbyLagForReady() (int64, time.Duration)
 }
 
 // TablespaceReconciler is a Kubernetes controller that ensures Tablespaces
@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -35,6 +36,7 @@ type PgBouncerInstanceInterface interface {
 	Pause() error
 	Resume() error
 	Reload() error
+	Drain(ctx context.Context) error
 }
 
 // NewPgBouncerInstance initializes a new pgBouncerInstance
@@ -143,3 +145,38 @@ func (p *pgBouncerInstance) Reload() error {
 
 	return nil
 }
+
+// Drain gracefully shuts down the PgBouncer instance, waiting for the
+// in-flight queries to complete and for the server connections to be
+// closed. The passed context bounds the time spent waiting
+func (p *pgBouncerInstance) Drain(ctx context.Context) error {
+	// First step: connect to the pgbouncer administrative database
+	db, err := p.pool.Connection("pgbouncer")
+	if err != nil {
+		return fmt.Errorf("while connecting to pgbouncer database locally: %w", err)
+	}
+
+	// Second step: wait for the in-flight queries to complete. PgBouncer
+	// refuses to be paused twice, so we skip this step if the user
+	// already requested the pooler to be paused
+	if !p.Paused() {
+		if _, err = db.ExecContext(ctx, "PAUSE"); err != nil {
+			return fmt.Errorf("while pausing instance: %w", err)
+		}
+
+		p.mu.Lock()
+		p.paused = true
+		p.mu.Unlock()
+	}
+
+	// Third step: wait for the server connections to be closed
+	if _, err = db.ExecContext(ctx, "WAIT_CLOSE"); err != nil {
+		return fmt.Errorf("while waiting for server connections to close: %w", err)
+	}
+
+	// Fourth step: shut down pgbouncer. The process terminates before
+	// answering, so the connection error we get back is expected
+	_, _ = db.ExecContext(ctx, "SHUTDOWN")
+
+	return nil
+}
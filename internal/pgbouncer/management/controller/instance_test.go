@@ -17,7 +17,9 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"sync"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -88,6 +90,52 @@ var _ = Describe("PgBouncerInstance", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	Context("when the instance is drained", func() {
+		It("should pause, wait for the server connections and shut down", func(ctx SpecContext) {
+			mock.ExpectExec("PAUSE").WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec("WAIT_CLOSE").WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec("SHUTDOWN").WillReturnError(driver.ErrBadConn)
+
+			pgBouncerInstance := &pgBouncerInstance{
+				mu:     &sync.RWMutex{},
+				paused: false,
+				pool:   &fakePooler{DB: db},
+			}
+
+			err := pgBouncerInstance.Drain(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pgBouncerInstance.Paused()).To(BeTrue())
+		})
+
+		It("should not pause an instance which is already paused", func(ctx SpecContext) {
+			mock.ExpectExec("WAIT_CLOSE").WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec("SHUTDOWN").WillReturnResult(sqlmock.NewResult(1, 1))
+
+			pgBouncerInstance := &pgBouncerInstance{
+				mu:     &sync.RWMutex{},
+				paused: true,
+				pool:   &fakePooler{DB: db},
+			}
+
+			err := pgBouncerInstance.Drain(ctx)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not shut down the instance if the drain fails", func(ctx SpecContext) {
+			mock.ExpectExec("PAUSE").WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec("WAIT_CLOSE").WillReturnError(context.DeadlineExceeded)
+
+			pgBouncerInstance := &pgBouncerInstance{
+				mu:     &sync.RWMutex{},
+				paused: false,
+				pool:   &fakePooler{DB: db},
+			}
+
+			err := pgBouncerInstance.Drain(ctx)
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+		})
+	})
 })
 
 type fakePooler struct {
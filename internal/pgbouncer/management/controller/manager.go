@@ -115,6 +115,11 @@ func (r *PgBouncerReconciler) Stop() {
 	}
 }
 
+// Drain gracefully drains and shuts down the managed PgBouncer instance
+func (r *PgBouncerReconciler) Drain(ctx context.Context) error {
+	return r.instance.Drain(ctx)
+}
+
 // GetClient returns the dynamic client that is being used for a certain reconciler
 func (r *PgBouncerReconciler) GetClient() ctrl.Client {
 	return r.client
@@ -19,6 +19,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -160,6 +161,22 @@ func (v *BackupCustomValidator) validate(r *apiv1.Backup) field.ErrorList {
 		))
 	}
 
+	if r.Spec.Instance != "" && r.Spec.Target != "" {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "instance"),
+			r.Spec.Instance,
+			"cannot be specified together with target",
+		))
+	}
+
+	if r.Spec.Instance != "" && !strings.HasPrefix(r.Spec.Instance, r.Spec.Cluster.Name+"-") {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "instance"),
+			r.Spec.Instance,
+			fmt.Sprintf("is not an instance of cluster %s", r.Spec.Cluster.Name),
+		))
+	}
+
 	if r.Spec.Method == apiv1.BackupMethodPlugin && r.Spec.PluginConfiguration.IsEmpty() {
 		result = append(result, field.Invalid(
 			field.NewPath("spec", "pluginConfiguration"),
@@ -67,6 +67,45 @@ var _ = Describe("Backup webhook validate", func() {
 		Expect(result[0].Field).To(Equal("spec.online"))
 	})
 
+	It("accepts an instance of the cluster", func() {
+		backup := &apiv1.Backup{
+			Spec: apiv1.BackupSpec{
+				Cluster:  apiv1.LocalObjectReference{Name: "cluster-example"},
+				Method:   apiv1.BackupMethodBarmanObjectStore,
+				Instance: "cluster-example-3",
+			},
+		}
+		result := v.validate(backup)
+		Expect(result).To(BeEmpty())
+	})
+
+	It("complains if the instance is not part of the cluster", func() {
+		backup := &apiv1.Backup{
+			Spec: apiv1.BackupSpec{
+				Cluster:  apiv1.LocalObjectReference{Name: "cluster-example"},
+				Method:   apiv1.BackupMethodBarmanObjectStore,
+				Instance: "another-cluster-1",
+			},
+		}
+		result := v.validate(backup)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.instance"))
+	})
+
+	It("complains if both instance and target are set", func() {
+		backup := &apiv1.Backup{
+			Spec: apiv1.BackupSpec{
+				Cluster:  apiv1.LocalObjectReference{Name: "cluster-example"},
+				Method:   apiv1.BackupMethodBarmanObjectStore,
+				Target:   apiv1.BackupTargetPrimary,
+				Instance: "cluster-example-3",
+			},
+		}
+		result := v.validate(backup)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.instance"))
+	})
+
 	It("complains if onlineConfiguration is set on a barman backup", func() {
 		backup := &apiv1.Backup{
 			Spec: apiv1.BackupSpec{
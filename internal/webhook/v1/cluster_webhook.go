@@ -20,6 +20,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"math"
+	"net"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -32,6 +36,7 @@ import (
 	"github.com/cloudnative-pg/machinery/pkg/types"
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	storagesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	"github.com/robfig/cron"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -51,7 +56,103 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
 
-const sharedBuffersParameter = "shared_buffers"
+const (
+	sharedBuffersParameter  = "shared_buffers"
+	hugePagesParameter      = "huge_pages"
+	lcMessagesParameter     = "lc_messages"
+	walCompressionParameter = "wal_compression"
+
+	maxReplicationSlotsParameter = "max_replication_slots"
+
+	trackCommitTimestampParameter         = "track_commit_timestamp"
+	maxLogicalReplicationWorkersParameter = "max_logical_replication_workers"
+	autovacuumParameter                   = "autovacuum"
+
+	sslModeParameter = "sslmode"
+)
+
+// secureSSLModes are the libpq `sslmode` values that never fall back to an
+// unencrypted connection
+var secureSSLModes = []string{"require", "verify-ca", "verify-full"}
+
+// integerParameterRange is the range of values accepted by
+// PostgreSQL for an integer configuration parameter
+type integerParameterRange struct {
+	min int64
+	max int64
+}
+
+// integerParameterRanges is a curated list of commonly set integer
+// configuration parameters that make PostgreSQL fail to start when
+// out of range.
+// Ref: the `min_val` and `max_val` columns of `pg_settings`
+var integerParameterRanges = map[string]integerParameterRange{
+	"autovacuum_max_workers":            {min: 1, max: 262143},
+	"max_connections":                   {min: 1, max: 262143},
+	"max_locks_per_transaction":         {min: 10, max: math.MaxInt32},
+	"max_logical_replication_workers":   {min: 0, max: 262143},
+	"max_parallel_workers":              {min: 0, max: 1024},
+	"max_parallel_workers_per_gather":   {min: 0, max: 1024},
+	"max_prepared_transactions":         {min: 0, max: 262143},
+	"max_replication_slots":             {min: 0, max: 262143},
+	"max_sync_workers_per_subscription": {min: 0, max: 262143},
+	"max_wal_senders":                   {min: 0, max: 262143},
+	"max_worker_processes":              {min: 0, max: 262143},
+	"superuser_reserved_connections":    {min: 0, max: 262143},
+}
+
+// parameterAvailability is the range of PostgreSQL versions
+// supporting a configuration parameter
+type parameterAvailability struct {
+	// introducedIn is the first version supporting the parameter,
+	// zero if the parameter is available in every supported version
+	introducedIn version.Data
+	// removedIn is the first version not supporting the parameter anymore,
+	// zero if the parameter is still available
+	removedIn version.Data
+}
+
+// parameterAvailabilities is a curated list of configuration parameters
+// which have been introduced or removed in a PostgreSQL major version,
+// and which would make PostgreSQL fail to start when not supported.
+// Parameters not listed here, like the ones of the extensions, are
+// never rejected.
+// Ref: the release notes of the PostgreSQL major versions
+var parameterAvailabilities = map[string]parameterAvailability{
+	// Removed parameters
+	"checkpoint_segments":               {removedIn: version.New(9, 5)},
+	"ssl_renegotiation_limit":           {removedIn: version.New(9, 5)},
+	"min_parallel_relation_size":        {removedIn: version.New(10, 0)},
+	"sql_inheritance":                   {removedIn: version.New(10, 0)},
+	"replacement_sort_tuples":           {removedIn: version.New(11, 0)},
+	"wal_keep_segments":                 {removedIn: version.New(13, 0)},
+	"operator_precedence_warning":       {removedIn: version.New(14, 0)},
+	"vacuum_cleanup_index_scale_factor": {removedIn: version.New(14, 0)},
+	"stats_temp_directory":              {removedIn: version.New(15, 0)},
+	"force_parallel_mode":               {removedIn: version.New(16, 0)},
+	"promote_trigger_file":              {removedIn: version.New(16, 0)},
+	"vacuum_defer_cleanup_age":          {removedIn: version.New(16, 0)},
+	"db_user_namespace":                 {removedIn: version.New(17, 0)},
+	"old_snapshot_threshold":            {removedIn: version.New(17, 0)},
+	"trace_recovery_messages":           {removedIn: version.New(17, 0)},
+
+	// Introduced parameters
+	"hash_mem_multiplier":              {introducedIn: version.New(13, 0)},
+	"logical_decoding_work_mem":        {introducedIn: version.New(13, 0)},
+	"max_slot_wal_keep_size":           {introducedIn: version.New(13, 0)},
+	"wal_keep_size":                    {introducedIn: version.New(13, 0)},
+	"client_connection_check_interval": {introducedIn: version.New(14, 0)},
+	"idle_session_timeout":             {introducedIn: version.New(14, 0)},
+	"log_startup_progress_interval":    {introducedIn: version.New(15, 0)},
+	"recovery_prefetch":                {introducedIn: version.New(15, 0)},
+	"wal_decode_buffer_size":           {introducedIn: version.New(15, 0)},
+	"debug_parallel_query":             {introducedIn: version.New(16, 0)},
+	"summarize_wal":                    {introducedIn: version.New(17, 0)},
+	"transaction_timeout":              {introducedIn: version.New(17, 0)},
+	"wal_summary_keep_time":            {introducedIn: version.New(17, 0)},
+	"io_method":                        {introducedIn: version.New(18, 0)},
+	"io_workers":                       {introducedIn: version.New(18, 0)},
+}
 
 // clusterLog is for logging in this package.
 var clusterLog = log.WithName("cluster-resource").WithValues("version", "v1")
@@ -106,7 +207,7 @@ func (v *ClusterCustomValidator) ValidateCreate(_ context.Context, obj runtime.O
 	}
 	clusterLog.Info("Validation for Cluster upon creation", "name", cluster.GetName(), "namespace", cluster.GetNamespace())
 
-	allErrs := v.validate(cluster)
+	allErrs := append(v.validate(cluster), v.validatePrimaryUpdateMethod(cluster)...)
 	allWarnings := v.getAdmissionWarnings(cluster)
 
 	if len(allErrs) == 0 {
@@ -142,7 +243,7 @@ func (v *ClusterCustomValidator) ValidateUpdate(
 		v.validate(cluster),
 		v.validateClusterChanges(cluster, oldCluster)...,
 	)
-	allWarnings := v.getAdmissionWarnings(cluster)
+	allWarnings := append(v.getAdmissionWarnings(cluster), getServerNameChangeAdmissionWarnings(cluster, oldCluster)...)
 
 	if len(allErrs) == 0 {
 		return allWarnings, nil
@@ -183,6 +284,7 @@ func (v *ClusterCustomValidator) validate(r *apiv1.Cluster) (allErrs field.Error
 		v.validatePrimaryUpdateStrategy,
 		v.validateMinSyncReplicas,
 		v.validateMaxSyncReplicas,
+		v.validateFailoverDelay,
 		v.validateStorageSize,
 		v.validateWalStorageSize,
 		v.validateEphemeralVolumeSource,
@@ -199,13 +301,18 @@ func (v *ClusterCustomValidator) validate(r *apiv1.Cluster) (allErrs field.Error
 		v.validateReplicaMode,
 		v.validateBackupConfiguration,
 		v.validateRetentionPolicy,
+		v.validateBackupVerification,
+		v.validateMaxStandbyLagForReady,
 		v.validateConfiguration,
 		v.validateSynchronousReplicaConfiguration,
 		v.validateLDAP,
+		v.validateHBARules,
+		v.validateIdentMaps,
 		v.validateReplicationSlots,
 		v.validateEnv,
 		v.validateManagedServices,
 		v.validateManagedRoles,
+		v.validateManagedGrants,
 		v.validateManagedExtensions,
 		v.validateResources,
 		v.validateHibernationAnnotation,
@@ -231,6 +338,8 @@ func (v *ClusterCustomValidator) validateClusterChanges(r, old *apiv1.Cluster) (
 	type validationFunc func(*apiv1.Cluster, *apiv1.Cluster) field.ErrorList
 	validations := []validationFunc{
 		v.validateImageChange,
+		v.validateInstancesChangeDuringMajorUpgrade,
+		v.validateInstancesScaleDown,
 		v.validateConfigurationChange,
 		v.validateStorageChange,
 		v.validateWalStorageChange,
@@ -239,6 +348,8 @@ func (v *ClusterCustomValidator) validateClusterChanges(r, old *apiv1.Cluster) (
 		v.validateReplicationSlotsChange,
 		v.validateWALLevelChange,
 		v.validateReplicaClusterChange,
+		v.validateInitDBImmutableOptionsChange,
+		v.validatePrimaryUpdateMethodChange,
 	}
 	for _, validate := range validations {
 		allErrs = append(allErrs, validate(r, old)...)
@@ -274,6 +385,162 @@ func (v *ClusterCustomValidator) validateLDAP(r *apiv1.Cluster) field.ErrorList
 	return result
 }
 
+// hbaAuthenticationMethods are the authentication methods accepted in pg_hba.conf
+var hbaAuthenticationMethods = []string{
+	"trust", "reject", "scram-sha-256", "md5", "password", "gss", "sspi",
+	"ident", "peer", "ldap", "radius", "cert", "pam", "bsd", "oauth",
+}
+
+// hbaAddressKeywords are the keywords accepted as addresses in pg_hba.conf
+var hbaAddressKeywords = []string{"all", "samehost", "samenet"}
+
+// hbaOptionNameRegex matches the names of the options of the HBA authentication methods
+var hbaOptionNameRegex = regexp.MustCompile(`^[a-z_]+$`)
+
+// validateHBARules validates the typed pg_hba.conf rules
+func (v *ClusterCustomValidator) validateHBARules(r *apiv1.Cluster) field.ErrorList {
+	var result field.ErrorList
+
+	for i, rule := range r.Spec.PostgresConfiguration.HBARules {
+		path := field.NewPath("spec", "postgresql", "hbaRules").Index(i)
+
+		isLocal := rule.Type == apiv1.HBAConnectionTypeLocal
+		switch rule.Type {
+		case apiv1.HBAConnectionTypeLocal, apiv1.HBAConnectionTypeHost, apiv1.HBAConnectionTypeHostSSL,
+			apiv1.HBAConnectionTypeHostNoSSL, apiv1.HBAConnectionTypeHostGSSEnc, apiv1.HBAConnectionTypeHostNoGSSEnc:
+		default:
+			result = append(result, field.NotSupported(path.Child("type"), rule.Type, []apiv1.HBAConnectionType{
+				apiv1.HBAConnectionTypeLocal, apiv1.HBAConnectionTypeHost, apiv1.HBAConnectionTypeHostSSL,
+				apiv1.HBAConnectionTypeHostNoSSL, apiv1.HBAConnectionTypeHostGSSEnc, apiv1.HBAConnectionTypeHostNoGSSEnc,
+			}))
+		}
+
+		result = append(result, validateHBANames(path.Child("databases"), rule.Databases)...)
+		result = append(result, validateHBANames(path.Child("users"), rule.Users)...)
+		result = append(result, validateHBANames(path.Child("groups"), rule.Groups)...)
+
+		switch {
+		case isLocal && rule.Address != "":
+			result = append(result, field.Forbidden(path.Child("address"),
+				"local connections don't have an address"))
+		case !isLocal && rule.Address == "":
+			result = append(result, field.Required(path.Child("address"),
+				"an address is required for non-local connections"))
+		case !isLocal && !isValidHBAAddress(rule.Address):
+			result = append(result, field.Invalid(path.Child("address"), rule.Address,
+				"the address should be a CIDR range, a host name, or one of 'all', 'samehost' and 'samenet'"))
+		}
+
+		switch {
+		case !slices.Contains(hbaAuthenticationMethods, rule.Method):
+			result = append(result, field.NotSupported(path.Child("method"), rule.Method, hbaAuthenticationMethods))
+		case rule.Method == "peer" && !isLocal:
+			result = append(result, field.Invalid(path.Child("method"), rule.Method,
+				"the peer authentication method is only available for local connections"))
+		case rule.Method == "trust" && !isLocal && !utils.IsTrustAuthenticationAllowed(&r.ObjectMeta):
+			result = append(result, field.Forbidden(path.Child("method"),
+				fmt.Sprintf("the trust authentication method is not allowed for non-local connections, "+
+					"unless the %s annotation is set to 'enabled'", utils.AllowTrustAuthenticationAnnotationName)))
+		}
+
+		for name := range rule.Options {
+			if !hbaOptionNameRegex.MatchString(name) {
+				result = append(result, field.Invalid(path.Child("options").Key(name), name,
+					"option names should only contain lowercase letters and underscores"))
+			}
+		}
+	}
+
+	return result
+}
+
+// validateHBANames validates a list of database or role names of an HBA rule.
+// Names are rendered unquoted, so they can't contain the characters that
+// have a special meaning in pg_hba.conf
+func validateHBANames(path *field.Path, names []string) field.ErrorList {
+	var result field.ErrorList
+
+	for i, name := range names {
+		if name == "" || strings.ContainsAny(name, " \t\n,\"#") ||
+			strings.HasPrefix(name, "+") || strings.HasPrefix(name, "@") {
+			result = append(result, field.Invalid(path.Index(i), name,
+				"the name can't be empty, start with '+' or '@', or contain spaces, "+
+					"commas, double quotes and '#'"))
+		}
+	}
+
+	return result
+}
+
+// isValidHBAAddress checks whether the passed string is a valid pg_hba.conf address
+func isValidHBAAddress(address string) bool {
+	if slices.Contains(hbaAddressKeywords, address) {
+		return true
+	}
+
+	if _, _, err := net.ParseCIDR(address); err == nil {
+		return true
+	}
+
+	// A host name, possibly starting with a dot to match its suffix
+	return len(validationutil.IsDNS1123Subdomain(strings.TrimPrefix(strings.ToLower(address), "."))) == 0
+}
+
+// reservedIdentMapName is the name of the pg_ident.conf map managed by the operator
+const reservedIdentMapName = "local"
+
+// validateIdentMaps validates the typed pg_ident.conf user name maps
+func (v *ClusterCustomValidator) validateIdentMaps(r *apiv1.Cluster) field.ErrorList {
+	var result field.ErrorList
+
+	mapNames := stringset.New()
+	for i, identMap := range r.Spec.PostgresConfiguration.IdentMaps {
+		path := field.NewPath("spec", "postgresql", "identMaps").Index(i)
+
+		switch {
+		case !isValidIdentToken(identMap.Name) || strings.HasPrefix(identMap.Name, "/"):
+			result = append(result, field.Invalid(path.Child("name"), identMap.Name,
+				"the map name can't be empty, start with '/', or contain spaces, double quotes and '#'"))
+		case identMap.Name == reservedIdentMapName:
+			result = append(result, field.Invalid(path.Child("name"), identMap.Name,
+				"this map name is reserved for the operator"))
+		case mapNames.Has(identMap.Name):
+			result = append(result, field.Duplicate(path.Child("name"), identMap.Name))
+		}
+		mapNames.Put(identMap.Name)
+
+		if len(identMap.Mappings) == 0 {
+			result = append(result, field.Required(path.Child("mappings"), "at least a mapping is required"))
+		}
+
+		for j, mapping := range identMap.Mappings {
+			mappingPath := path.Child("mappings").Index(j)
+			if !isValidIdentToken(mapping.SystemUsername) {
+				result = append(result, field.Invalid(mappingPath.Child("systemUsername"), mapping.SystemUsername,
+					"the system user name can't be empty, or contain spaces, double quotes and '#'"))
+			} else if pattern, isRegex := strings.CutPrefix(mapping.SystemUsername, "/"); isRegex {
+				if _, err := regexp.Compile(pattern); err != nil {
+					result = append(result, field.Invalid(mappingPath.Child("systemUsername"), mapping.SystemUsername,
+						fmt.Sprintf("invalid regular expression: %v", err)))
+				}
+			}
+
+			if !isValidIdentToken(mapping.DatabaseUsername) {
+				result = append(result, field.Invalid(mappingPath.Child("databaseUsername"), mapping.DatabaseUsername,
+					"the database user name can't be empty, or contain spaces, double quotes and '#'"))
+			}
+		}
+	}
+
+	return result
+}
+
+// isValidIdentToken checks whether the passed string can be rendered
+// unquoted in a pg_ident.conf line
+func isValidIdentToken(token string) bool {
+	return token != "" && !strings.ContainsAny(token, " \t\n\"#")
+}
+
 // validateEnv validate the environment variables settings proposed by the user
 func (v *ClusterCustomValidator) validateEnv(r *apiv1.Cluster) field.ErrorList {
 	var result field.ErrorList
@@ -334,12 +601,20 @@ func (v *ClusterCustomValidator) validateInitDB(r *apiv1.Cluster) field.ErrorLis
 	result = v.validateApplicationDatabase(initDBOptions.Database, initDBOptions.Owner,
 		"initdb")
 
-	if initDBOptions.WalSegmentSize != 0 && !utils.IsPowerOfTwo(initDBOptions.WalSegmentSize) {
+	switch walSegmentSize := initDBOptions.WalSegmentSize; {
+	case walSegmentSize < 0 || walSegmentSize > maxWalSegmentSize:
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "bootstrap", "initdb", "walSegmentSize"),
+				walSegmentSize,
+				fmt.Sprintf("WAL segment size must be between 1 and %d megabytes", maxWalSegmentSize)))
+	case walSegmentSize != 0 && !utils.IsPowerOfTwo(walSegmentSize):
 		result = append(
 			result,
 			field.Invalid(
 				field.NewPath("spec", "bootstrap", "initdb", "walSegmentSize"),
-				initDBOptions.WalSegmentSize,
+				walSegmentSize,
 				"WAL segment size must be a power of 2"))
 	}
 
@@ -385,19 +660,83 @@ func (v *ClusterCustomValidator) validateImport(r *apiv1.Cluster) field.ErrorLis
 		return nil
 	}
 
+	result := v.validateImportSchemaOnly(importSpec)
+	result = append(result, v.validateImportExclusions(importSpec)...)
+
+	if importSpec.Jobs < 0 || importSpec.Jobs > maxImportJobs {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "bootstrap", "initdb", "import", "jobs"),
+			importSpec.Jobs,
+			fmt.Sprintf("The number of import jobs must be between 1 and %d", maxImportJobs)))
+	}
+
 	switch importSpec.Type {
 	case apiv1.MicroserviceSnapshotType:
-		return v.validateMicroservice(importSpec)
+		return append(result, v.validateMicroservice(importSpec)...)
 	case apiv1.MonolithSnapshotType:
-		return v.validateMonolith(importSpec)
+		return append(result, v.validateMonolith(importSpec)...)
 	default:
-		return field.ErrorList{
+		return append(result,
 			field.Invalid(
 				field.NewPath("spec", "bootstrap", "initdb", "import", "type"),
 				importSpec.Type,
-				"Unrecognized import type"),
+				"Unrecognized import type"))
+	}
+}
+
+// maxImportJobs is the maximum number of parallel jobs of a logical import
+const maxImportJobs = 32
+
+// validateImportExclusions checks the table and schema patterns
+// excluded from the import
+func (v *ClusterCustomValidator) validateImportExclusions(s *apiv1.Import) field.ErrorList {
+	var result field.ErrorList
+	checkPatterns := func(path *field.Path, patterns []string, kind string) {
+		if len(patterns) > 1 && slices.Contains(patterns, "*") {
+			result = append(result, field.Invalid(path, patterns,
+				fmt.Sprintf("Wildcard exclusion cannot be used along other %s patterns", kind)))
+		}
+		for i, pattern := range patterns {
+			if strings.TrimSpace(pattern) == "" {
+				result = append(result, field.Invalid(path.Index(i), pattern,
+					fmt.Sprintf("The %s pattern cannot be empty", kind)))
+			}
+		}
+	}
+
+	importPath := field.NewPath("spec", "bootstrap", "initdb", "import")
+	checkPatterns(importPath.Child("excludeTables"), s.ExcludeTables, "table")
+	checkPatterns(importPath.Child("excludeSchemas"), s.ExcludeSchemas, "schema")
+
+	return result
+}
+
+// dataOnlyDumpOptions are the pg_dump and pg_restore options
+// requesting the data of the tables, without the schema
+var dataOnlyDumpOptions = []string{"-a", "--data-only", "--section=data"}
+
+// validateImportSchemaOnly checks that a schema-only import isn't
+// combined with extra options requesting the data only
+func (v *ClusterCustomValidator) validateImportSchemaOnly(s *apiv1.Import) field.ErrorList {
+	if !s.SchemaOnly {
+		return nil
+	}
+
+	var result field.ErrorList
+	checkOptions := func(path *field.Path, options []string) {
+		for i, option := range options {
+			if slices.Contains(dataOnlyDumpOptions, option) {
+				result = append(result, field.Invalid(path.Index(i), option,
+					"data-only options cannot be used along with schemaOnly"))
+			}
 		}
 	}
+
+	importPath := field.NewPath("spec", "bootstrap", "initdb", "import")
+	checkOptions(importPath.Child("pgDumpExtraOptions"), s.PgDumpExtraOptions)
+	checkOptions(importPath.Child("pgRestoreExtraOptions"), s.PgRestoreExtraOptions)
+
+	return result
 }
 
 func (v *ClusterCustomValidator) validateMicroservice(s *apiv1.Import) field.ErrorList {
@@ -922,6 +1261,54 @@ func (v *ClusterCustomValidator) validateSynchronousReplicaConfiguration(r *apiv
 		result = append(result, err)
 	}
 
+	return append(result, validateSynchronousStandbyNamesConsistency(r)...)
+}
+
+// validateSynchronousStandbyNamesConsistency checks that the list of standby
+// names generated for `synchronous_standby_names` can satisfy the required
+// number of synchronous standbys, and that it doesn't contain duplicates
+func validateSynchronousStandbyNamesConsistency(r *apiv1.Cluster) field.ErrorList {
+	var result field.ErrorList
+	synchronous := r.Spec.PostgresConfiguration.Synchronous
+	path := field.NewPath("spec", "postgresql", "synchronous")
+
+	if maxFromCluster := synchronous.MaxStandbyNamesFromCluster; maxFromCluster != nil {
+		if *maxFromCluster < 0 {
+			result = append(result, field.Invalid(
+				path.Child("maxStandbyNamesFromCluster"),
+				*maxFromCluster,
+				"maxStandbyNamesFromCluster must be a non negative integer"))
+		}
+
+		// When the cap is not lower than the number of standbys, the check is
+		// the one about the total number of instances. With the preferred data
+		// durability, the number of synchronous standbys is adjusted dynamically.
+		externalStandbys := len(synchronous.StandbyNamesPre) + len(synchronous.StandbyNamesPost)
+		if *maxFromCluster >= 0 && *maxFromCluster < r.Spec.Instances-1 &&
+			synchronous.DataDurability != apiv1.DataDurabilityLevelPreferred &&
+			synchronous.Number > *maxFromCluster+externalStandbys {
+			result = append(result, field.Invalid(
+				path.Child("number"),
+				synchronous.Number,
+				fmt.Sprintf("the number of synchronous standbys can't be satisfied by the %d standby names "+
+					"listed in synchronous_standby_names (maxStandbyNamesFromCluster: %d, standby names: %d)",
+					*maxFromCluster+externalStandbys, *maxFromCluster, externalStandbys)))
+		}
+	}
+
+	standbyNames := stringset.New()
+	checkDuplicates := func(fieldName string, names []string) {
+		for idx, name := range names {
+			if standbyNames.Has(name) {
+				result = append(result, field.Duplicate(path.Child(fieldName).Index(idx), name))
+				continue
+			}
+			standbyNames.Put(name)
+		}
+	}
+	checkDuplicates("standbyNamesPre", synchronous.StandbyNamesPre)
+	checkDuplicates("standbyNamesPost", synchronous.StandbyNamesPost)
+
 	return result
 }
 
@@ -964,6 +1351,11 @@ func (v *ClusterCustomValidator) validateConfiguration(r *apiv1.Cluster) field.E
 	}
 	sanitizedParameters := postgres.CreatePostgresqlConfiguration(info).GetConfigurationParameters()
 
+	// The effective parameters also include the mandatory ones, which
+	// are needed to check the dependencies between the parameters
+	info.IncludingMandatory = true
+	effectiveParameters := postgres.CreatePostgresqlConfiguration(info).GetConfigurationParameters()
+
 	for key, value := range r.Spec.PostgresConfiguration.Parameters {
 		_, isFixed := postgres.FixedConfigurationParameters[key]
 		sanitizedValue, presentInSanitizedConfiguration := sanitizedParameters[key]
@@ -978,8 +1370,6 @@ func (v *ClusterCustomValidator) validateConfiguration(r *apiv1.Cluster) field.E
 	}
 
 	walLevel := postgres.WalLevelValue(sanitizedParameters[postgres.ParameterWalLevel])
-	hasWalLevelRequirement := r.Spec.Instances > 1 || sanitizedParameters[postgres.ParameterArchiveMode] != "off" ||
-		r.IsReplica()
 	if !walLevel.IsKnownValue() {
 		result = append(
 			result,
@@ -991,25 +1381,6 @@ func (v *ClusterCustomValidator) validateConfiguration(r *apiv1.Cluster) field.E
 					postgres.WalLevelValueReplica,
 					postgres.WalLevelValueMinimal,
 				)))
-	} else if hasWalLevelRequirement && !walLevel.IsStricterThanMinimal() {
-		result = append(
-			result,
-			field.Invalid(
-				field.NewPath("spec", "postgresql", "parameters", postgres.ParameterWalLevel),
-				walLevel,
-				"`wal_level` should be set at `logical` or `replica` when `archive_mode` is `on`, "+
-					"'.instances' field is greater than 1, or this is a replica cluster"))
-	}
-
-	if walLevel == "minimal" {
-		if value, ok := sanitizedParameters[postgres.ParameterMaxWalSenders]; !ok || value != "0" {
-			result = append(
-				result,
-				field.Invalid(
-					field.NewPath("spec", "postgresql", "parameters", "max_wal_senders"),
-					walLevel,
-					"`max_wal_senders` should be set at `0` when `wal_level` is `minimal`"))
-		}
 	}
 
 	if value := r.Spec.PostgresConfiguration.Parameters[sharedBuffersParameter]; value != "" {
@@ -1029,8 +1400,7 @@ func (v *ClusterCustomValidator) validateConfiguration(r *apiv1.Cluster) field.E
 
 	walLogHintsValue, walLogHintsSet := r.Spec.PostgresConfiguration.Parameters[postgres.ParameterWalLogHints]
 	if walLogHintsSet {
-		walLogHintsActivated, err := postgres.ParsePostgresConfigBoolean(walLogHintsValue)
-		if err != nil {
+		if _, err := postgres.ParsePostgresConfigBoolean(walLogHintsValue); err != nil {
 			result = append(
 				result,
 				field.Invalid(
@@ -1038,20 +1408,35 @@ func (v *ClusterCustomValidator) validateConfiguration(r *apiv1.Cluster) field.E
 					walLogHintsValue,
 					"invalid `wal_log_hints`. Must be a postgres boolean"))
 		}
-		if r.Spec.Instances > 1 && !walLogHintsActivated {
-			result = append(
-				result,
-				field.Invalid(
-					field.NewPath("spec", "postgresql", "parameters", postgres.ParameterWalLogHints),
-					r.Spec.PostgresConfiguration.Parameters[postgres.ParameterWalLogHints],
-					"`wal_log_hints` must be set to `on` when `instances` > 1"))
-		}
 	}
 
+	// verify the dependencies between the parameters and with the cluster specification
+	result = append(result, validateParameterDependencies(r, effectiveParameters)...)
+
+	// verify the reference to the ConfigMap included in the configuration
+	result = append(result, validateIncludeConfigMap(r.Spec.PostgresConfiguration)...)
+
+	// verify that the parameters are supported by this PostgreSQL version
+	result = append(result, validateParametersAvailability(r.Spec.PostgresConfiguration, pgVersion)...)
+
+	// verify that the integer parameters are within the range accepted by PostgreSQL
+	result = append(result, validateIntegerParameters(r.Spec.PostgresConfiguration)...)
+
+	// verify that the wal_compression method is available in this PostgreSQL version
+	result = append(result, validateWalCompression(
+		r.Spec.PostgresConfiguration, pgVersion.Major())...)
+
 	// verify the postgres setting min_wal_size < max_wal_size < volume size
 	result = append(result, validateWalSizeConfiguration(
 		r.Spec.PostgresConfiguration, r.Spec.WalStorage.GetSizeOrNil())...)
 
+	// verify that huge pages are requested when PostgreSQL is forced to use them
+	result = append(result, validateHugePagesConfiguration(
+		r.Spec.PostgresConfiguration, r.Spec.Resources)...)
+
+	// verify that the user shared preload libraries can be merged with the operator ones
+	result = append(result, validateSharedPreloadLibraries(r.Spec.PostgresConfiguration)...)
+
 	if err := validateSyncReplicaElectionConstraint(
 		r.Spec.PostgresConfiguration.SyncReplicaElectionConstraint,
 	); err != nil {
@@ -1061,127 +1446,517 @@ func (v *ClusterCustomValidator) validateConfiguration(r *apiv1.Cluster) field.E
 	return result
 }
 
-// validateWalSizeConfiguration verifies that min_wal_size < max_wal_size < wal volume size
-func validateWalSizeConfiguration(
-	postgresConfig apiv1.PostgresConfiguration, walVolumeSize *resource.Quantity,
-) field.ErrorList {
-	const (
-		minWalSizeKey     = "min_wal_size"
-		minWalSizeDefault = "80MB"
-		maxWalSizeKey     = "max_wal_size"
-		maxWalSizeDefault = "1GB"
-	)
+// validateIncludeConfigMap verifies that the reference to the ConfigMap
+// included in the PostgreSQL configuration is complete
+func validateIncludeConfigMap(postgresConfig apiv1.PostgresConfiguration) field.ErrorList {
+	reference := postgresConfig.IncludeConfigMap
+	if reference == nil {
+		return nil
+	}
 
 	var result field.ErrorList
-
-	minWalSize, hasMinWalSize := postgresConfig.Parameters[minWalSizeKey]
-	if minWalSize == "" {
-		minWalSize = minWalSizeDefault
-		hasMinWalSize = false
+	path := field.NewPath("spec", "postgresql", "includeConfigMap")
+	if reference.Name == "" {
+		result = append(result, field.Required(path.Child("name"),
+			"the name of the ConfigMap included in the configuration is required"))
 	}
-	minWalSizeValue, err := parsePostgresQuantityValue(minWalSize)
-	if err != nil {
-		result = append(
-			result,
-			field.Invalid(
-				field.NewPath("spec", "postgresql", "parameters", minWalSizeKey),
-				minWalSize,
-				fmt.Sprintf("Invalid value for configuration parameter %s", minWalSizeKey)))
+	if reference.Key == "" {
+		result = append(result, field.Required(path.Child("key"),
+			"the key of the ConfigMap included in the configuration is required"))
 	}
 
-	maxWalSize, hasMaxWalSize := postgresConfig.Parameters[maxWalSizeKey]
-	if maxWalSize == "" {
-		maxWalSize = maxWalSizeDefault
-		hasMaxWalSize = false
-	}
-	maxWalSizeValue, err := parsePostgresQuantityValue(maxWalSize)
-	if err != nil {
-		result = append(
-			result,
-			field.Invalid(
-				field.NewPath("spec", "postgresql", "parameters", maxWalSizeKey),
-				maxWalSize,
-				fmt.Sprintf("Invalid value for configuration parameter %s", maxWalSizeKey)))
-	}
+	return result
+}
 
-	if !minWalSizeValue.IsZero() && !maxWalSizeValue.IsZero() &&
-		minWalSizeValue.Cmp(maxWalSizeValue) >= 0 {
-		result = append(
-			result,
-			field.Invalid(
-				field.NewPath("spec", "postgresql", "parameters", minWalSizeKey),
-				minWalSize,
-				fmt.Sprintf("Invalid vale. Parameter %s (default %s) should be smaller than parameter %s (default %s)",
-					minWalSizeKey, minWalSizeDefault, maxWalSizeKey, maxWalSizeDefault)))
+// validateSharedPreloadLibraries verifies that the shared preload libraries
+// requested by the user can be merged with the ones required by the operator
+// in the `shared_preload_libraries` parameter
+func validateSharedPreloadLibraries(postgresConfig apiv1.PostgresConfiguration) field.ErrorList {
+	var result field.ErrorList
+	path := field.NewPath("spec", "postgresql", "shared_preload_libraries")
+	for idx, library := range postgresConfig.AdditionalLibraries {
+		if strings.ContainsAny(library, `,'"`) {
+			result = append(result, field.Invalid(path.Index(idx), library,
+				"the name of a shared preload library cannot contain commas or quotes"))
+		}
 	}
 
-	if walVolumeSize == nil {
-		return result
-	}
+	return result
+}
 
-	if hasMinWalSize &&
-		!minWalSizeValue.IsZero() &&
-		minWalSizeValue.Cmp(*walVolumeSize) >= 0 {
-		result = append(
-			result,
-			field.Invalid(
-				field.NewPath("spec", "postgresql", "parameters", minWalSizeKey),
-				minWalSize,
-				fmt.Sprintf("Invalid value. Parameter %s (default %s) should be smaller than WAL volume size",
-					minWalSizeKey, minWalSizeDefault)))
-	}
+// parameterDependencyRule is a dependency of a configuration parameter on
+// other parameters or on the cluster specification
+type parameterDependencyRule struct {
+	// parameter is the configuration parameter reported when the
+	// dependency is not satisfied
+	parameter string
+
+	// isSatisfied checks the dependency given the cluster and its effective
+	// configuration parameters. Values which can't be parsed are considered
+	// satisfying the dependency, as they are reported by other validations
+	isSatisfied func(cluster *apiv1.Cluster, parameters map[string]string) bool
+
+	// message explains the dependency which is not satisfied
+	message string
+}
+
+// parameterDependencyRules are the dependencies checked by validateParameterDependencies
+var parameterDependencyRules = []parameterDependencyRule{
+	{
+		parameter:   postgres.ParameterWalLevel,
+		isSatisfied: isWalLevelDependencySatisfied,
+		message: "`wal_level` should be set at `logical` or `replica` when `archive_mode` is `on`, " +
+			"'.instances' field is greater than 1, or this is a replica cluster",
+	},
+	{
+		parameter:   postgres.ParameterMaxWalSenders,
+		isSatisfied: isMaxWalSendersDependencySatisfied,
+		message:     "`max_wal_senders` should be set at `0` when `wal_level` is `minimal`",
+	},
+	{
+		parameter:   postgres.ParameterWalLogHints,
+		isSatisfied: isWalLogHintsDependencySatisfied,
+		message:     "`wal_log_hints` must be set to `on` when `instances` > 1",
+	},
+	{
+		parameter:   maxReplicationSlotsParameter,
+		isSatisfied: isMaxReplicationSlotsDependencySatisfied,
+		message: "`max_replication_slots` must be greater than or equal to the number of " +
+			"replication slots for High Availability, which is `instances` - 1",
+	},
+}
+
+// validateParameterDependencies verifies the rules listed in parameterDependencyRules
+// against the effective configuration parameters of the cluster
+func validateParameterDependencies(r *apiv1.Cluster, parameters map[string]string) field.ErrorList {
+	var result field.ErrorList
 
-	if hasMaxWalSize &&
-		!maxWalSizeValue.IsZero() &&
-		maxWalSizeValue.Cmp(*walVolumeSize) >= 0 {
-		result = append(
-			result,
-			field.Invalid(
-				field.NewPath("spec", "postgresql", "parameters", maxWalSizeKey),
-				maxWalSize,
-				fmt.Sprintf("Invalid value. Parameter %s (default %s) should be smaller than WAL volume size",
-					maxWalSizeKey, maxWalSizeDefault)))
+	for _, rule := range parameterDependencyRules {
+		if rule.isSatisfied(r, parameters) {
+			continue
+		}
+
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "postgresql", "parameters", rule.parameter),
+			parameters[rule.parameter],
+			rule.message))
 	}
 
 	return result
 }
 
-// parsePostgresQuantityValue converts the  sizes in the PostgreSQL configuration
-// into kubernetes resource.Quantity values
-// Ref: Numeric with Unit @ https://www.postgresql.org/docs/current/config-setting.html#CONFIG-SETTING-NAMES-VALUES
-func parsePostgresQuantityValue(value string) (resource.Quantity, error) {
-	// If no suffix, default is MB
-	if _, err := strconv.Atoi(value); err == nil {
-		value += "MB"
+func isWalLevelDependencySatisfied(r *apiv1.Cluster, parameters map[string]string) bool {
+	walLevel := postgres.WalLevelValue(parameters[postgres.ParameterWalLevel])
+	if !walLevel.IsKnownValue() {
+		return true
 	}
 
-	// If there is a suffix it must be "B"
-	if value[len(value)-1:] != "B" {
-		return resource.Quantity{}, resource.ErrFormatWrong
-	}
+	hasWalLevelRequirement := r.Spec.Instances > 1 || parameters[postgres.ParameterArchiveMode] != "off" ||
+		r.IsReplica()
+	return !hasWalLevelRequirement || walLevel.IsStricterThanMinimal()
+}
 
-	// Kubernetes uses Mi rather than MB, Gi rather than GB. Drop the "B"
-	value = strings.TrimSuffix(value, "B")
+func isMaxWalSendersDependencySatisfied(_ *apiv1.Cluster, parameters map[string]string) bool {
+	if parameters[postgres.ParameterWalLevel] != string(postgres.WalLevelValueMinimal) {
+		return true
+	}
 
-	// Spaces are allowed in postgres between number and unit in Postgres, but not in Kubernetes
-	value = strings.ReplaceAll(value, " ", "")
+	value, ok := parameters[postgres.ParameterMaxWalSenders]
+	return ok && value == "0"
+}
 
-	// Add the 'i' suffix unless it is a bare number (it was 'B' before)
-	if _, err := strconv.Atoi(value); err != nil {
-		value += "i"
+func isWalLogHintsDependencySatisfied(r *apiv1.Cluster, parameters map[string]string) bool {
+	if r.Spec.Instances <= 1 {
+		return true
+	}
 
-		// 'kB' must translate to 'Ki'
-		value = strings.ReplaceAll(value, "ki", "Ki")
+	value, ok := parameters[postgres.ParameterWalLogHints]
+	if !ok {
+		return true
 	}
 
-	return resource.ParseQuantity(value)
+	walLogHintsActivated, err := postgres.ParsePostgresConfigBoolean(value)
+	return err != nil || walLogHintsActivated
 }
 
-// validateConfigurationChange determines whether a PostgreSQL configuration
-// change can be applied
-func (v *ClusterCustomValidator) validateConfigurationChange(r, old *apiv1.Cluster) field.ErrorList {
-	var result field.ErrorList
-
+func isMaxReplicationSlotsDependencySatisfied(r *apiv1.Cluster, parameters map[string]string) bool {
+	if r.Spec.ReplicationSlots == nil || !r.Spec.ReplicationSlots.HighAvailability.GetEnabled() {
+		return true
+	}
+
+	value, ok := parameters[maxReplicationSlotsParameter]
+	if !ok {
+		return true
+	}
+
+	maxReplicationSlots, err := parsePostgresIntegerValue(value)
+	return err != nil || maxReplicationSlots >= int64(r.Spec.Instances-1)
+}
+
+// validateParametersAvailability verifies that the parameters listed in
+// parameterAvailabilities are supported by the given PostgreSQL version
+func validateParametersAvailability(
+	postgresConfig apiv1.PostgresConfiguration,
+	pgVersion version.Data,
+) field.ErrorList {
+	var result field.ErrorList
+
+	for _, key := range slices.Sorted(maps.Keys(postgresConfig.Parameters)) {
+		availability, ok := parameterAvailabilities[key]
+		if !ok {
+			continue
+		}
+
+		// The fixed parameters are already reported as not configurable
+		if _, isFixed := postgres.FixedConfigurationParameters[key]; isFixed {
+			continue
+		}
+
+		path := field.NewPath("spec", "postgresql", "parameters", key)
+		value := postgresConfig.Parameters[key]
+		switch {
+		case availability.removedIn.Major() > 0 && !pgVersion.Less(availability.removedIn):
+			result = append(result, field.Invalid(
+				path,
+				value,
+				fmt.Sprintf("`%s` has been removed in PostgreSQL %s and is not supported by PostgreSQL %d",
+					key, getPrintablePostgresVersion(availability.removedIn), pgVersion.Major())))
+		case pgVersion.Less(availability.introducedIn):
+			result = append(result, field.Invalid(
+				path,
+				value,
+				fmt.Sprintf("`%s` has been introduced in PostgreSQL %s and is not supported by PostgreSQL %d",
+					key, getPrintablePostgresVersion(availability.introducedIn), pgVersion.Major())))
+		}
+	}
+
+	return result
+}
+
+// getPrintablePostgresVersion formats a PostgreSQL version, including
+// the minor number only for the versions preceding PostgreSQL 10
+func getPrintablePostgresVersion(pgVersion version.Data) string {
+	if pgVersion.Major() < 10 {
+		return fmt.Sprintf("%d.%d", pgVersion.Major(), pgVersion.Minor())
+	}
+	return fmt.Sprintf("%d", pgVersion.Major())
+}
+
+// validateIntegerParameters verifies that the integer parameters listed in
+// integerParameterRanges are within the range accepted by PostgreSQL
+func validateIntegerParameters(postgresConfig apiv1.PostgresConfiguration) field.ErrorList {
+	var result field.ErrorList
+
+	for _, key := range slices.Sorted(maps.Keys(integerParameterRanges)) {
+		value, ok := postgresConfig.Parameters[key]
+		if !ok {
+			continue
+		}
+
+		path := field.NewPath("spec", "postgresql", "parameters", key)
+		intValue, err := parsePostgresIntegerValue(value)
+		if err != nil {
+			result = append(result, field.Invalid(
+				path,
+				value,
+				fmt.Sprintf("invalid `%s`. Must be an integer", key)))
+			continue
+		}
+
+		valueRange := integerParameterRanges[key]
+		if intValue < valueRange.min || intValue > valueRange.max {
+			result = append(result, field.Invalid(
+				path,
+				value,
+				fmt.Sprintf("`%s` must be between %d and %d",
+					key, valueRange.min, valueRange.max)))
+		}
+	}
+
+	return result
+}
+
+// parsePostgresIntegerValue parses the value of an integer configuration
+// parameter without unit. Like PostgreSQL, it accepts decimal, octal and
+// hexadecimal notations, and rounds fractional values
+// Ref: Numeric @ https://www.postgresql.org/docs/current/config-setting.html#CONFIG-SETTING-NAMES-VALUES
+func parsePostgresIntegerValue(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	// Go accepts digit separators in the notations with a base prefix,
+	// while PostgreSQL never does
+	if strings.Contains(value, "_") {
+		return 0, strconv.ErrSyntax
+	}
+
+	if intValue, err := parseCIntegerValue(value); err == nil {
+		return intValue, nil
+	}
+
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(floatValue) || math.IsInf(floatValue, 0) {
+		return 0, strconv.ErrSyntax
+	}
+
+	floatValue = math.RoundToEven(floatValue)
+	if floatValue < math.MinInt64 || floatValue >= math.MaxInt64 {
+		return 0, strconv.ErrRange
+	}
+
+	return int64(floatValue), nil
+}
+
+// parseCIntegerValue parses an integer like the C strtol function with
+// base 0, as PostgreSQL does: the `0x` prefix denotes the hexadecimal
+// notation, a leading zero the octal one, and any other value is decimal
+func parseCIntegerValue(value string) (int64, error) {
+	digits := strings.TrimLeft(value, "+-")
+	if len(value)-len(digits) > 1 {
+		return 0, strconv.ErrSyntax
+	}
+	sign := value[:len(value)-len(digits)]
+
+	base := 10
+	switch {
+	case strings.HasPrefix(digits, "0x"), strings.HasPrefix(digits, "0X"):
+		base = 16
+		digits = digits[2:]
+	case len(digits) > 1 && digits[0] == '0':
+		base = 8
+		digits = digits[1:]
+	}
+
+	return strconv.ParseInt(sign+digits, base, 64)
+}
+
+// validateWalCompression verifies that the value of `wal_compression` is
+// supported by the PostgreSQL major version. Before PostgreSQL 15 the
+// parameter is a boolean, while newer versions also accept the name of
+// the compression method
+func validateWalCompression(postgresConfig apiv1.PostgresConfiguration, pgMajor uint64) field.ErrorList {
+	value, ok := postgresConfig.Parameters[walCompressionParameter]
+	if !ok {
+		return nil
+	}
+
+	if _, err := postgres.ParsePostgresConfigBoolean(value); err == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec", "postgresql", "parameters", walCompressionParameter)
+	switch strings.ToLower(value) {
+	case "pglz", "lz4", "zstd":
+		if pgMajor < 15 {
+			return field.ErrorList{
+				field.Invalid(
+					path,
+					value,
+					fmt.Sprintf("PostgreSQL %d only accepts a boolean value for `wal_compression`, "+
+						"compression methods are supported from PostgreSQL 15", pgMajor)),
+			}
+		}
+		return nil
+	default:
+		return field.ErrorList{
+			field.Invalid(
+				path,
+				value,
+				"invalid `wal_compression`. Must be a postgres boolean, `pglz`, `lz4` or `zstd`"),
+		}
+	}
+}
+
+// validateWalSizeConfiguration verifies that min_wal_size < max_wal_size < wal volume size
+func validateWalSizeConfiguration(
+	postgresConfig apiv1.PostgresConfiguration, walVolumeSize *resource.Quantity,
+) field.ErrorList {
+	const (
+		minWalSizeKey     = "min_wal_size"
+		minWalSizeDefault = "80MB"
+		maxWalSizeKey     = "max_wal_size"
+		maxWalSizeDefault = "1GB"
+	)
+
+	var result field.ErrorList
+
+	minWalSize, hasMinWalSize := postgresConfig.Parameters[minWalSizeKey]
+	if minWalSize == "" {
+		minWalSize = minWalSizeDefault
+		hasMinWalSize = false
+	}
+	minWalSizeValue, err := parsePostgresQuantityValue(minWalSize)
+	if err != nil {
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "postgresql", "parameters", minWalSizeKey),
+				minWalSize,
+				fmt.Sprintf("Invalid value for configuration parameter %s", minWalSizeKey)))
+	}
+
+	maxWalSize, hasMaxWalSize := postgresConfig.Parameters[maxWalSizeKey]
+	if maxWalSize == "" {
+		maxWalSize = maxWalSizeDefault
+		hasMaxWalSize = false
+	}
+	maxWalSizeValue, err := parsePostgresQuantityValue(maxWalSize)
+	if err != nil {
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "postgresql", "parameters", maxWalSizeKey),
+				maxWalSize,
+				fmt.Sprintf("Invalid value for configuration parameter %s", maxWalSizeKey)))
+	}
+
+	if !minWalSizeValue.IsZero() && !maxWalSizeValue.IsZero() &&
+		minWalSizeValue.Cmp(maxWalSizeValue) >= 0 {
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "postgresql", "parameters", minWalSizeKey),
+				minWalSize,
+				fmt.Sprintf("Invalid vale. Parameter %s (default %s) should be smaller than parameter %s (default %s)",
+					minWalSizeKey, minWalSizeDefault, maxWalSizeKey, maxWalSizeDefault)))
+	}
+
+	if walVolumeSize == nil {
+		return result
+	}
+
+	if hasMinWalSize &&
+		!minWalSizeValue.IsZero() &&
+		minWalSizeValue.Cmp(*walVolumeSize) >= 0 {
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "postgresql", "parameters", minWalSizeKey),
+				minWalSize,
+				fmt.Sprintf("Invalid value. Parameter %s (default %s) should be smaller than WAL volume size",
+					minWalSizeKey, minWalSizeDefault)))
+	}
+
+	if hasMaxWalSize &&
+		!maxWalSizeValue.IsZero() &&
+		maxWalSizeValue.Cmp(*walVolumeSize) >= 0 {
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "postgresql", "parameters", maxWalSizeKey),
+				maxWalSize,
+				fmt.Sprintf("Invalid value. Parameter %s (default %s) should be smaller than WAL volume size",
+					maxWalSizeKey, maxWalSizeDefault)))
+	}
+
+	return result
+}
+
+// validateHugePagesConfiguration verifies that, when `huge_pages` is enabled
+// with `on` or any other spelling of a true boolean, the Pod requests enough
+// huge pages to cover `shared_buffers`. PostgreSQL would otherwise refuse to
+// start. The `try` value doesn't need any resource, as PostgreSQL falls back
+// to regular pages
+func validateHugePagesConfiguration(
+	postgresConfig apiv1.PostgresConfiguration, resources corev1.ResourceRequirements,
+) field.ErrorList {
+	const sharedBuffersDefault = "128MB"
+
+	value, ok := postgresConfig.Parameters[hugePagesParameter]
+	if !ok {
+		return nil
+	}
+	if enabled, err := postgres.ParsePostgresConfigBoolean(strings.TrimSpace(value)); err != nil || !enabled {
+		return nil
+	}
+
+	hugePagesSize := resource.Quantity{}
+	hasHugePages := false
+	for _, resourceName := range []corev1.ResourceName{
+		corev1.ResourceHugePagesPrefix + "2Mi",
+		corev1.ResourceHugePagesPrefix + "1Gi",
+	} {
+		// Kubernetes defaults huge pages requests to their limits, as they
+		// can't be overcommitted
+		quantity, found := resources.Requests[resourceName]
+		if !found {
+			quantity, found = resources.Limits[resourceName]
+		}
+		if found {
+			hasHugePages = true
+			hugePagesSize.Add(quantity)
+		}
+	}
+
+	if !hasHugePages {
+		return field.ErrorList{
+			field.Required(
+				field.NewPath("spec", "resources", "requests", corev1.ResourceHugePagesPrefix+"2Mi"),
+				fmt.Sprintf("`%s` is set to `on` but no `%s2Mi` or `%s1Gi` resource is requested: "+
+					"PostgreSQL will fail to start. Request huge pages or set `%s` to `try`",
+					hugePagesParameter, corev1.ResourceHugePagesPrefix, corev1.ResourceHugePagesPrefix,
+					hugePagesParameter)),
+		}
+	}
+
+	sharedBuffers := postgresConfig.Parameters[sharedBuffersParameter]
+	if sharedBuffers == "" {
+		sharedBuffers = sharedBuffersDefault
+	}
+	sharedBuffersValue, err := parsePostgresQuantityValue(sharedBuffers)
+	if err != nil {
+		// The validation error will be already raised by validateConfiguration
+		return nil
+	}
+
+	if hugePagesSize.Cmp(sharedBuffersValue) < 0 {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec", "resources", "requests"),
+				hugePagesSize.String(),
+				fmt.Sprintf("Huge pages request is lower than PostgreSQL `%s` value (%s) "+
+					"while `%s` is set to `on`",
+					sharedBuffersParameter, sharedBuffers, hugePagesParameter)),
+		}
+	}
+
+	return nil
+}
+
+// parsePostgresQuantityValue converts the  sizes in the PostgreSQL configuration
+// into kubernetes resource.Quantity values
+// Ref: Numeric with Unit @ https://www.postgresql.org/docs/current/config-setting.html#CONFIG-SETTING-NAMES-VALUES
+func parsePostgresQuantityValue(value string) (resource.Quantity, error) {
+	// If no suffix, default is MB
+	if _, err := strconv.Atoi(value); err == nil {
+		value += "MB"
+	}
+
+	// If there is a suffix it must be "B"
+	if value[len(value)-1:] != "B" {
+		return resource.Quantity{}, resource.ErrFormatWrong
+	}
+
+	// Kubernetes uses Mi rather than MB, Gi rather than GB. Drop the "B"
+	value = strings.TrimSuffix(value, "B")
+
+	// Spaces are allowed in postgres between number and unit in Postgres, but not in Kubernetes
+	value = strings.ReplaceAll(value, " ", "")
+
+	// Add the 'i' suffix unless it is a bare number (it was 'B' before)
+	if _, err := strconv.Atoi(value); err != nil {
+		value += "i"
+
+		// 'kB' must translate to 'Ki'
+		value = strings.ReplaceAll(value, "ki", "Ki")
+	}
+
+	return resource.ParseQuantity(value)
+}
+
+// validateConfigurationChange determines whether a PostgreSQL configuration
+// change can be applied
+func (v *ClusterCustomValidator) validateConfigurationChange(r, old *apiv1.Cluster) field.ErrorList {
+	var result field.ErrorList
+
 	if old.Spec.ImageName != r.Spec.ImageName {
 		diff := utils.CollectDifferencesFromMaps(old.Spec.PostgresConfiguration.Parameters,
 			r.Spec.PostgresConfiguration.Parameters)
@@ -1262,6 +2037,98 @@ func (v *ClusterCustomValidator) validateImageChange(r, old *apiv1.Cluster) fiel
 	return result
 }
 
+// validateInstancesScaleDown rejects a scale down leaving fewer standbys
+// than the ones required by the synchronous replication configuration,
+// as the primary wouldn't be able to satisfy synchronous commit anymore.
+// The minimum number of instances is the lowest one accepted by the
+// validation of the synchronous replication configuration
+func (v *ClusterCustomValidator) validateInstancesScaleDown(r, old *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Instances >= old.Spec.Instances {
+		return nil
+	}
+
+	// A configuration which was already invalid is reported by the
+	// validation of the synchronous replication
+	if len(v.getSynchronousReplicationErrors(old)) > 0 {
+		return nil
+	}
+
+	errs := v.getSynchronousReplicationErrors(r)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	minInstances := old.Spec.Instances
+	candidate := r.DeepCopy()
+	for instances := r.Spec.Instances + 1; instances < old.Spec.Instances; instances++ {
+		candidate.Spec.Instances = instances
+		if len(v.getSynchronousReplicationErrors(candidate)) == 0 {
+			minInstances = instances
+			break
+		}
+	}
+
+	fields := make([]string, 0, len(errs))
+	for _, err := range errs {
+		if !slices.Contains(fields, err.Field) {
+			fields = append(fields, err.Field)
+		}
+	}
+
+	return field.ErrorList{
+		field.Forbidden(
+			field.NewPath("spec", "instances"),
+			fmt.Sprintf("cannot scale down from %d to %d instances: at least %d instances are required by %s",
+				old.Spec.Instances, r.Spec.Instances, minInstances, strings.Join(fields, ", "))),
+	}
+}
+
+// getSynchronousReplicationErrors validates the synchronous replication
+// configuration of the cluster against its number of instances
+func (v *ClusterCustomValidator) getSynchronousReplicationErrors(r *apiv1.Cluster) field.ErrorList {
+	return append(v.validateSynchronousReplicaConfiguration(r), v.validateMaxSyncReplicas(r)...)
+}
+
+// validateInstancesChangeDuringMajorUpgrade rejects a change of the
+// number of instances applied together with a major version upgrade
+// of the PostgreSQL image, as the two operations must not be mixed
+func (v *ClusterCustomValidator) validateInstancesChangeDuringMajorUpgrade(r, old *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Instances == old.Spec.Instances {
+		return nil
+	}
+
+	newCluster := r.DeepCopy()
+	newCluster.Status.Image = ""
+	newVersion, err := newCluster.GetPostgresqlVersion()
+	if err != nil {
+		// The validation error will be already raised by the
+		// validateImageName function
+		return nil
+	}
+
+	oldCluster := old.DeepCopy()
+	oldCluster.Status.Image = ""
+	oldVersion, err := oldCluster.GetPostgresqlVersion()
+	if err != nil {
+		// The validation error will be already raised by the
+		// validateImageName function
+		return nil
+	}
+
+	if newVersion.Major() == oldVersion.Major() {
+		return nil
+	}
+
+	return field.ErrorList{
+		field.Invalid(
+			field.NewPath("spec", "instances"),
+			r.Spec.Instances,
+			fmt.Sprintf("can't change the number of instances from %d to %d while upgrading "+
+				"PostgreSQL from major %d to %d: apply the two changes separately",
+				old.Spec.Instances, r.Spec.Instances, oldVersion.Major(), newVersion.Major())),
+	}
+}
+
 // Validate the recovery target to ensure that the mutual exclusivity
 // of options is respected and plus validating the format of targetTime
 // if specified
@@ -1327,10 +2194,60 @@ func (v *ClusterCustomValidator) validateRecoveryTarget(r *apiv1.Cluster) field.
 		}
 	}
 
+	result = append(result, validateRecoveryTargetAction(recoveryTarget)...)
+
 	return result
 }
 
+// validateRecoveryTargetAction validates the action to be taken when
+// the recovery target is reached
+func validateRecoveryTargetAction(recoveryTarget *apiv1.RecoveryTarget) field.ErrorList {
+	actionPath := field.NewPath("spec", "bootstrap", "recovery", "recoveryTarget", "recoveryTargetAction")
+
+	switch recoveryTarget.RecoveryTargetAction {
+	case "":
+		return nil
+	case apiv1.RecoveryTargetActionPromote, apiv1.RecoveryTargetActionPause:
+	default:
+		return field.ErrorList{
+			field.NotSupported(
+				actionPath,
+				recoveryTarget.RecoveryTargetAction,
+				[]string{
+					string(apiv1.RecoveryTargetActionPromote),
+					string(apiv1.RecoveryTargetActionPause),
+				}),
+		}
+	}
+
+	// PostgreSQL ignores the recovery target action when no
+	// recovery target is set
+	if countRecoveryTargets(recoveryTarget) == 0 {
+		return field.ErrorList{
+			field.Invalid(
+				actionPath,
+				recoveryTarget.RecoveryTargetAction,
+				"recoveryTargetAction requires a recovery target to be set"),
+		}
+	}
+
+	return nil
+}
+
 func validateTargetExclusiveness(recoveryTarget *apiv1.RecoveryTarget) field.ErrorList {
+	var result field.ErrorList
+
+	if countRecoveryTargets(recoveryTarget) > 1 {
+		result = append(result, field.Invalid(
+			field.NewPath("spec", "bootstrap", "recovery", "recoveryTarget"),
+			recoveryTarget,
+			"Recovery target options are mutually exclusive"))
+	}
+	return result
+}
+
+// countRecoveryTargets returns the number of recovery targets that are set
+func countRecoveryTargets(recoveryTarget *apiv1.RecoveryTarget) int {
 	targets := 0
 	if recoveryTarget.TargetImmediate != nil {
 		targets++
@@ -1348,15 +2265,7 @@ func validateTargetExclusiveness(recoveryTarget *apiv1.RecoveryTarget) field.Err
 		targets++
 	}
 
-	var result field.ErrorList
-
-	if targets > 1 {
-		result = append(result, field.Invalid(
-			field.NewPath("spec", "bootstrap", "recovery", "recoveryTarget"),
-			recoveryTarget,
-			"Recovery target options are mutually exclusive"))
-	}
-	return result
+	return targets
 }
 
 // Validate the update strategy related to the number of required
@@ -1388,6 +2297,33 @@ func (v *ClusterCustomValidator) validatePrimaryUpdateStrategy(r *apiv1.Cluster)
 	return nil
 }
 
+// Validate the primary update method related to the number of required
+// instances, as a switchover needs at least a replica to be promoted
+func (v *ClusterCustomValidator) validatePrimaryUpdateMethod(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.PrimaryUpdateMethod == apiv1.PrimaryUpdateMethodSwitchover && r.Spec.Instances == 1 {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec", "primaryUpdateMethod"),
+				r.Spec.PrimaryUpdateMethod,
+				"switchover update method is not allowed for clusters with a single instance, "+
+					"as there is no replica to promote: use 'restart' instead"),
+		}
+	}
+
+	return nil
+}
+
+// validatePrimaryUpdateMethodChange validates the primary update method only
+// when it or the number of instances changes, so that the clusters already
+// combining a single instance with the switchover method can still be updated
+func (v *ClusterCustomValidator) validatePrimaryUpdateMethodChange(r, old *apiv1.Cluster) field.ErrorList {
+	if r.Spec.PrimaryUpdateMethod == old.Spec.PrimaryUpdateMethod && r.Spec.Instances == old.Spec.Instances {
+		return nil
+	}
+
+	return v.validatePrimaryUpdateMethod(r)
+}
+
 // Validate the maximum number of synchronous instances
 // that should be kept in sync with the primary server
 func (v *ClusterCustomValidator) validateMaxSyncReplicas(r *apiv1.Cluster) field.ErrorList {
@@ -1431,6 +2367,20 @@ func (v *ClusterCustomValidator) validateMinSyncReplicas(r *apiv1.Cluster) field
 	return result
 }
 
+// validateFailoverDelay checks the delay applied before triggering a failover
+func (v *ClusterCustomValidator) validateFailoverDelay(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.FailoverDelay < 0 {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec", "failoverDelay"),
+				r.Spec.FailoverDelay,
+				"failoverDelay must be a non negative integer"),
+		}
+	}
+
+	return nil
+}
+
 func (v *ClusterCustomValidator) validateStorageSize(r *apiv1.Cluster) field.ErrorList {
 	return validateStorageConfigurationSize(*field.NewPath("spec", "storage"), r.Spec.StorageConfiguration)
 }
@@ -1707,19 +2657,65 @@ func (v *ClusterCustomValidator) validateExternalCluster(
 ) field.ErrorList {
 	var result field.ErrorList
 
-	if externalCluster.ConnectionParameters == nil &&
+	if !externalCluster.HasConnectionParameters() &&
 		externalCluster.BarmanObjectStore == nil &&
 		externalCluster.PluginConfiguration == nil {
 		result = append(result,
 			field.Invalid(
 				path,
 				externalCluster,
-				"one of connectionParameters, plugin and barmanObjectStore is required"))
+				"one of connectionParameters, connectionParametersSecret, plugin and barmanObjectStore is required"))
+	}
+
+	if externalCluster.ConnectionParametersSecret != nil && externalCluster.ConnectionParametersSecret.Name == "" {
+		result = append(result,
+			field.Required(
+				path.Child("connectionParametersSecret", "name"),
+				"the name of the connection parameters secret is required"))
+	}
+
+	return result
+}
+
+// maxWalSegmentSize is the maximum WAL segment size, in megabytes, accepted by initdb
+const maxWalSegmentSize = 1024
+
+// validateInitDBImmutableOptionsChange rejects changes to the initdb options
+// that can't be changed after the data directory has been initialized
+func (v *ClusterCustomValidator) validateInitDBImmutableOptionsChange(r, old *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Bootstrap == nil || r.Spec.Bootstrap.InitDB == nil ||
+		old.Spec.Bootstrap == nil || old.Spec.Bootstrap.InitDB == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	path := field.NewPath("spec", "bootstrap", "initdb")
+	newInitDB := r.Spec.Bootstrap.InitDB
+	oldInitDB := old.Spec.Bootstrap.InitDB
+
+	if getWalSegmentSizeMB(newInitDB) != getWalSegmentSizeMB(oldInitDB) {
+		result = append(result, field.Forbidden(path.Child("walSegmentSize"),
+			"the WAL segment size can't be changed after the cluster has been initialized"))
+	}
+
+	if ptr.Deref(newInitDB.DataChecksums, false) != ptr.Deref(oldInitDB.DataChecksums, false) {
+		result = append(result, field.Forbidden(path.Child("dataChecksums"),
+			"data checksums can't be changed after the cluster has been initialized"))
 	}
 
 	return result
 }
 
+// getWalSegmentSizeMB returns the WAL segment size, in megabytes, that initdb
+// uses with the passed options, defaulting to the PostgreSQL one when not set
+func getWalSegmentSizeMB(initDB *apiv1.BootstrapInitDB) int {
+	if initDB.WalSegmentSize == 0 {
+		return int(postgres.DefaultWALSegmentSize >> 20)
+	}
+
+	return initDB.WalSegmentSize
+}
+
 func (v *ClusterCustomValidator) validateReplicaClusterChange(r, old *apiv1.Cluster) field.ErrorList {
 	// If the replication role didn't change then everything
 	// is fine
@@ -1867,7 +2863,7 @@ func (v *ClusterCustomValidator) validateReplicaClusterExternalClusters(r *apiv1
 	}
 
 	// Check that the externalCluster references are correct
-	_, found := r.ExternalCluster(replicaClusterConf.Source)
+	sourceCluster, found := r.ExternalCluster(replicaClusterConf.Source)
 	if !found {
 		result = append(
 			result,
@@ -1875,6 +2871,8 @@ func (v *ClusterCustomValidator) validateReplicaClusterExternalClusters(r *apiv1
 				field.NewPath("spec", "replicaCluster", "primaryServerName"),
 				replicaClusterConf.Source,
 				fmt.Sprintf("External cluster %v not found", replicaClusterConf.Source)))
+	} else if !utils.IsInsecureReplicaSourceAllowed(&r.ObjectMeta) {
+		result = append(result, validateReplicaSourceSSLMode(r, sourceCluster)...)
 	}
 
 	if len(replicaClusterConf.Self) > 0 {
@@ -1899,7 +2897,99 @@ func (v *ClusterCustomValidator) validateReplicaClusterExternalClusters(r *apiv1
 					replicaClusterConf.Primary,
 					fmt.Sprintf("External cluster %v not found", replicaClusterConf.Primary)))
 		}
+
+		result = append(result, validateDistributedTopologySelf(r)...)
+	}
+	return result
+}
+
+// validateReplicaSourceSSLMode checks that the replica cluster streams from
+// its source using an encrypted connection, as a weaker `sslmode` could
+// silently fall back to an unencrypted one. A missing `sslmode` means the
+// libpq default, `prefer`, unless it is set in the connection parameters
+// secret, whose content is not known here
+func validateReplicaSourceSSLMode(r *apiv1.Cluster, source apiv1.ExternalCluster) field.ErrorList {
+	if !source.HasConnectionParameters() {
+		return nil
+	}
+
+	sslMode, ok := source.ConnectionParameters[sslModeParameter]
+	if ok && slices.Contains(secureSSLModes, sslMode) {
+		return nil
+	}
+	if !ok && source.ConnectionParametersSecret != nil {
+		return nil
+	}
+
+	index := slices.IndexFunc(r.Spec.ExternalClusters, func(cluster apiv1.ExternalCluster) bool {
+		return cluster.Name == source.Name
+	})
+	path := field.NewPath("spec", "externalClusters").Index(index).Child("connectionParameters", sslModeParameter)
+	detail := fmt.Sprintf(
+		"external cluster %q is the source of the replica cluster and must use one of the %v "+
+			"SSL modes. Set the %q annotation to %q to allow an insecure connection",
+		source.Name, secureSSLModes, utils.AllowInsecureReplicaSourceAnnotationName, "enabled")
+	if !ok {
+		return field.ErrorList{field.Required(path, detail)}
+	}
+
+	return field.ErrorList{field.Invalid(path, sslMode, detail)}
+}
+
+// validateDistributedTopologySelf checks, in a distributed topology, that a
+// replica cluster doesn't replicate from itself and that the external cluster
+// describing this cluster points to the object store where it archives
+func validateDistributedTopologySelf(r *apiv1.Cluster) field.ErrorList {
+	var result field.ErrorList
+	replicaClusterConf := r.Spec.ReplicaCluster
+
+	selfName := replicaClusterConf.Self
+	if len(selfName) == 0 {
+		selfName = r.Name
+	}
+
+	if r.IsReplica() && replicaClusterConf.Source == selfName {
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "replicaCluster", "source"),
+				replicaClusterConf.Source,
+				fmt.Sprintf("A replica cluster cannot replicate from itself (%v)", selfName)))
+	}
+
+	selfCluster, found := r.ExternalCluster(selfName)
+	if !found || selfCluster.BarmanObjectStore == nil ||
+		r.Spec.Backup == nil || r.Spec.Backup.BarmanObjectStore == nil {
+		return result
 	}
+
+	backupStore := r.Spec.Backup.BarmanObjectStore
+	if selfCluster.BarmanObjectStore.DestinationPath != backupStore.DestinationPath {
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "replicaCluster", "self"),
+				selfName,
+				fmt.Sprintf("External cluster %v doesn't match this cluster: its destinationPath %q "+
+					"differs from the one of the backup configuration %q",
+					selfName, selfCluster.BarmanObjectStore.DestinationPath, backupStore.DestinationPath)))
+	}
+
+	backupServerName := backupStore.ServerName
+	if len(backupServerName) == 0 {
+		backupServerName = r.Name
+	}
+	if selfCluster.GetServerName() != backupServerName {
+		result = append(
+			result,
+			field.Invalid(
+				field.NewPath("spec", "replicaCluster", "self"),
+				selfName,
+				fmt.Sprintf("External cluster %v doesn't match this cluster: its serverName %q "+
+					"differs from the one of the backup configuration %q",
+					selfName, selfCluster.GetServerName(), backupServerName)))
+	}
+
 	return result
 }
 
@@ -2028,6 +3118,49 @@ func (v *ClusterCustomValidator) validateRetentionPolicy(r *apiv1.Cluster) field
 	)
 }
 
+// validateBackupVerification validates the configuration of the
+// verification of the base backups
+func (v *ClusterCustomValidator) validateBackupVerification(r *apiv1.Cluster) field.ErrorList {
+	if r.Spec.Backup == nil || r.Spec.Backup.Verification == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+	path := field.NewPath("spec", "backup", "verification")
+
+	if r.Spec.Backup.BarmanObjectStore == nil {
+		result = append(result, field.Invalid(
+			path,
+			r.Spec.Backup.Verification,
+			"the verification of the backups requires a barmanObjectStore configuration",
+		))
+	}
+
+	if _, err := cron.Parse(r.Spec.Backup.Verification.Schedule); err != nil {
+		result = append(result, field.Invalid(
+			path.Child("schedule"),
+			r.Spec.Backup.Verification.Schedule,
+			err.Error(),
+		))
+	}
+
+	return result
+}
+
+func (v *ClusterCustomValidator) validateMaxStandbyLagForReady(r *apiv1.Cluster) field.ErrorList {
+	if _, _, err := r.GetMaxStandbyLagForReady(); err != nil {
+		return field.ErrorList{
+			field.Invalid(
+				field.NewPath("spec", "probes", "maxStandbyLagForReady"),
+				r.Spec.Probes.MaxStandbyLagForReady,
+				err.Error(),
+			),
+		}
+	}
+
+	return nil
+}
+
 func (v *ClusterCustomValidator) validateReplicationSlots(r *apiv1.Cluster) field.ErrorList {
 	if r.Spec.ReplicationSlots == nil {
 		r.Spec.ReplicationSlots = &apiv1.ReplicationSlotsConfiguration{
@@ -2144,6 +3277,14 @@ func (v *ClusterCustomValidator) validateManagedServices(r *apiv1.Cluster) field
 		))
 	}
 
+	if slices.Contains(managedServices.DisabledDefaultServices, apiv1.ServiceSelectorTypeROFresh) {
+		errs = append(errs, field.Invalid(
+			basePath.Child("disabledDefaultServices"),
+			apiv1.ServiceSelectorTypeROFresh,
+			"there is no default service of type ro-fresh.",
+		))
+	}
+
 	names := make([]string, len(managedServices.Additional))
 	for idx := range managedServices.Additional {
 		additionalService := &managedServices.Additional[idx]
@@ -2246,11 +3387,100 @@ func (v *ClusterCustomValidator) validateManagedRoles(r *apiv1.Cluster) field.Er
 					role.Name,
 					"This role both sets and disables a password"))
 		}
+		if role.ValidUntil != nil && role.ValidUntil.IsZero() {
+			result = append(
+				result,
+				field.Invalid(
+					field.NewPath("spec", "managed", "roles"),
+					role.Name,
+					"validUntil must be a valid RFC3339 timestamp, omit it for a password that never expires"))
+		}
+	}
+
+	return result
+}
+
+// validateManagedGrants validates the privileges on the application database
+// objects declared by the user
+func (v *ClusterCustomValidator) validateManagedGrants(r *apiv1.Cluster) field.ErrorList {
+	var result field.ErrorList
+
+	if r.Spec.Managed == nil {
+		return nil
+	}
+
+	type grantedObject struct {
+		role       string
+		objectType apiv1.GrantObjectType
+		schema     string
+		name       string
+	}
+
+	grantedObjects := make(map[grantedObject]interface{})
+	for idx, grant := range r.Spec.Managed.Grants {
+		path := field.NewPath("spec", "managed", "grants").Index(idx)
+
+		result = append(result, validateGrantIdentifier(path.Child("role"), grant.Role)...)
+		if postgres.IsRoleReserved(grant.Role) || strings.EqualFold(grant.Role, "public") {
+			result = append(
+				result,
+				field.Invalid(path.Child("role"), grant.Role, "This role is reserved"))
+		}
+
+		result = append(result, validateGrantIdentifier(path.Child("schema"), grant.Schema)...)
+		if grant.ObjectType == apiv1.GrantObjectTypeSchema {
+			if grant.Name != "" {
+				result = append(
+					result,
+					field.Invalid(path.Child("name"), grant.Name, "name must be empty when granting on a schema"))
+			}
+		} else {
+			result = append(result, validateGrantIdentifier(path.Child("name"), grant.Name)...)
+		}
+
+		supportedPrivileges := grant.ObjectType.GetSupportedPrivileges()
+		for _, privilege := range grant.Privileges {
+			if !slices.Contains(supportedPrivileges, privilege) {
+				result = append(
+					result,
+					field.NotSupported(path.Child("privileges"), privilege, supportedPrivileges))
+			}
+		}
+
+		key := grantedObject{
+			role:       grant.Role,
+			objectType: grant.ObjectType,
+			schema:     grant.Schema,
+			name:       grant.Name,
+		}
+		if _, found := grantedObjects[key]; found {
+			result = append(
+				result,
+				field.Duplicate(path, fmt.Sprintf("%s %s", grant.ObjectType, grant.Role)))
+		}
+		grantedObjects[key] = nil
 	}
 
 	return result
 }
 
+// validateGrantIdentifier checks that a role or an object name used in a
+// grant is a valid PostgreSQL identifier. Identifiers are always quoted
+// in the generated statements, so only empty, truncated or NUL-containing
+// names need to be rejected
+func validateGrantIdentifier(path *field.Path, name string) field.ErrorList {
+	switch {
+	case name == "":
+		return field.ErrorList{field.Required(path, "")}
+	case len(name) > maxIdentifierLength:
+		return field.ErrorList{field.TooLong(path, name, maxIdentifierLength)}
+	case strings.ContainsRune(name, 0):
+		return field.ErrorList{field.Invalid(path, name, "must not contain NUL characters")}
+	default:
+		return nil
+	}
+}
+
 // validateManagedExtensions validate the managed extensions parameters set by the user
 func (v *ClusterCustomValidator) validateManagedExtensions(r *apiv1.Cluster) field.ErrorList {
 	allErrors := field.ErrorList{}
@@ -2320,7 +3550,60 @@ func (v *ClusterCustomValidator) validatePgFailoverSlots(r *apiv1.Cluster) field
 }
 
 func (v *ClusterCustomValidator) getAdmissionWarnings(r *apiv1.Cluster) admission.Warnings {
-	return getMaintenanceWindowsAdmissionWarnings(r)
+	list := getMaintenanceWindowsAdmissionWarnings(r)
+	list = append(list, getLcMessagesAdmissionWarnings(r)...)
+	list = append(list, getManagedRolesAdmissionWarnings(r)...)
+	list = append(list, getTrackCommitTimestampAdmissionWarnings(r)...)
+	list = append(list, getAutovacuumAdmissionWarnings(r)...)
+	list = append(list, getSynchronousQuorumAdmissionWarnings(r)...)
+	list = append(list, getImportJobsAdmissionWarnings(r)...)
+	list = append(list, getSharedPreloadLibrariesAdmissionWarnings(r)...)
+	return append(list, getReplicaSourceSSLModeAdmissionWarnings(r)...)
+}
+
+// getSharedPreloadLibrariesAdmissionWarnings warns the user about the
+// empty and duplicated entries in the list of shared preload libraries,
+// which are ignored when merging it with the libraries required by the operator
+func getSharedPreloadLibrariesAdmissionWarnings(r *apiv1.Cluster) admission.Warnings {
+	var result admission.Warnings
+	seen := make(map[string]bool, len(r.Spec.PostgresConfiguration.AdditionalLibraries))
+	for _, library := range r.Spec.PostgresConfiguration.AdditionalLibraries {
+		library = strings.TrimSpace(library)
+		switch {
+		case library == "":
+			result = append(result,
+				"`.spec.postgresql.shared_preload_libraries` contains an empty entry, which will be ignored")
+		case seen[library]:
+			result = append(result, fmt.Sprintf(
+				"`.spec.postgresql.shared_preload_libraries` contains %q more than once, "+
+					"only the first occurrence will be used", library))
+		}
+		seen[library] = true
+	}
+
+	return result
+}
+
+// getImportJobsAdmissionWarnings warns the user when the CPU requested
+// by the instances, which is also requested by the import job, is lower
+// than the number of parallel jobs of a logical import
+func getImportJobsAdmissionWarnings(r *apiv1.Cluster) admission.Warnings {
+	if r.Spec.Bootstrap == nil || r.Spec.Bootstrap.InitDB == nil ||
+		r.Spec.Bootstrap.InitDB.Import == nil || r.Spec.Bootstrap.InitDB.Import.Jobs <= 1 {
+		return nil
+	}
+
+	jobs := r.Spec.Bootstrap.InitDB.Import.Jobs
+	cpuRequest, ok := r.Spec.Resources.Requests[corev1.ResourceCPU]
+	if ok && cpuRequest.MilliValue() >= int64(jobs)*1000 {
+		return nil
+	}
+
+	return admission.Warnings{
+		fmt.Sprintf("The import runs %d parallel jobs, but the CPU requested in `.spec.resources` "+
+			"is lower than that: consider requesting at least %d CPUs to let the import job use them",
+			jobs, jobs),
+	}
 }
 
 func getMaintenanceWindowsAdmissionWarnings(r *apiv1.Cluster) admission.Warnings {
@@ -2334,6 +3617,206 @@ func getMaintenanceWindowsAdmissionWarnings(r *apiv1.Cluster) admission.Warnings
 	return result
 }
 
+// getLcMessagesAdmissionWarnings warns the user when `lc_messages` is set to
+// a non-English locale, as the operator relies on parsing PostgreSQL messages
+// (e.g. to detect the recovery state) and translated messages could confuse it
+func getLcMessagesAdmissionWarnings(r *apiv1.Cluster) admission.Warnings {
+	value, ok := r.Spec.PostgresConfiguration.Parameters[lcMessagesParameter]
+	if !ok || isEnglishLocale(value) {
+		return nil
+	}
+
+	return admission.Warnings{
+		fmt.Sprintf("`%s` is set to %q: non-English PostgreSQL messages could confuse "+
+			"the operator while parsing the PostgreSQL logs and output. "+
+			"Consider keeping the default value or using an English locale", lcMessagesParameter, value),
+	}
+}
+
+// getTrackCommitTimestampAdmissionWarnings warns the user when logical
+// replication is explicitly configured but commit timestamps are not
+// tracked, as subscribers need them to detect the conflicts depending on
+// the origin of the replicated changes. These conflicts are detected
+// since PostgreSQL 18.
+func getTrackCommitTimestampAdmissionWarnings(r *apiv1.Cluster) admission.Warnings {
+	// The image name is validated elsewhere
+	if pgVersion, err := r.GetPostgresqlVersion(); err != nil || pgVersion.Major() < 18 {
+		return nil
+	}
+
+	parameters := r.Spec.PostgresConfiguration.Parameters
+
+	_, hasLogicalReplicationWorkers := parameters[maxLogicalReplicationWorkersParameter]
+	isLogicalReplicationConfigured := hasLogicalReplicationWorkers ||
+		postgres.WalLevelValue(parameters[postgres.ParameterWalLevel]) == postgres.WalLevelValueLogical
+	if !isLogicalReplicationConfigured {
+		return nil
+	}
+
+	if value, ok := parameters[trackCommitTimestampParameter]; ok {
+		if enabled, err := postgres.ParsePostgresConfigBoolean(value); err == nil && enabled {
+			return nil
+		}
+	}
+
+	return admission.Warnings{
+		fmt.Sprintf("Logical replication is configured but `%s` is off: conflicts depending on the "+
+			"origin of the replicated changes, such as `update_origin_differs` and `delete_origin_differs`, "+
+			"won't be detected on subscribers", trackCommitTimestampParameter),
+	}
+}
+
+// getAutovacuumAdmissionWarnings warns the user when autovacuum is disabled
+// globally, as the transaction IDs are not frozen anymore and the database
+// is exposed to the risk of a transaction ID wraparound
+func getAutovacuumAdmissionWarnings(r *apiv1.Cluster) admission.Warnings {
+	value, ok := r.Spec.PostgresConfiguration.Parameters[autovacuumParameter]
+	if !ok {
+		return nil
+	}
+
+	if enabled, err := postgres.ParsePostgresConfigBoolean(value); err != nil || enabled {
+		return nil
+	}
+
+	return admission.Warnings{
+		fmt.Sprintf("`%s` is disabled: tables won't be vacuumed and frozen regularly, exposing the "+
+			"database to bloat and to the risk of a transaction ID wraparound, which forces PostgreSQL "+
+			"to stop accepting writes. PostgreSQL still launches anti-wraparound vacuums, but only when the "+
+			"wraparound is close", autovacuumParameter),
+	}
+}
+
+// getServerNameChangeAdmissionWarnings warns the user when the server name
+// used in the object store changes after a backup has been taken, as the
+// new backups and WAL files won't be stored together with the existing ones
+func getServerNameChangeAdmissionWarnings(r, old *apiv1.Cluster) admission.Warnings {
+	if r.Spec.Backup == nil || r.Spec.Backup.BarmanObjectStore == nil ||
+		old.Spec.Backup == nil || old.Spec.Backup.BarmanObjectStore == nil {
+		return nil
+	}
+
+	if _, hasBackups := old.Status.LastSuccessfulBackupByMethod[apiv1.BackupMethodBarmanObjectStore]; !hasBackups {
+		return nil
+	}
+
+	getServerName := func(cluster *apiv1.Cluster) string {
+		if serverName := cluster.Spec.Backup.BarmanObjectStore.ServerName; serverName != "" {
+			return serverName
+		}
+		return cluster.Name
+	}
+
+	oldServerName, newServerName := getServerName(old), getServerName(r)
+	if oldServerName == newServerName {
+		return nil
+	}
+
+	return admission.Warnings{
+		fmt.Sprintf("The server name in the object store changed from %q to %q after backups have been taken: "+
+			"the new backups and WAL files will be stored apart from the existing ones, which won't be "+
+			"considered anymore for the recovery and the retention policy of this cluster",
+			oldServerName, newServerName),
+	}
+}
+
+// getSynchronousQuorumAdmissionWarnings warns the user when the number of
+// instances and the number of synchronous standbys of a quorum-based
+// synchronous replication yield a fragile topology. Standbys outside the
+// cluster, listed in `standbyNamesPre` and `standbyNamesPost`, take part
+// in the quorum too, and in that case we cannot reason about it
+func getSynchronousQuorumAdmissionWarnings(r *apiv1.Cluster) admission.Warnings {
+	synchronous := r.Spec.PostgresConfiguration.Synchronous
+	if synchronous == nil ||
+		synchronous.Method != apiv1.SynchronousReplicaConfigurationMethodAny ||
+		len(synchronous.StandbyNamesPre) > 0 ||
+		len(synchronous.StandbyNamesPost) > 0 ||
+		synchronous.Number < 1 ||
+		r.Spec.Instances < 2 {
+		return nil
+	}
+
+	if synchronous.Number >= r.Spec.Instances-1 &&
+		synchronous.DataDurability != apiv1.DataDurabilityLevelPreferred {
+		return admission.Warnings{
+			fmt.Sprintf("The synchronous quorum requires %d standbys out of %d: "+
+				"the unavailability of any standby will block write operations on the primary",
+				synchronous.Number, r.Spec.Instances-1),
+		}
+	}
+
+	if r.Spec.Instances%2 == 0 && 2*(synchronous.Number+1) == r.Spec.Instances {
+		return admission.Warnings{
+			fmt.Sprintf("The primary and its %d synchronous standbys are exactly half of the %d instances: "+
+				"a network partition could leave no majority holding every committed transaction, "+
+				"consider using an odd number of instances", synchronous.Number, r.Spec.Instances),
+		}
+	}
+
+	return nil
+}
+
+// getReplicaSourceSSLModeAdmissionWarnings warns the user when the source of
+// a replica cluster doesn't set `sslmode` in its connection parameters, but
+// has a connection parameters secret which could set it. The secret is not
+// read, and without it the missing `sslmode` is rejected by the validation
+func getReplicaSourceSSLModeAdmissionWarnings(r *apiv1.Cluster) admission.Warnings {
+	if r.Spec.ReplicaCluster == nil || utils.IsInsecureReplicaSourceAllowed(&r.ObjectMeta) {
+		return nil
+	}
+
+	source, found := r.ExternalCluster(r.Spec.ReplicaCluster.Source)
+	if !found || source.ConnectionParametersSecret == nil {
+		return nil
+	}
+
+	if _, ok := source.ConnectionParameters[sslModeParameter]; ok {
+		return nil
+	}
+
+	return admission.Warnings{
+		fmt.Sprintf("The external cluster %q, source of the replica cluster, doesn't set `sslmode` in its "+
+			"connectionParameters: unless it is set in the connection parameters secret %q, consider "+
+			"setting it to one of %v to avoid streaming over an unencrypted connection",
+			source.Name, source.ConnectionParametersSecret.Name, secureSSLModes),
+	}
+}
+
+// getManagedRolesAdmissionWarnings warns the user about managed roles
+// setting a password expiry while disabling the password, as the
+// expiry has no effect on a role without a password
+func getManagedRolesAdmissionWarnings(r *apiv1.Cluster) admission.Warnings {
+	if r.Spec.Managed == nil {
+		return nil
+	}
+
+	var result admission.Warnings
+	for _, role := range r.Spec.Managed.Roles {
+		if role.DisablePassword && role.ValidUntil != nil {
+			result = append(
+				result,
+				fmt.Sprintf("Managed role %q sets `validUntil` but disables the password: "+
+					"the expiry is meaningless for a role without a password", role.Name))
+		}
+	}
+
+	return result
+}
+
+// isEnglishLocale checks if the passed locale produces untranslated
+// PostgreSQL messages
+func isEnglishLocale(locale string) bool {
+	locale = strings.TrimSpace(strings.Trim(locale, "'\""))
+	switch {
+	case locale == "", locale == "C", locale == "POSIX":
+		return true
+	case strings.HasPrefix(locale, "C."), strings.HasPrefix(locale, "en_"), locale == "en":
+		return true
+	default:
+		return false
+	}
+}
+
 // validate whether the hibernation configuration is valid
 func (v *ClusterCustomValidator) validateHibernationAnnotation(r *apiv1.Cluster) field.ErrorList {
 	value, ok := r.Annotations[utils.HibernationAnnotationName]
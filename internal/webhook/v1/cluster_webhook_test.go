@@ -353,6 +353,81 @@ var _ = Describe("initdb options validation", func() {
 		result := v.validateSuperuserSecret(cluster)
 		Expect(result).To(HaveLen(1))
 	})
+
+	DescribeTable("validates the WAL segment size",
+		func(walSegmentSize int, expectedErrors int) {
+			cluster := &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					Bootstrap: &apiv1.BootstrapConfiguration{
+						InitDB: &apiv1.BootstrapInitDB{
+							WalSegmentSize: walSegmentSize,
+						},
+					},
+				},
+			}
+			Expect(v.validateInitDB(cluster)).To(HaveLen(expectedErrors))
+		},
+		Entry("default", 0, 0),
+		Entry("power of two", 64, 0),
+		Entry("maximum", 1024, 0),
+		Entry("not a power of two", 48, 1),
+		Entry("negative", -16, 1),
+		Entry("above the maximum", 2048, 1),
+	)
+})
+
+var _ = Describe("initdb immutable options change validation", func() {
+	var v *ClusterCustomValidator
+	var oldCluster, cluster *apiv1.Cluster
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+		oldCluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: &apiv1.BootstrapInitDB{},
+				},
+			},
+		}
+		cluster = oldCluster.DeepCopy()
+	})
+
+	It("allows unchanged options", func() {
+		oldCluster.Spec.Bootstrap.InitDB.WalSegmentSize = 64
+		oldCluster.Spec.Bootstrap.InitDB.DataChecksums = ptr.To(true)
+		cluster.Spec.Bootstrap.InitDB.WalSegmentSize = 64
+		cluster.Spec.Bootstrap.InitDB.DataChecksums = ptr.To(true)
+		Expect(v.validateInitDBImmutableOptionsChange(cluster, oldCluster)).To(BeEmpty())
+	})
+
+	It("treats the missing data checksums setting as disabled", func() {
+		cluster.Spec.Bootstrap.InitDB.DataChecksums = ptr.To(false)
+		Expect(v.validateInitDBImmutableOptionsChange(cluster, oldCluster)).To(BeEmpty())
+	})
+
+	It("rejects changing the WAL segment size", func() {
+		cluster.Spec.Bootstrap.InitDB.WalSegmentSize = 64
+		result := v.validateInitDBImmutableOptionsChange(cluster, oldCluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.bootstrap.initdb.walSegmentSize"))
+	})
+
+	It("treats the missing WAL segment size as the default one", func() {
+		cluster.Spec.Bootstrap.InitDB.WalSegmentSize = 16
+		Expect(v.validateInitDBImmutableOptionsChange(cluster, oldCluster)).To(BeEmpty())
+		Expect(v.validateInitDBImmutableOptionsChange(oldCluster, cluster)).To(BeEmpty())
+	})
+
+	It("rejects enabling data checksums", func() {
+		cluster.Spec.Bootstrap.InitDB.DataChecksums = ptr.To(true)
+		result := v.validateInitDBImmutableOptionsChange(cluster, oldCluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.bootstrap.initdb.dataChecksums"))
+	})
+
+	It("ignores clusters not bootstrapped with initdb", func() {
+		cluster.Spec.Bootstrap.InitDB.WalSegmentSize = 64
+		Expect(v.validateInitDBImmutableOptionsChange(cluster, &apiv1.Cluster{})).To(BeEmpty())
+	})
 })
 
 var _ = Describe("ImagePullPolicy validation", func() {
@@ -1426,6 +1501,95 @@ var _ = Describe("validate image name change", func() {
 	})
 })
 
+var _ = Describe("validate instances change during a major upgrade", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	It("complains when instances change together with a major upgrade", func() {
+		clusterOld := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:16.4",
+				Instances: 3,
+			},
+		}
+		clusterNew := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:17.0",
+				Instances: 2,
+			},
+		}
+		result := v.validateInstancesChangeDuringMajorUpgrade(clusterNew, clusterOld)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.instances"))
+	})
+
+	It("complains when using image catalogs", func() {
+		clusterOld := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageCatalogRef: &apiv1.ImageCatalogRef{Major: 16},
+				Instances:       3,
+			},
+		}
+		clusterNew := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageCatalogRef: &apiv1.ImageCatalogRef{Major: 17},
+				Instances:       4,
+			},
+		}
+		Expect(v.validateInstancesChangeDuringMajorUpgrade(clusterNew, clusterOld)).To(HaveLen(1))
+	})
+
+	It("doesn't complain when only the instances change", func() {
+		clusterOld := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:16.4",
+				Instances: 3,
+			},
+		}
+		clusterNew := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:16.4",
+				Instances: 2,
+			},
+		}
+		Expect(v.validateInstancesChangeDuringMajorUpgrade(clusterNew, clusterOld)).To(BeEmpty())
+	})
+
+	It("doesn't complain when instances change with a minor upgrade", func() {
+		clusterOld := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:16.3",
+				Instances: 3,
+			},
+		}
+		clusterNew := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:16.4",
+				Instances: 2,
+			},
+		}
+		Expect(v.validateInstancesChangeDuringMajorUpgrade(clusterNew, clusterOld)).To(BeEmpty())
+	})
+
+	It("doesn't complain when only the major version changes", func() {
+		clusterOld := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:16.4",
+				Instances: 3,
+			},
+		}
+		clusterNew := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:17.0",
+				Instances: 3,
+			},
+		}
+		Expect(v.validateInstancesChangeDuringMajorUpgrade(clusterNew, clusterOld)).To(BeEmpty())
+	})
+})
+
 var _ = Describe("recovery target", func() {
 	var v *ClusterCustomValidator
 	BeforeEach(func() {
@@ -1702,6 +1866,74 @@ var _ = Describe("recovery target", func() {
 			Expect(v.validateRecoveryTarget(cluster)).To(HaveLen(1))
 		})
 	})
+
+	Context("recovery target action", func() {
+		It("allows pausing at the recovery target", func() {
+			cluster := &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					Bootstrap: &apiv1.BootstrapConfiguration{
+						Recovery: &apiv1.BootstrapRecovery{
+							RecoveryTarget: &apiv1.RecoveryTarget{
+								TargetTime:           "2021-09-01 10:22:47.000000+06",
+								RecoveryTargetAction: apiv1.RecoveryTargetActionPause,
+							},
+						},
+					},
+				},
+			}
+			Expect(v.validateRecoveryTarget(cluster)).To(BeEmpty())
+		})
+
+		It("allows promoting at the recovery target", func() {
+			cluster := &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					Bootstrap: &apiv1.BootstrapConfiguration{
+						Recovery: &apiv1.BootstrapRecovery{
+							RecoveryTarget: &apiv1.RecoveryTarget{
+								TargetLSN:            "1/1",
+								RecoveryTargetAction: apiv1.RecoveryTargetActionPromote,
+							},
+						},
+					},
+				},
+			}
+			Expect(v.validateRecoveryTarget(cluster)).To(BeEmpty())
+		})
+
+		It("rejects unknown actions", func() {
+			cluster := &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					Bootstrap: &apiv1.BootstrapConfiguration{
+						Recovery: &apiv1.BootstrapRecovery{
+							RecoveryTarget: &apiv1.RecoveryTarget{
+								TargetLSN:            "1/1",
+								RecoveryTargetAction: "shutdown",
+							},
+						},
+					},
+				},
+			}
+			result := v.validateRecoveryTarget(cluster)
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Type).To(Equal(field.ErrorTypeNotSupported))
+		})
+
+		It("requires a recovery target", func() {
+			cluster := &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					Bootstrap: &apiv1.BootstrapConfiguration{
+						Recovery: &apiv1.BootstrapRecovery{
+							RecoveryTarget: &apiv1.RecoveryTarget{
+								TargetTLI:            "latest",
+								RecoveryTargetAction: apiv1.RecoveryTargetActionPause,
+							},
+						},
+					},
+				},
+			}
+			Expect(v.validateRecoveryTarget(cluster)).To(HaveLen(1))
+		})
+	})
 })
 
 var _ = Describe("primary update strategy", func() {
@@ -1761,6 +1993,109 @@ var _ = Describe("primary update strategy", func() {
 	})
 })
 
+var _ = Describe("primary update method", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	It("allows 'switchover' for clusters with replicas", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PrimaryUpdateMethod: apiv1.PrimaryUpdateMethodSwitchover,
+				Instances:           3,
+			},
+		}
+		Expect(v.validatePrimaryUpdateMethod(cluster)).To(BeEmpty())
+	})
+
+	It("prevents 'switchover' for single-instance clusters", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PrimaryUpdateMethod: apiv1.PrimaryUpdateMethodSwitchover,
+				Instances:           1,
+			},
+		}
+		result := v.validatePrimaryUpdateMethod(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.primaryUpdateMethod"))
+		Expect(result[0].Detail).To(ContainSubstring("single instance"))
+	})
+
+	It("allows 'restart' for single-instance clusters", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PrimaryUpdateMethod: apiv1.PrimaryUpdateMethodRestart,
+				Instances:           1,
+			},
+		}
+		Expect(v.validatePrimaryUpdateMethod(cluster)).To(BeEmpty())
+	})
+
+	It("allows the default method for single-instance clusters", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances: 1,
+			},
+		}
+		Expect(v.validatePrimaryUpdateMethod(cluster)).To(BeEmpty())
+	})
+
+	It("allows updating a single-instance cluster already using 'switchover'", func() {
+		oldCluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PrimaryUpdateMethod: apiv1.PrimaryUpdateMethodSwitchover,
+				Instances:           1,
+			},
+		}
+		cluster := oldCluster.DeepCopy()
+		cluster.Spec.ImageName = "postgres:18.1"
+		Expect(v.validatePrimaryUpdateMethodChange(cluster, oldCluster)).To(BeEmpty())
+	})
+
+	It("prevents scaling down a cluster using 'switchover' to a single instance", func() {
+		oldCluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PrimaryUpdateMethod: apiv1.PrimaryUpdateMethodSwitchover,
+				Instances:           3,
+			},
+		}
+		cluster := oldCluster.DeepCopy()
+		cluster.Spec.Instances = 1
+		Expect(v.validatePrimaryUpdateMethodChange(cluster, oldCluster)).To(HaveLen(1))
+	})
+
+	It("prevents switching a single-instance cluster to 'switchover'", func() {
+		oldCluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PrimaryUpdateMethod: apiv1.PrimaryUpdateMethodRestart,
+				Instances:           1,
+			},
+		}
+		cluster := oldCluster.DeepCopy()
+		cluster.Spec.PrimaryUpdateMethod = apiv1.PrimaryUpdateMethodSwitchover
+		Expect(v.validatePrimaryUpdateMethodChange(cluster, oldCluster)).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("validateFailoverDelay", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	It("accepts the default and positive delays", func() {
+		Expect(v.validateFailoverDelay(&apiv1.Cluster{})).To(BeEmpty())
+		Expect(v.validateFailoverDelay(&apiv1.Cluster{Spec: apiv1.ClusterSpec{FailoverDelay: 30}})).To(BeEmpty())
+	})
+
+	It("rejects a negative delay", func() {
+		result := v.validateFailoverDelay(&apiv1.Cluster{Spec: apiv1.ClusterSpec{FailoverDelay: -1}})
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.failoverDelay"))
+	})
+})
+
 var _ = Describe("Number of synchronous replicas", func() {
 	var v *ClusterCustomValidator
 	BeforeEach(func() {
@@ -1844,6 +2179,71 @@ var _ = Describe("validateSynchronousReplicaConfiguration", func() {
 		v = &ClusterCustomValidator{}
 	})
 
+	Context("consistency of the standby names", func() {
+		var cluster *apiv1.Cluster
+		BeforeEach(func() {
+			cluster = &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					Instances: 5,
+					PostgresConfiguration: apiv1.PostgresConfiguration{
+						Synchronous: &apiv1.SynchronousReplicaConfiguration{
+							Method:                     apiv1.SynchronousReplicaConfigurationMethodAny,
+							Number:                     2,
+							MaxStandbyNamesFromCluster: ptr.To(2),
+						},
+					},
+				},
+			}
+		})
+
+		It("accepts a number that can be satisfied by the capped standby names", func() {
+			Expect(v.validateSynchronousReplicaConfiguration(cluster)).To(BeEmpty())
+
+			cluster.Spec.PostgresConfiguration.Synchronous.Number = 3
+			cluster.Spec.PostgresConfiguration.Synchronous.MaxStandbyNamesFromCluster = ptr.To(1)
+			cluster.Spec.PostgresConfiguration.Synchronous.StandbyNamesPre = []string{"dr"}
+			cluster.Spec.PostgresConfiguration.Synchronous.StandbyNamesPost = []string{"backup"}
+			Expect(v.validateSynchronousReplicaConfiguration(cluster)).To(BeEmpty())
+		})
+
+		It("rejects a number exceeding the capped standby names", func() {
+			cluster.Spec.PostgresConfiguration.Synchronous.Number = 3
+			cluster.Spec.PostgresConfiguration.Synchronous.MaxStandbyNamesFromCluster = ptr.To(1)
+			cluster.Spec.PostgresConfiguration.Synchronous.StandbyNamesPre = []string{"dr"}
+			result := v.validateSynchronousReplicaConfiguration(cluster)
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal("spec.postgresql.synchronous.number"))
+			Expect(result[0].Detail).To(ContainSubstring("maxStandbyNamesFromCluster: 1"))
+		})
+
+		It("doesn't check the capped standby names with the preferred data durability", func() {
+			cluster.Spec.PostgresConfiguration.Synchronous.Number = 3
+			cluster.Spec.PostgresConfiguration.Synchronous.MaxStandbyNamesFromCluster = ptr.To(1)
+			cluster.Spec.PostgresConfiguration.Synchronous.DataDurability = apiv1.DataDurabilityLevelPreferred
+			Expect(v.validateSynchronousReplicaConfiguration(cluster)).To(BeEmpty())
+		})
+
+		It("rejects a negative maxStandbyNamesFromCluster", func() {
+			cluster.Spec.PostgresConfiguration.Synchronous.Number = 1
+			cluster.Spec.PostgresConfiguration.Synchronous.MaxStandbyNamesFromCluster = ptr.To(-1)
+			result := v.validateSynchronousReplicaConfiguration(cluster)
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal("spec.postgresql.synchronous.maxStandbyNamesFromCluster"))
+		})
+
+		It("rejects duplicated standby names", func() {
+			cluster.Spec.PostgresConfiguration.Synchronous.Number = 1
+			cluster.Spec.PostgresConfiguration.Synchronous.MaxStandbyNamesFromCluster = ptr.To(4)
+			cluster.Spec.PostgresConfiguration.Synchronous.StandbyNamesPre = []string{"dr", "dr"}
+			cluster.Spec.PostgresConfiguration.Synchronous.StandbyNamesPost = []string{"backup", "dr"}
+			result := v.validateSynchronousReplicaConfiguration(cluster)
+			Expect(result).To(HaveLen(2))
+			Expect(result[0].Type).To(Equal(field.ErrorTypeDuplicate))
+			Expect(result[0].Field).To(Equal("spec.postgresql.synchronous.standbyNamesPre[1]"))
+			Expect(result[1].Field).To(Equal("spec.postgresql.synchronous.standbyNamesPost[1]"))
+		})
+	})
+
 	It("returns no error when synchronous configuration is nil", func() {
 		cluster := &apiv1.Cluster{
 			Spec: apiv1.ClusterSpec{
@@ -1913,28 +2313,172 @@ var _ = Describe("validateSynchronousReplicaConfiguration", func() {
 	})
 })
 
-var _ = Describe("storage configuration validation", func() {
-	var v *ClusterCustomValidator
+var _ = Describe("synchronous quorum admission warnings", func() {
+	var cluster *apiv1.Cluster
 	BeforeEach(func() {
-		v = &ClusterCustomValidator{}
-	})
-
-	It("complains if the size is being reduced", func() {
-		clusterOld := &apiv1.Cluster{
+		cluster = &apiv1.Cluster{
 			Spec: apiv1.ClusterSpec{
-				StorageConfiguration: apiv1.StorageConfiguration{
-					Size: "1G",
+				Instances: 4,
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Synchronous: &apiv1.SynchronousReplicaConfiguration{
+						Method: apiv1.SynchronousReplicaConfigurationMethodAny,
+						Number: 1,
+					},
 				},
 			},
 		}
+	})
 
-		clusterNew := &apiv1.Cluster{
-			Spec: apiv1.ClusterSpec{
-				StorageConfiguration: apiv1.StorageConfiguration{
-					Size: "512M",
-				},
-			},
-		}
+	It("doesn't warn without quorum-based synchronous replication", func() {
+		cluster.Spec.PostgresConfiguration.Synchronous.Method = apiv1.SynchronousReplicaConfigurationMethodFirst
+		Expect(getSynchronousQuorumAdmissionWarnings(cluster)).To(BeEmpty())
+
+		cluster.Spec.PostgresConfiguration.Synchronous = nil
+		Expect(getSynchronousQuorumAdmissionWarnings(cluster)).To(BeEmpty())
+	})
+
+	DescribeTable("doesn't warn on a robust quorum",
+		func(instances, number int) {
+			cluster.Spec.Instances = instances
+			cluster.Spec.PostgresConfiguration.Synchronous.Number = number
+			Expect(getSynchronousQuorumAdmissionWarnings(cluster)).To(BeEmpty())
+		},
+		Entry("one out of three instances", 3, 1),
+		Entry("two out of five instances", 5, 2),
+		Entry("two out of four instances", 4, 2),
+	)
+
+	It("warns when every standby is required by the quorum", func() {
+		cluster.Spec.Instances = 3
+		cluster.Spec.PostgresConfiguration.Synchronous.Number = 2
+		warnings := getSynchronousQuorumAdmissionWarnings(cluster)
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0]).To(ContainSubstring("block write operations"))
+	})
+
+	It("doesn't warn when every standby is required but the data durability is preferred", func() {
+		cluster.Spec.Instances = 3
+		cluster.Spec.PostgresConfiguration.Synchronous.Number = 2
+		cluster.Spec.PostgresConfiguration.Synchronous.DataDurability = apiv1.DataDurabilityLevelPreferred
+		Expect(getSynchronousQuorumAdmissionWarnings(cluster)).To(BeEmpty())
+	})
+
+	It("warns when the quorum is exactly half of an even number of instances", func() {
+		warnings := getSynchronousQuorumAdmissionWarnings(cluster)
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0]).To(ContainSubstring("odd number of instances"))
+	})
+
+	It("doesn't warn when standbys outside the cluster take part in the quorum", func() {
+		cluster.Spec.PostgresConfiguration.Synchronous.StandbyNamesPost = []string{"external"}
+		Expect(getSynchronousQuorumAdmissionWarnings(cluster)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("validateInstancesScaleDown", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	scale := func(cluster *apiv1.Cluster, from, to int) (*apiv1.Cluster, *apiv1.Cluster) {
+		oldCluster := cluster.DeepCopy()
+		oldCluster.Spec.Instances = from
+		newCluster := cluster.DeepCopy()
+		newCluster.Spec.Instances = to
+		return newCluster, oldCluster
+	}
+
+	Context("with the legacy synchronous replication configuration", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				MinSyncReplicas: 1,
+				MaxSyncReplicas: 2,
+			},
+		}
+
+		It("accepts a scale down leaving enough standbys", func() {
+			Expect(v.validateInstancesScaleDown(scale(cluster, 5, 3))).To(BeEmpty())
+		})
+
+		It("accepts a scale up", func() {
+			Expect(v.validateInstancesScaleDown(scale(cluster, 1, 2))).To(BeEmpty())
+		})
+
+		It("rejects a scale down below maxSyncReplicas + 1", func() {
+			result := v.validateInstancesScaleDown(scale(cluster, 3, 2))
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(result[0].Field).To(Equal("spec.instances"))
+			Expect(result[0].Detail).To(ContainSubstring("at least 3 instances"))
+			Expect(result[0].Detail).To(ContainSubstring("spec.maxSyncReplicas"))
+		})
+	})
+
+	Context("with the synchronous replication configuration", func() {
+		var cluster *apiv1.Cluster
+		BeforeEach(func() {
+			cluster = &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					PostgresConfiguration: apiv1.PostgresConfiguration{
+						Synchronous: &apiv1.SynchronousReplicaConfiguration{
+							Method: apiv1.SynchronousReplicaConfigurationMethodAny,
+							Number: 2,
+						},
+					},
+				},
+			}
+		})
+
+		It("accepts a scale down leaving enough standbys", func() {
+			Expect(v.validateInstancesScaleDown(scale(cluster, 4, 3))).To(BeEmpty())
+		})
+
+		It("rejects a scale down below number + 1", func() {
+			result := v.validateInstancesScaleDown(scale(cluster, 3, 2))
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(result[0].Detail).To(ContainSubstring("at least 3 instances"))
+			Expect(result[0].Detail).To(ContainSubstring("spec.postgresql.synchronous"))
+		})
+
+		It("takes the external standbys into account", func() {
+			cluster.Spec.PostgresConfiguration.Synchronous.StandbyNamesPre = []string{"external-dr"}
+			Expect(v.validateInstancesScaleDown(scale(cluster, 3, 2))).To(BeEmpty())
+
+			result := v.validateInstancesScaleDown(scale(cluster, 3, 1))
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Detail).To(ContainSubstring("at least 2 instances"))
+		})
+	})
+
+	It("accepts any scale down without synchronous replication", func() {
+		Expect(v.validateInstancesScaleDown(scale(&apiv1.Cluster{}, 3, 1))).To(BeEmpty())
+	})
+})
+
+var _ = Describe("storage configuration validation", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	It("complains if the size is being reduced", func() {
+		clusterOld := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				StorageConfiguration: apiv1.StorageConfiguration{
+					Size: "1G",
+				},
+			},
+		}
+
+		clusterNew := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				StorageConfiguration: apiv1.StorageConfiguration{
+					Size: "512M",
+				},
+			},
+		}
 
 		Expect(v.validateStorageChange(clusterNew, clusterOld)).ToNot(BeEmpty())
 	})
@@ -2130,6 +2674,36 @@ var _ = Describe("validation of an external cluster", func() {
 		cluster.Spec.ExternalClusters[0].BarmanObjectStore = &apiv1.BarmanObjectStoreConfiguration{}
 		Expect(v.validateExternalClusters(cluster)).To(BeEmpty())
 	})
+
+	It("accepts a connection parameters secret as the only connection source", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ExternalClusters: []apiv1.ExternalCluster{
+					{
+						Name:                       "origin",
+						ConnectionParametersSecret: &apiv1.LocalObjectReference{Name: "origin-connection"},
+					},
+				},
+			},
+		}
+		Expect(v.validateExternalClusters(cluster)).To(BeEmpty())
+	})
+
+	It("requires the name of the connection parameters secret", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ExternalClusters: []apiv1.ExternalCluster{
+					{
+						Name:                       "origin",
+						ConnectionParametersSecret: &apiv1.LocalObjectReference{},
+					},
+				},
+			},
+		}
+		result := v.validateExternalClusters(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.externalClusters[0].connectionParametersSecret.name"))
+	})
 })
 
 var _ = Describe("bootstrap base backup validation", func() {
@@ -3102,6 +3676,170 @@ var _ = Describe("validate the replica cluster external clusters", func() {
 		result := v.validateReplicaClusterExternalClusters(cluster)
 		Expect(result).ToNot(BeEmpty())
 	})
+
+	Context("distributed topology self mapping", func() {
+		var cluster *apiv1.Cluster
+
+		BeforeEach(func() {
+			cluster = &apiv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-eu-central",
+				},
+				Spec: apiv1.ClusterSpec{
+					ReplicaCluster: &apiv1.ReplicaClusterConfiguration{
+						Primary: "cluster-eu-south",
+						Source:  "cluster-eu-south",
+					},
+					Backup: &apiv1.BackupConfiguration{
+						BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+							DestinationPath: "s3://cluster-eu-central/",
+						},
+					},
+					ExternalClusters: []apiv1.ExternalCluster{
+						{
+							Name: "cluster-eu-south",
+							BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+								DestinationPath: "s3://cluster-eu-south/",
+							},
+						},
+						{
+							Name: "cluster-eu-central",
+							BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+								DestinationPath: "s3://cluster-eu-central/",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("doesn't complain when the self mapping matches the backup configuration", func() {
+			Expect(v.validateReplicaClusterExternalClusters(cluster)).To(BeEmpty())
+		})
+
+		It("complains when a replica cluster replicates from itself", func() {
+			cluster.Spec.ReplicaCluster.Source = "cluster-eu-central"
+			result := v.validateReplicaClusterExternalClusters(cluster)
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal("spec.replicaCluster.source"))
+		})
+
+		It("doesn't complain when the primary cluster uses itself as source", func() {
+			cluster.Spec.ReplicaCluster.Primary = "cluster-eu-central"
+			cluster.Spec.ReplicaCluster.Source = "cluster-eu-central"
+			Expect(v.validateReplicaClusterExternalClusters(cluster)).To(BeEmpty())
+		})
+
+		It("complains when the self mapping points to another object store", func() {
+			cluster.Spec.ExternalClusters[1].BarmanObjectStore.DestinationPath = "s3://cluster-eu-south/"
+			result := v.validateReplicaClusterExternalClusters(cluster)
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal("spec.replicaCluster.self"))
+		})
+
+		It("complains when the explicit self mapping uses another server name", func() {
+			cluster.Spec.ReplicaCluster.Self = "eu-central"
+			cluster.Spec.ExternalClusters[1].Name = "eu-central"
+			result := v.validateReplicaClusterExternalClusters(cluster)
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal("spec.replicaCluster.self"))
+			Expect(result[0].Detail).To(ContainSubstring("serverName"))
+		})
+
+		It("doesn't complain when the explicit self mapping sets the matching server name", func() {
+			cluster.Spec.ReplicaCluster.Self = "eu-central"
+			cluster.Spec.ExternalClusters[1].Name = "eu-central"
+			cluster.Spec.ExternalClusters[1].BarmanObjectStore.ServerName = "cluster-eu-central"
+			Expect(v.validateReplicaClusterExternalClusters(cluster)).To(BeEmpty())
+		})
+	})
+
+	Context("source sslmode", func() {
+		var cluster *apiv1.Cluster
+		BeforeEach(func() {
+			cluster = &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					ReplicaCluster: &apiv1.ReplicaClusterConfiguration{
+						Enabled: ptr.To(true),
+						Source:  "cluster-example",
+					},
+					ExternalClusters: []apiv1.ExternalCluster{
+						{
+							Name: "unrelated",
+							ConnectionParameters: map[string]string{
+								"sslmode": "disable",
+							},
+						},
+						{
+							Name: "cluster-example",
+							ConnectionParameters: map[string]string{
+								"host": "cluster-example-rw",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		DescribeTable("accepts the secure SSL modes",
+			func(sslMode string) {
+				cluster.Spec.ExternalClusters[1].ConnectionParameters["sslmode"] = sslMode
+				Expect(v.validateReplicaClusterExternalClusters(cluster)).To(BeEmpty())
+			},
+			Entry("require", "require"),
+			Entry("verify-ca", "verify-ca"),
+			Entry("verify-full", "verify-full"),
+		)
+
+		DescribeTable("rejects the SSL modes that allow an unencrypted connection",
+			func(sslMode string) {
+				cluster.Spec.ExternalClusters[1].ConnectionParameters["sslmode"] = sslMode
+				result := v.validateReplicaClusterExternalClusters(cluster)
+				Expect(result).To(HaveLen(1))
+				Expect(result[0].Field).To(Equal("spec.externalClusters[1].connectionParameters.sslmode"))
+				Expect(result[0].Detail).To(ContainSubstring(`"cluster-example"`))
+			},
+			Entry("disable", "disable"),
+			Entry("allow", "allow"),
+			Entry("prefer", "prefer"),
+		)
+
+		It("accepts an insecure SSL mode when the opt-out annotation is set", func() {
+			cluster.Spec.ExternalClusters[1].ConnectionParameters["sslmode"] = "disable"
+			cluster.Annotations = map[string]string{
+				utils.AllowInsecureReplicaSourceAnnotationName: "enabled",
+			}
+			Expect(v.validateReplicaClusterExternalClusters(cluster)).To(BeEmpty())
+		})
+
+		It("rejects a source which doesn't set the SSL mode", func() {
+			result := v.validateReplicaClusterExternalClusters(cluster)
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Type).To(Equal(field.ErrorTypeRequired))
+			Expect(result[0].Field).To(Equal("spec.externalClusters[1].connectionParameters.sslmode"))
+			Expect(getReplicaSourceSSLModeAdmissionWarnings(cluster)).To(BeEmpty())
+		})
+
+		It("accepts a source without the SSL mode when the opt-out annotation is set", func() {
+			cluster.Annotations = map[string]string{
+				utils.AllowInsecureReplicaSourceAnnotationName: "enabled",
+			}
+			Expect(v.validateReplicaClusterExternalClusters(cluster)).To(BeEmpty())
+		})
+
+		It("warns when the SSL mode could be set in the connection parameters secret", func() {
+			cluster.Spec.ExternalClusters[1].ConnectionParametersSecret = &apiv1.LocalObjectReference{
+				Name: "source-parameters",
+			}
+			Expect(v.validateReplicaClusterExternalClusters(cluster)).To(BeEmpty())
+			warnings := getReplicaSourceSSLModeAdmissionWarnings(cluster)
+			Expect(warnings).To(HaveLen(1))
+			Expect(warnings[0]).To(ContainSubstring(`"source-parameters"`))
+
+			cluster.Spec.ExternalClusters[1].ConnectionParameters["sslmode"] = "verify-full"
+			Expect(getReplicaSourceSSLModeAdmissionWarnings(cluster)).To(BeEmpty())
+		})
+	})
 })
 
 var _ = Describe("Validation changes", func() {
@@ -3177,6 +3915,81 @@ var _ = Describe("Backup retention policy validation", func() {
 	})
 })
 
+var _ = Describe("validation of the backup verification", func() {
+	var v *ClusterCustomValidator
+	var cluster *apiv1.Cluster
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{
+					BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{},
+					Verification: &apiv1.BackupVerificationConfiguration{
+						Schedule: "0 0 3 * * 0",
+					},
+				},
+			},
+		}
+	})
+
+	It("accepts a valid schedule", func() {
+		Expect(v.validateBackupVerification(cluster)).To(BeEmpty())
+	})
+
+	It("complains about an invalid schedule", func() {
+		cluster.Spec.Backup.Verification.Schedule = "every sunday"
+		Expect(v.validateBackupVerification(cluster)).To(HaveLen(1))
+	})
+
+	It("requires a barmanObjectStore configuration", func() {
+		cluster.Spec.Backup.BarmanObjectStore = nil
+		Expect(v.validateBackupVerification(cluster)).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("serverName change admission warnings", func() {
+	var oldCluster, cluster *apiv1.Cluster
+	BeforeEach(func() {
+		oldCluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example"},
+			Spec: apiv1.ClusterSpec{
+				Backup: &apiv1.BackupConfiguration{
+					BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+						DestinationPath: "s3://backups/",
+					},
+				},
+			},
+			Status: apiv1.ClusterStatus{
+				LastSuccessfulBackupByMethod: map[apiv1.BackupMethod]metav1.Time{
+					apiv1.BackupMethodBarmanObjectStore: metav1.Now(),
+				},
+			},
+		}
+		cluster = oldCluster.DeepCopy()
+		cluster.Spec.Backup.BarmanObjectStore.ServerName = "new"
+	})
+
+	It("doesn't warn when the cluster has no backups", func() {
+		oldCluster.Status.LastSuccessfulBackupByMethod = nil
+		Expect(getServerNameChangeAdmissionWarnings(cluster, oldCluster)).To(BeEmpty())
+	})
+
+	It("doesn't warn when the server name doesn't change", func() {
+		cluster.Spec.Backup.BarmanObjectStore.ServerName = "cluster-example"
+		Expect(getServerNameChangeAdmissionWarnings(cluster, oldCluster)).To(BeEmpty())
+	})
+
+	It("doesn't warn when the object store is removed", func() {
+		Expect(getServerNameChangeAdmissionWarnings(&apiv1.Cluster{}, oldCluster)).To(BeEmpty())
+	})
+
+	It("warns when the server name changes after backups have been taken", func() {
+		warnings := getServerNameChangeAdmissionWarnings(cluster, oldCluster)
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0]).To(ContainSubstring(`from "cluster-example" to "new"`))
+	})
+})
+
 var _ = Describe("validation of imports", func() {
 	var v *ClusterCustomValidator
 	BeforeEach(func() {
@@ -3403,65 +4216,201 @@ var _ = Describe("validation of imports", func() {
 		result := v.validateImport(cluster)
 		Expect(result).To(BeEmpty())
 	})
-})
-
-var _ = Describe("validation of replication slots configuration", func() {
-	var v *ClusterCustomValidator
-	BeforeEach(func() {
-		v = &ClusterCustomValidator{}
-	})
-
-	It("can be enabled on the default PostgreSQL image", func() {
+	It("accepts schema-only microservice imports", func() {
 		cluster := &apiv1.Cluster{
 			Spec: apiv1.ClusterSpec{
-				ImageName: versions.DefaultImageName,
-				ReplicationSlots: &apiv1.ReplicationSlotsConfiguration{
-					HighAvailability: &apiv1.ReplicationSlotsHAConfiguration{
-						Enabled: ptr.To(true),
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: &apiv1.BootstrapInitDB{
+						Database: "app",
+						Owner:    "app",
+						Import: &apiv1.Import{
+							Type:               apiv1.MicroserviceSnapshotType,
+							Databases:          []string{"foo"},
+							SchemaOnly:         true,
+							PgDumpExtraOptions: []string{"--no-comments"},
+						},
 					},
-					UpdateInterval: 0,
 				},
 			},
 		}
-		cluster.Default()
 
-		result := v.validateReplicationSlots(cluster)
+		result := v.validateImport(cluster)
 		Expect(result).To(BeEmpty())
 	})
 
-	It("set replicationSlots by default", func() {
+	It("rejects schema-only imports with data-only options", func() {
 		cluster := &apiv1.Cluster{
 			Spec: apiv1.ClusterSpec{
-				ImageName: versions.DefaultImageName,
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: &apiv1.BootstrapInitDB{
+						Database: "app",
+						Owner:    "app",
+						Import: &apiv1.Import{
+							Type:                  apiv1.MicroserviceSnapshotType,
+							Databases:             []string{"foo"},
+							SchemaOnly:            true,
+							PgDumpExtraOptions:    []string{"--no-comments", "--data-only"},
+							PgRestoreExtraOptions: []string{"--section=data"},
+						},
+					},
+				},
 			},
 		}
-		cluster.Default()
-		Expect(cluster.Spec.ReplicationSlots).ToNot(BeNil())
-		Expect(cluster.Spec.ReplicationSlots.HighAvailability).ToNot(BeNil())
-		Expect(cluster.Spec.ReplicationSlots.HighAvailability.Enabled).To(HaveValue(BeTrue()))
 
-		result := v.validateReplicationSlots(cluster)
-		Expect(result).To(BeEmpty())
+		result := v.validateImport(cluster)
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Field).To(Equal("spec.bootstrap.initdb.import.pgDumpExtraOptions[1]"))
+		Expect(result[1].Field).To(Equal("spec.bootstrap.initdb.import.pgRestoreExtraOptions[0]"))
 	})
 
-	It("set replicationSlots.highAvailability by default", func() {
+	It("accepts monolith import with excluded tables and schemas", func() {
 		cluster := &apiv1.Cluster{
 			Spec: apiv1.ClusterSpec{
-				ImageName: versions.DefaultImageName,
-				ReplicationSlots: &apiv1.ReplicationSlotsConfiguration{
-					UpdateInterval: 30,
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: &apiv1.BootstrapInitDB{
+						Import: &apiv1.Import{
+							Type:           apiv1.MonolithSnapshotType,
+							Databases:      []string{"*"},
+							ExcludeTables:  []string{"public.audit_*", "logs"},
+							ExcludeSchemas: []string{"staging"},
+						},
+					},
 				},
 			},
 		}
-		cluster.Default()
-		Expect(cluster.Spec.ReplicationSlots.HighAvailability).ToNot(BeNil())
-		Expect(cluster.Spec.ReplicationSlots.HighAvailability.Enabled).To(HaveValue(BeTrue()))
 
-		result := v.validateReplicationSlots(cluster)
+		result := v.validateImport(cluster)
 		Expect(result).To(BeEmpty())
 	})
 
-	It("allows enabling replication slots on the fly", func() {
+	It("rejects wildcard exclusions along other patterns and empty patterns", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: &apiv1.BootstrapInitDB{
+						Import: &apiv1.Import{
+							Type:           apiv1.MonolithSnapshotType,
+							Databases:      []string{"*"},
+							ExcludeTables:  []string{"*", "logs"},
+							ExcludeSchemas: []string{" "},
+						},
+					},
+				},
+			},
+		}
+
+		result := v.validateImport(cluster)
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Field).To(Equal("spec.bootstrap.initdb.import.excludeTables"))
+		Expect(result[1].Field).To(Equal("spec.bootstrap.initdb.import.excludeSchemas[0]"))
+	})
+
+	DescribeTable("validates the number of import jobs",
+		func(jobs int, expectedErrors int) {
+			cluster := &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					Bootstrap: &apiv1.BootstrapConfiguration{
+						InitDB: &apiv1.BootstrapInitDB{
+							Import: &apiv1.Import{
+								Type:      apiv1.MonolithSnapshotType,
+								Databases: []string{"*"},
+								Jobs:      jobs,
+							},
+						},
+					},
+				},
+			}
+			Expect(v.validateImport(cluster)).To(HaveLen(expectedErrors))
+		},
+		Entry("sequential import", 0, 0),
+		Entry("parallel import", 8, 0),
+		Entry("negative", -1, 1),
+		Entry("above the maximum", 33, 1),
+	)
+
+	It("warns when the CPU request is lower than the number of import jobs", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: &apiv1.BootstrapInitDB{
+						Import: &apiv1.Import{
+							Type:      apiv1.MonolithSnapshotType,
+							Databases: []string{"*"},
+							Jobs:      4,
+						},
+					},
+				},
+			},
+		}
+		Expect(getImportJobsAdmissionWarnings(cluster)).To(HaveLen(1))
+
+		cluster.Spec.Resources.Requests = corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("2"),
+		}
+		Expect(getImportJobsAdmissionWarnings(cluster)).To(HaveLen(1))
+
+		cluster.Spec.Resources.Requests[corev1.ResourceCPU] = resource.MustParse("4")
+		Expect(getImportJobsAdmissionWarnings(cluster)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("validation of replication slots configuration", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	It("can be enabled on the default PostgreSQL image", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: versions.DefaultImageName,
+				ReplicationSlots: &apiv1.ReplicationSlotsConfiguration{
+					HighAvailability: &apiv1.ReplicationSlotsHAConfiguration{
+						Enabled: ptr.To(true),
+					},
+					UpdateInterval: 0,
+				},
+			},
+		}
+		cluster.Default()
+
+		result := v.validateReplicationSlots(cluster)
+		Expect(result).To(BeEmpty())
+	})
+
+	It("set replicationSlots by default", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: versions.DefaultImageName,
+			},
+		}
+		cluster.Default()
+		Expect(cluster.Spec.ReplicationSlots).ToNot(BeNil())
+		Expect(cluster.Spec.ReplicationSlots.HighAvailability).ToNot(BeNil())
+		Expect(cluster.Spec.ReplicationSlots.HighAvailability.Enabled).To(HaveValue(BeTrue()))
+
+		result := v.validateReplicationSlots(cluster)
+		Expect(result).To(BeEmpty())
+	})
+
+	It("set replicationSlots.highAvailability by default", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: versions.DefaultImageName,
+				ReplicationSlots: &apiv1.ReplicationSlotsConfiguration{
+					UpdateInterval: 30,
+				},
+			},
+		}
+		cluster.Default()
+		Expect(cluster.Spec.ReplicationSlots.HighAvailability).ToNot(BeNil())
+		Expect(cluster.Spec.ReplicationSlots.HighAvailability.Enabled).To(HaveValue(BeTrue()))
+
+		result := v.validateReplicationSlots(cluster)
+		Expect(result).To(BeEmpty())
+	})
+
+	It("allows enabling replication slots on the fly", func() {
 		oldCluster := &apiv1.Cluster{
 			Spec: apiv1.ClusterSpec{
 				ImageName: versions.DefaultImageName,
@@ -3587,6 +4536,37 @@ var _ = Describe("validation of replication slots configuration", func() {
 	})
 })
 
+var _ = Describe("validation of the maximum standby lag for readiness", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	It("accepts a cluster without probes configuration", func() {
+		Expect(v.validateMaxStandbyLagForReady(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	DescribeTable("validates the maximum lag",
+		func(maxLag string, errors int) {
+			cluster := &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					Probes: &apiv1.ProbesConfiguration{
+						MaxStandbyLagForReady: maxLag,
+					},
+				},
+			}
+			Expect(v.validateMaxStandbyLagForReady(cluster)).To(HaveLen(errors))
+		},
+		Entry("disabled", "", 0),
+		Entry("as a duration", "30s", 0),
+		Entry("as a size", "64Mi", 0),
+		Entry("as a number of bytes", "1048576", 0),
+		Entry("not a duration nor a size", "a lot", 1),
+		Entry("negative", "-10s", 1),
+		Entry("zero", "0", 1),
+	)
+})
+
 var _ = Describe("Environment variables validation", func() {
 	var v *ClusterCustomValidator
 	BeforeEach(func() {
@@ -3640,6 +4620,155 @@ var _ = Describe("Environment variables validation", func() {
 	})
 })
 
+var _ = Describe("typed HBA rules validation", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	clusterWithRules := func(rules ...apiv1.HBARule) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{},
+			},
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					HBARules: rules,
+				},
+			},
+		}
+	}
+
+	It("accepts valid rules", func() {
+		cluster := clusterWithRules(
+			apiv1.HBARule{
+				Type:      apiv1.HBAConnectionTypeHostSSL,
+				Databases: []string{"app"},
+				Groups:    []string{"readers"},
+				Address:   "10.0.0.0/8",
+				Method:    "cert",
+				Options:   map[string]string{"clientcert": "verify-full"},
+			},
+			apiv1.HBARule{Type: apiv1.HBAConnectionTypeHost, Address: ".example.com", Method: "scram-sha-256"},
+			apiv1.HBARule{Type: apiv1.HBAConnectionTypeHost, Address: "samenet", Method: "reject"},
+			apiv1.HBARule{Type: apiv1.HBAConnectionTypeLocal, Users: []string{"app"}, Method: "peer"},
+		)
+		Expect(v.validateHBARules(cluster)).To(BeEmpty())
+	})
+
+	DescribeTable("rejects invalid rules",
+		func(rule apiv1.HBARule, fieldPath string) {
+			result := v.validateHBARules(clusterWithRules(rule))
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal(fieldPath))
+		},
+		Entry("unknown connection type",
+			apiv1.HBARule{Type: "tcp", Address: "all", Method: "md5"},
+			"spec.postgresql.hbaRules[0].type"),
+		Entry("name containing a comma",
+			apiv1.HBARule{Type: apiv1.HBAConnectionTypeHost, Users: []string{"a,b"}, Address: "all", Method: "md5"},
+			"spec.postgresql.hbaRules[0].users[0]"),
+		Entry("group passed as a user",
+			apiv1.HBARule{Type: apiv1.HBAConnectionTypeHost, Users: []string{"+readers"}, Address: "all", Method: "md5"},
+			"spec.postgresql.hbaRules[0].users[0]"),
+		Entry("missing address",
+			apiv1.HBARule{Type: apiv1.HBAConnectionTypeHost, Method: "md5"},
+			"spec.postgresql.hbaRules[0].address"),
+		Entry("address of a local connection",
+			apiv1.HBARule{Type: apiv1.HBAConnectionTypeLocal, Address: "all", Method: "peer"},
+			"spec.postgresql.hbaRules[0].address"),
+		Entry("invalid address",
+			apiv1.HBARule{Type: apiv1.HBAConnectionTypeHost, Address: "10.0.0.0/64", Method: "md5"},
+			"spec.postgresql.hbaRules[0].address"),
+		Entry("unknown method",
+			apiv1.HBARule{Type: apiv1.HBAConnectionTypeHost, Address: "all", Method: "magic"},
+			"spec.postgresql.hbaRules[0].method"),
+		Entry("peer method for non-local connections",
+			apiv1.HBARule{Type: apiv1.HBAConnectionTypeHost, Address: "all", Method: "peer"},
+			"spec.postgresql.hbaRules[0].method"),
+		Entry("invalid option name",
+			apiv1.HBARule{Type: apiv1.HBAConnectionTypeHost, Address: "all", Method: "cert",
+				Options: map[string]string{"client cert": "verify-full"}},
+			"spec.postgresql.hbaRules[0].options[client cert]"),
+	)
+
+	It("rejects the trust method for non-local connections unless annotated", func() {
+		cluster := clusterWithRules(
+			apiv1.HBARule{Type: apiv1.HBAConnectionTypeLocal, Method: "trust"},
+			apiv1.HBARule{Type: apiv1.HBAConnectionTypeHost, Address: "samehost", Method: "trust"},
+		)
+		result := v.validateHBARules(cluster)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.postgresql.hbaRules[1].method"))
+
+		cluster.Annotations[utils.AllowTrustAuthenticationAnnotationName] = "enabled"
+		Expect(v.validateHBARules(cluster)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("typed ident maps validation", func() {
+	var v *ClusterCustomValidator
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+	})
+
+	clusterWithMaps := func(maps ...apiv1.IdentMap) *apiv1.Cluster {
+		return &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					IdentMaps: maps,
+				},
+			},
+		}
+	}
+
+	It("accepts valid maps", func() {
+		cluster := clusterWithMaps(
+			apiv1.IdentMap{
+				Name: "certmap",
+				Mappings: []apiv1.IdentMapping{
+					{SystemUsername: "/^(.*)@example\\.com$", DatabaseUsername: "\\1"},
+					{SystemUsername: "admin", DatabaseUsername: "postgres"},
+				},
+			},
+		)
+		Expect(v.validateIdentMaps(cluster)).To(BeEmpty())
+	})
+
+	DescribeTable("rejects invalid maps",
+		func(identMap apiv1.IdentMap, fieldPath string) {
+			result := v.validateIdentMaps(clusterWithMaps(identMap))
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal(fieldPath))
+		},
+		Entry("map name containing a space",
+			apiv1.IdentMap{Name: "cert map", Mappings: []apiv1.IdentMapping{{SystemUsername: "a", DatabaseUsername: "b"}}},
+			"spec.postgresql.identMaps[0].name"),
+		Entry("map name reserved for the operator",
+			apiv1.IdentMap{Name: "local", Mappings: []apiv1.IdentMapping{{SystemUsername: "a", DatabaseUsername: "b"}}},
+			"spec.postgresql.identMaps[0].name"),
+		Entry("map without mappings",
+			apiv1.IdentMap{Name: "certmap"},
+			"spec.postgresql.identMaps[0].mappings"),
+		Entry("invalid regular expression",
+			apiv1.IdentMap{Name: "certmap", Mappings: []apiv1.IdentMapping{{SystemUsername: "/^(.*$", DatabaseUsername: "b"}}},
+			"spec.postgresql.identMaps[0].mappings[0].systemUsername"),
+		Entry("database user name containing a double quote",
+			apiv1.IdentMap{Name: "certmap", Mappings: []apiv1.IdentMapping{{SystemUsername: "a", DatabaseUsername: `b"`}}},
+			"spec.postgresql.identMaps[0].mappings[0].databaseUsername"),
+	)
+
+	It("rejects duplicate map names", func() {
+		mappings := []apiv1.IdentMapping{{SystemUsername: "a", DatabaseUsername: "b"}}
+		result := v.validateIdentMaps(clusterWithMaps(
+			apiv1.IdentMap{Name: "certmap", Mappings: mappings},
+			apiv1.IdentMap{Name: "certmap", Mappings: mappings},
+		))
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.postgresql.identMaps[1].name"))
+	})
+})
+
 var _ = Describe("Storage configuration validation", func() {
 	var v *ClusterCustomValidator
 	BeforeEach(func() {
@@ -3862,6 +4991,167 @@ var _ = Describe("Role management validation", func() {
 		}
 		Expect(v.validateManagedRoles(cluster)).To(HaveLen(1))
 	})
+
+	It("should succeed if validUntil is a valid timestamp", func() {
+		validUntil := metav1.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					Roles: []apiv1.RoleConfiguration{
+						{
+							Name:            "my_test",
+							ValidUntil:      &validUntil,
+							ConnectionLimit: -1,
+						},
+					},
+				},
+			},
+		}
+		Expect(v.validateManagedRoles(cluster)).To(BeEmpty())
+	})
+
+	It("should produce an error if validUntil is the zero timestamp", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					Roles: []apiv1.RoleConfiguration{
+						{
+							Name:            "my_test",
+							ValidUntil:      &metav1.Time{},
+							ConnectionLimit: -1,
+						},
+					},
+				},
+			},
+		}
+		Expect(v.validateManagedRoles(cluster)).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("managed roles admission warnings", func() {
+	validUntil := metav1.Now()
+
+	It("doesn't warn when there is no management stanza", func() {
+		Expect(getManagedRolesAdmissionWarnings(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("doesn't warn when the password expiry is set on a role with a password", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					Roles: []apiv1.RoleConfiguration{
+						{
+							Name:       "app",
+							ValidUntil: &validUntil,
+						},
+					},
+				},
+			},
+		}
+		Expect(getManagedRolesAdmissionWarnings(cluster)).To(BeEmpty())
+	})
+
+	It("warns when the password expiry is set on a role with a disabled password", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{
+					Roles: []apiv1.RoleConfiguration{
+						{
+							Name:            "app",
+							DisablePassword: true,
+							ValidUntil:      &validUntil,
+						},
+						{
+							Name:            "other",
+							DisablePassword: true,
+						},
+					},
+				},
+			},
+		}
+		warnings := getManagedRolesAdmissionWarnings(cluster)
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0]).To(ContainSubstring(`"app"`))
+	})
+})
+
+var _ = Describe("Grant management validation", func() {
+	var v *ClusterCustomValidator
+	var cluster *apiv1.Cluster
+	BeforeEach(func() {
+		v = &ClusterCustomValidator{}
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Managed: &apiv1.ManagedConfiguration{},
+			},
+		}
+	})
+
+	tableGrant := apiv1.GrantConfiguration{
+		Role:       "app_reader",
+		ObjectType: apiv1.GrantObjectTypeTable,
+		Schema:     "public",
+		Name:       "orders",
+		Privileges: []apiv1.GrantPrivilege{apiv1.GrantPrivilegeSelect},
+	}
+
+	It("should succeed if there is no management stanza", func() {
+		Expect(v.validateManagedGrants(&apiv1.Cluster{})).To(BeEmpty())
+	})
+
+	It("should succeed with valid grants", func() {
+		schemaGrant := apiv1.GrantConfiguration{
+			Role:       "app_reader",
+			ObjectType: apiv1.GrantObjectTypeSchema,
+			Schema:     "public",
+			Privileges: []apiv1.GrantPrivilege{apiv1.GrantPrivilegeUsage},
+		}
+		cluster.Spec.Managed.Grants = []apiv1.GrantConfiguration{tableGrant, schemaGrant}
+		Expect(v.validateManagedGrants(cluster)).To(BeEmpty())
+	})
+
+	It("should reject reserved roles", func() {
+		postgresGrant := tableGrant
+		postgresGrant.Role = "postgres"
+		publicGrant := tableGrant
+		publicGrant.Role = "PUBLIC"
+		cluster.Spec.Managed.Grants = []apiv1.GrantConfiguration{postgresGrant, publicGrant}
+		Expect(v.validateManagedGrants(cluster)).To(HaveLen(2))
+	})
+
+	It("should reject invalid identifiers", func() {
+		grant := tableGrant
+		grant.Role = ""
+		grant.Schema = strings.Repeat("a", 64)
+		grant.Name = "orders\x00"
+		cluster.Spec.Managed.Grants = []apiv1.GrantConfiguration{grant}
+		Expect(v.validateManagedGrants(cluster)).To(HaveLen(3))
+	})
+
+	It("should require a name for tables and sequences only", func() {
+		unnamedTableGrant := tableGrant
+		unnamedTableGrant.Name = ""
+		namedSchemaGrant := apiv1.GrantConfiguration{
+			Role:       "app_reader",
+			ObjectType: apiv1.GrantObjectTypeSchema,
+			Schema:     "public",
+			Name:       "orders",
+		}
+		cluster.Spec.Managed.Grants = []apiv1.GrantConfiguration{unnamedTableGrant, namedSchemaGrant}
+		Expect(v.validateManagedGrants(cluster)).To(HaveLen(2))
+	})
+
+	It("should reject privileges not supported by the object type", func() {
+		grant := tableGrant
+		grant.Privileges = []apiv1.GrantPrivilege{apiv1.GrantPrivilegeSelect, apiv1.GrantPrivilegeUsage}
+		cluster.Spec.Managed.Grants = []apiv1.GrantConfiguration{grant}
+		Expect(v.validateManagedGrants(cluster)).To(HaveLen(1))
+	})
+
+	It("should reject duplicate grants on the same object", func() {
+		cluster.Spec.Managed.Grants = []apiv1.GrantConfiguration{tableGrant, tableGrant}
+		Expect(v.validateManagedGrants(cluster)).To(HaveLen(1))
+	})
 })
 
 var _ = Describe("Managed Extensions validation", func() {
@@ -4302,6 +5592,492 @@ var _ = Describe("validateResources", func() {
 	})
 })
 
+var _ = DescribeTable("validateWalCompression",
+	func(value string, pgMajor uint64, valid bool) {
+		postgresConfig := apiv1.PostgresConfiguration{
+			Parameters: map[string]string{},
+		}
+		if value != "" {
+			postgresConfig.Parameters["wal_compression"] = value
+		}
+
+		errors := validateWalCompression(postgresConfig, pgMajor)
+		if valid {
+			Expect(errors).To(BeEmpty())
+		} else {
+			Expect(errors).To(HaveLen(1))
+			Expect(errors[0].Field).To(Equal("spec.postgresql.parameters.wal_compression"))
+		}
+	},
+	Entry("not set", "", uint64(13), true),
+	Entry("boolean on PostgreSQL 13", "on", uint64(13), true),
+	Entry("boolean synonym on PostgreSQL 17", "true", uint64(17), true),
+	Entry("pglz on PostgreSQL 14", "pglz", uint64(14), false),
+	Entry("lz4 on PostgreSQL 14", "lz4", uint64(14), false),
+	Entry("lz4 on PostgreSQL 15", "lz4", uint64(15), true),
+	Entry("zstd on PostgreSQL 17", "zstd", uint64(17), true),
+	Entry("unknown method", "gzip", uint64(17), false),
+)
+
+var _ = Describe("validateParameterDependencies", func() {
+	validParameters := func() map[string]string {
+		return map[string]string{
+			"wal_level":             "logical",
+			"max_wal_senders":       "10",
+			"wal_log_hints":         "on",
+			"max_replication_slots": "32",
+			"archive_mode":          "on",
+		}
+	}
+
+	DescribeTable("reports the dependencies which are not satisfied",
+		func(instances int, haSlots bool, changes map[string]string, invalidParameter string) {
+			parameters := validParameters()
+			for key, value := range changes {
+				if value == "" {
+					delete(parameters, key)
+					continue
+				}
+				parameters[key] = value
+			}
+
+			cluster := &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					Instances: instances,
+					ReplicationSlots: &apiv1.ReplicationSlotsConfiguration{
+						HighAvailability: &apiv1.ReplicationSlotsHAConfiguration{
+							Enabled: ptr.To(haSlots),
+						},
+					},
+				},
+			}
+			errors := validateParameterDependencies(cluster, parameters)
+			if invalidParameter == "" {
+				Expect(errors).To(BeEmpty())
+			} else {
+				Expect(errors).To(HaveLen(1))
+				Expect(errors[0].Field).To(Equal("spec.postgresql.parameters." + invalidParameter))
+			}
+		},
+		Entry("all the dependencies are satisfied", 3, true, map[string]string{}, ""),
+		Entry("minimal wal_level with archiving",
+			1, true, map[string]string{"wal_level": "minimal", "max_wal_senders": "0"}, "wal_level"),
+		Entry("minimal wal_level with more than one instance",
+			3, true, map[string]string{
+				"wal_level": "minimal", "max_wal_senders": "0", "archive_mode": "off",
+			}, "wal_level"),
+		Entry("minimal wal_level with one instance and without archiving",
+			1, true, map[string]string{
+				"wal_level": "minimal", "max_wal_senders": "0", "archive_mode": "off",
+			}, ""),
+		Entry("unknown wal_level, reported by another validation",
+			3, true, map[string]string{"wal_level": "test"}, ""),
+		Entry("minimal wal_level with max_wal_senders not set to 0",
+			1, true, map[string]string{"wal_level": "minimal", "archive_mode": "off"}, "max_wal_senders"),
+		Entry("minimal wal_level with max_wal_senders not set",
+			1, true, map[string]string{
+				"wal_level": "minimal", "archive_mode": "off", "max_wal_senders": "",
+			}, "max_wal_senders"),
+		Entry("wal_log_hints off with more than one instance",
+			3, true, map[string]string{"wal_log_hints": "off"}, "wal_log_hints"),
+		Entry("wal_log_hints off with one instance",
+			1, true, map[string]string{"wal_log_hints": "off"}, ""),
+		Entry("invalid wal_log_hints, reported by another validation",
+			3, true, map[string]string{"wal_log_hints": "foo"}, ""),
+		Entry("max_replication_slots lower than the HA slots",
+			5, true, map[string]string{"max_replication_slots": "3"}, "max_replication_slots"),
+		Entry("max_replication_slots equal to the HA slots",
+			5, true, map[string]string{"max_replication_slots": "4"}, ""),
+		Entry("max_replication_slots lower than the instances with HA slots disabled",
+			5, false, map[string]string{"max_replication_slots": "0"}, ""),
+	)
+
+	It("checks the dependencies on the effective parameters of the cluster", func() {
+		v := &ClusterCustomValidator{}
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				Instances: 3,
+				ImageName: "postgres:17",
+				ReplicationSlots: &apiv1.ReplicationSlotsConfiguration{
+					HighAvailability: &apiv1.ReplicationSlotsHAConfiguration{
+						Enabled: ptr.To(true),
+					},
+				},
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"max_replication_slots": "1",
+					},
+				},
+			},
+		}
+
+		errors := v.validateConfiguration(cluster)
+		Expect(errors).To(HaveLen(1))
+		Expect(errors[0].Field).To(Equal("spec.postgresql.parameters.max_replication_slots"))
+	})
+})
+
+var _ = Describe("shared preload libraries validation", func() {
+	It("accepts valid library names", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					AdditionalLibraries: []string{"pg_cron", "$libdir/timescaledb"},
+				},
+			},
+		}
+		Expect(validateSharedPreloadLibraries(cluster.Spec.PostgresConfiguration)).To(BeEmpty())
+		Expect(getSharedPreloadLibrariesAdmissionWarnings(cluster)).To(BeEmpty())
+	})
+
+	It("rejects library names containing commas or quotes", func() {
+		postgresConfig := apiv1.PostgresConfiguration{
+			AdditionalLibraries: []string{"pg_cron,pgaudit", "pg_cron", "'timescaledb'", `"citus"`},
+		}
+		errors := validateSharedPreloadLibraries(postgresConfig)
+		Expect(errors).To(HaveLen(3))
+		Expect(errors[0].Field).To(Equal("spec.postgresql.shared_preload_libraries[0]"))
+		Expect(errors[1].Field).To(Equal("spec.postgresql.shared_preload_libraries[2]"))
+		Expect(errors[2].Field).To(Equal("spec.postgresql.shared_preload_libraries[3]"))
+	})
+
+	It("warns about empty and duplicated entries", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					AdditionalLibraries: []string{"pg_cron", "", "pg_cron ", "pgaudit"},
+				},
+			},
+		}
+		warnings := getSharedPreloadLibrariesAdmissionWarnings(cluster)
+		Expect(warnings).To(HaveLen(2))
+		Expect(warnings[0]).To(ContainSubstring("empty entry"))
+		Expect(warnings[1]).To(ContainSubstring(`"pg_cron" more than once`))
+	})
+})
+
+var _ = Describe("ConfigMap included in the PostgreSQL configuration", func() {
+	It("requires the name and the key of the ConfigMap", func() {
+		postgresConfig := apiv1.PostgresConfiguration{
+			IncludeConfigMap: &apiv1.ConfigMapKeySelector{
+				LocalObjectReference: apiv1.LocalObjectReference{Name: "custom-parameters"},
+				Key:                  "custom.conf",
+			},
+		}
+		Expect(validateIncludeConfigMap(postgresConfig)).To(BeEmpty())
+		Expect(validateIncludeConfigMap(apiv1.PostgresConfiguration{})).To(BeEmpty())
+
+		postgresConfig.IncludeConfigMap = &apiv1.ConfigMapKeySelector{}
+		errors := validateIncludeConfigMap(postgresConfig)
+		Expect(errors).To(HaveLen(2))
+		Expect(errors[0].Field).To(Equal("spec.postgresql.includeConfigMap.name"))
+		Expect(errors[1].Field).To(Equal("spec.postgresql.includeConfigMap.key"))
+	})
+})
+
+var _ = DescribeTable("validateParametersAvailability",
+	func(key string, pgVersion pgversion.Data, valid bool) {
+		postgresConfig := apiv1.PostgresConfiguration{
+			Parameters: map[string]string{key: "1"},
+		}
+
+		errors := validateParametersAvailability(postgresConfig, pgVersion)
+		if valid {
+			Expect(errors).To(BeEmpty())
+		} else {
+			Expect(errors).To(HaveLen(1))
+			Expect(errors[0].Field).To(Equal("spec.postgresql.parameters." + key))
+			Expect(errors[0].Detail).To(ContainSubstring(key))
+			Expect(errors[0].Detail).To(ContainSubstring(fmt.Sprintf("PostgreSQL %d", pgVersion.Major())))
+		}
+	},
+	Entry("checkpoint_segments on PostgreSQL 12", "checkpoint_segments", pgversion.New(12, 0), false),
+	Entry("wal_keep_segments on PostgreSQL 12", "wal_keep_segments", pgversion.New(12, 4), true),
+	Entry("wal_keep_segments on PostgreSQL 13", "wal_keep_segments", pgversion.New(13, 0), false),
+	Entry("wal_keep_size on PostgreSQL 12", "wal_keep_size", pgversion.New(12, 4), false),
+	Entry("wal_keep_size on PostgreSQL 13", "wal_keep_size", pgversion.New(13, 0), true),
+	Entry("vacuum_defer_cleanup_age on PostgreSQL 15", "vacuum_defer_cleanup_age", pgversion.New(15, 3), true),
+	Entry("vacuum_defer_cleanup_age on PostgreSQL 16", "vacuum_defer_cleanup_age", pgversion.New(16, 1), false),
+	Entry("transaction_timeout on PostgreSQL 16", "transaction_timeout", pgversion.New(16, 4), false),
+	Entry("transaction_timeout on PostgreSQL 17", "transaction_timeout", pgversion.New(17, 0), true),
+	Entry("an extension parameter", "pg_stat_statements.max", pgversion.New(17, 0), true),
+	Entry("an unknown parameter", "my_custom_parameter", pgversion.New(12, 0), true),
+)
+
+var _ = DescribeTable("validateParametersAvailability skips the fixed parameters",
+	func(key string) {
+		postgresConfig := apiv1.PostgresConfiguration{
+			Parameters: map[string]string{key: "/tmp"},
+		}
+		Expect(validateParametersAvailability(postgresConfig, pgversion.New(17, 0))).To(BeEmpty())
+	},
+	Entry("stats_temp_directory", "stats_temp_directory"),
+	Entry("promote_trigger_file", "promote_trigger_file"),
+)
+
+var _ = DescribeTable("validateIntegerParameters",
+	func(key, value string, valid bool) {
+		postgresConfig := apiv1.PostgresConfiguration{
+			Parameters: map[string]string{key: value},
+		}
+
+		errors := validateIntegerParameters(postgresConfig)
+		if valid {
+			Expect(errors).To(BeEmpty())
+		} else {
+			Expect(errors).To(HaveLen(1))
+			Expect(errors[0].Field).To(Equal("spec.postgresql.parameters." + key))
+		}
+	},
+	Entry("parameter not in the curated list", "work_mem", "0", true),
+	Entry("max_connections within range", "max_connections", "200", true),
+	Entry("max_connections with spaces", "max_connections", " 200 ", true),
+	Entry("max_connections in hexadecimal notation", "max_connections", "0x64", true),
+	Entry("max_connections in octal notation", "max_connections", "0144", true),
+	Entry("max_connections in binary notation", "max_connections", "0b1100100", false),
+	Entry("max_connections in Go octal notation", "max_connections", "0o144", false),
+	Entry("max_connections with digit separators", "max_connections", "1_00", false),
+	Entry("max_connections rounded to a valid value", "max_connections", "0.6", true),
+	Entry("max_connections set to zero", "max_connections", "0", false),
+	Entry("max_connections above the maximum", "max_connections", "262144", false),
+	Entry("max_connections not an integer", "max_connections", "many", false),
+	Entry("max_wal_senders set to zero", "max_wal_senders", "0", true),
+	Entry("max_wal_senders negative", "max_wal_senders", "-1", false),
+	Entry("max_parallel_workers above the maximum", "max_parallel_workers", "2048", false),
+	Entry("max_locks_per_transaction below the minimum", "max_locks_per_transaction", "5", false),
+	Entry("max_locks_per_transaction overflowing", "max_locks_per_transaction", "1e30", false),
+)
+
+var _ = DescribeTable("parsePostgresIntegerValue",
+	func(value string, expected int64, valid bool) {
+		result, err := parsePostgresIntegerValue(value)
+		if !valid {
+			Expect(err).To(HaveOccurred())
+			return
+		}
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(expected))
+	},
+	Entry("decimal", "100", int64(100), true),
+	Entry("negative decimal", "-100", int64(-100), true),
+	Entry("hexadecimal", "0x64", int64(100), true),
+	Entry("signed hexadecimal", "+0X64", int64(100), true),
+	Entry("octal", "0144", int64(100), true),
+	Entry("leading zero followed by non-octal digits", "09", int64(9), true),
+	Entry("fractional", "99.5", int64(100), true),
+	Entry("binary", "0b1100100", int64(0), false),
+	Entry("Go octal", "0o144", int64(0), false),
+	Entry("digit separators", "1_000", int64(0), false),
+	Entry("double sign", "--1", int64(0), false),
+	Entry("empty hexadecimal", "0x", int64(0), false),
+)
+
+var _ = Describe("validateHugePagesConfiguration", func() {
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"shared_buffers": "1GB",
+					},
+				},
+				Resources: corev1.ResourceRequirements{
+					Requests: map[corev1.ResourceName]resource.Quantity{},
+					Limits:   map[corev1.ResourceName]resource.Quantity{},
+				},
+			},
+		}
+	})
+
+	It("doesn't complain when huge_pages is not set", func() {
+		Expect(validateHugePagesConfiguration(
+			cluster.Spec.PostgresConfiguration, cluster.Spec.Resources)).To(BeEmpty())
+	})
+
+	It("doesn't require any huge pages resource when huge_pages is set to try", func() {
+		cluster.Spec.PostgresConfiguration.Parameters["huge_pages"] = "try"
+		Expect(validateHugePagesConfiguration(
+			cluster.Spec.PostgresConfiguration, cluster.Spec.Resources)).To(BeEmpty())
+	})
+
+	It("complains when huge_pages is on and no huge pages resource is requested", func() {
+		cluster.Spec.PostgresConfiguration.Parameters["huge_pages"] = "on"
+		errors := validateHugePagesConfiguration(cluster.Spec.PostgresConfiguration, cluster.Spec.Resources)
+		Expect(errors).To(HaveLen(1))
+		Expect(errors[0].Type).To(Equal(field.ErrorTypeRequired))
+		Expect(errors[0].Field).To(Equal("spec.resources.requests.hugepages-2Mi"))
+	})
+
+	It("complains when huge_pages is enabled with another boolean spelling", func() {
+		for _, value := range []string{"true", "yes", "1", "ON"} {
+			cluster.Spec.PostgresConfiguration.Parameters["huge_pages"] = value
+			Expect(validateHugePagesConfiguration(
+				cluster.Spec.PostgresConfiguration, cluster.Spec.Resources)).To(HaveLen(1), value)
+		}
+	})
+
+	It("doesn't complain when huge_pages is disabled", func() {
+		for _, value := range []string{"off", "false", "no", "0"} {
+			cluster.Spec.PostgresConfiguration.Parameters["huge_pages"] = value
+			Expect(validateHugePagesConfiguration(
+				cluster.Spec.PostgresConfiguration, cluster.Spec.Resources)).To(BeEmpty(), value)
+		}
+	})
+
+	It("accepts 2Mi huge pages covering shared_buffers", func() {
+		cluster.Spec.PostgresConfiguration.Parameters["huge_pages"] = "on"
+		cluster.Spec.Resources.Requests["hugepages-2Mi"] = resource.MustParse("1Gi")
+		Expect(validateHugePagesConfiguration(
+			cluster.Spec.PostgresConfiguration, cluster.Spec.Resources)).To(BeEmpty())
+	})
+
+	It("complains when 2Mi huge pages don't cover shared_buffers", func() {
+		cluster.Spec.PostgresConfiguration.Parameters["huge_pages"] = "on"
+		cluster.Spec.Resources.Requests["hugepages-2Mi"] = resource.MustParse("512Mi")
+		errors := validateHugePagesConfiguration(cluster.Spec.PostgresConfiguration, cluster.Spec.Resources)
+		Expect(errors).To(HaveLen(1))
+		Expect(errors[0].Field).To(Equal("spec.resources.requests"))
+	})
+
+	It("accepts 1Gi huge pages covering shared_buffers", func() {
+		cluster.Spec.PostgresConfiguration.Parameters["huge_pages"] = "on"
+		cluster.Spec.Resources.Requests["hugepages-1Gi"] = resource.MustParse("2Gi")
+		Expect(validateHugePagesConfiguration(
+			cluster.Spec.PostgresConfiguration, cluster.Spec.Resources)).To(BeEmpty())
+	})
+
+	It("complains when 1Gi huge pages don't cover shared_buffers", func() {
+		cluster.Spec.PostgresConfiguration.Parameters["huge_pages"] = "on"
+		cluster.Spec.PostgresConfiguration.Parameters["shared_buffers"] = "4GB"
+		cluster.Spec.Resources.Limits["hugepages-1Gi"] = resource.MustParse("2Gi")
+		Expect(validateHugePagesConfiguration(
+			cluster.Spec.PostgresConfiguration, cluster.Spec.Resources)).To(HaveLen(1))
+	})
+
+	It("uses the PostgreSQL default for shared_buffers when not set", func() {
+		cluster.Spec.PostgresConfiguration.Parameters = map[string]string{"huge_pages": "on"}
+		cluster.Spec.Resources.Requests["hugepages-2Mi"] = resource.MustParse("128Mi")
+		Expect(validateHugePagesConfiguration(
+			cluster.Spec.PostgresConfiguration, cluster.Spec.Resources)).To(BeEmpty())
+	})
+
+	It("is invoked by validateConfiguration", func() {
+		cluster.Spec.PostgresConfiguration.Parameters["huge_pages"] = "on"
+		v := &ClusterCustomValidator{}
+		Expect(v.validateConfiguration(cluster)).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("lc_messages admission warnings", func() {
+	var cluster *apiv1.Cluster
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{},
+				},
+			},
+		}
+	})
+
+	It("doesn't warn when lc_messages is not set", func() {
+		Expect(getLcMessagesAdmissionWarnings(cluster)).To(BeEmpty())
+	})
+
+	It("doesn't warn when lc_messages uses an English locale", func() {
+		for _, value := range []string{"C", "POSIX", "C.UTF-8", "en_US.UTF-8", "'en_GB.utf8'"} {
+			cluster.Spec.PostgresConfiguration.Parameters["lc_messages"] = value
+			Expect(getLcMessagesAdmissionWarnings(cluster)).To(BeEmpty(), value)
+		}
+	})
+
+	It("warns when lc_messages uses a non-English locale", func() {
+		cluster.Spec.PostgresConfiguration.Parameters["lc_messages"] = "it_IT.UTF-8"
+		warnings := getLcMessagesAdmissionWarnings(cluster)
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0]).To(ContainSubstring("it_IT.UTF-8"))
+	})
+
+	It("is part of the cluster admission warnings", func() {
+		v := &ClusterCustomValidator{}
+		cluster.Spec.PostgresConfiguration.Parameters["lc_messages"] = "de_DE.UTF-8"
+		Expect(v.getAdmissionWarnings(cluster)).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("track_commit_timestamp admission warnings", func() {
+	var cluster *apiv1.Cluster
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: "postgres:18.1",
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{},
+				},
+			},
+		}
+	})
+
+	It("doesn't warn when logical replication is not explicitly configured", func() {
+		Expect(getTrackCommitTimestampAdmissionWarnings(&apiv1.Cluster{})).To(BeEmpty())
+		cluster.Spec.PostgresConfiguration.Parameters["wal_level"] = "replica"
+		Expect(getTrackCommitTimestampAdmissionWarnings(cluster)).To(BeEmpty())
+	})
+
+	It("doesn't warn when commit timestamps are tracked", func() {
+		cluster.Spec.PostgresConfiguration.Parameters["wal_level"] = "logical"
+		cluster.Spec.PostgresConfiguration.Parameters["track_commit_timestamp"] = "on"
+		Expect(getTrackCommitTimestampAdmissionWarnings(cluster)).To(BeEmpty())
+	})
+
+	It("warns when logical replication is configured without tracking commit timestamps", func() {
+		cluster.Spec.PostgresConfiguration.Parameters["wal_level"] = "logical"
+		Expect(getTrackCommitTimestampAdmissionWarnings(cluster)).To(HaveLen(1))
+
+		cluster.Spec.PostgresConfiguration.Parameters = map[string]string{
+			"max_logical_replication_workers": "8",
+			"track_commit_timestamp":          "off",
+		}
+		Expect(getTrackCommitTimestampAdmissionWarnings(cluster)).To(HaveLen(1))
+	})
+
+	It("doesn't warn before PostgreSQL 18, which doesn't detect these conflicts", func() {
+		cluster.Spec.ImageName = "postgres:17.6"
+		cluster.Spec.PostgresConfiguration.Parameters["wal_level"] = "logical"
+		Expect(getTrackCommitTimestampAdmissionWarnings(cluster)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("autovacuum admission warnings", func() {
+	var cluster *apiv1.Cluster
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{},
+				},
+			},
+		}
+	})
+
+	It("doesn't warn when autovacuum is enabled", func() {
+		Expect(getAutovacuumAdmissionWarnings(cluster)).To(BeEmpty())
+		cluster.Spec.PostgresConfiguration.Parameters["autovacuum"] = "on"
+		Expect(getAutovacuumAdmissionWarnings(cluster)).To(BeEmpty())
+	})
+
+	It("warns when autovacuum is disabled", func() {
+		for _, value := range []string{"off", "false", "0"} {
+			cluster.Spec.PostgresConfiguration.Parameters["autovacuum"] = value
+			warnings := getAutovacuumAdmissionWarnings(cluster)
+			Expect(warnings).To(HaveLen(1))
+			Expect(warnings[0]).To(ContainSubstring("wraparound"))
+		}
+	})
+})
+
 var _ = Describe("Tablespaces validation", func() {
 	var v *ClusterCustomValidator
 	BeforeEach(func() {
@@ -4800,6 +6576,15 @@ var _ = Describe("validateManagedServices", func() {
 			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
 			Expect(errs[0].Field).To(Equal("spec.managed.services.disabledDefaultServices"))
 		})
+
+		It("should not allow the disablement of the ro-fresh service", func() {
+			cluster.Spec.Managed.Services.DisabledDefaultServices = []apiv1.ServiceSelectorType{
+				apiv1.ServiceSelectorTypeROFresh,
+			}
+			errs := v.validateManagedServices(cluster)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.managed.services.disabledDefaultServices"))
+		})
 	})
 })
 
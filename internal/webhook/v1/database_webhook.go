@@ -0,0 +1,422 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"github.com/cloudnative-pg/machinery/pkg/stringset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
+)
+
+// maxIdentifierLength is the maximum length of a PostgreSQL identifier,
+// as NAMEDATALEN is 64 by default, including the terminator
+const maxIdentifierLength = 63
+
+var (
+	// extensionNameRegex matches the names of the extensions, as allowed
+	// for the names of their control files
+	extensionNameRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_-]*$`)
+
+	// extensionVersionRegex matches the versions of the extensions
+	extensionVersionRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.+-]*$`)
+
+	// schemaNameRegex matches the schema names that don't need quoting
+	schemaNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+	// localeRegex matches the names of the libc, ICU, and builtin locales,
+	// such as `en_US.UTF-8`, `sr_RS@latin`, or `und-u-ks-level2`
+	localeRegex = regexp.MustCompile(`^[A-Za-z0-9_.@+=-]+$`)
+
+	// encodingCleanupRegex matches the characters PostgreSQL ignores
+	// while looking up an encoding by name
+	encodingCleanupRegex = regexp.MustCompile(`[^a-z0-9]`)
+)
+
+// serverEncodings are the names, and their aliases, of the encodings that
+// can be used by a PostgreSQL database, without the characters ignored by
+// PostgreSQL while looking them up
+var serverEncodings = stringset.From([]string{
+	"abc", "alt", "euccn", "eucjis2004", "eucjp", "euckr", "euctw",
+	"iso88591", "iso885910", "iso885913", "iso885914", "iso885915", "iso885916",
+	"iso88592", "iso88593", "iso88594", "iso88595", "iso88596", "iso88597",
+	"iso88598", "iso88599", "koi8", "koi8r", "koi8u",
+	"latin1", "latin10", "latin2", "latin3", "latin4", "latin5",
+	"latin6", "latin7", "latin8", "latin9", "muleinternal", "sqlascii",
+	"tcvn", "tcvn5712", "unicode", "utf8", "vscii", "win",
+	"win1250", "win1251", "win1252", "win1253", "win1254", "win1255",
+	"win1256", "win1257", "win1258", "win866", "win874",
+	"windows1250", "windows1251", "windows1252", "windows1253", "windows1254",
+	"windows1255", "windows1256", "windows1257", "windows1258", "windows866", "windows874",
+})
+
+// localeProviders are the locale providers supported by PostgreSQL
+var localeProviders = stringset.From([]string{"libc", "icu", "builtin"})
+
+// databaseLog is for logging in this package.
+var databaseLog = log.WithName("database-resource").WithValues("version", "v1")
+
+// SetupDatabaseWebhookWithManager registers the webhook for Database in the manager.
+func SetupDatabaseWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&apiv1.Database{}).
+		WithValidator(&DatabaseCustomValidator{client: mgr.GetClient()}).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:webhookVersions={v1},admissionReviewVersions={v1},verbs=create;update,path=/validate-postgresql-cnpg-io-v1-database,mutating=false,failurePolicy=fail,groups=postgresql.cnpg.io,resources=databases,versions=v1,name=vdatabase.cnpg.io,sideEffects=None
+
+// DatabaseCustomValidator struct is responsible for validating the Database resource
+// when it is created, updated, or deleted.
+type DatabaseCustomValidator struct {
+	// client is used to read the Cluster the Database is referring to.
+	// When nil, the cross-resource validations are skipped
+	client client.Client
+}
+
+var _ webhook.CustomValidator = &DatabaseCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Database.
+func (v *DatabaseCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	database, ok := obj.(*apiv1.Database)
+	if !ok {
+		return nil, fmt.Errorf("expected a Database object but got %T", obj)
+	}
+	databaseLog.Info("Validation for Database upon creation",
+		"name", database.GetName(), "namespace", database.GetNamespace())
+
+	warnings := v.getRolesAdmissionWarnings(ctx, database)
+	allErrs := v.validate(database)
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		schema.GroupKind{Group: "postgresql.cnpg.io", Kind: "Database"},
+		database.Name, allErrs)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Database.
+func (v *DatabaseCustomValidator) ValidateUpdate(
+	ctx context.Context,
+	_, newObj runtime.Object,
+) (admission.Warnings, error) {
+	database, ok := newObj.(*apiv1.Database)
+	if !ok {
+		return nil, fmt.Errorf("expected a Database object for the newObj but got %T", newObj)
+	}
+	databaseLog.Info("Validation for Database upon update",
+		"name", database.GetName(), "namespace", database.GetNamespace())
+
+	warnings := v.getRolesAdmissionWarnings(ctx, database)
+	allErrs := v.validate(database)
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		schema.GroupKind{Group: "postgresql.cnpg.io", Kind: "Database"},
+		database.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Database.
+func (v *DatabaseCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	database, ok := obj.(*apiv1.Database)
+	if !ok {
+		return nil, fmt.Errorf("expected a Database object but got %T", obj)
+	}
+	databaseLog.Info("Validation for Database upon deletion",
+		"name", database.GetName(), "namespace", database.GetNamespace())
+
+	return nil, nil
+}
+
+func (v *DatabaseCustomValidator) validate(d *apiv1.Database) (allErrs field.ErrorList) {
+	type validationFunc func(*apiv1.Database) field.ErrorList
+	validations := []validationFunc{
+		v.validateIdentifiers,
+		v.validateEncoding,
+		v.validateLocale,
+		v.validateExtensions,
+		v.validateDefaultPrivileges,
+	}
+
+	for _, validate := range validations {
+		allErrs = append(allErrs, validate(d)...)
+	}
+
+	return allErrs
+}
+
+// validateIdentifiers verifies that the names of the database
+// and of its owner are valid PostgreSQL identifiers
+func (v *DatabaseCustomValidator) validateIdentifiers(d *apiv1.Database) field.ErrorList {
+	result := validateGrantIdentifier(field.NewPath("spec", "name"), d.Spec.Name)
+	return append(result, validateGrantIdentifier(field.NewPath("spec", "owner"), d.Spec.Owner)...)
+}
+
+// validateEncoding verifies that the encoding can be used by a database
+func (v *DatabaseCustomValidator) validateEncoding(d *apiv1.Database) field.ErrorList {
+	if d.Spec.Encoding == "" {
+		return nil
+	}
+
+	encoding := encodingCleanupRegex.ReplaceAllString(strings.ToLower(d.Spec.Encoding), "")
+	if serverEncodings.Has(encoding) {
+		return nil
+	}
+
+	return field.ErrorList{
+		field.Invalid(field.NewPath("spec", "encoding"), d.Spec.Encoding,
+			"unknown encoding, or encoding not supported as a server encoding"),
+	}
+}
+
+// validateLocale verifies the locale provider and the names of the locales
+func (v *DatabaseCustomValidator) validateLocale(d *apiv1.Database) field.ErrorList {
+	var result field.ErrorList
+
+	if d.Spec.LocaleProvider != "" && !localeProviders.Has(strings.ToLower(d.Spec.LocaleProvider)) {
+		result = append(result, field.NotSupported(
+			field.NewPath("spec", "localeProvider"), d.Spec.LocaleProvider, localeProviders.ToSortedList()))
+	}
+
+	locales := []struct {
+		name  string
+		value string
+	}{
+		{name: "locale", value: d.Spec.Locale},
+		{name: "localeCollate", value: d.Spec.LcCollate},
+		{name: "localeCType", value: d.Spec.LcCtype},
+		{name: "icuLocale", value: d.Spec.IcuLocale},
+		{name: "builtinLocale", value: d.Spec.BuiltinLocale},
+	}
+	for _, locale := range locales {
+		if locale.value != "" && !isValidIdentifier(locale.value, localeRegex) {
+			result = append(result, field.Invalid(field.NewPath("spec", locale.name), locale.value,
+				fmt.Sprintf("locale names must match %q and be at most %d characters long",
+					localeRegex.String(), maxIdentifierLength)))
+		}
+	}
+
+	return result
+}
+
+// validateExtensions verifies that the managed extensions are listed once,
+// and that their names, versions, and schemas are safe to be used in the
+// SQL commands managing them
+func (v *DatabaseCustomValidator) validateExtensions(d *apiv1.Database) field.ErrorList {
+	var result field.ErrorList
+
+	basePath := field.NewPath("spec", "extensions")
+	seen := make(map[string]bool, len(d.Spec.Extensions))
+	for idx, extension := range d.Spec.Extensions {
+		path := basePath.Index(idx)
+
+		switch {
+		case !isValidIdentifier(extension.Name, extensionNameRegex):
+			result = append(result, field.Invalid(path.Child("name"), extension.Name,
+				fmt.Sprintf("extension names must match %q and be at most %d characters long",
+					extensionNameRegex.String(), maxIdentifierLength)))
+		case seen[extension.Name]:
+			result = append(result, field.Duplicate(path.Child("name"), extension.Name))
+		}
+		seen[extension.Name] = true
+
+		if extension.Version != "" && !isValidIdentifier(extension.Version, extensionVersionRegex) {
+			result = append(result, field.Invalid(path.Child("version"), extension.Version,
+				fmt.Sprintf("extension versions must match %q and be at most %d characters long",
+					extensionVersionRegex.String(), maxIdentifierLength)))
+		}
+
+		if extension.Schema != "" && !isValidIdentifier(extension.Schema, schemaNameRegex) {
+			result = append(result, field.Invalid(path.Child("schema"), extension.Schema,
+				fmt.Sprintf("schema names must match %q and be at most %d characters long",
+					schemaNameRegex.String(), maxIdentifierLength)))
+		}
+	}
+
+	return result
+}
+
+// validateDefaultPrivileges verifies the roles, the schemas, the object
+// types, and the privileges of the managed default privileges, which must
+// be listed once per grantor, grantee, object type, and schema
+func (v *DatabaseCustomValidator) validateDefaultPrivileges(d *apiv1.Database) field.ErrorList {
+	var result field.ErrorList
+
+	type defaultPrivilegesKey struct {
+		grantor    string
+		grantee    string
+		objectType apiv1.DefaultPrivilegesObjectType
+		schema     string
+	}
+
+	basePath := field.NewPath("spec", "defaultPrivileges")
+	seen := make(map[defaultPrivilegesKey]bool, len(d.Spec.DefaultPrivileges))
+	for idx, defaultPrivileges := range d.Spec.DefaultPrivileges {
+		path := basePath.Index(idx)
+
+		result = append(result, validateGrantIdentifier(path.Child("grantor"), defaultPrivileges.Grantor)...)
+		if strings.EqualFold(defaultPrivileges.Grantor, "public") {
+			result = append(result, field.Invalid(path.Child("grantor"), defaultPrivileges.Grantor,
+				"This role is reserved"))
+		}
+
+		result = append(result, validateGrantIdentifier(path.Child("grantee"), defaultPrivileges.Grantee)...)
+		switch {
+		case postgres.IsRoleReserved(defaultPrivileges.Grantee) || strings.EqualFold(defaultPrivileges.Grantee, "public"):
+			result = append(result, field.Invalid(path.Child("grantee"), defaultPrivileges.Grantee,
+				"This role is reserved"))
+		case defaultPrivileges.Grantee == defaultPrivileges.Grantor:
+			result = append(result, field.Invalid(path.Child("grantee"), defaultPrivileges.Grantee,
+				"the grantee must be different from the grantor, which already owns the objects"))
+		}
+
+		supportedPrivileges := defaultPrivileges.ObjectType.GetSupportedPrivileges()
+		if len(supportedPrivileges) == 0 {
+			result = append(result, field.NotSupported(path.Child("objectType"), defaultPrivileges.ObjectType,
+				[]apiv1.DefaultPrivilegesObjectType{
+					apiv1.DefaultPrivilegesObjectTypeTables,
+					apiv1.DefaultPrivilegesObjectTypeSequences,
+					apiv1.DefaultPrivilegesObjectTypeFunctions,
+					apiv1.DefaultPrivilegesObjectTypeTypes,
+					apiv1.DefaultPrivilegesObjectTypeSchemas,
+				}))
+		}
+		for _, privilege := range defaultPrivileges.Privileges {
+			if len(supportedPrivileges) > 0 && !slices.Contains(supportedPrivileges, privilege) {
+				result = append(result, field.NotSupported(path.Child("privileges"), privilege, supportedPrivileges))
+			}
+		}
+
+		switch {
+		case defaultPrivileges.Schema == "":
+		case defaultPrivileges.ObjectType == apiv1.DefaultPrivilegesObjectTypeSchemas:
+			result = append(result, field.Invalid(path.Child("schema"), defaultPrivileges.Schema,
+				"schema must be empty when setting the default privileges on schemas"))
+		default:
+			result = append(result, validateGrantIdentifier(path.Child("schema"), defaultPrivileges.Schema)...)
+		}
+
+		key := defaultPrivilegesKey{
+			grantor:    defaultPrivileges.Grantor,
+			grantee:    defaultPrivileges.Grantee,
+			objectType: defaultPrivileges.ObjectType,
+			schema:     defaultPrivileges.Schema,
+		}
+		if seen[key] {
+			result = append(result, field.Duplicate(path, fmt.Sprintf("%s %s %s",
+				defaultPrivileges.ObjectType, defaultPrivileges.Grantor, defaultPrivileges.Grantee)))
+		}
+		seen[key] = true
+	}
+
+	return result
+}
+
+// getRolesAdmissionWarnings warns the user when the owner of the database,
+// or a role used in its default privileges, is not a role managed by the
+// Cluster. As the webhook cannot connect to PostgreSQL, the role may still
+// exist and be managed by other means
+func (v *DatabaseCustomValidator) getRolesAdmissionWarnings(
+	ctx context.Context,
+	d *apiv1.Database,
+) admission.Warnings {
+	if v.client == nil || d.Spec.ClusterRef.Name == "" || d.Spec.Ensure == apiv1.EnsureAbsent ||
+		(d.Spec.Owner == "" && len(d.Spec.DefaultPrivileges) == 0) {
+		return nil
+	}
+
+	var cluster apiv1.Cluster
+	if err := v.client.Get(
+		ctx,
+		client.ObjectKey{Namespace: d.Namespace, Name: d.Spec.ClusterRef.Name},
+		&cluster,
+	); err != nil {
+		// The Cluster may not have been created yet
+		if !apierrors.IsNotFound(err) {
+			databaseLog.Info("Unable to get the Cluster referred by the Database, skipping roles validation",
+				"name", d.Name, "namespace", d.Namespace, "cluster", d.Spec.ClusterRef.Name, "err", err.Error())
+		}
+		return nil
+	}
+
+	var warnings admission.Warnings
+	if d.Spec.Owner != "" && !isRoleKnownByCluster(&cluster, d.Spec.Owner) {
+		warnings = append(warnings, fmt.Sprintf(
+			"The owner %q of the database is not a role managed by the Cluster %q: "+
+				"make sure the role exists in PostgreSQL, or the database won't be created",
+			d.Spec.Owner, d.Spec.ClusterRef.Name))
+	}
+
+	reported := stringset.New()
+	for _, defaultPrivileges := range d.Spec.DefaultPrivileges {
+		for _, role := range []string{defaultPrivileges.Grantor, defaultPrivileges.Grantee} {
+			if role == "" || role == d.Spec.Owner || reported.Has(role) || isRoleKnownByCluster(&cluster, role) {
+				continue
+			}
+			reported.Put(role)
+			warnings = append(warnings, fmt.Sprintf(
+				"The role %q used in the default privileges is not a role managed by the Cluster %q: "+
+					"make sure the role exists in PostgreSQL, or the default privileges won't be applied",
+				role, d.Spec.ClusterRef.Name))
+		}
+	}
+
+	return warnings
+}
+
+// isRoleKnownByCluster checks if the role is the superuser, the owner of
+// the application database, or a role managed by the Cluster
+func isRoleKnownByCluster(cluster *apiv1.Cluster, role string) bool {
+	if role == "postgres" || role == cluster.GetApplicationDatabaseOwner() {
+		return true
+	}
+	if cluster.Spec.Managed == nil {
+		return false
+	}
+	for _, managedRole := range cluster.Spec.Managed.Roles {
+		if managedRole.Name == role && managedRole.Ensure != apiv1.EnsureAbsent {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isValidIdentifier checks if the passed value matches the regular
+// expression and is not longer than a PostgreSQL identifier
+func isValidIdentifier(value string, regex *regexp.Regexp) bool {
+	return len(value) <= maxIdentifierLength && regex.MatchString(value)
+}
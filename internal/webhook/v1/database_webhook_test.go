@@ -0,0 +1,320 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Database validation", func() {
+	var v *DatabaseCustomValidator
+	newDatabase := func(extensions ...apiv1.ExtensionSpec) *apiv1.Database {
+		return &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				Name:       "app",
+				Owner:      "app",
+				Extensions: extensions,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		v = &DatabaseCustomValidator{}
+	})
+
+	It("accepts a database without extensions", func() {
+		Expect(v.validate(newDatabase())).To(BeEmpty())
+	})
+
+	It("accepts valid extensions", func() {
+		Expect(v.validate(newDatabase(
+			apiv1.ExtensionSpec{Name: "postgis", Version: "3.4.2", Schema: "gis"},
+			apiv1.ExtensionSpec{Name: "uuid-ossp"},
+			apiv1.ExtensionSpec{Name: "pg_stat_statements", Ensure: apiv1.EnsureAbsent},
+		))).To(BeEmpty())
+	})
+
+	DescribeTable("rejects unsafe extension specifications",
+		func(extension apiv1.ExtensionSpec, expectedField string) {
+			result := v.validate(newDatabase(extension))
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(result[0].Field).To(Equal(expectedField))
+		},
+		Entry("with an empty name",
+			apiv1.ExtensionSpec{Name: ""}, "spec.extensions[0].name"),
+		Entry("with a quote in the name",
+			apiv1.ExtensionSpec{Name: `postgis"; DROP TABLE users; --`}, "spec.extensions[0].name"),
+		Entry("with a space in the name",
+			apiv1.ExtensionSpec{Name: "post gis"}, "spec.extensions[0].name"),
+		Entry("with a name longer than an identifier",
+			apiv1.ExtensionSpec{Name: strings.Repeat("a", 64)}, "spec.extensions[0].name"),
+		Entry("with a shell substitution in the version",
+			apiv1.ExtensionSpec{Name: "postgis", Version: "$(id)"}, "spec.extensions[0].version"),
+		Entry("with a semicolon in the schema",
+			apiv1.ExtensionSpec{Name: "postgis", Schema: "gis;"}, "spec.extensions[0].schema"),
+	)
+
+	It("rejects the extensions listed more than once", func() {
+		result := v.validate(newDatabase(
+			apiv1.ExtensionSpec{Name: "postgis"},
+			apiv1.ExtensionSpec{Name: "pg_trgm"},
+			apiv1.ExtensionSpec{Name: "postgis", Ensure: apiv1.EnsureAbsent},
+		))
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Type).To(Equal(field.ErrorTypeDuplicate))
+		Expect(result[0].Field).To(Equal("spec.extensions[2].name"))
+	})
+})
+
+var _ = Describe("Database name, owner, encoding, and locale validation", func() {
+	var v *DatabaseCustomValidator
+	var database *apiv1.Database
+
+	BeforeEach(func() {
+		v = &DatabaseCustomValidator{}
+		database = &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				Name:  "app",
+				Owner: "app",
+			},
+		}
+	})
+
+	It("accepts a valid database", func() {
+		database.Spec.Encoding = "UTF8"
+		database.Spec.LocaleProvider = "icu"
+		database.Spec.Locale = "en_US.UTF-8"
+		database.Spec.IcuLocale = "und-u-ks-level2"
+		Expect(v.validate(database)).To(BeEmpty())
+	})
+
+	It("requires the name and the owner", func() {
+		database.Spec.Name = ""
+		database.Spec.Owner = ""
+		result := v.validate(database)
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Field).To(Equal("spec.name"))
+		Expect(result[1].Field).To(Equal("spec.owner"))
+	})
+
+	It("rejects names longer than an identifier", func() {
+		database.Spec.Owner = strings.Repeat("o", 64)
+		result := v.validate(database)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Type).To(Equal(field.ErrorTypeTooLong))
+	})
+
+	DescribeTable("validates the encoding",
+		func(encoding string, valid bool) {
+			database.Spec.Encoding = encoding
+			if valid {
+				Expect(v.validate(database)).To(BeEmpty())
+			} else {
+				Expect(v.validate(database)).To(HaveLen(1))
+			}
+		},
+		Entry("UTF8", "UTF8", true),
+		Entry("an alias with a different case and punctuation", "utf-8", true),
+		Entry("LATIN1", "LATIN1", true),
+		Entry("an alias of LATIN1", "ISO_8859_1", true),
+		Entry("SQL_ASCII", "SQL_ASCII", true),
+		Entry("a client-only encoding", "SJIS", false),
+		Entry("an unknown encoding", "KLINGON", false),
+	)
+
+	It("rejects unknown locale providers", func() {
+		database.Spec.LocaleProvider = "glibc"
+		result := v.validate(database)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Type).To(Equal(field.ErrorTypeNotSupported))
+	})
+
+	It("rejects unsafe locale names", func() {
+		database.Spec.LcCollate = "en_US'; DROP DATABASE app; --"
+		database.Spec.BuiltinLocale = "C.UTF-8"
+		result := v.validate(database)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Field).To(Equal("spec.localeCollate"))
+	})
+})
+
+var _ = Describe("Database roles warnings", func() {
+	var cluster *apiv1.Cluster
+	var database *apiv1.Database
+	var v *DatabaseCustomValidator
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				Bootstrap: &apiv1.BootstrapConfiguration{
+					InitDB: &apiv1.BootstrapInitDB{Database: "app", Owner: "app"},
+				},
+				Managed: &apiv1.ManagedConfiguration{
+					Roles: []apiv1.RoleConfiguration{
+						{Name: "reporting", Ensure: apiv1.EnsurePresent},
+						{Name: "legacy", Ensure: apiv1.EnsureAbsent},
+					},
+				},
+			},
+		}
+		database = &apiv1.Database{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-one", Namespace: "default"},
+			Spec: apiv1.DatabaseSpec{
+				ClusterRef: corev1.LocalObjectReference{Name: cluster.Name},
+				Name:       "one",
+			},
+		}
+		v = &DatabaseCustomValidator{
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.BuildWithAllKnownScheme()).
+				WithObjects(cluster).
+				Build(),
+		}
+	})
+
+	DescribeTable("warns when the owner is not managed by the cluster",
+		func(ctx SpecContext, owner string, expectedWarnings int) {
+			database.Spec.Owner = owner
+			Expect(v.getRolesAdmissionWarnings(ctx, database)).To(HaveLen(expectedWarnings))
+		},
+		Entry("with the application owner", "app", 0),
+		Entry("with the superuser", "postgres", 0),
+		Entry("with a managed role", "reporting", 0),
+		Entry("with a managed role that should be absent", "legacy", 1),
+		Entry("with an unknown role", "someone", 1),
+	)
+
+	It("warns once for each unknown role used in the default privileges", func(ctx SpecContext) {
+		database.Spec.Owner = "app"
+		database.Spec.DefaultPrivileges = []apiv1.DefaultPrivilegesConfiguration{
+			{Grantor: "app", Grantee: "reporting", ObjectType: apiv1.DefaultPrivilegesObjectTypeTables},
+			{Grantor: "app", Grantee: "someone", ObjectType: apiv1.DefaultPrivilegesObjectTypeTables},
+			{Grantor: "app", Grantee: "someone", ObjectType: apiv1.DefaultPrivilegesObjectTypeSequences},
+			{Grantor: "legacy", Grantee: "reporting", ObjectType: apiv1.DefaultPrivilegesObjectTypeTables},
+		}
+		warnings := v.getRolesAdmissionWarnings(ctx, database)
+		Expect(warnings).To(HaveLen(2))
+		Expect(warnings[0]).To(ContainSubstring(`"someone"`))
+		Expect(warnings[1]).To(ContainSubstring(`"legacy"`))
+	})
+
+	It("doesn't warn when the cluster doesn't exist yet", func(ctx SpecContext) {
+		database.Spec.ClusterRef.Name = "cluster-other"
+		database.Spec.Owner = "someone"
+		Expect(v.getRolesAdmissionWarnings(ctx, database)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Database default privileges validation", func() {
+	var database *apiv1.Database
+	var v *DatabaseCustomValidator
+
+	BeforeEach(func() {
+		database = &apiv1.Database{
+			Spec: apiv1.DatabaseSpec{
+				Name:  "app",
+				Owner: "app",
+				DefaultPrivileges: []apiv1.DefaultPrivilegesConfiguration{
+					{
+						Grantor:    "app",
+						Grantee:    "reporting",
+						ObjectType: apiv1.DefaultPrivilegesObjectTypeTables,
+						Schema:     "sales",
+						Privileges: []apiv1.GrantPrivilege{apiv1.GrantPrivilegeSelect},
+					},
+					{
+						Grantor:    "app",
+						Grantee:    "reporting",
+						ObjectType: apiv1.DefaultPrivilegesObjectTypeFunctions,
+						Privileges: []apiv1.GrantPrivilege{apiv1.GrantPrivilegeExecute},
+					},
+					{
+						Grantor:    "app",
+						Grantee:    "reporting",
+						ObjectType: apiv1.DefaultPrivilegesObjectTypeSchemas,
+					},
+				},
+			},
+		}
+		v = &DatabaseCustomValidator{}
+	})
+
+	It("accepts valid default privileges", func() {
+		Expect(v.validate(database)).To(BeEmpty())
+	})
+
+	DescribeTable("rejects invalid default privileges",
+		func(update func(*apiv1.DefaultPrivilegesConfiguration), expectedField string) {
+			update(&database.Spec.DefaultPrivileges[0])
+			result := v.validate(database)
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Field).To(Equal(expectedField))
+		},
+		Entry("without a grantor", func(d *apiv1.DefaultPrivilegesConfiguration) {
+			d.Grantor = ""
+		}, "spec.defaultPrivileges[0].grantor"),
+		Entry("with the public pseudo-role as grantor", func(d *apiv1.DefaultPrivilegesConfiguration) {
+			d.Grantor = "PUBLIC"
+		}, "spec.defaultPrivileges[0].grantor"),
+		Entry("with a reserved grantee", func(d *apiv1.DefaultPrivilegesConfiguration) {
+			d.Grantee = "streaming_replica"
+		}, "spec.defaultPrivileges[0].grantee"),
+		Entry("with the grantor as grantee", func(d *apiv1.DefaultPrivilegesConfiguration) {
+			d.Grantee = "app"
+		}, "spec.defaultPrivileges[0].grantee"),
+		Entry("with an unknown object type", func(d *apiv1.DefaultPrivilegesConfiguration) {
+			d.ObjectType = "views"
+			d.Privileges = nil
+		}, "spec.defaultPrivileges[0].objectType"),
+		Entry("with a privilege not supported by the object type", func(d *apiv1.DefaultPrivilegesConfiguration) {
+			d.Privileges = []apiv1.GrantPrivilege{apiv1.GrantPrivilegeExecute}
+		}, "spec.defaultPrivileges[0].privileges"),
+		Entry("with a schema when the objects are schemas", func(d *apiv1.DefaultPrivilegesConfiguration) {
+			d.ObjectType = apiv1.DefaultPrivilegesObjectTypeSchemas
+			d.Privileges = []apiv1.GrantPrivilege{apiv1.GrantPrivilegeUsage}
+		}, "spec.defaultPrivileges[0].schema"),
+		Entry("with a schema longer than an identifier", func(d *apiv1.DefaultPrivilegesConfiguration) {
+			d.Schema = strings.Repeat("s", 64)
+		}, "spec.defaultPrivileges[0].schema"),
+	)
+
+	It("rejects the default privileges listed more than once", func() {
+		database.Spec.DefaultPrivileges = append(database.Spec.DefaultPrivileges, apiv1.DefaultPrivilegesConfiguration{
+			Grantor:    "app",
+			Grantee:    "reporting",
+			ObjectType: apiv1.DefaultPrivilegesObjectTypeTables,
+			Schema:     "sales",
+		})
+		result := v.validate(database)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Type).To(Equal(field.ErrorTypeDuplicate))
+		Expect(result[0].Field).To(Equal("spec.defaultPrivileges[3]"))
+	})
+})
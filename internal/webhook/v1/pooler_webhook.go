@@ -19,6 +19,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	"github.com/cloudnative-pg/machinery/pkg/stringset"
@@ -27,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -94,7 +96,7 @@ var poolerLog = log.WithName("pooler-resource").WithValues("version", "v1")
 // SetupPoolerWebhookWithManager registers the webhook for Pooler in the manager.
 func SetupPoolerWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&apiv1.Pooler{}).
-		WithValidator(&PoolerCustomValidator{}).
+		WithValidator(&PoolerCustomValidator{client: mgr.GetClient()}).
 		Complete()
 }
 
@@ -105,12 +107,16 @@ func SetupPoolerWebhookWithManager(mgr ctrl.Manager) error {
 
 // PoolerCustomValidator struct is responsible for validating the Pooler resource
 // when it is created, updated, or deleted.
-type PoolerCustomValidator struct{}
+type PoolerCustomValidator struct {
+	// client is used to read the Cluster the Pooler is referring to.
+	// When nil, the cross-resource validations are skipped
+	client client.Client
+}
 
 var _ webhook.CustomValidator = &PoolerCustomValidator{}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Pooler.
-func (v *PoolerCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (v *PoolerCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	pooler, ok := obj.(*apiv1.Pooler)
 	if !ok {
 		return nil, fmt.Errorf("expected a Pooler object but got %T", obj)
@@ -124,6 +130,7 @@ func (v *PoolerCustomValidator) ValidateCreate(_ context.Context, obj runtime.Ob
 		warns = append(warns, fmt.Sprintf("The operator won't handle the Pooler %q integration with the Cluster %q (%q). "+
 			"Manually configure it as described in the docs.", pooler.Name, pooler.Spec.Cluster.Name, pooler.Namespace))
 	}
+	warns = append(warns, v.getMaxConnectionsAdmissionWarnings(ctx, pooler)...)
 
 	allErrs := v.validate(pooler)
 
@@ -138,7 +145,7 @@ func (v *PoolerCustomValidator) ValidateCreate(_ context.Context, obj runtime.Ob
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Pooler.
 func (v *PoolerCustomValidator) ValidateUpdate(
-	_ context.Context,
+	ctx context.Context,
 	oldObj, newObj runtime.Object,
 ) (admission.Warnings, error) {
 	pooler, ok := newObj.(*apiv1.Pooler)
@@ -160,6 +167,7 @@ func (v *PoolerCustomValidator) ValidateUpdate(
 		warns = append(warns, fmt.Sprintf("The operator won't handle the Pooler %q integration with the Cluster %q (%q). "+
 			"Manually configure it as described in the docs.", pooler.Name, pooler.Spec.Cluster.Name, pooler.Namespace))
 	}
+	warns = append(warns, v.getMaxConnectionsAdmissionWarnings(ctx, pooler)...)
 
 	allErrs := v.validate(pooler)
 	if len(allErrs) == 0 {
@@ -252,3 +260,132 @@ func (v *PoolerCustomValidator) validatePgbouncerGenericParameters(r *apiv1.Pool
 	}
 	return result
 }
+
+// getMaxConnectionsAdmissionWarnings warns the user when the server connections
+// that the Poolers referring to a Cluster can open exceed the connections
+// available in PostgreSQL, that are `max_connections` minus the ones reserved
+// to superusers via `superuser_reserved_connections`
+func (v *PoolerCustomValidator) getMaxConnectionsAdmissionWarnings(
+	ctx context.Context,
+	r *apiv1.Pooler,
+) admission.Warnings {
+	if v.client == nil || r.Spec.PgBouncer == nil || r.Spec.Cluster.Name == "" {
+		return nil
+	}
+
+	var cluster apiv1.Cluster
+	if err := v.client.Get(
+		ctx,
+		client.ObjectKey{Namespace: r.Namespace, Name: r.Spec.Cluster.Name},
+		&cluster,
+	); err != nil {
+		// The Cluster may not have been created yet
+		if !apierrors.IsNotFound(err) {
+			poolerLog.Info("Unable to get the Cluster referred by the Pooler, skipping max_connections validation",
+				"name", r.Name, "namespace", r.Namespace, "cluster", r.Spec.Cluster.Name, "err", err.Error())
+		}
+		return nil
+	}
+
+	availableConnections, err := getAvailableServerConnections(cluster.Spec.PostgresConfiguration.Parameters)
+	if err != nil {
+		// The Cluster webhook is in charge of validating the PostgreSQL parameters
+		return nil
+	}
+
+	requestedConnections := getPoolerServerConnectionsCeiling(r)
+
+	var poolers apiv1.PoolerList
+	if err := v.client.List(ctx, &poolers, client.InNamespace(r.Namespace)); err != nil {
+		poolerLog.Info("Unable to list the Poolers, considering only the validated one",
+			"name", r.Name, "namespace", r.Namespace, "err", err.Error())
+	}
+	for idx := range poolers.Items {
+		pooler := &poolers.Items[idx]
+		if pooler.Name == r.Name || pooler.Spec.Cluster.Name != r.Spec.Cluster.Name ||
+			pooler.Spec.PgBouncer == nil {
+			continue
+		}
+		requestedConnections += getPoolerServerConnectionsCeiling(pooler)
+	}
+
+	if requestedConnections <= availableConnections {
+		return nil
+	}
+
+	return admission.Warnings{
+		fmt.Sprintf("The Poolers of the Cluster %q can open up to %d server connections for each "+
+			"database/user pair (pool size multiplied by the number of instances), exceeding the %d connections "+
+			"available in PostgreSQL (`max_connections` - `superuser_reserved_connections`). "+
+			"Clients could be refused once the pools are saturated",
+			r.Spec.Cluster.Name, requestedConnections, availableConnections),
+	}
+}
+
+// getAvailableServerConnections returns the number of connections that
+// can be opened to PostgreSQL by non-superuser roles
+func getAvailableServerConnections(parameters map[string]string) (int, error) {
+	const (
+		maxConnectionsDefault               = 100
+		superuserReservedConnectionsDefault = 3
+	)
+
+	maxConnections, err := getIntegerParameter(parameters, "max_connections", maxConnectionsDefault)
+	if err != nil {
+		return 0, err
+	}
+
+	superuserReservedConnections, err := getIntegerParameter(
+		parameters, "superuser_reserved_connections", superuserReservedConnectionsDefault)
+	if err != nil {
+		return 0, err
+	}
+
+	return maxConnections - superuserReservedConnections, nil
+}
+
+// getPoolerServerConnectionsCeiling returns the maximum number of server
+// connections that the Pooler can open for a single database/user pair,
+// considering all its instances
+func getPoolerServerConnectionsCeiling(r *apiv1.Pooler) int {
+	const (
+		defaultPoolSizeDefault = 20
+		reservePoolSizeDefault = 0
+	)
+
+	parameters := r.Spec.PgBouncer.Parameters
+
+	// Invalid values are ignored, as PgBouncer won't start with them
+	defaultPoolSize, _ := getIntegerParameter(parameters, "default_pool_size", defaultPoolSizeDefault)
+	reservePoolSize, _ := getIntegerParameter(parameters, "reserve_pool_size", reservePoolSizeDefault)
+	ceiling := defaultPoolSize + reservePoolSize
+
+	for _, limitParameter := range []string{"max_db_connections", "max_user_connections"} {
+		if limit, _ := getIntegerParameter(parameters, limitParameter, 0); limit > 0 && limit < ceiling {
+			ceiling = limit
+		}
+	}
+
+	instances := 1
+	if r.Spec.Instances != nil {
+		instances = int(*r.Spec.Instances)
+	}
+
+	return ceiling * instances
+}
+
+// getIntegerParameter parses an integer configuration parameter, returning
+// the passed default value when it is not set
+func getIntegerParameter(parameters map[string]string, name string, defaultValue int) (int, error) {
+	value, ok := parameters[name]
+	if !ok || value == "" {
+		return defaultValue, nil
+	}
+
+	result, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue, fmt.Errorf("invalid value for parameter %s: %w", name, err)
+	}
+
+	return result, nil
+}
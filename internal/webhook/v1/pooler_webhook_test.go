@@ -18,8 +18,11 @@ package v1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -136,3 +139,113 @@ var _ = Describe("Pooler validation", func() {
 		Expect(v.validatePgbouncerGenericParameters(pooler)).To(BeEmpty())
 	})
 })
+
+var _ = Describe("Pooler max_connections validation", func() {
+	var cluster *apiv1.Cluster
+
+	newPooler := func(name string, instances int32, parameters map[string]string) *apiv1.Pooler {
+		return &apiv1.Pooler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: apiv1.PoolerSpec{
+				Cluster:   apiv1.LocalObjectReference{Name: "cluster-example"},
+				Instances: ptr.To(instances),
+				PgBouncer: &apiv1.PgBouncerSpec{
+					PoolMode:   apiv1.PgBouncerPoolModeTransaction,
+					Parameters: parameters,
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-example",
+				Namespace: "default",
+			},
+			Spec: apiv1.ClusterSpec{
+				Instances: 3,
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					Parameters: map[string]string{
+						"max_connections":                "100",
+						"superuser_reserved_connections": "10",
+					},
+				},
+			},
+		}
+	})
+
+	It("computes the server connections ceiling of a Pooler", func() {
+		Expect(getPoolerServerConnectionsCeiling(newPooler("pooler", 1, nil))).To(Equal(20))
+		Expect(getPoolerServerConnectionsCeiling(newPooler("pooler", 3, map[string]string{
+			"default_pool_size": "30",
+			"reserve_pool_size": "5",
+		}))).To(Equal(105))
+		Expect(getPoolerServerConnectionsCeiling(newPooler("pooler", 2, map[string]string{
+			"default_pool_size":  "30",
+			"max_db_connections": "10",
+		}))).To(Equal(20))
+	})
+
+	It("computes the available server connections", func() {
+		Expect(getAvailableServerConnections(nil)).To(Equal(97))
+		Expect(getAvailableServerConnections(cluster.Spec.PostgresConfiguration.Parameters)).To(Equal(90))
+		_, err := getAvailableServerConnections(map[string]string{"max_connections": "many"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("doesn't warn when the Cluster can accept the pooler connections", func(ctx SpecContext) {
+		pooler := newPooler("pooler", 2, map[string]string{"default_pool_size": "40"})
+		v := &PoolerCustomValidator{
+			client: fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).
+				WithObjects(cluster).Build(),
+		}
+		Expect(v.getMaxConnectionsAdmissionWarnings(ctx, pooler)).To(BeEmpty())
+	})
+
+	It("warns when the pooler connections exceed the available ones", func(ctx SpecContext) {
+		pooler := newPooler("pooler", 3, map[string]string{"default_pool_size": "40"})
+		v := &PoolerCustomValidator{
+			client: fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).
+				WithObjects(cluster).Build(),
+		}
+		warnings := v.getMaxConnectionsAdmissionWarnings(ctx, pooler)
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0]).To(ContainSubstring("120"))
+		Expect(warnings[0]).To(ContainSubstring("90"))
+	})
+
+	It("considers the other Poolers of the same Cluster", func(ctx SpecContext) {
+		otherPooler := newPooler("pooler-ro", 2, map[string]string{"default_pool_size": "40"})
+		unrelatedPooler := newPooler("unrelated", 10, nil)
+		unrelatedPooler.Spec.Cluster.Name = "another-cluster"
+		pooler := newPooler("pooler", 1, map[string]string{"default_pool_size": "40"})
+		v := &PoolerCustomValidator{
+			client: fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).
+				WithObjects(cluster, otherPooler, unrelatedPooler).Build(),
+		}
+		Expect(v.getMaxConnectionsAdmissionWarnings(ctx, pooler)).To(HaveLen(1))
+	})
+
+	It("doesn't warn when the Cluster doesn't exist", func(ctx SpecContext) {
+		pooler := newPooler("pooler", 10, nil)
+		v := &PoolerCustomValidator{
+			client: fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).Build(),
+		}
+		Expect(v.getMaxConnectionsAdmissionWarnings(ctx, pooler)).To(BeEmpty())
+	})
+
+	It("reports the warnings upon Pooler creation", func(ctx SpecContext) {
+		pooler := newPooler("pooler", 5, nil)
+		v := &PoolerCustomValidator{
+			client: fake.NewClientBuilder().WithScheme(scheme.BuildWithAllKnownScheme()).
+				WithObjects(cluster).Build(),
+		}
+		warnings, err := v.ValidateCreate(ctx, pooler)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+	})
+})
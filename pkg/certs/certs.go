@@ -267,6 +267,17 @@ func (pair *KeyPair) RenewCertificate(
 	caPrivateKey *ecdsa.PrivateKey,
 	parentCertificate *x509.Certificate,
 	altDNSNames []string,
+) error {
+	return pair.renewCertificateAt(time.Now(), caPrivateKey, parentCertificate, altDNSNames)
+}
+
+// renewCertificateAt is like RenewCertificate, but the validity of the
+// new certificate starts at the passed time
+func (pair *KeyPair) renewCertificateAt(
+	now time.Time,
+	caPrivateKey *ecdsa.PrivateKey,
+	parentCertificate *x509.Certificate,
+	altDNSNames []string,
 ) error {
 	oldCertificate, err := pair.ParseCertificate()
 	if err != nil {
@@ -274,7 +285,7 @@ func (pair *KeyPair) RenewCertificate(
 	}
 
 	certificateDuration := getCertificateDuration()
-	notBefore := time.Now().Add(time.Minute * -5)
+	notBefore := now.Add(time.Minute * -5)
 	notAfter := notBefore.Add(certificateDuration)
 
 	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
@@ -330,6 +341,23 @@ func (pair *KeyPair) IsExpiring() (bool, *time.Time, error) {
 	return false, &cert.NotAfter, nil
 }
 
+// IsInRenewalWindow checks if, at the passed time, the remaining validity
+// of the certificate is less than the passed percentage of its lifetime
+func (pair *KeyPair) IsInRenewalWindow(now time.Time, thresholdPercentage int) (bool, error) {
+	cert, err := pair.ParseCertificate()
+	if err != nil {
+		return true, err
+	}
+
+	if now.Before(cert.NotBefore) {
+		return true, nil
+	}
+
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	remaining := cert.NotAfter.Sub(now)
+	return remaining*100 < lifetime*time.Duration(thresholdPercentage), nil
+}
+
 // DoAltDNSNamesMatch checks if the certificate has all of the specified altDNSNames
 func (pair *KeyPair) DoAltDNSNamesMatch(altDNSNames []string) (bool, error) {
 	cert, err := pair.ParseCertificate()
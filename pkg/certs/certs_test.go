@@ -22,6 +22,8 @@ import (
 	"encoding/pem"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
+
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -381,3 +383,64 @@ var _ = Describe("Certicate duration and expiration threshold", func() {
 		Expect(threshold).To(BeEquivalentTo(tenDays))
 	})
 })
+
+var _ = Describe("Client certificate renewal window", func() {
+	var (
+		caSecret   *v1.Secret
+		secret     *v1.Secret
+		notBefore  time.Time
+		lifetime   time.Duration
+		renewalPct = 33
+	)
+
+	BeforeEach(func() {
+		ca, err := CreateRootCA("test", "namespace")
+		Expect(err).ToNot(HaveOccurred())
+		pair, err := ca.CreateAndSignPair("streaming_replica", CertTypeClient, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		caSecret = ca.GenerateCASecret("namespace", "ca")
+		secret = pair.GenerateCertificateSecret("namespace", "replication")
+
+		cert, err := pair.ParseCertificate()
+		Expect(err).ToNot(HaveOccurred())
+		notBefore = cert.NotBefore
+		lifetime = cert.NotAfter.Sub(cert.NotBefore)
+	})
+
+	It("detects when a certificate enters the renewal window", func() {
+		pair, err := ParseServerSecret(secret)
+		Expect(err).ToNot(HaveOccurred())
+
+		inWindow, err := pair.IsInRenewalWindow(notBefore.Add(lifetime/2), renewalPct)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inWindow).To(BeFalse())
+
+		inWindow, err = pair.IsInRenewalWindow(notBefore.Add(lifetime*3/4), renewalPct)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inWindow).To(BeTrue())
+	})
+
+	It("renews the certificate only once in the renewal window", func() {
+		now := notBefore.Add(lifetime * 3 / 4)
+
+		renewed, err := RenewClientLeafCertificate(caSecret, secret, notBefore.Add(lifetime/2), renewalPct)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(renewed).To(BeFalse())
+
+		renewed, err = RenewClientLeafCertificate(caSecret, secret, now, renewalPct)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(renewed).To(BeTrue())
+
+		pair, err := ParseServerSecret(secret)
+		Expect(err).ToNot(HaveOccurred())
+		cert, err := pair.ParseCertificate()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cert.NotAfter).To(BeTemporally("~", now.Add(lifetime), 10*time.Minute))
+		Expect(cert.Subject.CommonName).To(Equal("streaming_replica"))
+
+		renewed, err = RenewClientLeafCertificate(caSecret, secret, now, renewalPct)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(renewed).To(BeFalse())
+	})
+})
@@ -106,30 +106,77 @@ func RenewLeafCertificate(caSecret *v1.Secret, secret *v1.Secret, altDNSNames []
 		return false, nil
 	}
 
+	if err := renewLeafCertificateSecret(time.Now(), caSecret, secret, pair, altDNSNames); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RenewClientLeafCertificate renews a secret containing a client
+// certificate, given the secret containing the CA that will sign it,
+// when less than the passed percentage of its lifetime is left at the
+// passed time. Returns true if the certificate has been renewed
+func RenewClientLeafCertificate(
+	caSecret *v1.Secret,
+	secret *v1.Secret,
+	now time.Time,
+	thresholdPercentage int,
+) (bool, error) {
+	pair, err := ParseServerSecret(secret)
+	if err != nil {
+		return false, err
+	}
+
+	inRenewalWindow, err := pair.IsInRenewalWindow(now, thresholdPercentage)
+	if err != nil {
+		return false, err
+	}
+
+	if !inRenewalWindow {
+		return false, nil
+	}
+
+	if err := renewLeafCertificateSecret(now, caSecret, secret, pair, nil); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// renewLeafCertificateSecret signs a new certificate for the key pair
+// with the CA, storing it in the secret
+func renewLeafCertificateSecret(
+	now time.Time,
+	caSecret *v1.Secret,
+	secret *v1.Secret,
+	pair *KeyPair,
+	altDNSNames []string,
+) error {
 	// Parse the CA secret to get the private key
 	caPair, err := ParseCASecret(caSecret)
 	if err != nil {
-		return false, err
+		return err
 	}
 
 	caPrivateKey, err := caPair.ParseECPrivateKey()
 	if err != nil {
-		return false, err
+		return err
 	}
 
 	caCertificate, err := caPair.ParseCertificate()
 	if err != nil {
-		return false, err
+		return err
 	}
 
-	err = pair.RenewCertificate(caPrivateKey, caCertificate, altDNSNames)
+	err = pair.renewCertificateAt(now, caPrivateKey, caCertificate, altDNSNames)
 	if err != nil {
-		return false, err
+		return err
 	}
 
 	secret.Data["tls.crt"] = pair.Certificate
 
-	return true, nil
+	return nil
 }
 
 // Setup ensures that we have the required PKI infrastructure to make the operator and the clusters working
@@ -26,14 +26,40 @@ import (
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/configfile"
 )
 
+// ConnectionOption customizes the connection parameters used to
+// connect to an external server, after the connection parameters
+// secret has been merged
+type ConnectionOption func(connectionParameters map[string]string)
+
+// WithDatabaseName makes the connection target the passed database
+func WithDatabaseName(databaseName string) ConnectionOption {
+	return func(connectionParameters map[string]string) {
+		connectionParameters["dbname"] = databaseName
+	}
+}
+
+// WithoutDatabaseName removes the database name from the connection
+// parameters, letting the caller choose the database to connect to
+func WithoutDatabaseName() ConnectionOption {
+	return func(connectionParameters map[string]string) {
+		delete(connectionParameters, "dbname")
+	}
+}
+
 // GetServerConnectionString gets the connection string to be
 // used to connect to this external server, without dumping
 // the required cryptographic material
 func GetServerConnectionString(
+	ctx context.Context,
+	client ctrl.Client,
+	namespace string,
 	server *apiv1.ExternalCluster,
 	databaseName string,
-) string {
-	connectionParameters := maps.Clone(server.ConnectionParameters)
+) (string, error) {
+	connectionParameters, err := getConnectionParameters(ctx, client, namespace, server)
+	if err != nil {
+		return "", err
+	}
 
 	if server.SSLCert != nil {
 		name := getSecretKeyRefFileName(server.Name, server.SSLCert)
@@ -56,23 +82,32 @@ func GetServerConnectionString(
 	}
 
 	if databaseName != "" {
-		connectionParameters["dbname"] = databaseName
+		WithDatabaseName(databaseName)(connectionParameters)
 	}
 
-	return configfile.CreateConnectionString(connectionParameters)
+	return configfile.CreateConnectionString(connectionParameters), nil
 }
 
 // ConfigureConnectionToServer creates a connection string to the external
 // server, using the configuration inside the cluster and dumping the secret when
-// needed in a custom passfile.
+// needed in a custom passfile. The passed options are applied to the
+// connection parameters after the connection parameters secret is merged.
 // Returns a connection string or any error encountered
 func ConfigureConnectionToServer(
 	ctx context.Context,
 	client ctrl.Client,
 	namespace string,
 	server *apiv1.ExternalCluster,
+	options ...ConnectionOption,
 ) (string, error) {
-	connectionParameters := maps.Clone(server.ConnectionParameters)
+	connectionParameters, err := getConnectionParameters(ctx, client, namespace, server)
+	if err != nil {
+		return "", err
+	}
+
+	for _, option := range options {
+		option(connectionParameters)
+	}
 
 	if server.SSLCert != nil {
 		name, err := dumpSecretKeyRefToFile(ctx, client, namespace, server.Name, server.SSLCert)
@@ -117,3 +152,26 @@ func ConfigureConnectionToServer(
 
 	return configfile.CreateConnectionString(connectionParameters), nil
 }
+
+// getConnectionParameters merges the connection parameters contained in the
+// secret referenced by the external cluster, if any, with the ones set in the
+// external cluster definition, which take precedence
+func getConnectionParameters(
+	ctx context.Context,
+	client ctrl.Client,
+	namespace string,
+	server *apiv1.ExternalCluster,
+) (map[string]string, error) {
+	if server.ConnectionParametersSecret == nil {
+		return maps.Clone(server.ConnectionParameters), nil
+	}
+
+	connectionParameters, err := readConnectionParametersSecret(
+		ctx, client, namespace, server.ConnectionParametersSecret.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	maps.Copy(connectionParameters, server.ConnectionParameters)
+	return connectionParameters, nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("connection parameters secret", func() {
+	const namespace = "default"
+
+	newClient := func(data map[string][]byte) ctrl.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "conn", Namespace: namespace},
+				Data:       data,
+			}).
+			Build()
+	}
+
+	It("uses the inline connection parameters when no secret is referenced", func(ctx SpecContext) {
+		server := &apiv1.ExternalCluster{
+			Name:                 "origin",
+			ConnectionParameters: map[string]string{"host": "origin-rw"},
+		}
+
+		parameters, err := getConnectionParameters(ctx, newClient(nil), namespace, server)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parameters).To(Equal(map[string]string{"host": "origin-rw"}))
+	})
+
+	It("merges the secret parameters, giving precedence to the inline ones", func(ctx SpecContext) {
+		cli := newClient(map[string][]byte{
+			"host":    []byte("central-host"),
+			"user":    []byte("streaming_replica"),
+			"sslmode": []byte("verify-full"),
+		})
+		server := &apiv1.ExternalCluster{
+			Name:                       "origin",
+			ConnectionParameters:       map[string]string{"host": "origin-rw", "dbname": "postgres"},
+			ConnectionParametersSecret: &apiv1.LocalObjectReference{Name: "conn"},
+		}
+
+		parameters, err := getConnectionParameters(ctx, cli, namespace, server)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parameters).To(Equal(map[string]string{
+			"host":    "origin-rw",
+			"user":    "streaming_replica",
+			"sslmode": "verify-full",
+			"dbname":  "postgres",
+		}))
+		Expect(server.ConnectionParameters).To(HaveLen(2))
+	})
+
+	It("rejects the secrets containing parameters managed by the operator", func(ctx SpecContext) {
+		cli := newClient(map[string][]byte{
+			"host":     []byte("central-host"),
+			"passfile": []byte("/tmp/pgpass"),
+		})
+		server := &apiv1.ExternalCluster{
+			Name:                       "origin",
+			ConnectionParametersSecret: &apiv1.LocalObjectReference{Name: "conn"},
+		}
+
+		_, err := getConnectionParameters(ctx, cli, namespace, server)
+		Expect(err).To(MatchError(ContainSubstring("passfile")))
+	})
+
+	It("fails when the secret doesn't exist", func(ctx SpecContext) {
+		server := &apiv1.ExternalCluster{
+			Name:                       "origin",
+			ConnectionParametersSecret: &apiv1.LocalObjectReference{Name: "missing"},
+		}
+
+		_, err := getConnectionParameters(ctx, newClient(nil), namespace, server)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("server connection string", func() {
+	const namespace = "default"
+
+	var cli ctrl.Client
+
+	BeforeEach(func() {
+		cli = fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "conn", Namespace: namespace},
+				Data: map[string][]byte{
+					"sslmode": []byte("verify-full"),
+					"dbname":  []byte("postgres"),
+				},
+			}).
+			Build()
+	})
+
+	It("includes the parameters of the connection parameters secret", func(ctx SpecContext) {
+		server := &apiv1.ExternalCluster{
+			Name:                       "origin",
+			ConnectionParameters:       map[string]string{"host": "origin-rw"},
+			ConnectionParametersSecret: &apiv1.LocalObjectReference{Name: "conn"},
+		}
+
+		connectionString, err := GetServerConnectionString(ctx, cli, namespace, server, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(connectionString).To(ContainSubstring("sslmode='verify-full'"))
+		Expect(connectionString).To(ContainSubstring("dbname='postgres'"))
+		Expect(connectionString).To(ContainSubstring("host='origin-rw'"))
+	})
+
+	It("overrides the database name coming from the secret", func(ctx SpecContext) {
+		server := &apiv1.ExternalCluster{
+			Name:                       "origin",
+			ConnectionParameters:       map[string]string{"host": "origin-rw"},
+			ConnectionParametersSecret: &apiv1.LocalObjectReference{Name: "conn"},
+		}
+
+		connectionString, err := GetServerConnectionString(ctx, cli, namespace, server, "app")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(connectionString).To(ContainSubstring("dbname='app'"))
+		Expect(connectionString).ToNot(ContainSubstring("dbname='postgres'"))
+	})
+
+	It("removes the database name coming from the secret when requested", func(ctx SpecContext) {
+		customExternalSecretsPath = GinkgoT().TempDir()
+		DeferCleanup(func() {
+			customExternalSecretsPath = ""
+		})
+		server := &apiv1.ExternalCluster{
+			Name:                       "origin",
+			ConnectionParameters:       map[string]string{"host": "origin-rw"},
+			ConnectionParametersSecret: &apiv1.LocalObjectReference{Name: "conn"},
+		}
+
+		connectionString, err := ConfigureConnectionToServer(ctx, cli, namespace, server, WithoutDatabaseName())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(connectionString).To(ContainSubstring("sslmode='verify-full'"))
+		Expect(connectionString).ToNot(ContainSubstring("dbname"))
+	})
+})
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
@@ -68,6 +69,35 @@ func readSecretKeyRef(
 	return string(value), err
 }
 
+// operatorManagedConnectionParameters are the connection parameters set by
+// the operator, which cannot be specified in a connection parameters secret
+var operatorManagedConnectionParameters = []string{"sslcert", "sslkey", "sslrootcert", "passfile"}
+
+// readConnectionParametersSecret reads the connection parameters contained
+// in a secret, where each key is a libpq keyword
+func readConnectionParametersSecret(
+	ctx context.Context, client ctrl.Client,
+	namespace string, secretName string,
+) (map[string]string, error) {
+	var secret corev1.Secret
+	err := client.Get(ctx, ctrl.ObjectKey{Namespace: namespace, Name: secretName}, &secret)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(secret.Data))
+	for key, value := range secret.Data {
+		if slices.Contains(operatorManagedConnectionParameters, key) {
+			return nil, fmt.Errorf(
+				"the connection parameters secret %v cannot contain the %v key, as it is managed by the operator",
+				secretName, key)
+		}
+		result[key] = string(value)
+	}
+
+	return result, nil
+}
+
 // getSecretKeyRefFileName get the name of the file where the content of the
 // connection secret will be dumped
 func getSecretKeyRefFileName(
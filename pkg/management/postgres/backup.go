@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"regexp"
 	"slices"
 	"time"
 
@@ -34,6 +35,7 @@ import (
 	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -67,6 +69,29 @@ type BackupCommand struct {
 	Instance     *Instance
 	Capabilities *barmanCapabilities.Capabilities
 	barmanBackup *barmanBackup.Command
+
+	// ServerName, when not empty, overrides the server name configured
+	// in the object store, storing the backup under an alternate path
+	ServerName string
+}
+
+// serverNameRegex matches the server names that can be safely used
+// as a component of the path of a backup in the object store
+var serverNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateBackupServerName checks that the passed name can be used to
+// override the server name of a backup
+func ValidateBackupServerName(name string) error {
+	if name == "" {
+		return fmt.Errorf("the server name cannot be empty")
+	}
+
+	if !serverNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid server name %q: it must start with a letter or a digit, "+
+			"and contain only letters, digits, '.', '_' and '-'", name)
+	}
+
+	return nil
 }
 
 // NewBarmanBackupCommand initializes a BackupCommand object, taking a physical
@@ -204,6 +229,15 @@ func (b *BackupCommand) run(ctx context.Context) {
 		}
 	}
 
+	// The retention policy and the recoverability point refer to the
+	// server name configured in the cluster, and must not be evaluated
+	// against a backup stored under an alternate path
+	if !useSameBackupLocation(b.Backup.GetStatus(), b.Cluster) {
+		b.Log.Info("Skipping backup maintenance for a backup stored outside the configured server name",
+			"serverName", b.Backup.GetStatus().ServerName)
+		return
+	}
+
 	b.backupMaintenance(ctx)
 }
 
@@ -254,6 +288,14 @@ func (b *BackupCommand) takeBackup(ctx context.Context) error {
 	b.Log.Debug("extracted barman backup", "backup", barmanBackup)
 	assignBarmanBackupToBackup(b.Backup, barmanBackup)
 
+	// The size is only informative, we don't want to fail
+	// a good backup because we cannot detect it
+	if backupSize, err := b.getDatabasesSize(ctx); err != nil {
+		b.Log.Error(err, "Can't detect the size of the backup")
+	} else {
+		b.Backup.Status.BackupSize = backupSize
+	}
+
 	if err := PatchBackupStatusAndRetry(ctx, b.Client, b.Backup); err != nil {
 		b.Log.Error(err, "Can't set backup status as completed")
 	}
@@ -269,8 +311,22 @@ func (b *BackupCommand) takeBackup(ctx context.Context) error {
 }
 
 func (b *BackupCommand) backupMaintenance(ctx context.Context) {
+	var retentionRun *apiv1.RetentionRunStatus
+	var backupIDsBeforeRetention []string
+
 	// Delete backups per policy
 	if b.Cluster.Spec.Backup.RetentionPolicy != "" {
+		// The catalog before enforcing the policy is needed
+		// to count the deleted backups
+		if dataBeforeRetention, err := b.getBackupData(ctx); err == nil {
+			backupIDsBeforeRetention = dataBeforeRetention.GetBackupIDs()
+		}
+
+		retentionRun = &apiv1.RetentionRunStatus{
+			Time:            pgTime.GetCurrentTimestampWithFormat(time.RFC3339),
+			RetentionPolicy: b.Cluster.Spec.Backup.RetentionPolicy,
+		}
+
 		// TODO: refactor retention policy and move it in the Barman library
 		b.Log.Info("Applying backup retention policy",
 			"retentionPolicy", b.Cluster.Spec.Backup.RetentionPolicy)
@@ -283,6 +339,7 @@ func (b *BackupCommand) backupMaintenance(ctx context.Context) {
 		); err != nil {
 			// Proper logging already happened inside DeleteBackupsByPolicy
 			b.Recorder.Event(b.Cluster, "Warning", "RetentionPolicyFailed", "Retention policy failed")
+			retentionRun.Error = err.Error()
 			// We do not want to return here, we must go on to set the fist recoverability point
 		}
 	}
@@ -293,6 +350,13 @@ func (b *BackupCommand) backupMaintenance(ctx context.Context) {
 		return
 	}
 
+	if retentionRun != nil {
+		retentionRun.DeletedBackups = countDeletedBackups(backupIDsBeforeRetention, data.GetBackupIDs())
+		b.Log.Info("Backup retention policy enforced",
+			"retentionPolicy", retentionRun.RetentionPolicy,
+			"deletedBackups", retentionRun.DeletedBackups)
+	}
+
 	if err := deleteBackupsNotInCatalog(ctx, b.Client, b.Cluster, data.GetBackupIDs()); err != nil {
 		b.Log.Error(err, "while deleting Backups not present in the catalog")
 	}
@@ -306,6 +370,9 @@ func (b *BackupCommand) backupMaintenance(ctx context.Context) {
 			data.GetFirstRecoverabilityPoint(),
 			data.GetLastSuccessfulBackupTime(),
 		)
+		if retentionRun != nil {
+			b.Cluster.Status.LastRetentionRun = retentionRun
+		}
 
 		if equality.Semantic.DeepEqual(origCluster.Status, b.Cluster.Status) {
 			return nil
@@ -316,6 +383,18 @@ func (b *BackupCommand) backupMaintenance(ctx context.Context) {
 	}
 }
 
+// countDeletedBackups counts the backups of the first catalog which are
+// not in the second one
+func countDeletedBackups(backupIDsBefore, backupIDsAfter []string) int {
+	deleted := 0
+	for _, backupID := range backupIDsBefore {
+		if !slices.Contains(backupIDsAfter, backupID) {
+			deleted++
+		}
+	}
+	return deleted
+}
+
 // PatchBackupStatusAndRetry updates a certain backup's status in the k8s database,
 // retries when error occurs
 // TODO: this method does not belong here, it should be moved to api/v1/backup_types.go
@@ -361,6 +440,10 @@ func (b *BackupCommand) setupBackupStatus() {
 	if backupStatus.ServerName == "" {
 		backupStatus.ServerName = b.Cluster.Name
 	}
+	// The server name requested for this backup takes precedence
+	if b.ServerName != "" {
+		backupStatus.ServerName = b.ServerName
+	}
 	backupStatus.Phase = apiv1.BackupPhaseRunning
 }
 
@@ -375,6 +458,24 @@ func assignBarmanBackupToBackup(backup *apiv1.Backup, barmanBackup *barmanCatalo
 	backupStatus.EndWal = barmanBackup.EndWal
 	backupStatus.BeginLSN = barmanBackup.BeginLSN
 	backupStatus.EndLSN = barmanBackup.EndLSN
+	backupStatus.SetDuration()
+}
+
+// getDatabasesSize gets the total size of the databases of the instance,
+// which is the amount of data contained in the backup before compression
+func (b *BackupCommand) getDatabasesSize(ctx context.Context) (*resource.Quantity, error) {
+	db, err := b.Instance.GetSuperUserDB()
+	if err != nil {
+		return nil, err
+	}
+
+	var size int64
+	row := db.QueryRowContext(ctx, "SELECT COALESCE(sum(pg_catalog.pg_database_size(oid)), 0) FROM pg_catalog.pg_database")
+	if err := row.Scan(&size); err != nil {
+		return nil, err
+	}
+
+	return resource.NewQuantity(size, resource.BinarySI), nil
 }
 
 // deleteBackupsNotInCatalog deletes all Backup objects pointing to the given cluster that are not
@@ -215,3 +215,69 @@ var _ = Describe("generate backup options", func() {
 				))
 	})
 })
+
+var _ = Describe("backup server name", func() {
+	cluster := &apiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "test"},
+		Spec: apiv1.ClusterSpec{
+			Backup: &apiv1.BackupConfiguration{
+				BarmanObjectStore: &apiv1.BarmanObjectStoreConfiguration{
+					DestinationPath: "s3://bucket/",
+				},
+			},
+		},
+	}
+
+	DescribeTable("validates the server name",
+		func(serverName string, valid bool) {
+			err := ValidateBackupServerName(serverName)
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("a plain name", "cluster-example", true),
+		Entry("a name with dots and underscores", "cluster_example.v2", true),
+		Entry("an empty name", "", false),
+		Entry("a parent directory", "..", false),
+		Entry("a nested path", "cluster/example", false),
+		Entry("a name with spaces", "cluster example", false),
+	)
+
+	It("uses the configured server name by default", func() {
+		backupCommand := BackupCommand{
+			Cluster:      cluster,
+			Backup:       &apiv1.Backup{},
+			Capabilities: &barmanCapabilities.Capabilities{},
+		}
+		backupCommand.setupBackupStatus()
+		Expect(backupCommand.Backup.Status.ServerName).To(Equal("test-cluster"))
+		Expect(useSameBackupLocation(&backupCommand.Backup.Status, cluster)).To(BeTrue())
+	})
+
+	It("uses the requested server name when set", func() {
+		backupCommand := BackupCommand{
+			Cluster:      cluster,
+			Backup:       &apiv1.Backup{},
+			Capabilities: &barmanCapabilities.Capabilities{},
+			ServerName:   "alternate",
+		}
+		backupCommand.setupBackupStatus()
+		Expect(backupCommand.Backup.Status.ServerName).To(Equal("alternate"))
+		Expect(useSameBackupLocation(&backupCommand.Backup.Status, cluster)).To(BeFalse())
+	})
+})
+
+var _ = Describe("countDeletedBackups", func() {
+	It("counts the backups removed from the catalog", func() {
+		Expect(countDeletedBackups(
+			[]string{"20240101T000000", "20240102T000000", "20240103T000000"},
+			[]string{"20240103T000000", "20240104T000000"},
+		)).To(Equal(2))
+	})
+
+	It("returns zero when the catalog before the retention is unknown", func() {
+		Expect(countDeletedBackups(nil, []string{"20240103T000000"})).To(BeZero())
+	})
+})
@@ -18,10 +18,12 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -88,9 +90,33 @@ func (instance *Instance) RefreshConfigurationFilesFromCluster(
 		instance.ConfigSha256 = sha256
 	}
 
+	if postgresConfigurationChanged {
+		_, addedLibraries := postgres.MergeSharedPreloadLibraries(
+			cluster.Spec.PostgresConfiguration.AdditionalLibraries,
+			postgres.GetRequiredSharedPreloadLibraries(cluster.Spec.PostgresConfiguration.Parameters))
+		if len(addedLibraries) > 0 {
+			log.FromContext(ctx).Info("Adding the shared preload libraries required by the managed extensions",
+				"userLibraries", cluster.Spec.PostgresConfiguration.AdditionalLibraries,
+				"addedLibraries", addedLibraries)
+		}
+	}
+
 	return postgresConfigurationChanged, nil
 }
 
+// GetCustomConfiguration reads the PostgreSQL configuration generated by
+// the operator, returning an empty string if it has not been written yet
+func (instance *Instance) GetCustomConfiguration() (string, error) {
+	content, err := os.ReadFile(path.Join(instance.PgData, constants.PostgresqlCustomConfigurationFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
 // GeneratePostgresqlHBA generates the pg_hba.conf content with the LDAP configuration if configured.
 func (instance *Instance) GeneratePostgresqlHBA(cluster *apiv1.Cluster, ldapBindPassword string) (string, error) {
 	version, err := cluster.GetPostgresqlVersion()
@@ -110,8 +136,15 @@ func (instance *Instance) GeneratePostgresqlHBA(cluster *apiv1.Cluster, ldapBind
 		defaultAuthenticationMethod = "md5"
 	}
 
+	// The typed rules follow the ones written by the user
+	// as plain pg_hba.conf lines
+	userRules := make([]string, 0,
+		len(cluster.Spec.PostgresConfiguration.PgHBA)+len(cluster.Spec.PostgresConfiguration.HBARules))
+	userRules = append(userRules, cluster.Spec.PostgresConfiguration.PgHBA...)
+	userRules = append(userRules, buildHBARules(cluster.Spec.PostgresConfiguration.HBARules)...)
+
 	return postgres.CreateHBARules(
-		cluster.Spec.PostgresConfiguration.PgHBA,
+		userRules,
 		defaultAuthenticationMethod,
 		buildLDAPConfigString(cluster, ldapBindPassword))
 }
@@ -207,6 +240,60 @@ func quoteHbaLiteral(literal string) string {
 	return fmt.Sprintf(`"%s"`, literal)
 }
 
+// buildHBARules renders the typed HBA rules as pg_hba.conf lines
+func buildHBARules(rules []apiv1.HBARule) []string {
+	result := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		databases := "all"
+		if len(rule.Databases) > 0 {
+			databases = strings.Join(rule.Databases, ",")
+		}
+
+		roles := make([]string, 0, len(rule.Users)+len(rule.Groups))
+		roles = append(roles, rule.Users...)
+		for _, group := range rule.Groups {
+			roles = append(roles, "+"+group)
+		}
+		users := "all"
+		if len(roles) > 0 {
+			users = strings.Join(roles, ",")
+		}
+
+		fields := []string{string(rule.Type), databases, users}
+		if rule.Type != apiv1.HBAConnectionTypeLocal {
+			fields = append(fields, rule.Address)
+		}
+		fields = append(fields, rule.Method)
+
+		optionNames := make([]string, 0, len(rule.Options))
+		for name := range rule.Options {
+			optionNames = append(optionNames, name)
+		}
+		sort.Strings(optionNames)
+		for _, name := range optionNames {
+			fields = append(fields, fmt.Sprintf("%s=%s", name, quoteHbaLiteral(rule.Options[name])))
+		}
+
+		result = append(result, strings.Join(fields, " "))
+	}
+
+	return result
+}
+
+// GetIdentLines returns the user-defined pg_ident.conf lines of a cluster,
+// rendering the typed user name maps after the plain lines
+func GetIdentLines(cluster *apiv1.Cluster) []string {
+	result := slices.Clone(cluster.Spec.PostgresConfiguration.PgIdent)
+	for _, identMap := range cluster.Spec.PostgresConfiguration.IdentMaps {
+		for _, mapping := range identMap.Mappings {
+			result = append(result, fmt.Sprintf("%s %s %s",
+				identMap.Name, mapping.SystemUsername, mapping.DatabaseUsername))
+		}
+	}
+
+	return result
+}
+
 // generatePostgresqlIdent generates the pg_ident.conf content given
 // a set of additional pg_ident lines that is usually taken from the
 // Cluster configuration
@@ -260,17 +347,7 @@ func UpdateReplicaConfiguration(pgData, primaryConnInfo, slotName string) (chang
 // Returns a boolean indicating if any changes were done and any errors encountered
 func configurePostgresOverrideConfFile(pgData, primaryConnInfo, slotName string) (changed bool, err error) {
 	targetFile := path.Join(pgData, constants.PostgresqlOverrideConfigurationFile)
-	options := map[string]string{
-		"restore_command": fmt.Sprintf(
-			"/controller/manager wal-restore --log-destination %s/%s.json %%f %%p",
-			postgres.LogPath, postgres.LogFileName),
-		"recovery_target_timeline": "latest",
-		"primary_conninfo":         primaryConnInfo,
-	}
-
-	if len(slotName) > 0 {
-		options["primary_slot_name"] = slotName
-	}
+	options := GetReplicaConfigurationOptions(primaryConnInfo, slotName)
 
 	// Ensure that override.conf file contains just the above options
 	changed, err = configfile.WritePostgresConfiguration(targetFile, options)
@@ -285,6 +362,26 @@ func configurePostgresOverrideConfFile(pgData, primaryConnInfo, slotName string)
 	return changed, nil
 }
 
+// GetReplicaConfigurationOptions returns the options written in the override.conf
+// file of a replica, using the specified connection string to connect to the
+// primary server. The `primary_slot_name` option is generated only when the
+// parameter slotName is not empty
+func GetReplicaConfigurationOptions(primaryConnInfo, slotName string) map[string]string {
+	options := map[string]string{
+		"restore_command": fmt.Sprintf(
+			"/controller/manager wal-restore --log-destination %s/%s.json %%f %%p",
+			postgres.LogPath, postgres.LogFileName),
+		"recovery_target_timeline": "latest",
+		"primary_conninfo":         primaryConnInfo,
+	}
+
+	if len(slotName) > 0 {
+		options["primary_slot_name"] = slotName
+	}
+
+	return options
+}
+
 // createStandbySignal creates a standby.signal file for PostgreSQL 12 and beyond
 func createStandbySignal(pgData string) error {
 	emptyFile, err := os.Create(filepath.Clean(filepath.Join(pgData, "standby.signal")))
@@ -418,7 +515,78 @@ func createPostgresqlConfiguration(
 		info.RecoveryMinApplyDelay = cluster.Spec.ReplicaCluster.MinApplyDelay.Duration
 	}
 
-	return postgres.CreatePostgresqlConfFile(postgres.CreatePostgresqlConfiguration(info))
+	postgresConfiguration, sha256 := postgres.CreatePostgresqlConfFile(postgres.CreatePostgresqlConfiguration(info))
+
+	// The parameters of the ConfigMap are included before the ones
+	// of the cluster, which take precedence over them
+	if cluster.Spec.PostgresConfiguration.IncludeConfigMap != nil {
+		postgresConfiguration = fmt.Sprintf("include_if_exists '%s'\n%s",
+			constants.PostgresqlIncludeConfigurationFile, postgresConfiguration)
+	}
+
+	return postgresConfiguration, sha256
+}
+
+// RefreshIncludedConfiguration writes the PostgreSQL configuration parameters
+// read from the ConfigMap referenced by the cluster in the file included
+// by the configuration generated by the operator, returning true if the
+// file has been changed. The fixed parameters and the include directives
+// are ignored.
+func (instance *Instance) RefreshIncludedConfiguration(ctx context.Context, content string) (bool, error) {
+	contextLogger := log.FromContext(ctx)
+
+	sanitizedContent, ignoredLines := sanitizeIncludedConfiguration(content)
+	if len(ignoredLines) > 0 {
+		contextLogger.Warning("Ignoring the fixed parameters and the include directives "+
+			"of the included configuration",
+			"lines", ignoredLines)
+	}
+
+	return InstallPgDataFileContent(
+		ctx,
+		instance.PgData,
+		sanitizedContent,
+		constants.PostgresqlIncludeConfigurationFile)
+}
+
+// sanitizeIncludedConfiguration removes the fixed parameters and the include
+// directives from a block of PostgreSQL configuration, returning the
+// sanitized content together with the ignored lines
+func sanitizeIncludedConfiguration(content string) (string, []string) {
+	if content == "" {
+		return "", nil
+	}
+
+	lines := strings.Split(content, "\n")
+	sanitizedLines := make([]string, 0, len(lines))
+	var ignoredLines []string
+	for _, line := range lines {
+		trimmedLine := strings.TrimSpace(line)
+		if trimmedLine == "" || strings.HasPrefix(trimmedLine, "#") {
+			sanitizedLines = append(sanitizedLines, line)
+			continue
+		}
+
+		fields := strings.FieldsFunc(trimmedLine, func(r rune) bool {
+			return r == '=' || r == ' ' || r == '\t'
+		})
+		if len(fields) == 0 {
+			ignoredLines = append(ignoredLines, trimmedLine)
+			continue
+		}
+
+		name := strings.ToLower(fields[0])
+		_, isFixed := postgres.FixedConfigurationParameters[name]
+		isInclude := name == "include" || name == "include_if_exists" || name == "include_dir"
+		if isFixed || isInclude {
+			ignoredLines = append(ignoredLines, trimmedLine)
+			continue
+		}
+
+		sanitizedLines = append(sanitizedLines, line)
+	}
+
+	return strings.Join(sanitizedLines, "\n"), ignoredLines
 }
 
 // configurePostgresForImport configures Postgres to be optimized for the firt import
@@ -118,6 +118,85 @@ var _ = Describe("testing the building of the ldap config string", func() {
 	})
 })
 
+var _ = Describe("testing the building of the typed HBA rules", func() {
+	It("renders every field of the rules", func() {
+		rules := []apiv1.HBARule{
+			{
+				Type:      apiv1.HBAConnectionTypeHostSSL,
+				Databases: []string{"app", "reports"},
+				Users:     []string{"app"},
+				Groups:    []string{"readers"},
+				Address:   "10.0.0.0/8",
+				Method:    "cert",
+				Options:   map[string]string{"map": "certmap", "clientcert": "verify-full"},
+			},
+			{
+				Type:   apiv1.HBAConnectionTypeLocal,
+				Method: "peer",
+			},
+		}
+		Expect(buildHBARules(rules)).To(Equal([]string{
+			`hostssl app,reports app,+readers 10.0.0.0/8 cert clientcert="verify-full" map="certmap"`,
+			"local all all peer",
+		}))
+	})
+
+	It("keeps the operator rules before the user-defined ones", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				ImageName: versions.DefaultImageName,
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					PgHBA: []string{"host all app 10.0.0.0/8 scram-sha-256"},
+					HBARules: []apiv1.HBARule{
+						{
+							Type:    apiv1.HBAConnectionTypeHost,
+							Groups:  []string{"readers"},
+							Address: "all",
+							Method:  "reject",
+						},
+					},
+				},
+			},
+		}
+		instance := NewInstance()
+		content, err := instance.GeneratePostgresqlHBA(cluster, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		fixedRule := strings.Index(content, "hostssl replication streaming_replica all cert")
+		plainRule := strings.Index(content, "host all app 10.0.0.0/8 scram-sha-256")
+		typedRule := strings.Index(content, "host all +readers all reject")
+		Expect(fixedRule).To(BeNumerically(">=", 0))
+		Expect(plainRule).To(BeNumerically(">", fixedRule))
+		Expect(typedRule).To(BeNumerically(">", plainRule))
+	})
+})
+
+var _ = Describe("testing the building of the pg_ident.conf lines", func() {
+	It("renders the typed maps after the plain lines", func() {
+		cluster := &apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					PgIdent: []string{"plainmap admin postgres"},
+					IdentMaps: []apiv1.IdentMap{
+						{
+							Name: "certmap",
+							Mappings: []apiv1.IdentMapping{
+								{SystemUsername: `/^(.*)@example\.com$`, DatabaseUsername: `\1`},
+								{SystemUsername: "admin", DatabaseUsername: "app"},
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(GetIdentLines(cluster)).To(Equal([]string{
+			"plainmap admin postgres",
+			`certmap /^(.*)@example\.com$ \1`,
+			"certmap admin app",
+		}))
+	})
+})
+
 var _ = Describe("Test building of the list of temporary tablespaces", func() {
 	defaultVersion, err := version.FromTag(reference.New(versions.DefaultImageName).Tag)
 	Expect(err).ToNot(HaveOccurred())
@@ -257,3 +336,57 @@ var _ = Describe("recovery_min_apply_delay", func() {
 		Expect(config).ToNot(ContainSubstring("recovery_min_apply_delay"))
 	})
 })
+
+var _ = Describe("configuration included from a ConfigMap", func() {
+	defaultVersion, err := version.FromTag(reference.New(versions.DefaultImageName).Tag)
+	Expect(err).ToNot(HaveOccurred())
+
+	It("is included before the parameters of the cluster", func() {
+		cluster := apiv1.Cluster{
+			Spec: apiv1.ClusterSpec{
+				PostgresConfiguration: apiv1.PostgresConfiguration{
+					IncludeConfigMap: &apiv1.ConfigMapKeySelector{
+						LocalObjectReference: apiv1.LocalObjectReference{Name: "custom-parameters"},
+						Key:                  "custom.conf",
+					},
+				},
+			},
+		}
+		config, _ := createPostgresqlConfiguration(&cluster, true, defaultVersion.Major())
+		Expect(config).To(HavePrefix("include_if_exists 'include.conf'\n"))
+	})
+
+	It("is not included when no ConfigMap is referenced", func() {
+		config, _ := createPostgresqlConfiguration(&apiv1.Cluster{}, true, defaultVersion.Major())
+		Expect(config).ToNot(ContainSubstring("include_if_exists"))
+	})
+
+	It("ignores the fixed parameters and the include directives", func() {
+		content := "# custom parameters\n" +
+			"work_mem = '8MB'\n" +
+			"\n" +
+			"archive_command='/bin/true'\n" +
+			"ssl off\n" +
+			"include_dir 'conf.d'\n" +
+			"INCLUDE 'other.conf'\n" +
+			"  log_min_duration_statement = 1000"
+
+		sanitizedContent, ignoredLines := sanitizeIncludedConfiguration(content)
+		Expect(sanitizedContent).To(Equal("# custom parameters\n" +
+			"work_mem = '8MB'\n" +
+			"\n" +
+			"  log_min_duration_statement = 1000"))
+		Expect(ignoredLines).To(Equal([]string{
+			"archive_command='/bin/true'",
+			"ssl off",
+			"include_dir 'conf.d'",
+			"INCLUDE 'other.conf'",
+		}))
+	})
+
+	It("is empty when the ConfigMap is empty", func() {
+		sanitizedContent, ignoredLines := sanitizeIncludedConfiguration("")
+		Expect(sanitizedContent).To(BeEmpty())
+		Expect(ignoredLines).To(BeEmpty())
+	})
+})
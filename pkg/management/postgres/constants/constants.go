@@ -29,6 +29,11 @@ const (
 	// contain HA and DR settings)
 	PostgresqlOverrideConfigurationFile = "override.conf"
 
+	// PostgresqlIncludeConfigurationFile is the name of the file containing
+	// the PostgreSQL configuration parameters read from the ConfigMap
+	// referenced by the cluster
+	PostgresqlIncludeConfigurationFile = "include.conf"
+
 	// PostgresqlHBARulesFile is the name of the file which contains
 	// the host-based access rules
 	PostgresqlHBARulesFile = "pg_hba.conf"
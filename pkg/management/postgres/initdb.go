@@ -530,12 +530,14 @@ func executeLogicalImport(
 	}
 	defer originPool.ShutdownConnections()
 
+	progress := logicalimport.NewProgressReporter(client, cluster)
+
 	cloneType := cluster.Spec.Bootstrap.InitDB.Import.Type
 	switch cloneType {
 	case apiv1.MicroserviceSnapshotType:
-		return logicalimport.Microservice(ctx, cluster, destinationPool, originPool)
+		return logicalimport.Microservice(ctx, cluster, destinationPool, originPool, progress)
 	case apiv1.MonolithSnapshotType:
-		return logicalimport.Monolith(ctx, cluster, destinationPool, originPool)
+		return logicalimport.Monolith(ctx, cluster, destinationPool, originPool, progress)
 	default:
 		return fmt.Errorf("unrecognized clone type %s", cloneType)
 	}
@@ -552,14 +554,12 @@ func getConnectionPoolerForExternalCluster(
 		return nil, fmt.Errorf("missing external cluster")
 	}
 
-	modifiedExternalCluster := externalCluster.DeepCopy()
-	delete(modifiedExternalCluster.ConnectionParameters, "dbname")
-
 	sourceDBConnectionString, err := external.ConfigureConnectionToServer(
 		ctx,
 		client,
 		namespaceOfNewCluster,
-		modifiedExternalCluster,
+		&externalCluster,
+		external.WithoutDatabaseName(),
 	)
 	if err != nil {
 		return nil, err
@@ -186,6 +186,23 @@ type Instance struct {
 	// it's used by the readiness probe to know whether it should be short-circuited
 	canCheckReadiness atomic.Bool
 
+	// streamingRequiredForReadiness specifies whether the readiness probe
+	// of a streaming replica requires an active WAL receiver
+	streamingRequiredForReadiness atomic.Bool
+
+	// maxStandbyLagBytes and maxStandbyLagDuration are the maximum lag of
+	// a replica over which the readiness probe fails, zero if disabled
+	maxStandbyLagBytes    atomic.Int64
+	maxStandbyLagDuration atomic.Int64
+
+	// lastParameterChanges is the classification of the parameters
+	// changed by the latest update of the configuration
+	lastParameterChanges atomic.Pointer[postgres.ParameterChanges]
+
+	// lastHBAReload is the outcome of the latest reload triggered
+	// by a change of the host-based authentication rules
+	lastHBAReload atomic.Pointer[postgres.HBAReloadStatus]
+
 	// mightBeUnavailable specifies whether we expect the instance to be down
 	mightBeUnavailable atomic.Bool
 
@@ -234,6 +251,55 @@ func (instance *Instance) CanCheckReadiness() bool {
 	return instance.canCheckReadiness.Load()
 }
 
+// IsStreamingRequiredForReadiness checks whether the readiness probe of a
+// streaming replica requires an active WAL receiver
+func (instance *Instance) IsStreamingRequiredForReadiness() bool {
+	return instance.streamingRequiredForReadiness.Load()
+}
+
+// SetStreamingRequiredForReadiness marks whether the readiness probe of a
+// streaming replica requires an active WAL receiver
+func (instance *Instance) SetStreamingRequiredForReadiness(enabled bool) {
+	instance.streamingRequiredForReadiness.Store(enabled)
+}
+
+// GetLastParameterChanges gets the classification of the parameters
+// changed by the latest update of the configuration
+func (instance *Instance) GetLastParameterChanges() *postgres.ParameterChanges {
+	return instance.lastParameterChanges.Load()
+}
+
+// SetLastParameterChanges stores the classification of the parameters
+// changed by the latest update of the configuration
+func (instance *Instance) SetLastParameterChanges(changes *postgres.ParameterChanges) {
+	instance.lastParameterChanges.Store(changes)
+}
+
+// GetLastHBAReload gets the outcome of the latest reload triggered
+// by a change of the host-based authentication rules
+func (instance *Instance) GetLastHBAReload() *postgres.HBAReloadStatus {
+	return instance.lastHBAReload.Load()
+}
+
+// SetLastHBAReload stores the outcome of the latest reload triggered
+// by a change of the host-based authentication rules
+func (instance *Instance) SetLastHBAReload(status *postgres.HBAReloadStatus) {
+	instance.lastHBAReload.Store(status)
+}
+
+// GetMaxStandbyLagForReady gets the maximum lag of a replica, in bytes
+// and as a duration, over which the readiness probe fails
+func (instance *Instance) GetMaxStandbyLagForReady() (int64, time.Duration) {
+	return instance.maxStandbyLagBytes.Load(), time.Duration(instance.maxStandbyLagDuration.Load())
+}
+
+// SetMaxStandbyLagForReady sets the maximum lag of a replica, in bytes
+// and as a duration, over which the readiness probe fails
+func (instance *Instance) SetMaxStandbyLagForReady(maxLagBytes int64, maxLagDuration time.Duration) {
+	instance.maxStandbyLagBytes.Store(maxLagBytes)
+	instance.maxStandbyLagDuration.Store(int64(maxLagDuration))
+}
+
 // MightBeUnavailable checks whether we expect the instance to be down
 func (instance *Instance) MightBeUnavailable() bool {
 	return instance.mightBeUnavailable.Load()
@@ -723,6 +789,11 @@ func (instance *Instance) GetSuperUserDB() (*sql.DB, error) {
 	return instance.ConnectionPool().Connection("postgres")
 }
 
+// GetNamedDB gets a connection to the specified database on this instance
+func (instance *Instance) GetNamedDB(name string) (*sql.DB, error) {
+	return instance.ConnectionPool().Connection(name)
+}
+
 // GetTemplateDB gets a connection to the "template1" database on this instance
 func (instance *Instance) GetTemplateDB() (*sql.DB, error) {
 	return instance.ConnectionPool().Connection("template1")
@@ -917,6 +988,78 @@ func (instance *Instance) WaitForConfigReload(ctx context.Context) (*postgres.Po
 	return status, nil
 }
 
+// hbaReloadBackoff is the backoff used to wait for PostgreSQL to load
+// the configuration files after a reload, for about 10 seconds overall
+var hbaReloadBackoff = wait.Backoff{
+	Duration: 100 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    8,
+	Cap:      5 * time.Second,
+}
+
+// WaitForHBAReload waits for PostgreSQL to load the configuration files after
+// the passed time and reports whether the host-based authentication rules
+// and the user name maps have been accepted. An error in pg_hba.conf or in
+// pg_ident.conf makes PostgreSQL keep the previous content of that file,
+// without affecting the running sessions.
+func (instance *Instance) WaitForHBAReload(
+	ctx context.Context,
+	requestTime time.Time,
+) (*postgres.HBAReloadStatus, error) {
+	err := instance.WaitForSuperuserConnectionAvailable(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("while applying new host-based authentication rules: %w", err)
+	}
+
+	superUserDB, err := instance.GetSuperUserDB()
+	if err != nil {
+		return nil, err
+	}
+
+	var loadTime time.Time
+	errorIsRetryable := func(err error) bool {
+		return err != nil
+	}
+	err = retry.OnError(hbaReloadBackoff, errorIsRetryable, func() error {
+		row := superUserDB.QueryRow("SELECT pg_catalog.pg_conf_load_time()")
+		if err := row.Scan(&loadTime); err != nil {
+			return err
+		}
+		if loadTime.Before(requestTime) {
+			return fmt.Errorf("configuration not yet reloaded: loaded at %s, requested at %s",
+				loadTime.Format(time.RFC3339), requestTime.Format(time.RFC3339))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("while waiting for the host-based authentication rules to be reloaded: %w", err)
+	}
+
+	hbaErrors, err := instance.GetHBAFileRuleErrors(superUserDB)
+	if err != nil {
+		return nil, err
+	}
+	identErrors, err := instance.GetIdentFileMappingErrors(superUserDB)
+	if err != nil {
+		return nil, err
+	}
+
+	fileErrors := make([]string, 0, len(hbaErrors)+len(identErrors))
+	for _, message := range hbaErrors {
+		fileErrors = append(fileErrors, "pg_hba.conf: "+message)
+	}
+	for _, message := range identErrors {
+		fileErrors = append(fileErrors, "pg_ident.conf: "+message)
+	}
+
+	return &postgres.HBAReloadStatus{
+		ReloadTime: loadTime.Format(time.RFC3339),
+		Succeeded:  len(fileErrors) == 0,
+		Errors:     fileErrors,
+	}, nil
+}
+
 // waitForStreamingConnectionAvailable waits until we can connect to the passed
 // sql.DB connection using streaming protocol
 func waitForStreamingConnectionAvailable(ctx context.Context, db *sql.DB) error {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/cloudnative-pg/machinery/pkg/fileutils"
 
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 )
 
@@ -33,16 +34,20 @@ const (
 	pgRestore        executable = "pg_restore"
 	postgresDatabase            = "postgres"
 	dumpDirectory               = specs.PgDataPath + "/dumps"
+
+	// parallelDumpDirectory is where parallel imports store their dumps,
+	// in the ephemeral volume rather than in the PGDATA one
+	parallelDumpDirectory = postgres.ScratchDataDirectory + "/dumps"
 )
 
-func createDumpsDirectory() error {
-	return fileutils.EnsureDirectoryExists(dumpDirectory)
+func createDumpsDirectory(directory string) error {
+	return fileutils.EnsureDirectoryExists(directory)
 }
 
-func generateFileNameForDatabase(database string) string {
-	return fmt.Sprintf("%s/%s.dump", dumpDirectory, database)
+func generateFileNameForDatabase(directory, database string) string {
+	return fmt.Sprintf("%s/%s.dump", directory, database)
 }
 
-func cleanDumpDirectory() error {
-	return fileutils.RemoveDirectoryContent(dumpDirectory)
+func cleanDumpDirectory(directory string) error {
+	return fileutils.RemoveDirectoryContent(directory)
 }
@@ -31,7 +31,8 @@ import (
 )
 
 type databaseSnapshotter struct {
-	cluster *apiv1.Cluster
+	cluster  *apiv1.Cluster
+	progress *ProgressReporter
 }
 
 func (ds *databaseSnapshotter) getDatabaseList(ctx context.Context, target pool.Pooler) ([]string, error) {
@@ -91,23 +92,11 @@ func (ds *databaseSnapshotter) exportDatabases(
 	extraOptions []string,
 ) error {
 	contextLogger := log.FromContext(ctx)
-	sectionsToExport := []string{}
 
-	for _, section := range ds.getSectionsToExecute() {
-		sectionsToExport = append(sectionsToExport, fmt.Sprintf("--section=%s", section))
-	}
-
-	for _, database := range databases {
+	for idx, database := range databases {
+		ds.progress.setDatabase(ctx, apiv1.ImportPhaseExporting, database, idx)
 		contextLogger.Info("exporting database", "databaseName", database)
-		dsn := target.GetDsn(database)
-		options := []string{
-			"-Fd",
-			"-f", generateFileNameForDatabase(database),
-			"-d", dsn,
-			"-v",
-		}
-		options = append(options, sectionsToExport...)
-		options = append(options, extraOptions...)
+		options := ds.getPgDumpOptions(database, target.GetDsn(database), extraOptions)
 
 		contextLogger.Info("Running pg_dump", "cmd", pgDump,
 			"options", options)
@@ -121,6 +110,55 @@ func (ds *databaseSnapshotter) exportDatabases(
 	return nil
 }
 
+// getDumpDirectory returns the directory where the dumps are stored.
+// Parallel imports use the ephemeral volume, so that the dumps don't
+// compete for space with the imported data
+func (ds *databaseSnapshotter) getDumpDirectory() string {
+	if ds.cluster.Spec.Bootstrap.InitDB.Import.Jobs > 0 {
+		return parallelDumpDirectory
+	}
+	return dumpDirectory
+}
+
+// getJobsOptions returns the options setting the number of parallel
+// jobs of `pg_dump` and `pg_restore`, if requested
+func (ds *databaseSnapshotter) getJobsOptions() []string {
+	if jobs := ds.cluster.Spec.Bootstrap.InitDB.Import.Jobs; jobs > 0 {
+		return []string{fmt.Sprintf("--jobs=%d", jobs)}
+	}
+	return nil
+}
+
+// getPgDumpOptions builds the options of the `pg_dump` command
+// exporting the passed database
+func (ds *databaseSnapshotter) getPgDumpOptions(database, dsn string, extraOptions []string) []string {
+	options := []string{
+		"-Fd",
+		"-f", generateFileNameForDatabase(ds.getDumpDirectory(), database),
+		"-d", dsn,
+		"-v",
+	}
+
+	importSpec := ds.cluster.Spec.Bootstrap.InitDB.Import
+	if importSpec.SchemaOnly {
+		options = append(options, "--schema-only")
+	} else {
+		for _, section := range ds.getSectionsToExecute() {
+			options = append(options, fmt.Sprintf("--section=%s", section))
+		}
+	}
+
+	for _, pattern := range importSpec.ExcludeSchemas {
+		options = append(options, fmt.Sprintf("--exclude-schema=%s", pattern))
+	}
+	for _, pattern := range importSpec.ExcludeTables {
+		options = append(options, fmt.Sprintf("--exclude-table=%s", pattern))
+	}
+	options = append(options, ds.getJobsOptions()...)
+
+	return append(options, extraOptions...)
+}
+
 func (ds *databaseSnapshotter) importDatabases(
 	ctx context.Context,
 	target pool.Pooler,
@@ -129,7 +167,8 @@ func (ds *databaseSnapshotter) importDatabases(
 ) error {
 	contextLogger := log.FromContext(ctx)
 
-	for _, database := range databases {
+	for idx, database := range databases {
+		ds.progress.setDatabase(ctx, apiv1.ImportPhaseImporting, database, idx)
 		for _, section := range ds.getSectionsToExecute() {
 			targetDatabase := target.GetDsn(database)
 			contextLogger.Info(
@@ -156,9 +195,10 @@ func (ds *databaseSnapshotter) importDatabases(
 				"-U", "postgres",
 				"-d", targetDatabase,
 				"--section", section,
-				generateFileNameForDatabase(database),
+				generateFileNameForDatabase(ds.getDumpDirectory(), database),
 			}
 
+			options = append(options, ds.getJobsOptions()...)
 			options = append(options, extraOptions...)
 			options = append(options, alwaysPresentOptions...)
 
@@ -166,8 +206,7 @@ func (ds *databaseSnapshotter) importDatabases(
 				"cmd", pgRestore,
 				"options", options)
 
-			pgRestoreCommand := exec.Command(pgRestore, options...) // #nosec
-			err = execlog.RunStreaming(pgRestoreCommand, pgRestore)
+			err = ds.runPgRestore(ctx, target, database, section, options)
 			if err != nil {
 				return fmt.Errorf("error while executing pg_restore, section:%s, %w", section, err)
 			}
@@ -186,6 +225,7 @@ func (ds *databaseSnapshotter) importDatabaseContent(
 	extraOptions []string,
 ) error {
 	contextLogger := log.FromContext(ctx)
+	ds.progress.setDatabase(ctx, apiv1.ImportPhaseImporting, targetDatabase, 0)
 
 	// We are about to execute pg_restore here.
 	// That will execute "CREATE EXTENSION" and/or "COMMENT ON EXTENSION" as needed,
@@ -218,9 +258,10 @@ func (ds *databaseSnapshotter) importDatabaseContent(
 			fmt.Sprintf("--role=%s", owner),
 			"-d", targetDatabase,
 			"--section", section,
-			generateFileNameForDatabase(database),
+			generateFileNameForDatabase(ds.getDumpDirectory(), database),
 		}
 
+		options = append(options, ds.getJobsOptions()...)
 		options = append(options, extraOptions...)
 		options = append(options, alwaysPresentOptions...)
 
@@ -228,8 +269,7 @@ func (ds *databaseSnapshotter) importDatabaseContent(
 			"cmd", pgRestore,
 			"options", options)
 
-		pgRestoreCommand := exec.Command(pgRestore, options...) // #nosec
-		err = execlog.RunStreaming(pgRestoreCommand, pgRestore)
+		err = ds.runPgRestore(ctx, target, targetDatabase, section, options)
 		if err != nil {
 			return fmt.Errorf("error while executing pg_restore, section:%s, %w", section, err)
 		}
@@ -244,6 +284,25 @@ func (ds *databaseSnapshotter) importDatabaseContent(
 	return nil
 }
 
+// runPgRestore executes `pg_restore` with the passed options. While the
+// data section is being restored, the copy progress of the target
+// database is reported in the cluster status
+func (ds *databaseSnapshotter) runPgRestore(
+	ctx context.Context,
+	target pool.Pooler,
+	targetDatabase string,
+	section string,
+	options []string,
+) error {
+	if section == "data" {
+		stopWatching := ds.progress.watchCopyProgress(ctx, target, targetDatabase)
+		defer stopWatching()
+	}
+
+	pgRestoreCommand := exec.Command(pgRestore, options...) // #nosec
+	return execlog.RunStreaming(pgRestoreCommand, pgRestore)
+}
+
 func (ds *databaseSnapshotter) databaseExists(
 	target pool.Pooler,
 	dbName string,
@@ -299,7 +358,8 @@ func (ds *databaseSnapshotter) analyze(
 ) error {
 	contextLogger := log.FromContext(ctx)
 
-	for _, database := range databases {
+	for idx, database := range databases {
+		ds.progress.setDatabase(ctx, apiv1.ImportPhaseAnalyzing, database, idx)
 		contextLogger.Info(fmt.Sprintf("running analyze for database: %s", database))
 		db, err := target.Connection(database)
 		if err != nil {
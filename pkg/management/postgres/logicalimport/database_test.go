@@ -168,6 +168,52 @@ var _ = Describe("databaseSnapshotter methods test", func() {
 		})
 	})
 
+	Context("getPgDumpOptions testing", func() {
+		BeforeEach(func() {
+			ds.cluster.Spec.Bootstrap = &apiv1.BootstrapConfiguration{
+				InitDB: &apiv1.BootstrapInitDB{
+					Import: &apiv1.Import{},
+				},
+			}
+		})
+
+		It("should export every section by default", func() {
+			options := ds.getPgDumpOptions("app", "dbname=app", []string{"--no-comments"})
+			Expect(options).To(Equal([]string{
+				"-Fd", "-f", generateFileNameForDatabase(dumpDirectory, "app"), "-d", "dbname=app", "-v",
+				"--section=pre-data", "--section=data", "--section=post-data",
+				"--no-comments",
+			}))
+		})
+
+		It("should only export the schema when requested", func() {
+			ds.cluster.Spec.Bootstrap.InitDB.Import.SchemaOnly = true
+			options := ds.getPgDumpOptions("app", "dbname=app", nil)
+			Expect(options).To(ContainElement("--schema-only"))
+			Expect(options).ToNot(ContainElement("--section=data"))
+		})
+
+		It("should run parallel jobs storing the dumps in the ephemeral volume", func() {
+			ds.cluster.Spec.Bootstrap.InitDB.Import.Jobs = 4
+			options := ds.getPgDumpOptions("app", "dbname=app", nil)
+			Expect(options).To(ContainElement("--jobs=4"))
+			Expect(options).To(ContainElement(generateFileNameForDatabase(parallelDumpDirectory, "app")))
+			Expect(ds.getJobsOptions()).To(Equal([]string{"--jobs=4"}))
+		})
+
+		It("should exclude the requested schemas and tables", func() {
+			ds.cluster.Spec.Bootstrap.InitDB.Import.ExcludeSchemas = []string{"audit"}
+			ds.cluster.Spec.Bootstrap.InitDB.Import.ExcludeTables = []string{"public.logs_*", "events"}
+			options := ds.getPgDumpOptions("app", "dbname=app", []string{"--no-comments"})
+			Expect(options).To(HaveExactElements(
+				"-Fd", "-f", generateFileNameForDatabase(dumpDirectory, "app"), "-d", "dbname=app", "-v",
+				"--section=pre-data", "--section=data", "--section=post-data",
+				"--exclude-schema=audit", "--exclude-table=public.logs_*", "--exclude-table=events",
+				"--no-comments",
+			))
+		})
+	})
+
 	Context("getDatabaseList testing", func() {
 		const query = "SELECT datname FROM pg_database d " +
 			"WHERE datallowconn AND NOT datistemplate AND datallowconn AND datname != 'postgres' " +
@@ -31,15 +31,17 @@ func Microservice(
 	cluster *apiv1.Cluster,
 	destination pool.Pooler,
 	origin pool.Pooler,
+	progress *ProgressReporter,
 ) error {
 	contextLogger := log.FromContext(ctx)
-	ds := databaseSnapshotter{cluster: cluster}
+	ds := databaseSnapshotter{cluster: cluster, progress: progress}
 	initDB := cluster.Spec.Bootstrap.InitDB
 	databases := initDB.Import.Databases
+	progress.start(ctx, len(databases))
 
 	contextLogger.Info("starting microservice clone process")
 
-	if err := createDumpsDirectory(); err != nil {
+	if err := createDumpsDirectory(ds.getDumpDirectory()); err != nil {
 		return nil
 	}
 
@@ -71,7 +73,7 @@ func Microservice(
 		return err
 	}
 
-	if err := cleanDumpDirectory(); err != nil {
+	if err := cleanDumpDirectory(ds.getDumpDirectory()); err != nil {
 		return err
 	}
 
@@ -83,5 +85,10 @@ func Microservice(
 		return err
 	}
 
-	return ds.analyze(ctx, destination, []string{initDB.Database})
+	if err := ds.analyze(ctx, destination, []string{initDB.Database}); err != nil {
+		return err
+	}
+
+	progress.complete(ctx)
+	return nil
 }
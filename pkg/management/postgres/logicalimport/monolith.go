@@ -31,6 +31,7 @@ func Monolith(
 	cluster *apiv1.Cluster,
 	destination pool.Pooler,
 	origin pool.Pooler,
+	progress *ProgressReporter,
 ) error {
 	contextLogger := log.FromContext(ctx)
 	contextLogger.Info("starting monolith clone process")
@@ -44,13 +45,14 @@ func Monolith(
 		}
 	}
 
-	ds := databaseSnapshotter{cluster: cluster}
+	ds := databaseSnapshotter{cluster: cluster, progress: progress}
 	databases, err := ds.getDatabaseList(ctx, origin)
 	if err != nil {
 		return err
 	}
+	progress.start(ctx, len(databases))
 
-	if err := createDumpsDirectory(); err != nil {
+	if err := createDumpsDirectory(ds.getDumpDirectory()); err != nil {
 		return err
 	}
 
@@ -72,9 +74,14 @@ func Monolith(
 		return err
 	}
 
-	if err := cleanDumpDirectory(); err != nil {
+	if err := cleanDumpDirectory(ds.getDumpDirectory()); err != nil {
 		return err
 	}
 
-	return ds.analyze(ctx, destination, databases)
+	if err := ds.analyze(ctx, destination, databases); err != nil {
+		return err
+	}
+
+	progress.complete(ctx)
+	return nil
 }
@@ -0,0 +1,196 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalimport
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	pgTime "github.com/cloudnative-pg/machinery/pkg/postgres/time"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/management/postgres/pool"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/resources/status"
+)
+
+// copyProgressInterval is the interval between two samples of
+// `pg_stat_progress_copy` while the table data is being restored
+const copyProgressInterval = 30 * time.Second
+
+// copyProgressQuery reads the copies running in the current database.
+// `pg_stat_progress_copy` is only available since PostgreSQL 14
+const copyProgressQuery = `SELECT n.nspname || '.' || c.relname, p.bytes_processed
+FROM pg_catalog.pg_stat_progress_copy p
+JOIN pg_catalog.pg_class c ON c.oid = p.relid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE p.datname = current_database()
+ORDER BY 1`
+
+// ProgressReporter writes the progress of the logical import
+// in the status of the cluster. A nil reporter discards every update
+type ProgressReporter struct {
+	client  client.Client
+	cluster *apiv1.Cluster
+}
+
+// NewProgressReporter creates a reporter updating the status
+// of the passed cluster
+func NewProgressReporter(cli client.Client, cluster *apiv1.Cluster) *ProgressReporter {
+	return &ProgressReporter{
+		client:  cli,
+		cluster: cluster,
+	}
+}
+
+// start resets the import status, discarding the progress recorded
+// by a previous run of the import job
+func (r *ProgressReporter) start(ctx context.Context, databasesTotal int) {
+	now := pgTime.GetCurrentTimestamp()
+	r.update(ctx, func(importStatus *apiv1.ImportStatus) {
+		*importStatus = apiv1.ImportStatus{
+			Phase:          apiv1.ImportPhaseExporting,
+			DatabasesTotal: databasesTotal,
+			StartTime:      now,
+		}
+	})
+}
+
+// setDatabase records the database being processed in the passed phase
+func (r *ProgressReporter) setDatabase(
+	ctx context.Context,
+	phase apiv1.ImportPhase,
+	database string,
+	databasesDone int,
+) {
+	r.update(ctx, func(importStatus *apiv1.ImportStatus) {
+		importStatus.Phase = phase
+		importStatus.CurrentDatabase = database
+		importStatus.DatabasesDone = databasesDone
+		importStatus.CopyingTables = nil
+		importStatus.BytesProcessed = 0
+	})
+}
+
+// setCopyProgress records the tables whose data is being copied
+func (r *ProgressReporter) setCopyProgress(ctx context.Context, tables []string, bytesProcessed int64) {
+	r.update(ctx, func(importStatus *apiv1.ImportStatus) {
+		importStatus.CopyingTables = tables
+		importStatus.BytesProcessed = bytesProcessed
+	})
+}
+
+// complete marks the import as completed
+func (r *ProgressReporter) complete(ctx context.Context) {
+	r.update(ctx, func(importStatus *apiv1.ImportStatus) {
+		importStatus.Phase = apiv1.ImportPhaseCompleted
+		importStatus.CurrentDatabase = ""
+		importStatus.DatabasesDone = importStatus.DatabasesTotal
+		importStatus.CopyingTables = nil
+		importStatus.BytesProcessed = 0
+	})
+}
+
+// update applies the passed transaction to the import status.
+// Failing to report the progress is not a reason to fail the import,
+// so errors are just logged
+func (r *ProgressReporter) update(ctx context.Context, tx func(importStatus *apiv1.ImportStatus)) {
+	if r == nil {
+		return
+	}
+
+	now := pgTime.GetCurrentTimestamp()
+	if err := status.PatchWithOptimisticLock(ctx, r.client, r.cluster, func(cluster *apiv1.Cluster) {
+		if cluster.Status.Import == nil {
+			cluster.Status.Import = &apiv1.ImportStatus{}
+		}
+		tx(cluster.Status.Import)
+		cluster.Status.Import.LastUpdateTime = now
+	}); err != nil {
+		log.FromContext(ctx).Warning("cannot report the import progress", "err", err)
+	}
+}
+
+// watchCopyProgress periodically reports the copies running in the passed
+// database until the returned function is called
+func (r *ProgressReporter) watchCopyProgress(ctx context.Context, target pool.Pooler, database string) func() {
+	if r == nil {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(copyProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				db, err := target.Connection(database)
+				if err != nil {
+					continue
+				}
+				tables, bytesProcessed, err := getCopyProgress(ctx, db)
+				if err != nil {
+					log.FromContext(ctx).Debug("cannot read the copy progress", "err", err)
+					continue
+				}
+				r.setCopyProgress(ctx, tables, bytesProcessed)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// getCopyProgress returns the tables being copied in the database
+// and the total number of bytes processed by these copies
+func getCopyProgress(ctx context.Context, db *sql.DB) ([]string, int64, error) {
+	rows, err := db.QueryContext(ctx, copyProgressQuery)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var (
+		tables         []string
+		bytesProcessed int64
+	)
+	for rows.Next() {
+		var (
+			table string
+			bytes int64
+		)
+		if err := rows.Scan(&table, &bytes); err != nil {
+			return nil, 0, err
+		}
+		tables = append(tables, table)
+		bytesProcessed += bytes
+	}
+
+	return tables, bytesProcessed, rows.Err()
+}
@@ -0,0 +1,130 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logicalimport
+
+import (
+	"context"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("import progress reporting", func() {
+	var (
+		cluster  *apiv1.Cluster
+		cli      client.Client
+		reporter *ProgressReporter
+	)
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: "test-namespace",
+			},
+			Status: apiv1.ClusterStatus{
+				Import: &apiv1.ImportStatus{
+					Phase:           apiv1.ImportPhaseImporting,
+					CurrentDatabase: "stale",
+					DatabasesDone:   2,
+					DatabasesTotal:  3,
+					CopyingTables:   []string{"public.stale"},
+					BytesProcessed:  1024,
+				},
+			},
+		}
+		cli = fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(cluster).
+			WithStatusSubresource(cluster).
+			Build()
+		reporter = NewProgressReporter(cli, cluster)
+	})
+
+	getImportStatus := func(ctx context.Context) *apiv1.ImportStatus {
+		var current apiv1.Cluster
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(cluster), &current)).To(Succeed())
+		return current.Status.Import
+	}
+
+	It("discards the progress of a previous run when starting", func(ctx SpecContext) {
+		reporter.start(ctx, 4)
+
+		importStatus := getImportStatus(ctx)
+		Expect(importStatus.Phase).To(Equal(apiv1.ImportPhaseExporting))
+		Expect(importStatus.CurrentDatabase).To(BeEmpty())
+		Expect(importStatus.DatabasesDone).To(BeZero())
+		Expect(importStatus.DatabasesTotal).To(Equal(4))
+		Expect(importStatus.CopyingTables).To(BeEmpty())
+		Expect(importStatus.StartTime).ToNot(BeEmpty())
+		Expect(importStatus.LastUpdateTime).ToNot(BeEmpty())
+	})
+
+	It("tracks the database being processed and the copy progress", func(ctx SpecContext) {
+		reporter.start(ctx, 2)
+		reporter.setDatabase(ctx, apiv1.ImportPhaseImporting, "app", 1)
+		reporter.setCopyProgress(ctx, []string{"public.orders"}, 2048)
+
+		importStatus := getImportStatus(ctx)
+		Expect(importStatus.Phase).To(Equal(apiv1.ImportPhaseImporting))
+		Expect(importStatus.CurrentDatabase).To(Equal("app"))
+		Expect(importStatus.DatabasesDone).To(Equal(1))
+		Expect(importStatus.CopyingTables).To(ConsistOf("public.orders"))
+		Expect(importStatus.BytesProcessed).To(BeEquivalentTo(2048))
+
+		reporter.complete(ctx)
+
+		importStatus = getImportStatus(ctx)
+		Expect(importStatus.Phase).To(Equal(apiv1.ImportPhaseCompleted))
+		Expect(importStatus.CurrentDatabase).To(BeEmpty())
+		Expect(importStatus.DatabasesDone).To(Equal(2))
+		Expect(importStatus.CopyingTables).To(BeEmpty())
+	})
+
+	It("ignores updates when there is no reporter", func(ctx SpecContext) {
+		var nilReporter *ProgressReporter
+		Expect(func() {
+			nilReporter.start(ctx, 1)
+			nilReporter.watchCopyProgress(ctx, fakePooler{}, "app")()
+		}).ToNot(Panic())
+	})
+
+	It("reads the copy progress from pg_stat_progress_copy", func(ctx SpecContext) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectQuery(copyProgressQuery).WillReturnRows(
+			sqlmock.NewRows([]string{"table", "bytes_processed"}).
+				AddRow("public.customers", 100).
+				AddRow("public.orders", 200),
+		)
+
+		tables, bytesProcessed, err := getCopyProgress(ctx, db)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tables).To(Equal([]string{"public.customers", "public.orders"}))
+		Expect(bytesProcessed).To(BeEquivalentTo(300))
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+})
@@ -105,6 +105,15 @@ func (instance *Instance) GetStatus() (result *postgres.PostgresqlStatus, err er
 		}
 	}
 
+	if result.PendingRestart {
+		result.PendingRestartSettings, err = instance.GetPendingRestartSettings(superUserDB)
+		if err != nil {
+			return result, err
+		}
+	}
+	result.LastParameterChanges = instance.GetLastParameterChanges()
+	result.LastHBAReload = instance.GetLastHBAReload()
+
 	err = instance.fillStatus(result)
 	if err != nil {
 		return result, err
@@ -231,6 +240,116 @@ WHERE pending_settings.name IN (
 	return decreasedSensibleValues, nil
 }
 
+// GetPendingRestartSettings returns the names of the parameters whose new value
+// will only be applied after a restart of the instance
+func (instance *Instance) GetPendingRestartSettings(superUserDB *sql.DB) ([]string, error) {
+	rows, err := superUserDB.Query(
+		`SELECT name FROM pg_catalog.pg_settings WHERE pending_restart ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		exitErr := rows.Close()
+		if exitErr != nil {
+			err = exitErr
+		}
+	}()
+
+	var settings []string
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		settings = append(settings, name)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// GetHBAFileRuleErrors returns the errors detected by PostgreSQL while
+// parsing the current content of the pg_hba.conf file
+func (instance *Instance) GetHBAFileRuleErrors(superUserDB *sql.DB) ([]string, error) {
+	return getConfigurationFileErrors(superUserDB,
+		`SELECT line_number, error FROM pg_catalog.pg_hba_file_rules
+		WHERE error IS NOT NULL ORDER BY line_number`)
+}
+
+// GetIdentFileMappingErrors returns the errors detected by PostgreSQL while
+// parsing the current content of the pg_ident.conf file. The
+// pg_ident_file_mappings view is only available since PostgreSQL 15.
+func (instance *Instance) GetIdentFileMappingErrors(superUserDB *sql.DB) ([]string, error) {
+	if ver, _ := instance.GetPgVersion(); ver.Major < 15 {
+		return nil, nil
+	}
+
+	return getConfigurationFileErrors(superUserDB,
+		`SELECT line_number, error FROM pg_catalog.pg_ident_file_mappings
+		WHERE error IS NOT NULL ORDER BY line_number`)
+}
+
+// getConfigurationFileErrors runs a query returning the line number and
+// the error message of the invalid lines of a configuration file
+func getConfigurationFileErrors(superUserDB *sql.DB, query string) ([]string, error) {
+	rows, err := superUserDB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		exitErr := rows.Close()
+		if exitErr != nil {
+			err = exitErr
+		}
+	}()
+
+	var fileErrors []string
+	for rows.Next() {
+		var lineNumber sql.NullInt64
+		var message string
+		if err = rows.Scan(&lineNumber, &message); err != nil {
+			return nil, err
+		}
+		if lineNumber.Valid {
+			message = fmt.Sprintf("line %d: %s", lineNumber.Int64, message)
+		}
+		fileErrors = append(fileErrors, message)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return fileErrors, nil
+}
+
+// GetSettingsContext returns the context of every parameter known by
+// the server, as reported by the pg_settings view
+func (instance *Instance) GetSettingsContext(superUserDB *sql.DB) (map[string]string, error) {
+	rows, err := superUserDB.Query(`SELECT name, context FROM pg_catalog.pg_settings`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		exitErr := rows.Close()
+		if exitErr != nil {
+			err = exitErr
+		}
+	}()
+
+	contexts := make(map[string]string)
+	for rows.Next() {
+		var name, context string
+		if err = rows.Scan(&name, &context); err != nil {
+			return nil, err
+		}
+		contexts[name] = context
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return contexts, nil
+}
+
 // fillStatus extract the current instance information into the PostgresqlStatus
 // structure
 func (instance *Instance) fillStatus(result *postgres.PostgresqlStatus) error {
@@ -348,7 +467,8 @@ func (instance *Instance) fillStatusFromPrimary(result *postgres.PostgresqlStatu
 	return err
 }
 
-// fillArchiverStatus get information about the PostgreSQL archiving process
+// fillArchiverStatus get information about the PostgreSQL archiving process.
+// The archiver status is left empty when WAL archiving is disabled
 func fillArchiverStatus(superUserDB *sql.DB, result *postgres.PostgresqlStatus) error {
 	row := superUserDB.QueryRow(
 		`
@@ -357,16 +477,26 @@ func fillArchiverStatus(superUserDB *sql.DB, result *postgres.PostgresqlStatus)
 			COALESCE(last_archived_time,'-infinity'),
 			COALESCE(last_failed_wal, ''),
 			COALESCE(last_failed_time, '-infinity'),
-			COALESCE(last_archived_time,'-infinity') > COALESCE(last_failed_time, '-infinity') AS is_archiving
+			COALESCE(last_archived_time,'-infinity') > COALESCE(last_failed_time, '-infinity') AS is_archiving,
+			archived_count,
+			failed_count
 		FROM pg_catalog.pg_stat_archiver
+		WHERE current_setting('archive_mode') <> 'off'
 		`)
 
-	return row.Scan(&result.LastArchivedWAL,
+	err := row.Scan(&result.LastArchivedWAL,
 		&result.LastArchivedWALTime,
 		&result.LastFailedWAL,
 		&result.LastFailedWALTime,
 		&result.IsArchivingWAL,
+		&result.ArchivedCount,
+		&result.FailedCount,
 	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+
+	return err
 }
 
 // fillReplicationSlotsStatus get information about the replication slots
@@ -522,14 +652,26 @@ func (instance *Instance) fillStatusFromReplica(result *postgres.PostgresqlStatu
 	}
 
 	// pg_last_wal_receive_lsn may be NULL when using non-streaming
-	// replicas
+	// replicas, and pg_last_xact_replay_timestamp is NULL until
+	// the first transaction has been replayed
 	row := superUserDB.QueryRow(
 		"SELECT " +
 			"(SELECT timeline_id FROM pg_control_checkpoint()), " +
+			"COALESCE((SELECT received_tli FROM pg_catalog.pg_stat_wal_receiver), 0), " +
 			"COALESCE(pg_last_wal_receive_lsn()::varchar, ''), " +
 			"COALESCE(pg_last_wal_replay_lsn()::varchar, ''), " +
-			"pg_is_wal_replay_paused()")
-	if err := row.Scan(&result.TimeLineID, &result.ReceivedLsn, &result.ReplayLsn, &result.ReplayPaused); err != nil {
+			"pg_is_wal_replay_paused(), " +
+			"current_setting('recovery_min_apply_delay'), " +
+			"COALESCE(date_trunc('second', now() - pg_last_xact_replay_timestamp())::varchar, '')")
+	if err := row.Scan(
+		&result.TimeLineID,
+		&result.ReceivedTimeLineID,
+		&result.ReceivedLsn,
+		&result.ReplayLsn,
+		&result.ReplayPaused,
+		&result.MinApplyDelay,
+		&result.ReplayLag,
+	); err != nil {
 		return err
 	}
 
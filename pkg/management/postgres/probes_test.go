@@ -73,8 +73,10 @@ var _ = Describe("probes", func() {
 				"last_failed_wal",
 				"last_failed_time",
 				"is_archiving",
+				"archived_count",
+				"failed_count",
 			},
-			).AddRow("000000010000000000000001", "2021-05-05 12:00:00", "", "2021-05-05 12:00:00", false))
+			).AddRow("000000010000000000000001", "2021-05-05 12:00:00", "", "2021-05-05 12:00:00", false, 12, 3))
 
 		status := &postgres.PostgresqlStatus{}
 		err = fillArchiverStatus(db, status)
@@ -87,6 +89,117 @@ var _ = Describe("probes", func() {
 		Expect(status.LastFailedWAL).To(Equal(""))
 		Expect(status.LastFailedWALTime).To(Equal("2021-05-05 12:00:00"))
 		Expect(status.IsArchivingWAL).To(BeFalse())
+		Expect(status.ArchivedCount).To(BeEquivalentTo(12))
+		Expect(status.FailedCount).To(BeEquivalentTo(3))
+	})
+
+	It("fillArchiveStatus should leave the archiver status empty when archiving is disabled", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectQuery(`.*`).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"last_archived_wal",
+				"last_archived_time",
+				"last_failed_wal",
+				"last_failed_time",
+				"is_archiving",
+				"archived_count",
+				"failed_count",
+			}))
+
+		status := &postgres.PostgresqlStatus{}
+		Expect(fillArchiverStatus(db, status)).To(Succeed())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+
+		Expect(status.LastArchivedWAL).To(BeEmpty())
+		Expect(status.ArchivedCount).To(BeZero())
+		Expect(status.FailedCount).To(BeZero())
+	})
+
+	It("GetPendingRestartSettings should list the parameters waiting for a restart", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectQuery(`SELECT name FROM pg_catalog.pg_settings WHERE pending_restart`).
+			WillReturnRows(sqlmock.NewRows([]string{"name"}).
+				AddRow("max_connections").
+				AddRow("shared_buffers"))
+
+		instance := &Instance{}
+		settings, err := instance.GetPendingRestartSettings(db)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		Expect(settings).To(Equal([]string{"max_connections", "shared_buffers"}))
+	})
+
+	It("GetPendingRestartSettings should return nothing when no restart is pending", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectQuery(`.*`).WillReturnRows(sqlmock.NewRows([]string{"name"}))
+
+		instance := &Instance{}
+		settings, err := instance.GetPendingRestartSettings(db)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings).To(BeEmpty())
+	})
+
+	It("GetHBAFileRuleErrors should report the errors found in pg_hba.conf", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectQuery(`SELECT line_number, error FROM pg_catalog.pg_hba_file_rules`).
+			WillReturnRows(sqlmock.NewRows([]string{"line_number", "error"}).
+				AddRow(12, `invalid authentication method "md6"`).
+				AddRow(nil, "could not open file"))
+
+		instance := &Instance{}
+		hbaErrors, err := instance.GetHBAFileRuleErrors(db)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		Expect(hbaErrors).To(Equal([]string{
+			`line 12: invalid authentication method "md6"`,
+			"could not open file",
+		}))
+	})
+
+	It("GetHBAFileRuleErrors should return nothing when pg_hba.conf is valid", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectQuery(`.*`).WillReturnRows(sqlmock.NewRows([]string{"line_number", "error"}))
+
+		instance := &Instance{}
+		hbaErrors, err := instance.GetHBAFileRuleErrors(db)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hbaErrors).To(BeEmpty())
+	})
+
+	It("GetIdentFileMappingErrors should report the errors found in pg_ident.conf", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		mock.ExpectQuery(`SELECT line_number, error FROM pg_catalog.pg_ident_file_mappings`).
+			WillReturnRows(sqlmock.NewRows([]string{"line_number", "error"}).
+				AddRow(3, "missing entry at end of line"))
+
+		instance := &Instance{pgVersion: &semver.Version{Major: 15}}
+		identErrors, err := instance.GetIdentFileMappingErrors(db)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+		Expect(identErrors).To(Equal([]string{"line 3: missing entry at end of line"}))
+	})
+
+	It("GetIdentFileMappingErrors should do nothing before PostgreSQL 15", func() {
+		db, mock, err := sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		instance := &Instance{pgVersion: &semver.Version{Major: 14}}
+		identErrors, err := instance.GetIdentFileMappingErrors(db)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(identErrors).To(BeEmpty())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
 	})
 
 	Context("Fill basebackup stats", func() {
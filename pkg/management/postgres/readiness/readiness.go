@@ -20,14 +20,24 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"time"
 )
 
 // ErrStreamingReplicaNotConnected is raised for streaming replicas that never connected to its primary
 var ErrStreamingReplicaNotConnected = errors.New("streaming replica was never connected to the primary node")
 
+// ErrStreamingReplicaNotStreaming is raised for streaming replicas whose WAL receiver is not streaming
+var ErrStreamingReplicaNotStreaming = errors.New("streaming replica is not streaming from the source")
+
+// ErrStandbyLagTooHigh is raised for replicas whose lag is over the configured maximum
+var ErrStandbyLagTooHigh = errors.New("replica lag is over the configured maximum")
+
 // instanceInterface represents the required behavior for use in the readiness probe
 type instanceInterface interface {
 	CanCheckReadiness() bool
+	IsStreamingRequiredForReadiness() bool
+	GetMaxStandbyLagForReady() (int64, time.Duration)
 	GetSuperUserDB() (*sql.DB, error)
 }
 
@@ -61,6 +71,22 @@ func (data *Data) IsServerReady(ctx context.Context) error {
 		return err
 	}
 
+	// When requested, a streaming replica is ready only
+	// while its WAL receiver is streaming
+	if data.instance.IsStreamingRequiredForReadiness() {
+		if err := checkWALReceiverStreaming(ctx, superUserDB); err != nil {
+			return err
+		}
+	}
+
+	// When requested, a replica is ready only while its lag
+	// is within the configured maximum
+	if maxLagBytes, maxLagDuration := data.instance.GetMaxStandbyLagForReady(); maxLagBytes > 0 || maxLagDuration > 0 {
+		if err := checkStandbyLag(ctx, superUserDB, maxLagBytes, maxLagDuration); err != nil {
+			return err
+		}
+	}
+
 	// If we already validated this streaming replica, everything
 	// is fine
 	if data.streamingReplicaValidated {
@@ -99,3 +125,78 @@ func (data *Data) IsServerReady(ctx context.Context) error {
 	data.streamingReplicaValidated = true
 	return nil
 }
+
+// checkWALReceiverStreaming checks if the WAL receiver of a streaming
+// replica is streaming from the source. Primaries and replicas that
+// are fed only by the WAL archive are not checked
+func checkWALReceiverStreaming(ctx context.Context, superUserDB *sql.DB) error {
+	row := superUserDB.QueryRowContext(
+		ctx,
+		`
+		SELECT
+			NOT pg_is_in_recovery()
+			OR (SELECT coalesce(setting, '') = '' FROM pg_settings WHERE name = 'primary_conninfo')
+			OR EXISTS (SELECT 1 FROM pg_stat_wal_receiver WHERE status = 'streaming')
+		`,
+	)
+
+	var isStreaming bool
+	if err := row.Scan(&isStreaming); err != nil {
+		return err
+	}
+
+	if !isStreaming {
+		return ErrStreamingReplicaNotStreaming
+	}
+
+	return nil
+}
+
+// checkStandbyLag checks if the lag of a replica is within the passed
+// maximum values, when they are set. The lag in bytes is measured against
+// the latest WAL location sent by the source, while the lag in time is
+// measured from the commit time of the last replayed transaction.
+// The lag in time is unknown, and not checked, when the replica has no
+// active WAL receiver or has never received any WAL from the source,
+// as the commit time of the last replayed transaction doesn't tell how
+// far the replica is from the source
+func checkStandbyLag(ctx context.Context, superUserDB *sql.DB, maxLagBytes int64, maxLagDuration time.Duration) error {
+	row := superUserDB.QueryRowContext(
+		ctx,
+		`
+		SELECT
+			pg_is_in_recovery(),
+			COALESCE(GREATEST(pg_wal_lsn_diff(
+				(SELECT latest_end_lsn FROM pg_stat_wal_receiver),
+				pg_last_wal_replay_lsn()), 0), 0)::bigint,
+			CASE
+				WHEN pg_last_wal_receive_lsn() IS NULL THEN NULL
+				WHEN NOT EXISTS (SELECT 1 FROM pg_stat_wal_receiver WHERE status = 'streaming') THEN NULL
+				WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+				ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+			END::float8
+		`,
+	)
+
+	var isInRecovery bool
+	var lagBytes int64
+	var lagSeconds sql.NullFloat64
+	if err := row.Scan(&isInRecovery, &lagBytes, &lagSeconds); err != nil {
+		return err
+	}
+
+	if !isInRecovery {
+		return nil
+	}
+
+	if maxLagBytes > 0 && lagBytes > maxLagBytes {
+		return fmt.Errorf("%w: %d bytes behind the source", ErrStandbyLagTooHigh, lagBytes)
+	}
+
+	lag := time.Duration(lagSeconds.Float64 * float64(time.Second))
+	if maxLagDuration > 0 && lag > maxLagDuration {
+		return fmt.Errorf("%w: %s behind the source", ErrStandbyLagTooHigh, lag.Round(time.Millisecond))
+	}
+
+	return nil
+}
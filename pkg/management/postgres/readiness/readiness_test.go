@@ -0,0 +1,161 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeInstance struct {
+	db                *sql.DB
+	requiresStreaming bool
+	maxLagBytes       int64
+	maxLagDuration    time.Duration
+}
+
+func (f fakeInstance) CanCheckReadiness() bool {
+	return true
+}
+
+func (f fakeInstance) IsStreamingRequiredForReadiness() bool {
+	return f.requiresStreaming
+}
+
+func (f fakeInstance) GetMaxStandbyLagForReady() (int64, time.Duration) {
+	return f.maxLagBytes, f.maxLagDuration
+}
+
+func (f fakeInstance) GetSuperUserDB() (*sql.DB, error) {
+	return f.db, nil
+}
+
+var _ = Describe("Readiness probe", func() {
+	const (
+		streamingQuery = "pg_stat_wal_receiver"
+		connectedQuery = "primary_conninfo.*pg_last_wal_replay_lsn"
+		lagQuery       = "latest_end_lsn"
+	)
+
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+		ctx  context.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+		ctx = context.Background()
+		db, mock, err = sqlmock.New(sqlmock.MonitorPingsOption(true))
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(func() {
+			Expect(mock.ExpectationsWereMet()).To(Succeed())
+		})
+	})
+
+	It("doesn't check the WAL receiver by default", func() {
+		mock.ExpectPing()
+		mock.ExpectQuery(connectedQuery).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(true))
+
+		Expect(ForInstance(fakeInstance{db: db}).IsServerReady(ctx)).To(Succeed())
+	})
+
+	It("is ready when the WAL receiver is streaming", func() {
+		mock.ExpectPing()
+		mock.ExpectQuery(streamingQuery).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(true))
+		mock.ExpectQuery(connectedQuery).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(true))
+
+		Expect(ForInstance(fakeInstance{db: db, requiresStreaming: true}).IsServerReady(ctx)).To(Succeed())
+	})
+
+	It("is not ready when the WAL receiver is not streaming", func() {
+		mock.ExpectPing()
+		mock.ExpectQuery(streamingQuery).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(false))
+
+		err := ForInstance(fakeInstance{db: db, requiresStreaming: true}).IsServerReady(ctx)
+		Expect(err).To(MatchError(ErrStreamingReplicaNotStreaming))
+	})
+
+	It("checks the WAL receiver even after the replica has connected once", func() {
+		checker := ForInstance(fakeInstance{db: db, requiresStreaming: true})
+
+		mock.ExpectPing()
+		mock.ExpectQuery(streamingQuery).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(true))
+		mock.ExpectQuery(connectedQuery).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(true))
+		Expect(checker.IsServerReady(ctx)).To(Succeed())
+
+		mock.ExpectPing()
+		mock.ExpectQuery(streamingQuery).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(false))
+		Expect(checker.IsServerReady(ctx)).To(MatchError(ErrStreamingReplicaNotStreaming))
+	})
+
+	Context("with a maximum lag", func() {
+		lagRows := func(isInRecovery bool, lagBytes int64, lagSeconds any) *sqlmock.Rows {
+			return sqlmock.NewRows([]string{"in_recovery", "lag_bytes", "lag_seconds"}).
+				AddRow(isInRecovery, lagBytes, lagSeconds)
+		}
+
+		It("is ready when the lag is within the maximum", func() {
+			mock.ExpectPing()
+			mock.ExpectQuery(lagQuery).WillReturnRows(lagRows(true, 1024, 1))
+			mock.ExpectQuery(connectedQuery).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(true))
+
+			instance := fakeInstance{db: db, maxLagBytes: 2048, maxLagDuration: 10 * time.Second}
+			Expect(ForInstance(instance).IsServerReady(ctx)).To(Succeed())
+		})
+
+		It("is not ready when the lag in bytes is over the maximum", func() {
+			mock.ExpectPing()
+			mock.ExpectQuery(lagQuery).WillReturnRows(lagRows(true, 4096, 0))
+
+			instance := fakeInstance{db: db, maxLagBytes: 2048}
+			Expect(ForInstance(instance).IsServerReady(ctx)).To(MatchError(ErrStandbyLagTooHigh))
+		})
+
+		It("is not ready when the lag in time is over the maximum", func() {
+			mock.ExpectPing()
+			mock.ExpectQuery(lagQuery).WillReturnRows(lagRows(true, 0, 30))
+
+			instance := fakeInstance{db: db, maxLagDuration: 10 * time.Second}
+			Expect(ForInstance(instance).IsServerReady(ctx)).To(MatchError(ErrStandbyLagTooHigh))
+		})
+
+		It("is ready when the lag in time is unknown", func() {
+			mock.ExpectPing()
+			mock.ExpectQuery(lagQuery).WillReturnRows(lagRows(true, 0, nil))
+			mock.ExpectQuery(connectedQuery).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(true))
+
+			instance := fakeInstance{db: db, maxLagDuration: 10 * time.Second}
+			Expect(ForInstance(instance).IsServerReady(ctx)).To(Succeed())
+		})
+
+		It("doesn't check the lag on a primary", func() {
+			mock.ExpectPing()
+			mock.ExpectQuery(lagQuery).WillReturnRows(lagRows(false, 4096, 30))
+			mock.ExpectQuery(connectedQuery).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(true))
+
+			instance := fakeInstance{db: db, maxLagBytes: 2048, maxLagDuration: 10 * time.Second}
+			Expect(ForInstance(instance).IsServerReady(ctx)).To(Succeed())
+		})
+	})
+})
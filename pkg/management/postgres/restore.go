@@ -650,29 +650,32 @@ func (info InitInfo) writeCustomRestoreWalConfig(cluster *apiv1.Cluster, conf st
 // to complete the WAL recovery from the object storage and then start
 // as a new primary
 func getRestoreWalConfig(ctx context.Context, backup *apiv1.Backup) (string, error) {
-	var err error
+	cloudProviderOptions, err := barmanCommand.AppendCloudProviderOptionsFromBackup(
+		ctx, nil, backup.Status.BarmanCredentials)
+	if err != nil {
+		return "", err
+	}
 
+	return BuildRestoreWalConfig(backup, cloudProviderOptions), nil
+}
+
+// BuildRestoreWalConfig builds the content of the recovery configuration
+// restoring the WALs from the object storage, given the cloud provider
+// options, which depend on the installed Barman Cloud version
+func BuildRestoreWalConfig(backup *apiv1.Backup, cloudProviderOptions []string) string {
 	cmd := []string{barmanCapabilities.BarmanCloudWalRestore}
 	if backup.Status.EndpointURL != "" {
 		cmd = append(cmd, "--endpoint-url", backup.Status.EndpointURL)
 	}
 	cmd = append(cmd, backup.Status.DestinationPath)
 	cmd = append(cmd, backup.Status.ServerName)
-
-	cmd, err = barmanCommand.AppendCloudProviderOptionsFromBackup(
-		ctx, cmd, backup.Status.BarmanCredentials)
-	if err != nil {
-		return "", err
-	}
-
+	cmd = append(cmd, cloudProviderOptions...)
 	cmd = append(cmd, "%f", "%p")
 
-	recoveryFileContents := fmt.Sprintf(
+	return fmt.Sprintf(
 		"recovery_target_action = promote\n"+
 			"restore_command = '%s'\n",
 		strings.Join(cmd, " "))
-
-	return recoveryFileContents, nil
 }
 
 func (info InitInfo) writeRecoveryConfiguration(cluster *apiv1.Cluster, recoveryFileContents string) error {
@@ -857,7 +860,7 @@ func (info InitInfo) WriteInitialPostgresqlConf(ctx context.Context, cluster *ap
 	if err != nil {
 		return fmt.Errorf("while generating pg_hba.conf: %w", err)
 	}
-	_, err = temporaryInstance.RefreshPGIdent(ctx, cluster.Spec.PostgresConfiguration.PgIdent)
+	_, err = temporaryInstance.RefreshPGIdent(ctx, GetIdentLines(cluster))
 	if err != nil {
 		return fmt.Errorf("while generating pg_ident.conf: %w", err)
 	}
@@ -0,0 +1,129 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	barmanCredentials "github.com/cloudnative-pg/barman-cloud/pkg/credentials"
+	"github.com/cloudnative-pg/machinery/pkg/log"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+)
+
+// VerifyBackup restores the passed base backup in the PGDATA directory and
+// starts PostgreSQL on it, waiting until the WAL files required to reach a
+// consistent state have been replayed from the object store.
+// WAL archiving is disabled during the whole process, so the object store
+// is never written to
+func (info InitInfo) VerifyBackup(ctx context.Context, cli client.Client, backupName string) error {
+	contextLogger := log.FromContext(ctx).WithValues("backupName", backupName)
+
+	cluster, err := info.loadCluster(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	var backup apiv1.Backup
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: info.Namespace, Name: backupName}, &backup); err != nil {
+		return err
+	}
+	if backup.Spec.Cluster.Name != cluster.Name {
+		return fmt.Errorf("backup %s doesn't belong to cluster %s", backupName, cluster.Name)
+	}
+	if backup.Status.Method != apiv1.BackupMethodBarmanObjectStore {
+		return fmt.Errorf("backup %s has not been taken with the %s method",
+			backupName, apiv1.BackupMethodBarmanObjectStore)
+	}
+
+	env, err := barmanCredentials.EnvSetRestoreCloudCredentials(
+		ctx,
+		cli,
+		cluster.Namespace,
+		&apiv1.BarmanObjectStoreConfiguration{
+			BarmanCredentials: backup.Status.BarmanCredentials,
+			EndpointCA:        backup.Status.EndpointCA,
+			EndpointURL:       backup.Status.EndpointURL,
+			DestinationPath:   backup.Status.DestinationPath,
+			ServerName:        backup.Status.ServerName,
+		},
+		os.Environ())
+	if err != nil {
+		return err
+	}
+
+	contextLogger.Info("Verifying backup")
+
+	if err := info.ensureArchiveContainsLastCheckpointRedoWAL(ctx, cluster, env, &backup); err != nil {
+		return err
+	}
+
+	if err := info.restoreDataDir(ctx, &backup, env); err != nil {
+		return err
+	}
+
+	if err := info.WriteInitialPostgresqlConf(ctx, cluster); err != nil {
+		return err
+	}
+	if _, err := info.GetInstance().migratePostgresAutoConfFile(ctx); err != nil {
+		return err
+	}
+	if err := info.WriteRestoreHbaConf(ctx); err != nil {
+		return err
+	}
+
+	// Stop the recovery as soon as the backup is consistent. The recovery
+	// configuration also disables the WAL archiving
+	conf, err := getRestoreWalConfig(ctx, &backup)
+	if err != nil {
+		return err
+	}
+	recoveryTarget := apiv1.RecoveryTarget{TargetImmediate: ptr.To(true)}
+	if err := info.writeRecoveryConfiguration(cluster, conf+recoveryTarget.BuildPostgresOptions()); err != nil {
+		return err
+	}
+
+	instance := info.GetInstance()
+	instance.Env = env
+	if err := instance.VerifyPgDataCoherence(ctx); err != nil {
+		return err
+	}
+
+	return instance.WithActiveInstance(func() error {
+		db, err := instance.GetSuperUserDB()
+		if err != nil {
+			return err
+		}
+
+		if err := waitUntilRecoveryFinishes(db); err != nil {
+			return fmt.Errorf("while waiting for PostgreSQL to stop recovery mode: %w", err)
+		}
+
+		var databases int
+		row := db.QueryRowContext(ctx, "SELECT count(*) FROM pg_catalog.pg_database WHERE datallowconn")
+		if err := row.Scan(&databases); err != nil {
+			return fmt.Errorf("while querying the restored instance: %w", err)
+		}
+
+		contextLogger.Info("Backup verified", "databases", databases)
+		return nil
+	})
+}
@@ -56,14 +56,48 @@ const (
 // not valid in a replication slot name
 var replicationSlotInvalidCharacters = regexp.MustCompile(`[^a-z0-9_]`)
 
+// minimumNonExclusiveBackupMajorVersion is the first PostgreSQL major
+// version where pg_stop_backup accepts the wait_for_archive argument
+// of the non-exclusive backup API
+const minimumNonExclusiveBackupMajorVersion = 10
+
+// backupAPI is the set of queries used to start and stop a
+// non-exclusive physical backup
+type backupAPI struct {
+	startQuery string
+	stopQuery  string
+}
+
+// getBackupAPI returns the queries of the non-exclusive backup API
+// supported by the given PostgreSQL major version
+func getBackupAPI(postgresMajorVersion uint64) (*backupAPI, error) {
+	switch {
+	case postgresMajorVersion < minimumNonExclusiveBackupMajorVersion:
+		return nil, fmt.Errorf(
+			"PostgreSQL %d doesn't support the non-exclusive backup API required by online backups, "+
+				"at least PostgreSQL %d is needed",
+			postgresMajorVersion, minimumNonExclusiveBackupMajorVersion)
+	case postgresMajorVersion < 15:
+		return &backupAPI{
+			startQuery: "SELECT pg_start_backup($1, $2, false);",
+			stopQuery:  "SELECT lsn, labelfile, spcmapfile FROM pg_stop_backup(false, $1);",
+		}, nil
+	default:
+		return &backupAPI{
+			startQuery: "SELECT pg_backup_start(label => $1, fast => $2);",
+			stopQuery:  "SELECT lsn, labelfile, spcmapfile FROM pg_backup_stop(wait_for_archive => $1);",
+		}, nil
+	}
+}
+
 type backupConnection struct {
-	sync                 sync.Mutex
-	immediateCheckpoint  bool
-	waitForArchive       bool
-	conn                 *sql.Conn
-	postgresMajorVersion uint64
-	data                 BackupResultData
-	err                  error
+	sync                sync.Mutex
+	immediateCheckpoint bool
+	waitForArchive      bool
+	conn                *sql.Conn
+	api                 *backupAPI
+	data                BackupResultData
+	err                 error
 }
 
 func (bc *backupConnection) setPhase(phase BackupConnectionPhase, backupName string) {
@@ -114,6 +148,11 @@ func newBackupConnection(
 		return nil, err
 	}
 
+	api, err := getBackupAPI(vers.Major)
+	if err != nil {
+		return nil, err
+	}
+
 	// the context is used only while obtaining the connection
 	conn, err := superUserDB.Conn(ctx)
 	if err != nil {
@@ -121,10 +160,10 @@ func newBackupConnection(
 	}
 
 	return &backupConnection{
-		immediateCheckpoint:  immediateCheckpoint,
-		waitForArchive:       waitForArchive,
-		conn:                 conn,
-		postgresMajorVersion: vers.Major,
+		immediateCheckpoint: immediateCheckpoint,
+		waitForArchive:      waitForArchive,
+		conn:                conn,
+		api:                 api,
 		data: BackupResultData{
 			BackupName: backupName,
 			Phase:      Starting,
@@ -159,18 +198,13 @@ func (bc *backupConnection) startBackup(ctx context.Context, backupName string)
 		"SELECT pg_create_physical_replication_slot(slot_name => $1, immediately_reserve => true, temporary => true)",
 		slotName,
 	); err != nil {
-		bc.err = fmt.Errorf("while creating the replication slot: %w", bc.err)
+		bc.executeWithLock(backupName, func() error {
+			return fmt.Errorf("while creating the replication slot: %w", err)
+		})
 		return
 	}
 
-	var row *sql.Row
-	if bc.postgresMajorVersion < 15 {
-		row = bc.conn.QueryRowContext(ctx, "SELECT pg_start_backup($1, $2, false);", bc.data.BackupName,
-			bc.immediateCheckpoint)
-	} else {
-		row = bc.conn.QueryRowContext(ctx, "SELECT pg_backup_start(label => $1, fast => $2);", bc.data.BackupName,
-			bc.immediateCheckpoint)
-	}
+	row := bc.conn.QueryRowContext(ctx, bc.api.startQuery, bc.data.BackupName, bc.immediateCheckpoint)
 
 	bc.executeWithLock(backupName, func() error {
 		if err := row.Scan(&bc.data.BeginLSN); err != nil {
@@ -201,14 +235,7 @@ func (bc *backupConnection) stopBackup(ctx context.Context, backupName string) {
 		return
 	}
 
-	var row *sql.Row
-	if bc.postgresMajorVersion < 15 {
-		row = bc.conn.QueryRowContext(ctx,
-			"SELECT lsn, labelfile, spcmapfile FROM pg_stop_backup(false, $1);", bc.waitForArchive)
-	} else {
-		row = bc.conn.QueryRowContext(ctx,
-			"SELECT lsn, labelfile, spcmapfile FROM pg_backup_stop(wait_for_archive => $1);", bc.waitForArchive)
-	}
+	row := bc.conn.QueryRowContext(ctx, bc.api.stopQuery, bc.waitForArchive)
 
 	bc.executeWithLock(backupName, func() error {
 		if err := row.Scan(&bc.data.EndLSN, &bc.data.LabelFile, &bc.data.SpcmapFile); err != nil {
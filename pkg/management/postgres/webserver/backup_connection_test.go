@@ -0,0 +1,119 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webserver
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getBackupAPI", func() {
+	It("rejects versions without the non-exclusive backup API", func() {
+		_, err := getBackupAPI(9)
+		Expect(err).To(MatchError(ContainSubstring("PostgreSQL 9 doesn't support the non-exclusive backup API")))
+	})
+
+	It("uses pg_start_backup and pg_stop_backup before PostgreSQL 15", func() {
+		api, err := getBackupAPI(14)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(api.startQuery).To(ContainSubstring("pg_start_backup"))
+		Expect(api.stopQuery).To(ContainSubstring("pg_stop_backup"))
+	})
+
+	It("uses pg_backup_start and pg_backup_stop since PostgreSQL 15", func() {
+		api, err := getBackupAPI(15)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(api.startQuery).To(ContainSubstring("pg_backup_start"))
+		Expect(api.stopQuery).To(ContainSubstring("pg_backup_stop"))
+	})
+})
+
+var _ = Describe("backupConnection", func() {
+	const backupName = "cluster-example-backup"
+
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+		bc   *backupConnection
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		var err error
+		db, mock, err = sqlmock.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		conn, err := db.Conn(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		api, err := getBackupAPI(16)
+		Expect(err).ToNot(HaveOccurred())
+
+		bc = &backupConnection{
+			immediateCheckpoint: true,
+			waitForArchive:      true,
+			conn:                conn,
+			api:                 api,
+			data:                BackupResultData{BackupName: backupName, Phase: Starting},
+		}
+	})
+
+	AfterEach(func() {
+		mock.ExpectClose()
+		Expect(db.Close()).To(Succeed())
+		Expect(mock.ExpectationsWereMet()).To(Succeed())
+	})
+
+	It("records the LSN, the label and the tablespace map of the backup", func(ctx SpecContext) {
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_create_physical_replication_slot(")).
+			WithArgs("cluster_example_backup").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(bc.api.startQuery)).
+			WithArgs(backupName, true).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_backup_start"}).AddRow("0/6000028"))
+		mock.ExpectQuery(regexp.QuoteMeta(bc.api.stopQuery)).
+			WithArgs(true).
+			WillReturnRows(sqlmock.NewRows([]string{"lsn", "labelfile", "spcmapfile"}).
+				AddRow("0/6000100", []byte("label"), []byte("spcmap")))
+
+		bc.startBackup(ctx, backupName)
+		Expect(bc.err).ToNot(HaveOccurred())
+		Expect(bc.data.Phase).To(Equal(Started))
+		Expect(bc.data.BeginLSN).To(BeEquivalentTo("0/6000028"))
+
+		bc.stopBackup(ctx, backupName)
+		Expect(bc.err).ToNot(HaveOccurred())
+		Expect(bc.data.Phase).To(Equal(Completed))
+		Expect(bc.data.EndLSN).To(BeEquivalentTo("0/6000100"))
+		Expect(bc.data.LabelFile).To(BeEquivalentTo("label"))
+		Expect(bc.data.SpcmapFile).To(BeEquivalentTo("spcmap"))
+	})
+
+	It("reports the error creating the replication slot", func(ctx SpecContext) {
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_create_physical_replication_slot(")).
+			WillReturnError(errors.New("replication slots are exhausted"))
+
+		bc.startBackup(ctx, backupName)
+		Expect(bc.err).To(MatchError(ContainSubstring("replication slots are exhausted")))
+		Expect(bc.data.Phase).To(Equal(Starting))
+	})
+})
@@ -138,6 +138,14 @@ func (ws *localWebserverEndpoints) requestBackup(w http.ResponseWriter, r *http.
 		return
 	}
 
+	serverName := r.URL.Query().Get("serverName")
+	if r.URL.Query().Has("serverName") {
+		if err := postgres.ValidateBackupServerName(serverName); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
 	cluster, err := ws.getCluster(ctx)
 	if err != nil {
 		http.Error(
@@ -165,7 +173,7 @@ func (ws *localWebserverEndpoints) requestBackup(w http.ResponseWriter, r *http.
 			return
 		}
 
-		if err := ws.startBarmanBackup(ctx, cluster, &backup); err != nil {
+		if err := ws.startBarmanBackup(ctx, cluster, &backup, serverName); err != nil {
 			http.Error(
 				w,
 				fmt.Sprintf("error while requesting backup: %v", err.Error()),
@@ -175,6 +183,11 @@ func (ws *localWebserverEndpoints) requestBackup(w http.ResponseWriter, r *http.
 		_, _ = fmt.Fprint(w, "OK")
 
 	case apiv1.BackupMethodPlugin:
+		if serverName != "" {
+			http.Error(w, "The server name can be overridden only for Barman backups", http.StatusBadRequest)
+			return
+		}
+
 		if backup.Spec.PluginConfiguration.IsEmpty() {
 			http.Error(w, "Plugin backup not configured in the cluster", http.StatusConflict)
 			return
@@ -206,6 +219,7 @@ func (ws *localWebserverEndpoints) startBarmanBackup(
 	ctx context.Context,
 	cluster *apiv1.Cluster,
 	backup *apiv1.Backup,
+	serverName string,
 ) error {
 	backupLog := log.WithValues(
 		"backupName", backup.Name,
@@ -222,6 +236,7 @@ func (ws *localWebserverEndpoints) startBarmanBackup(
 	if err != nil {
 		return fmt.Errorf("while initializing backup: %w", err)
 	}
+	backupCommand.ServerName = serverName
 
 	if err := backupCommand.Start(ctx); err != nil {
 		return fmt.Errorf("while starting backup: %w", err)
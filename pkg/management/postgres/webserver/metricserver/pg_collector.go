@@ -51,6 +51,9 @@ type Exporter struct {
 	// - to ensure we are able to unit test
 	// - to make the struct adhere to the composition pattern instead of hardcoding dependencies inside the functions
 	getCluster func() (*apiv1.Cluster, error)
+	// the time of the last retention run accounted in the metrics,
+	// nil until the cluster status has been read for the first time
+	lastRetentionRunTime *string
 }
 
 // metrics here are related to the exporter itself, which is instrumented to
@@ -70,6 +73,7 @@ type metrics struct {
 	FirstRecoverabilityPoint     prometheus.Gauge
 	LastAvailableBackupTimestamp prometheus.Gauge
 	LastFailedBackupTimestamp    prometheus.Gauge
+	BackupRetentionDeleted       prometheus.Counter
 	FencingOn                    prometheus.Gauge
 	PgStatWalMetrics             PgStatWalMetrics
 	NodesUsed                    prometheus.Gauge
@@ -187,6 +191,12 @@ func newMetrics() *metrics {
 			Name:      "last_failed_backup_timestamp",
 			Help:      "The last failed backup as a unix timestamp",
 		}),
+		BackupRetentionDeleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: "backup",
+			Name:      "retention_deleted_total",
+			Help:      "Total number of backups deleted by the retention policy",
+		}),
 		FencingOn: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: PrometheusNamespace,
 			Subsystem: subsystem,
@@ -280,6 +290,7 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	e.Metrics.FencingOn.Describe(ch)
 	e.Metrics.LastFailedBackupTimestamp.Describe(ch)
 	e.Metrics.LastAvailableBackupTimestamp.Describe(ch)
+	e.Metrics.BackupRetentionDeleted.Describe(ch)
 	e.Metrics.NodesUsed.Describe(ch)
 
 	if e.queries != nil {
@@ -318,6 +329,7 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 	e.Metrics.FencingOn.Collect(ch)
 	e.Metrics.LastFailedBackupTimestamp.Collect(ch)
 	e.Metrics.LastAvailableBackupTimestamp.Collect(ch)
+	e.Metrics.BackupRetentionDeleted.Collect(ch)
 	e.Metrics.NodesUsed.Collect(ch)
 
 	if version, _ := e.instance.GetPgVersion(); version.Major >= 14 {
@@ -399,6 +411,8 @@ func (e *Exporter) collectPgMetrics(ch chan<- prometheus.Metric) {
 		e.collectFromPrimaryLastAvailableBackupTimestamp()
 
 		e.collectFromPrimaryLastFailedBackupTimestamp()
+
+		e.collectFromPrimaryBackupRetention()
 	}
 
 	if err := collectPGWalArchiveMetric(e); err != nil {
@@ -509,6 +523,37 @@ func (e *Exporter) collectFromPrimaryLastAvailableBackupTimestamp() {
 	})
 }
 
+// collectFromPrimaryBackupRetention accounts the backups deleted by each
+// retention run recorded in the cluster status. The run found when the
+// status is read for the first time is not accounted, as it may have
+// already been exposed before a restart of the instance manager
+func (e *Exporter) collectFromPrimaryBackupRetention() {
+	const errorLabel = "Collect.BackupRetention"
+
+	cluster, err := e.getCluster()
+	// there isn't a cached object yet
+	if errors.Is(err, cache.ErrCacheMiss) {
+		return
+	}
+	if err != nil {
+		log.Error(err, "error while retrieving cluster cache object")
+		e.Metrics.Error.Set(1)
+		e.Metrics.PgCollectionErrors.WithLabelValues(errorLabel).Inc()
+		return
+	}
+
+	var runTime string
+	if cluster.Status.LastRetentionRun != nil {
+		runTime = cluster.Status.LastRetentionRun.Time
+	}
+
+	isFirstRead := e.lastRetentionRunTime == nil
+	if !isFirstRead && *e.lastRetentionRunTime != runTime && cluster.Status.LastRetentionRun != nil {
+		e.Metrics.BackupRetentionDeleted.Add(float64(cluster.Status.LastRetentionRun.DeletedBackups))
+	}
+	e.lastRetentionRunTime = &runTime
+}
+
 func (e *Exporter) collectFromPrimaryFirstPointOnTimeRecovery() {
 	const errorLabel = "Collect.FirstRecoverabilityPoint"
 	e.setTimestampMetric(e.Metrics.FirstRecoverabilityPoint, errorLabel, func(cluster *apiv1.Cluster) string {
@@ -97,6 +97,44 @@ var _ = Describe("test metrics parsing", func() {
 		}
 	})
 
+	It("accounts the backups deleted by each new retention run", func() {
+		cluster := &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cluster-example",
+			},
+			Status: apiv1.ClusterStatus{
+				LastRetentionRun: &apiv1.RetentionRunStatus{
+					Time:           "2023-02-16T22:44:56Z",
+					DeletedBackups: 5,
+				},
+			},
+		}
+		exporter.getCluster = func() (*apiv1.Cluster, error) {
+			return cluster, nil
+		}
+
+		getDeletedBackups := func() float64 {
+			registry := prometheus.NewRegistry()
+			registry.MustRegister(exporter.Metrics.BackupRetentionDeleted)
+			metrics, err := registry.Gather()
+			Expect(err).ToNot(HaveOccurred())
+			return metrics[0].GetMetric()[0].GetCounter().GetValue()
+		}
+
+		// The run found at startup has already been accounted
+		exporter.collectFromPrimaryBackupRetention()
+		Expect(getDeletedBackups()).To(BeZero())
+
+		// The same run is accounted only once
+		cluster.Status.LastRetentionRun = &apiv1.RetentionRunStatus{
+			Time:           "2023-02-17T22:44:56Z",
+			DeletedBackups: 2,
+		}
+		exporter.collectFromPrimaryBackupRetention()
+		exporter.collectFromPrimaryBackupRetention()
+		Expect(getDeletedBackups()).To(BeEquivalentTo(2))
+	})
+
 	It("correctly parses the number of sync replicas when quorum-based", func() {
 		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		Expect(err).ToNot(HaveOccurred())
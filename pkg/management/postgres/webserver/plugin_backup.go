@@ -149,6 +149,7 @@ func (b *PluginBackupCommand) invokeStart(ctx context.Context) {
 	if !response.StoppedAt.IsZero() {
 		b.Backup.Status.StoppedAt = ptr.To(metav1.NewTime(response.StoppedAt))
 	}
+	b.Backup.Status.SetDuration()
 
 	if err := postgres.PatchBackupStatusAndRetry(ctx, b.Client, b.Backup); err != nil {
 		contextLogger.Error(err, "Can't set backup status as completed")
@@ -64,6 +64,17 @@ type StopBackupRequest struct {
 	BackupName string `json:"backupName"`
 }
 
+// ReloadResult is the outcome of the configuration reload of an instance
+type ReloadResult struct {
+	// PendingRestart is true when some of the changed parameters
+	// will only be applied after a restart of the instance
+	PendingRestart bool `json:"pendingRestart"`
+
+	// PendingRestartSettings are the names of the parameters waiting
+	// for a restart of the instance
+	PendingRestartSettings []string `json:"pendingRestartSettings,omitempty"`
+}
+
 // NewStopBackupRequest constructor
 func NewStopBackupRequest(backupName string) *StopBackupRequest {
 	return &StopBackupRequest{BackupName: backupName}
@@ -92,6 +103,7 @@ func NewRemoteWebServer(
 	serveMux.HandleFunc(url.PathReady, endpoints.isServerReady)
 	serveMux.HandleFunc(url.PathPgStatus, endpoints.pgStatus)
 	serveMux.HandleFunc(url.PathPgArchivePartial, endpoints.pgArchivePartial)
+	serveMux.HandleFunc(url.PathPgReload, endpoints.pgReload)
 	serveMux.HandleFunc(url.PathPGControlData, endpoints.pgControlData)
 	serveMux.HandleFunc(url.PathUpdate, endpoints.updateInstanceManager(cancelFunc, exitedConditions))
 
@@ -349,6 +361,42 @@ func (ws *remoteWebserverEndpoints) backup(w http.ResponseWriter, req *http.Requ
 	}
 }
 
+// pgReload reloads the configuration of the instance, waiting for PostgreSQL
+// to apply it, and reports which of the changed parameters require a restart
+func (ws *remoteWebserverEndpoints) pgReload(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := ws.instance.Reload(req.Context()); err != nil {
+		sendUnprocessableEntityJSONResponse(w, "RELOAD_FAILED", err.Error())
+		return
+	}
+
+	if _, err := ws.instance.WaitForConfigReload(req.Context()); err != nil {
+		sendUnprocessableEntityJSONResponse(w, "RELOAD_NOT_APPLIED", err.Error())
+		return
+	}
+
+	superUserDB, err := ws.instance.GetSuperUserDB()
+	if err != nil {
+		sendUnprocessableEntityJSONResponse(w, "CANNOT_INITIALIZE_CONNECTION", err.Error())
+		return
+	}
+
+	pendingRestartSettings, err := ws.instance.GetPendingRestartSettings(superUserDB)
+	if err != nil {
+		sendUnprocessableEntityJSONResponse(w, "CANNOT_GET_PENDING_RESTART", err.Error())
+		return
+	}
+
+	sendJSONResponseWithData(w, 200, ReloadResult{
+		PendingRestart:         len(pendingRestartSettings) > 0,
+		PendingRestartSettings: pendingRestartSettings,
+	})
+}
+
 func (ws *remoteWebserverEndpoints) pgArchivePartial(w http.ResponseWriter, req *http.Request) {
 	if !ws.instance.IsFenced() {
 		sendBadRequestJSONResponse(w, "NOT_FENCED", "")
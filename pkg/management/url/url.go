@@ -55,6 +55,9 @@ const (
 	// PathPgArchivePartial is the URL path to interact with the partial wal archive
 	PathPgArchivePartial string = "/pg/archive/partial"
 
+	// PathPgReload is the URL path to reload the PostgreSQL configuration
+	PathPgReload string = "/pg/reload"
+
 	// PathMetrics is the URL path for Metrics
 	PathMetrics string = "/metrics"
 
@@ -21,6 +21,7 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"strings"
 	"text/template"
@@ -596,7 +597,7 @@ func (p *PgConfiguration) AddSharedPreloadLibrary(newLibrary string) {
 	if len(newLibrary) == 0 {
 		return
 	}
-	if strings.Contains(p.configs[SharedPreloadLibraries], newLibrary) {
+	if slices.Contains(strings.Split(p.configs[SharedPreloadLibraries], ","), newLibrary) {
 		return
 	}
 	if libraries, ok := p.configs[SharedPreloadLibraries]; ok &&
@@ -733,14 +734,52 @@ func setDefaultConfigurations(info ConfigurationInfo, configuration *PgConfigura
 	}
 }
 
-// setManagedSharedPreloadLibraries sets all additional preloaded libraries
-func setManagedSharedPreloadLibraries(info ConfigurationInfo, configuration *PgConfiguration) {
+// GetRequiredSharedPreloadLibraries returns the shared preload libraries
+// needed by the managed extensions enabled in the passed user settings
+func GetRequiredSharedPreloadLibraries(userSettings map[string]string) []string {
+	var libraries []string
 	for _, extension := range ManagedExtensions {
-		if extension.IsUsed(info.UserSettings) {
-			for _, library := range extension.SharedPreloadLibraries {
-				configuration.AddSharedPreloadLibrary(library)
-			}
+		if extension.IsUsed(userSettings) {
+			libraries = append(libraries, extension.SharedPreloadLibraries...)
+		}
+	}
+	return libraries
+}
+
+// MergeSharedPreloadLibraries merges the shared preload libraries required by
+// the operator into the ones requested by the user. The result has all the
+// user provided libraries, in the same order, followed by the required ones
+// missing from the user list. Empty entries are removed, as well as any
+// duplicate, keeping its first occurrence. The required libraries which have
+// been added to the user list are returned too.
+func MergeSharedPreloadLibraries(userLibraries, requiredLibraries []string) (merged []string, added []string) {
+	seen := make(map[string]bool, len(userLibraries)+len(requiredLibraries))
+	for _, library := range userLibraries {
+		library = strings.TrimSpace(library)
+		if library == "" || seen[library] {
+			continue
+		}
+		seen[library] = true
+		merged = append(merged, library)
+	}
+
+	for _, library := range requiredLibraries {
+		library = strings.TrimSpace(library)
+		if library == "" || seen[library] {
+			continue
 		}
+		seen[library] = true
+		merged = append(merged, library)
+		added = append(added, library)
+	}
+
+	return merged, added
+}
+
+// setManagedSharedPreloadLibraries sets all additional preloaded libraries
+func setManagedSharedPreloadLibraries(info ConfigurationInfo, configuration *PgConfiguration) {
+	for _, library := range GetRequiredSharedPreloadLibraries(info.UserSettings) {
+		configuration.AddSharedPreloadLibrary(library)
 	}
 }
 
@@ -749,19 +788,9 @@ func setManagedSharedPreloadLibraries(info ConfigurationInfo, configuration *PgC
 // by the operator, removing any duplicate and keeping the first occurrence in case of duplicates.
 // Therefore the user provided order is preserved, if an overlap (with the ones already present) happens
 func setUserSharedPreloadLibraries(info ConfigurationInfo, configuration *PgConfiguration) {
-	oldLibraries := strings.Split(configuration.GetConfig(SharedPreloadLibraries), ",")
-	dedupedLibraries := make(map[string]bool, len(oldLibraries)+len(info.AdditionalSharedPreloadLibraries))
-	var libraries []string
-	for _, library := range append(info.AdditionalSharedPreloadLibraries, oldLibraries...) {
-		// if any, delete empty string
-		if library == "" {
-			continue
-		}
-		if !dedupedLibraries[library] {
-			dedupedLibraries[library] = true
-			libraries = append(libraries, library)
-		}
-	}
+	libraries, _ := MergeSharedPreloadLibraries(
+		info.AdditionalSharedPreloadLibraries,
+		strings.Split(configuration.GetConfig(SharedPreloadLibraries), ","))
 	if len(libraries) > 0 {
 		configuration.OverwriteConfig(SharedPreloadLibraries, strings.Join(libraries, ","))
 	}
@@ -403,3 +403,56 @@ var _ = Describe("recovery_min_apply_delay", func() {
 		Expect(config.GetConfig(ParameterRecoveyMinApplyDelay)).To(Equal("3600s"))
 	})
 })
+
+var _ = Describe("shared_preload_libraries merge", func() {
+	DescribeTable("merges the required libraries into the user ones",
+		func(userLibraries, requiredLibraries, expectedMerged, expectedAdded []string) {
+			merged, added := MergeSharedPreloadLibraries(userLibraries, requiredLibraries)
+			Expect(merged).To(Equal(expectedMerged))
+			Expect(added).To(Equal(expectedAdded))
+		},
+		Entry("with no libraries",
+			nil, nil,
+			nil, nil),
+		Entry("with empty entries only",
+			[]string{"", " "}, []string{""},
+			nil, nil),
+		Entry("with user libraries only",
+			[]string{"pg_cron", "timescaledb"}, nil,
+			[]string{"pg_cron", "timescaledb"}, nil),
+		Entry("with required libraries only",
+			nil, []string{"pgaudit", "pg_stat_statements"},
+			[]string{"pgaudit", "pg_stat_statements"}, []string{"pgaudit", "pg_stat_statements"}),
+		Entry("appending the required libraries after the user ones",
+			[]string{"timescaledb", "pg_cron"}, []string{"pgaudit"},
+			[]string{"timescaledb", "pg_cron", "pgaudit"}, []string{"pgaudit"}),
+		Entry("keeping the user position of a required library",
+			[]string{"pg_stat_statements", "pg_cron"}, []string{"pgaudit", "pg_stat_statements"},
+			[]string{"pg_stat_statements", "pg_cron", "pgaudit"}, []string{"pgaudit"}),
+		Entry("removing the duplicated user libraries",
+			[]string{"pg_cron", "timescaledb", "pg_cron"}, []string{"pgaudit", "pgaudit"},
+			[]string{"pg_cron", "timescaledb", "pgaudit"}, []string{"pgaudit"}),
+		Entry("removing the empty entries and the surrounding spaces",
+			[]string{" pg_cron", "", "timescaledb "}, []string{"", "pg_cron"},
+			[]string{"pg_cron", "timescaledb"}, nil),
+		Entry("not confusing libraries sharing a prefix",
+			[]string{"pg_stat"}, []string{"pg_stat_statements"},
+			[]string{"pg_stat", "pg_stat_statements"}, []string{"pg_stat_statements"}),
+	)
+
+	It("lists the libraries required by the managed extensions in use", func() {
+		Expect(GetRequiredSharedPreloadLibraries(nil)).To(BeEmpty())
+		Expect(GetRequiredSharedPreloadLibraries(map[string]string{
+			"pgaudit.log":                   "all",
+			"pg_stat_statements.max":        "10000",
+			"auto_explain.log_min_duration": "10s",
+		})).To(Equal([]string{"pgaudit", "pg_stat_statements", "auto_explain"}))
+	})
+
+	It("adds a library sharing a prefix with an existing one", func() {
+		config := &PgConfiguration{configs: map[string]string{SharedPreloadLibraries: "pg_stat_statements"}}
+		config.AddSharedPreloadLibrary("pg_stat")
+		config.AddSharedPreloadLibrary("pg_stat_statements")
+		Expect(config.GetConfig(SharedPreloadLibraries)).To(Equal("pg_stat_statements,pg_stat"))
+	})
+})
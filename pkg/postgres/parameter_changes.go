@@ -0,0 +1,94 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"slices"
+	"strings"
+)
+
+// ParameterContextPostmaster is the pg_settings context of the parameters
+// which can only be changed by restarting the server
+const ParameterContextPostmaster = "postmaster"
+
+// ParameterChanges are the parameters changed in the PostgreSQL configuration,
+// classified depending on how PostgreSQL applies their new value
+type ParameterChanges struct {
+	// ReloadOnly are the parameters applied by reloading the configuration
+	ReloadOnly []string `json:"reloadOnly,omitempty"`
+
+	// RestartRequired are the parameters applied only after restarting the instance
+	RestartRequired []string `json:"restartRequired,omitempty"`
+}
+
+// IsEmpty checks whether no parameter has been changed
+func (changes ParameterChanges) IsEmpty() bool {
+	return len(changes.ReloadOnly) == 0 && len(changes.RestartRequired) == 0
+}
+
+// GetChangedParameters compares two configuration files generated by the
+// operator and returns the sorted names of the parameters which have been
+// added, removed or changed
+func GetChangedParameters(oldConfiguration, newConfiguration string) []string {
+	oldParameters := parseConfigurationFile(oldConfiguration)
+	newParameters := parseConfigurationFile(newConfiguration)
+
+	var changed []string
+	for name, value := range newParameters {
+		if oldValue, ok := oldParameters[name]; !ok || oldValue != value {
+			changed = append(changed, name)
+		}
+	}
+	for name := range oldParameters {
+		if _, ok := newParameters[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+
+	slices.Sort(changed)
+	return changed
+}
+
+// ClassifyParameterChanges classifies the changed parameters using their
+// context, as reported by the pg_settings view. Parameters not known by
+// the server, like the ones of extensions which are not loaded, are applied
+// by a reload
+func ClassifyParameterChanges(changed []string, contexts map[string]string) ParameterChanges {
+	var result ParameterChanges
+	for _, name := range changed {
+		if contexts[name] == ParameterContextPostmaster {
+			result.RestartRequired = append(result.RestartRequired, name)
+		} else {
+			result.ReloadOnly = append(result.ReloadOnly, name)
+		}
+	}
+	return result
+}
+
+// parseConfigurationFile parses a configuration file generated by
+// CreatePostgresqlConfFile, ignoring the configuration checksum
+func parseConfigurationFile(content string) map[string]string {
+	result := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		name, value, found := strings.Cut(line, " = ")
+		if !found || name == CNPGConfigSha256 {
+			continue
+		}
+		result[strings.TrimSpace(name)] = value
+	}
+	return result
+}
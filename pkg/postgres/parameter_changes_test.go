@@ -0,0 +1,108 @@
+/*
+Copyright The CloudNativePG Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"encoding/json"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parameter changes", func() {
+	const oldConfiguration = "max_connections = '100'\n" +
+		"shared_buffers = '128MB'\n" +
+		"wal_keep_size = '512MB'\n" +
+		"work_mem = '4MB'\n" +
+		"cnpg.config_sha256 = 'abc'"
+
+	It("detects the added, removed and changed parameters", func() {
+		newConfiguration := "max_connections = '200'\n" +
+			"shared_buffers = '128MB'\n" +
+			"work_mem = '8MB'\n" +
+			"pg_stat_statements.max = '10000'\n" +
+			"cnpg.config_sha256 = 'def'"
+
+		Expect(GetChangedParameters(oldConfiguration, newConfiguration)).To(Equal([]string{
+			"max_connections",
+			"pg_stat_statements.max",
+			"wal_keep_size",
+			"work_mem",
+		}))
+	})
+
+	It("ignores the configuration checksum", func() {
+		newConfiguration := "max_connections = '100'\n" +
+			"shared_buffers = '128MB'\n" +
+			"wal_keep_size = '512MB'\n" +
+			"work_mem = '4MB'\n" +
+			"cnpg.config_sha256 = 'def'"
+
+		Expect(GetChangedParameters(oldConfiguration, newConfiguration)).To(BeEmpty())
+	})
+
+	It("considers every parameter as added when there's no previous configuration", func() {
+		Expect(GetChangedParameters("", oldConfiguration)).To(Equal([]string{
+			"max_connections",
+			"shared_buffers",
+			"wal_keep_size",
+			"work_mem",
+		}))
+	})
+
+	Context("with the pg_settings contexts fixture", func() {
+		var contexts map[string]string
+
+		BeforeEach(func() {
+			content, err := os.ReadFile("testdata/pg_settings_contexts.json")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(json.Unmarshal(content, &contexts)).To(Succeed())
+		})
+
+		It("classifies the changes depending on the parameter context", func() {
+			changes := ClassifyParameterChanges([]string{
+				"archive_timeout",
+				"log_min_duration_statement",
+				"max_connections",
+				"shared_preload_libraries",
+				"work_mem",
+			}, contexts)
+
+			Expect(changes.ReloadOnly).To(Equal([]string{
+				"archive_timeout",
+				"log_min_duration_statement",
+				"work_mem",
+			}))
+			Expect(changes.RestartRequired).To(Equal([]string{
+				"max_connections",
+				"shared_preload_libraries",
+			}))
+			Expect(changes.IsEmpty()).To(BeFalse())
+		})
+
+		It("applies the parameters unknown to the server with a reload", func() {
+			changes := ClassifyParameterChanges([]string{"pg_stat_statements.max"}, contexts)
+			Expect(changes.ReloadOnly).To(Equal([]string{"pg_stat_statements.max"}))
+			Expect(changes.RestartRequired).To(BeEmpty())
+		})
+
+		It("reports no changes when nothing changed", func() {
+			Expect(ClassifyParameterChanges(nil, contexts).IsEmpty()).To(BeTrue())
+		})
+	})
+})
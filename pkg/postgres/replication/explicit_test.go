@@ -26,6 +26,63 @@ import (
 )
 
 var _ = Describe("synchronous replica configuration with the new API", func() {
+	DescribeTable("generates the expected synchronous_standby_names",
+		func(config apiv1.SynchronousReplicaConfiguration, expected string) {
+			cluster := createFakeCluster("example")
+			cluster.Spec.PostgresConfiguration.Synchronous = &config
+			cluster.Status = apiv1.ClusterStatus{
+				CurrentPrimary: "example-1",
+				InstancesStatus: map[apiv1.PodStatus][]string{
+					apiv1.PodHealthy: {"example-1", "example-2", "example-3"},
+				},
+			}
+
+			Expect(explicitSynchronousStandbyNames(cluster)).To(Equal(expected))
+		},
+		Entry("quorum among the cluster instances",
+			apiv1.SynchronousReplicaConfiguration{
+				Method: apiv1.SynchronousReplicaConfigurationMethodAny,
+				Number: 1,
+			},
+			`ANY 1 ("example-2","example-3","example-1")`),
+		Entry("priority among the cluster instances",
+			apiv1.SynchronousReplicaConfiguration{
+				Method: apiv1.SynchronousReplicaConfigurationMethodFirst,
+				Number: 1,
+			},
+			`FIRST 1 ("example-2","example-3","example-1")`),
+		Entry("quorum including external standbys",
+			apiv1.SynchronousReplicaConfiguration{
+				Method:           apiv1.SynchronousReplicaConfigurationMethodAny,
+				Number:           2,
+				StandbyNamesPre:  []string{"dr-1"},
+				StandbyNamesPost: []string{"dr-2"},
+			},
+			`ANY 2 ("dr-1","example-2","example-3","example-1","dr-2")`),
+		Entry("priority with capped cluster instances",
+			apiv1.SynchronousReplicaConfiguration{
+				Method:                     apiv1.SynchronousReplicaConfigurationMethodFirst,
+				Number:                     2,
+				MaxStandbyNamesFromCluster: ptr.To(1),
+				StandbyNamesPost:           []string{"dr-1"},
+			},
+			`FIRST 2 ("example-2","dr-1")`),
+		Entry("quorum with standby names requiring escaping",
+			apiv1.SynchronousReplicaConfiguration{
+				Method:          apiv1.SynchronousReplicaConfigurationMethodAny,
+				Number:          1,
+				StandbyNamesPre: []string{`dr "east"`},
+			},
+			`ANY 1 ("dr ""east""","example-2","example-3","example-1")`),
+		Entry("quorum with preferred data durability",
+			apiv1.SynchronousReplicaConfiguration{
+				Method:         apiv1.SynchronousReplicaConfigurationMethodAny,
+				Number:         3,
+				DataDurability: apiv1.DataDurabilityLevelPreferred,
+			},
+			`ANY 2 ("example-2","example-3")`),
+	)
+
 	When("data durability is required", func() {
 		It("creates configuration with the ANY clause", func() {
 			cluster := createFakeCluster("example")
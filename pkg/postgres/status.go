@@ -52,6 +52,8 @@ type PostgresqlStatus struct {
 	LastArchivedWALTime string `json:"lastArchivedWALTime,omitempty"`
 	LastFailedWAL       string `json:"lastFailedWAL,omitempty"`
 	LastFailedWALTime   string `json:"lastFailedWALTime,omitempty"`
+	ArchivedCount       int64  `json:"archivedCount,omitempty"`
+	FailedCount         int64  `json:"failedCount,omitempty"`
 
 	// WAL Status
 
@@ -64,6 +66,19 @@ type PostgresqlStatus struct {
 	// SELECT timeline_id FROM pg_control_checkpoint()
 	TimeLineID int `json:"timeLineID,omitempty"`
 
+	// The timeline of the last WAL location received by a streaming standby
+	// SELECT received_tli FROM pg_stat_wal_receiver
+	ReceivedTimeLineID int `json:"receivedTimeLineID,omitempty"`
+
+	// The value of recovery_min_apply_delay as read from a standby
+	// SELECT current_setting('recovery_min_apply_delay')
+	MinApplyDelay string `json:"minApplyDelay,omitempty"`
+
+	// The time elapsed since the last transaction replayed by a standby
+	// was committed on the primary
+	// SELECT now() - pg_last_xact_replay_timestamp()
+	ReplayLag string `json:"replayLag,omitempty"`
+
 	// This field is set when there is an error while extracting the
 	// status of a Pod
 	Error error `json:"-"`
@@ -75,6 +90,18 @@ type PostgresqlStatus struct {
 	// contains the PgStatBasebackup rows content.
 	PgStatBasebackupsInfo []PgStatBasebackup `json:"pgStatBasebackupsInfo,omitempty"`
 
+	// The parameters whose new value will be applied only after
+	// a restart of the instance
+	PendingRestartSettings []string `json:"pendingRestartSettings,omitempty"`
+
+	// The classification of the parameters changed by the latest
+	// update of the configuration
+	LastParameterChanges *ParameterChanges `json:"lastParameterChanges,omitempty"`
+
+	// The outcome of the latest reload triggered by a change of
+	// the host-based authentication configuration
+	LastHBAReload *HBAReloadStatus `json:"lastHBAReload,omitempty"`
+
 	// Status of the instance manager
 	ExecutableHash             string `json:"executableHash"`
 	InstanceManagerVersion     string `json:"instanceManagerVersion"`
@@ -92,6 +119,22 @@ type PostgresqlStatus struct {
 	IsPodReady bool `json:"isPodReady"`
 }
 
+// HBAReloadStatus is the outcome of a configuration reload triggered by
+// a change of the host-based authentication rules
+type HBAReloadStatus struct {
+	// The time when PostgreSQL loaded the configuration files
+	// SELECT pg_conf_load_time()
+	ReloadTime string `json:"reloadTime,omitempty"`
+
+	// True when PostgreSQL loaded the new rules without errors
+	Succeeded bool `json:"succeeded"`
+
+	// The errors detected by PostgreSQL in the pg_hba.conf and pg_ident.conf files
+	// SELECT line_number, error FROM pg_hba_file_rules WHERE error IS NOT NULL
+	// SELECT line_number, error FROM pg_ident_file_mappings WHERE error IS NOT NULL
+	Errors []string `json:"errors,omitempty"`
+}
+
 // PgStatReplication contains the replications of replicas as reported by the primary instance
 type PgStatReplication struct {
 	ApplicationName string    `json:"applicationName,omitempty"`
@@ -316,6 +359,45 @@ func (list PostgresqlStatusList) AreWalReceiversDown(primaryName string) bool {
 	return true
 }
 
+// GetReplicationLag gets the replication lag, in bytes, of the replicas
+// reporting their status, measured as the difference between the current
+// WAL location of the primary and the last WAL location replayed by each
+// replica. Returns nil if the primary is not reporting its status
+func (list PostgresqlStatusList) GetReplicationLag() map[string]int64 {
+	var primaryLSN int64
+	primaryFound := false
+	for _, item := range list.Items {
+		if item.IsPrimary && item.Error == nil && item.Pod != nil {
+			lsn, err := item.CurrentLsn.Parse()
+			if err != nil {
+				return nil
+			}
+			primaryLSN = lsn
+			primaryFound = true
+			break
+		}
+	}
+	if !primaryFound {
+		return nil
+	}
+
+	result := make(map[string]int64)
+	for _, item := range list.Items {
+		if item.IsPrimary || item.Error != nil || item.Pod == nil || item.ReplayLsn == "" {
+			continue
+		}
+
+		replayLSN, err := item.ReplayLsn.Parse()
+		if err != nil {
+			continue
+		}
+
+		result[item.Pod.Name] = max(primaryLSN-replayLSN, 0)
+	}
+
+	return result
+}
+
 // IsPodReporting if a pod is ready
 func (list PostgresqlStatusList) IsPodReporting(podname string) bool {
 	for _, item := range list.Items {
@@ -234,3 +234,33 @@ var _ = Describe("PostgreSQL status real", func() {
 		})
 	})
 })
+
+var _ = Describe("Replication lag", func() {
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	It("computes the lag of the replicas from the primary current LSN", func() {
+		list := PostgresqlStatusList{
+			Items: []PostgresqlStatus{
+				{Pod: newPod("cluster-1"), IsPrimary: true, CurrentLsn: "0/3000000"},
+				{Pod: newPod("cluster-2"), ReplayLsn: "0/1000000"},
+				{Pod: newPod("cluster-3"), ReplayLsn: "0/3000000"},
+				{Pod: newPod("cluster-4"), Error: fmt.Errorf("cannot connect to PostgreSQL")},
+			},
+		}
+		Expect(list.GetReplicationLag()).To(Equal(map[string]int64{
+			"cluster-2": 0x2000000,
+			"cluster-3": 0,
+		}))
+	})
+
+	It("returns nil when the primary is not reporting its status", func() {
+		list := PostgresqlStatusList{
+			Items: []PostgresqlStatus{
+				{Pod: newPod("cluster-2"), ReplayLsn: "0/1000000"},
+			},
+		}
+		Expect(list.GetReplicationLag()).To(BeNil())
+	})
+})
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
@@ -171,15 +172,25 @@ func (se *Reconciler) Reconcile(
 	// Step 1: backup preparation.
 	// This will set PostgreSQL in backup mode for hot snapshots, or fence the Pods for cold snapshots.
 	if len(volumeSnapshots) == 0 {
+		if err := ensureBackupPVCsAreComplete(cluster, pvcs); err != nil {
+			return nil, err
+		}
+
 		if res, err := exec.prepare(ctx, cluster, backup, targetPod); res != nil || err != nil {
 			return res, err
 		}
 	}
 
 	// Step 2: create snapshot
-	if len(volumeSnapshots) == 0 {
-		// we execute the snapshots only if we don't find any
-		if err := se.createSnapshotPVCGroupStep(ctx, cluster, pvcs, backup, targetPod); err != nil {
+	// The snapshots of every PVC of the instance are taken together. A previous
+	// reconciliation loop could have been interrupted after creating only part
+	// of them, so we create the missing ones
+	pvcsToSnapshot, err := getPVCsWithoutSnapshot(pvcs, volumeSnapshots, backup.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvcsToSnapshot) > 0 {
+		if err := se.createSnapshotPVCGroupStep(ctx, cluster, pvcsToSnapshot, backup, targetPod); err != nil {
 			return nil, err
 		}
 
@@ -262,10 +273,12 @@ func (se *Reconciler) completeSnapshotBackupStep(
 ) (*ctrl.Result, error) {
 	contextLogger := log.FromContext(ctx)
 	backup.Status.SetAsCompleted()
+	backup.Status.SetDuration()
 	snapshots, err := getBackupVolumeSnapshots(ctx, se.cli, backup.Namespace, backup.Name)
 	if err != nil {
 		return nil, err
 	}
+	backup.Status.BackupSize = snapshots.getRestoreSize()
 
 	if err := annotateSnapshotsWithBackupData(ctx, se.cli, snapshots, &backup.Status); err != nil {
 		contextLogger.Error(err, "while enriching the snapshots's status")
@@ -382,6 +395,71 @@ func (se *Reconciler) createSnapshotPVCGroupStep(
 	return nil
 }
 
+// ensureBackupPVCsAreComplete checks that the PVCs of the target instance
+// include every volume of the cluster, that is PGDATA, the WAL volume
+// and the one of each tablespace. A set of snapshots lacking any of them
+// couldn't be used to restore the instance
+func ensureBackupPVCsAreComplete(cluster *apiv1.Cluster, pvcs []corev1.PersistentVolumeClaim) error {
+	var hasPgData, hasPgWal bool
+	tablespaces := make(map[string]bool, len(cluster.Spec.Tablespaces))
+	for _, pvc := range pvcs {
+		switch utils.PVCRole(pvc.Labels[utils.PvcRoleLabelName]) {
+		case utils.PVCRolePgData:
+			hasPgData = true
+		case utils.PVCRolePgWal:
+			hasPgWal = true
+		case utils.PVCRolePgTablespace:
+			tablespaces[pvc.Labels[utils.TablespaceNameLabelName]] = true
+		}
+	}
+
+	var missing []string
+	if !hasPgData {
+		missing = append(missing, string(utils.PVCRolePgData))
+	}
+	if cluster.ShouldCreateWalArchiveVolume() && !hasPgWal {
+		missing = append(missing, string(utils.PVCRolePgWal))
+	}
+	for _, tablespace := range cluster.Spec.Tablespaces {
+		if !tablespaces[tablespace.Name] {
+			missing = append(missing, fmt.Sprintf("%s %s", utils.PVCRolePgTablespace, tablespace.Name))
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("cannot take a complete set of volume snapshots, missing PVCs for: %s",
+			strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// getPVCsWithoutSnapshot returns the PVCs whose VolumeSnapshot for
+// the given backup has not been created yet
+func getPVCsWithoutSnapshot(
+	pvcs []corev1.PersistentVolumeClaim,
+	snapshots slice,
+	backupName string,
+) ([]corev1.PersistentVolumeClaim, error) {
+	existingSnapshots := make(map[string]bool, len(snapshots))
+	for _, snapshot := range snapshots {
+		existingSnapshots[snapshot.Name] = true
+	}
+
+	var result []corev1.PersistentVolumeClaim
+	for i := range pvcs {
+		pvcCalculator, err := persistentvolumeclaim.GetExpectedObjectCalculator(pvcs[i].GetLabels())
+		if err != nil {
+			return nil, err
+		}
+		if !existingSnapshots[pvcCalculator.GetSnapshotName(backupName)] {
+			result = append(result, pvcs[i])
+		}
+	}
+
+	return result, nil
+}
+
 // waitSnapshotToBeProvisionedStep waits for every PVC snapshot to be claimed
 func (se *Reconciler) waitSnapshotToBeProvisionedStep(
 	ctx context.Context,
@@ -351,6 +351,153 @@ var _ = Describe("Volumesnapshot reconciler", func() {
 	})
 })
 
+var _ = Describe("Volumesnapshot reconciler with tablespaces", func() {
+	const (
+		namespace   = "test-namespace"
+		clusterName = "cluster-example"
+		backupName  = "backup-example"
+	)
+	var (
+		cluster   *apiv1.Cluster
+		targetPod *v1.Pod
+		pvcs      []v1.PersistentVolumeClaim
+		backup    *apiv1.Backup
+	)
+
+	newPVC := func(name string, role utils.PVCRole, tablespaceName string) v1.PersistentVolumeClaim {
+		labels := map[string]string{utils.PvcRoleLabelName: string(role)}
+		if tablespaceName != "" {
+			labels[utils.TablespaceNameLabelName] = tablespaceName
+		}
+		return v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		}
+	}
+
+	newSnapshot := func(name string) *storagesnapshotv1.VolumeSnapshot {
+		return &storagesnapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        name,
+				Annotations: map[string]string{},
+				Labels:      map[string]string{utils.BackupNameLabelName: backupName},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        clusterName,
+				Annotations: map[string]string{},
+			},
+			Spec: apiv1.ClusterSpec{
+				WalStorage:  &apiv1.StorageConfiguration{Size: "1Gi"},
+				Tablespaces: []apiv1.TablespaceConfiguration{{Name: "tbs1"}, {Name: "tbs_2"}},
+				Backup: &apiv1.BackupConfiguration{
+					VolumeSnapshot: &apiv1.VolumeSnapshotConfiguration{
+						ClassName:           "csi-hostpath-snapclass",
+						TablespaceClassName: map[string]string{"tbs1": "csi-tbs-snapclass"},
+						Online:              ptr.To(false),
+					},
+				},
+			},
+		}
+		targetPod = &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: clusterName + "-2"},
+		}
+		pvcs = []v1.PersistentVolumeClaim{
+			newPVC(clusterName+"-2", utils.PVCRolePgData, ""),
+			newPVC(clusterName+"-2-wal", utils.PVCRolePgWal, ""),
+			newPVC(clusterName+"-2-tbs-tbs1", utils.PVCRolePgTablespace, "tbs1"),
+			newPVC(clusterName+"-2-tbs-tbs-2", utils.PVCRolePgTablespace, "tbs_2"),
+		}
+		backup = &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: backupName},
+		}
+	})
+
+	It("snapshots every tablespace together with the data and the WALs", func(ctx SpecContext) {
+		mockClient := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(backup, cluster, targetPod).
+			Build()
+		executor := NewReconcilerBuilder(mockClient, record.NewFakeRecorder(10)).Build()
+
+		result, err := executor.Reconcile(ctx, cluster, backup, targetPod, pvcs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+
+		var snapshotList storagesnapshotv1.VolumeSnapshotList
+		Expect(mockClient.List(ctx, &snapshotList)).To(Succeed())
+		classNames := make(map[string]string, len(snapshotList.Items))
+		for _, snapshot := range snapshotList.Items {
+			classNames[snapshot.Name] = ptr.Deref(snapshot.Spec.VolumeSnapshotClassName, "")
+		}
+		Expect(classNames).To(Equal(map[string]string{
+			backupName:                "csi-hostpath-snapclass",
+			backupName + "-wal":       "csi-hostpath-snapclass",
+			backupName + "-tbs-tbs1":  "csi-tbs-snapclass",
+			backupName + "-tbs-tbs-2": "csi-hostpath-snapclass",
+		}))
+
+		var status apiv1.BackupSnapshotStatus
+		status.SetSnapshotElements(snapshotList.Items)
+		Expect(status.Elements).To(ConsistOf(
+			apiv1.BackupSnapshotElementStatus{Name: backupName, Type: string(utils.PVCRolePgData)},
+			apiv1.BackupSnapshotElementStatus{Name: backupName + "-wal", Type: string(utils.PVCRolePgWal)},
+			apiv1.BackupSnapshotElementStatus{
+				Name:           backupName + "-tbs-tbs1",
+				Type:           string(utils.PVCRolePgTablespace),
+				TablespaceName: "tbs1",
+			},
+			apiv1.BackupSnapshotElementStatus{
+				Name:           backupName + "-tbs-tbs-2",
+				Type:           string(utils.PVCRolePgTablespace),
+				TablespaceName: "tbs_2",
+			},
+		))
+	})
+
+	It("completes a partially created set of snapshots", func(ctx SpecContext) {
+		mockClient := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(backup, cluster, targetPod, newSnapshot(backupName), newSnapshot(backupName+"-wal")).
+			Build()
+		executor := NewReconcilerBuilder(mockClient, record.NewFakeRecorder(10)).Build()
+
+		result, err := executor.Reconcile(ctx, cluster, backup, targetPod, pvcs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeNil())
+
+		var snapshotList storagesnapshotv1.VolumeSnapshotList
+		Expect(mockClient.List(ctx, &snapshotList)).To(Succeed())
+		Expect(snapshotList.Items).To(HaveLen(4))
+	})
+
+	It("refuses to start the backup when a tablespace PVC is missing", func(ctx SpecContext) {
+		mockClient := fake.NewClientBuilder().
+			WithScheme(scheme.BuildWithAllKnownScheme()).
+			WithObjects(backup, cluster, targetPod).
+			Build()
+		executor := NewReconcilerBuilder(mockClient, record.NewFakeRecorder(10)).Build()
+
+		_, err := executor.Reconcile(ctx, cluster, backup, targetPod, pvcs[:3])
+		Expect(err).To(MatchError(ContainSubstring("missing PVCs for: PG_TABLESPACE tbs_2")))
+
+		var latestCluster apiv1.Cluster
+		Expect(mockClient.Get(ctx, k8client.ObjectKeyFromObject(cluster), &latestCluster)).To(Succeed())
+		data, err := utils.GetFencedInstances(latestCluster.Annotations)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data.Len()).To(Equal(0))
+
+		var snapshotList storagesnapshotv1.VolumeSnapshotList
+		Expect(mockClient.List(ctx, &snapshotList)).To(Succeed())
+		Expect(snapshotList.Items).To(BeEmpty())
+	})
+})
+
 var _ = Describe("transferLabelsToAnnotations", func() {
 	const (
 		exampleValueOne = "value1"
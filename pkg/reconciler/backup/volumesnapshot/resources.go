@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	storagesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
@@ -106,6 +107,22 @@ func (s slice) getControldata() (string, error) {
 	return "", fmt.Errorf("could not retrieve pg_controldata from any snapshot")
 }
 
+// getRestoreSize returns the sum of the restore sizes of the VolumeSnapshots,
+// or nil when none of them has reported it yet
+func (s slice) getRestoreSize() *resource.Quantity {
+	var total *resource.Quantity
+	for _, volumeSnapshot := range s {
+		if volumeSnapshot.Status == nil || volumeSnapshot.Status.RestoreSize == nil {
+			continue
+		}
+		if total == nil {
+			total = resource.NewQuantity(0, resource.BinarySI)
+		}
+		total.Add(*volumeSnapshot.Status.RestoreSize)
+	}
+	return total
+}
+
 // getBackupVolumeSnapshots extracts the list of volume snapshots related
 // to a backup name
 func getBackupVolumeSnapshots(
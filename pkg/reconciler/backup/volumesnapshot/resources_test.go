@@ -20,6 +20,7 @@ import (
 	"errors"
 
 	storagesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
@@ -193,3 +194,32 @@ var _ = Describe("parseVolumeSnapshotInfo", func() {
 		})
 	})
 })
+
+var _ = Describe("getRestoreSize", func() {
+	It("should sum the restore size of the snapshots", func() {
+		snapshots := slice{
+			{
+				Status: &storagesnapshotv1.VolumeSnapshotStatus{
+					RestoreSize: ptr.To(resource.MustParse("1Gi")),
+				},
+			},
+			{},
+			{
+				Status: &storagesnapshotv1.VolumeSnapshotStatus{
+					RestoreSize: ptr.To(resource.MustParse("512Mi")),
+				},
+			},
+		}
+		size := snapshots.getRestoreSize()
+		Expect(size).ToNot(BeNil())
+		Expect(size.Value()).To(BeEquivalentTo(1536 * 1024 * 1024))
+	})
+
+	It("should return nil when no snapshot reported its size", func() {
+		snapshots := slice{
+			{},
+			{Status: &storagesnapshotv1.VolumeSnapshotStatus{}},
+		}
+		Expect(snapshots.getRestoreSize()).To(BeNil())
+	})
+})
@@ -19,6 +19,7 @@ package instance
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/cloudnative-pg/machinery/pkg/log"
 	corev1 "k8s.io/api/core/v1"
@@ -26,6 +27,7 @@ import (
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 )
@@ -36,8 +38,10 @@ func ReconcileMetadata(
 	cli client.Client,
 	cluster *apiv1.Cluster,
 	instances []corev1.Pod,
+	instancesStatus postgres.PostgresqlStatusList,
 ) error {
 	contextLogger := log.FromContext(ctx)
+	replicationLag := instancesStatus.GetReplicationLag()
 
 	for idx := range instances {
 		origInstance := instances[idx].DeepCopy()
@@ -55,6 +59,9 @@ func ReconcileMetadata(
 		// Update any modified/new annotations coming from the cluster resource
 		modified = updateClusterAnnotations(ctx, cluster, instance) || modified
 
+		// Update the replication lag labels used by the "ro-fresh" services
+		modified = updateReplicationLagLabels(cluster, instance, replicationLag) || modified
+
 		if !modified {
 			continue
 		}
@@ -237,3 +244,65 @@ func updateOperatorLabels(
 
 	return modified
 }
+
+// updateReplicationLagLabels keeps the replication lag labels of the replicas
+// up to date, and removes them from the primary. When the lag of a replica
+// is unknown, the replica is not considered fresh and its lag label is removed,
+// so that the "ro-fresh" services don't route reads to it.
+//
+// Returns true if the instance needed updating
+func updateReplicationLagLabels(
+	cluster *apiv1.Cluster,
+	instance *corev1.Pod,
+	replicationLag map[string]int64,
+) bool {
+	if instance.Name == cluster.Status.CurrentPrimary {
+		_, hasLagLabel := instance.Labels[utils.ReplicationLagLabelName]
+		_, hasFreshLabel := instance.Labels[utils.FreshReplicaLabelName]
+		delete(instance.Labels, utils.ReplicationLagLabelName)
+		delete(instance.Labels, utils.FreshReplicaLabelName)
+		return hasLagLabel || hasFreshLabel
+	}
+
+	if instance.Labels == nil {
+		instance.Labels = make(map[string]string)
+	}
+
+	lag, ok := replicationLag[instance.Name]
+	if !ok {
+		_, hasLagLabel := instance.Labels[utils.ReplicationLagLabelName]
+		isFresh := instance.Labels[utils.FreshReplicaLabelName] != "false"
+		delete(instance.Labels, utils.ReplicationLagLabelName)
+		instance.Labels[utils.FreshReplicaLabelName] = "false"
+		return hasLagLabel || isFresh
+	}
+
+	lagValue := getReplicationLagLabelValue(lag)
+	freshValue := strconv.FormatBool(lag <= cluster.GetFreshReplicaMaxLag())
+	if instance.Labels[utils.ReplicationLagLabelName] == lagValue &&
+		instance.Labels[utils.FreshReplicaLabelName] == freshValue {
+		return false
+	}
+
+	instance.Labels[utils.ReplicationLagLabelName] = lagValue
+	instance.Labels[utils.FreshReplicaLabelName] = freshValue
+	return true
+}
+
+// getReplicationLagLabelValue rounds the replication lag down to a power
+// of two MiB, to avoid updating the Pods every time the lag changes
+func getReplicationLagLabelValue(lag int64) string {
+	const mebibyte = 1024 * 1024
+
+	lagMiB := lag / mebibyte
+	if lagMiB == 0 {
+		return "0"
+	}
+
+	bucket := int64(1)
+	for bucket*2 <= lagMiB {
+		bucket *= 2
+	}
+
+	return fmt.Sprintf("%dMi", bucket)
+}
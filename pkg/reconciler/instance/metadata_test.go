@@ -25,6 +25,7 @@ import (
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/scheme"
+	"github.com/cloudnative-pg/cloudnative-pg/pkg/postgres"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/specs"
 	"github.com/cloudnative-pg/cloudnative-pg/pkg/utils"
 
@@ -482,7 +483,7 @@ var _ = Describe("metadata reconciliation test", func() {
 				WithObjects(&instances[0], &instances[1]).
 				Build()
 
-			err := ReconcileMetadata(context.Background(), cli, cluster, instances)
+			err := ReconcileMetadata(context.Background(), cli, cluster, instances, postgres.PostgresqlStatusList{})
 			Expect(err).ToNot(HaveOccurred())
 
 			var updatedInstanceList corev1.PodList
@@ -568,3 +569,62 @@ var _ = Describe("metadata update functions", func() {
 		})
 	})
 })
+
+var _ = Describe("replication lag labels", func() {
+	var cluster *apiv1.Cluster
+
+	BeforeEach(func() {
+		cluster = &apiv1.Cluster{
+			Status: apiv1.ClusterStatus{CurrentPrimary: "cluster-1"},
+		}
+	})
+
+	It("sets the labels on the replicas", func() {
+		instance := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "cluster-2"}}
+		Expect(updateReplicationLagLabels(cluster, instance, map[string]int64{"cluster-2": 5 * 1024 * 1024})).
+			To(BeTrue())
+		Expect(instance.Labels).To(HaveKeyWithValue(utils.ReplicationLagLabelName, "4Mi"))
+		Expect(instance.Labels).To(HaveKeyWithValue(utils.FreshReplicaLabelName, "true"))
+
+		Expect(updateReplicationLagLabels(cluster, instance, map[string]int64{"cluster-2": 6 * 1024 * 1024})).
+			To(BeFalse())
+
+		Expect(updateReplicationLagLabels(cluster, instance, map[string]int64{"cluster-2": 40 * 1024 * 1024})).
+			To(BeTrue())
+		Expect(instance.Labels).To(HaveKeyWithValue(utils.ReplicationLagLabelName, "32Mi"))
+		Expect(instance.Labels).To(HaveKeyWithValue(utils.FreshReplicaLabelName, "false"))
+	})
+
+	It("marks the replica as not fresh when the lag is unknown", func() {
+		instance := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:   "cluster-2",
+			Labels: map[string]string{utils.ReplicationLagLabelName: "0", utils.FreshReplicaLabelName: "true"},
+		}}
+		Expect(updateReplicationLagLabels(cluster, instance, nil)).To(BeTrue())
+		Expect(instance.Labels).ToNot(HaveKey(utils.ReplicationLagLabelName))
+		Expect(instance.Labels).To(HaveKeyWithValue(utils.FreshReplicaLabelName, "false"))
+
+		Expect(updateReplicationLagLabels(cluster, instance, map[string]int64{"cluster-3": 0})).To(BeFalse())
+	})
+
+	It("removes the labels from the primary", func() {
+		instance := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:   "cluster-1",
+			Labels: map[string]string{utils.ReplicationLagLabelName: "0", utils.FreshReplicaLabelName: "true"},
+		}}
+		Expect(updateReplicationLagLabels(cluster, instance, nil)).To(BeTrue())
+		Expect(instance.Labels).To(BeEmpty())
+		Expect(updateReplicationLagLabels(cluster, instance, nil)).To(BeFalse())
+	})
+
+	DescribeTable("rounds the lag down to a power of two MiB",
+		func(lag int64, expected string) {
+			Expect(getReplicationLagLabelValue(lag)).To(Equal(expected))
+		},
+		Entry("no lag", int64(0), "0"),
+		Entry("less than a MiB", int64(1024*1024-1), "0"),
+		Entry("one MiB", int64(1024*1024), "1Mi"),
+		Entry("three MiB", int64(3*1024*1024), "2Mi"),
+		Entry("one GiB", int64(1024*1024*1024), "1024Mi"),
+	)
+})
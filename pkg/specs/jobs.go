@@ -24,6 +24,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
 	"github.com/cloudnative-pg/cloudnative-pg/internal/configuration"
@@ -241,6 +242,43 @@ func addBarmanEndpointCAToJobFromCluster(cluster apiv1.Cluster, backup *apiv1.Ba
 	}
 }
 
+// GetBackupVerificationJobName returns the name of the job
+// verifying the base backups of a cluster
+func GetBackupVerificationJobName(clusterName string) string {
+	return jobRoleBackupVerification.getJobName(clusterName)
+}
+
+// CreateBackupVerificationJob creates a job restoring the passed backup in
+// a throwaway Pod, verifying that it can be recovered. The job uses
+// ephemeral volumes instead of the PVCs of an instance and is never retried
+func CreateBackupVerificationJob(cluster apiv1.Cluster, backup *apiv1.Backup) *batchv1.Job {
+	jobName := GetBackupVerificationJobName(cluster.Name)
+	initCommand := []string{
+		"/controller/manager",
+		"instance",
+		"verify-backup",
+		"--backup-name",
+		backup.Name,
+	}
+
+	job := createJob(cluster, jobName, jobName, jobRoleBackupVerification, initCommand)
+	job.Labels[utils.JobRoleLabelName] = string(jobRoleBackupVerification)
+	job.Spec.BackoffLimit = ptr.To[int32](0)
+
+	for idx := range job.Spec.Template.Spec.Volumes {
+		volume := &job.Spec.Template.Spec.Volumes[idx]
+		if volume.PersistentVolumeClaim != nil {
+			volume.VolumeSource = corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+		}
+	}
+
+	if backup.Status.EndpointCA != nil && backup.Status.EndpointCA.Name != "" && backup.Status.EndpointCA.Key != "" {
+		AddBarmanEndpointCAToPodSpec(&job.Spec.Template.Spec, backup.Status.EndpointCA, backup.Status.BarmanCredentials)
+	}
+
+	return job
+}
+
 // CreatePrimaryJobViaPgBaseBackup creates a new primary instance in a Pod
 func CreatePrimaryJobViaPgBaseBackup(cluster apiv1.Cluster, nodeSerial int) *batchv1.Job {
 	initCommand := []string{
@@ -303,6 +341,8 @@ const (
 	jobRoleFullRecovery     jobRole = "full-recovery"
 	jobRoleJoin             jobRole = "join"
 	jobRoleSnapshotRecovery jobRole = "snapshot-recovery"
+
+	jobRoleBackupVerification jobRole = "verify-backup"
 )
 
 var jobRoleList = []jobRole{jobRoleImport, jobRoleInitDB, jobRolePGBaseBackup, jobRoleFullRecovery, jobRoleJoin}
@@ -325,8 +365,18 @@ func GetPossibleJobNames(instanceName string) []string {
 // The role should describe the purpose of the executed job
 func createPrimaryJob(cluster apiv1.Cluster, nodeSerial int, role jobRole, initCommand []string) *batchv1.Job {
 	instanceName := GetInstanceName(cluster.Name, nodeSerial)
-	jobName := role.getJobName(instanceName)
+	return createJob(cluster, instanceName, role.getJobName(instanceName), role, initCommand)
+}
 
+// createJob create a job named jobName that executes the provided command
+// using the volumes of the instance named instanceName
+func createJob(
+	cluster apiv1.Cluster,
+	instanceName string,
+	jobName string,
+	role jobRole,
+	initCommand []string,
+) *batchv1.Job {
 	envConfig := CreatePodEnvConfig(cluster, jobName)
 
 	job := &batchv1.Job{
@@ -193,3 +193,35 @@ var _ = Describe("Job created via InitDB", func() {
 		Expect(initdbFlags).Should(ContainSubstring("'--icu-rules=&A < z <<< Z'"))
 	})
 })
+
+var _ = Describe("Backup verification job", func() {
+	It("restores the backup on ephemeral volumes", func() {
+		cluster := apiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-example", Namespace: "default"},
+			Spec: apiv1.ClusterSpec{
+				WalStorage: &apiv1.StorageConfiguration{Size: "1Gi"},
+			},
+		}
+		backup := &apiv1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-example", Namespace: "default"},
+			Status: apiv1.BackupStatus{
+				BarmanCredentials: apiv1.BarmanCredentials{AWS: &apiv1.S3Credentials{}},
+				EndpointCA: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "minio-ca"},
+					Key:                  "ca.crt",
+				},
+			},
+		}
+
+		job := CreateBackupVerificationJob(cluster, backup)
+		Expect(job.Name).To(Equal("cluster-example-verify-backup"))
+		Expect(*job.Spec.BackoffLimit).To(BeZero())
+		Expect(job.Spec.Template.Spec.Containers[0].Command).To(
+			ContainElements("verify-backup", "--backup-name", "backup-example"))
+
+		for _, volume := range job.Spec.Template.Spec.Volumes {
+			Expect(volume.PersistentVolumeClaim).To(BeNil(), "volume %s uses a PVC", volume.Name)
+		}
+		Expect(job.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", "barman-endpoint-ca")))
+	})
+})
@@ -258,6 +258,12 @@ func getInvolvedConfigMapNames(cluster apiv1.Cluster) []string {
 		}
 	}
 
+	// The instance manager includes the content of this ConfigMap
+	// in the PostgreSQL configuration
+	if includeConfigMap := cluster.Spec.PostgresConfiguration.IncludeConfigMap; includeConfigMap != nil {
+		involvedConfigMapNames = append(involvedConfigMapNames, includeConfigMap.Name)
+	}
+
 	return cleanupResourceList(involvedConfigMapNames)
 }
 
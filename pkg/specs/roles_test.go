@@ -195,6 +195,17 @@ var _ = Describe("Roles", func() {
 			"testPassword",
 		))
 	})
+
+	It("should contain the ConfigMap included in the PostgreSQL configuration", func() {
+		clusterWithInclude := cluster.DeepCopy()
+		clusterWithInclude.Spec.PostgresConfiguration.IncludeConfigMap = &apiv1.ConfigMapKeySelector{
+			LocalObjectReference: apiv1.LocalObjectReference{Name: "testIncludeConfigMap"},
+			Key:                  "custom.conf",
+		}
+		serviceAccount := CreateRole(*clusterWithInclude, nil)
+		Expect(serviceAccount.Rules[0].ResourceNames).To(ConsistOf(
+			"thisTest", "testConfigMapKeySelector", "testIncludeConfigMap"))
+	})
 })
 
 var _ = Describe("Secrets", func() {
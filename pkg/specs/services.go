@@ -191,6 +191,10 @@ func buildDefaultService(cluster apiv1.Cluster, serviceConf apiv1.ManagedService
 		return CreateClusterReadWriteService(cluster), nil
 	case apiv1.ServiceSelectorTypeR:
 		return CreateClusterReadService(cluster), nil
+	case apiv1.ServiceSelectorTypeROFresh:
+		service := CreateClusterReadOnlyService(cluster)
+		service.Spec.Selector[utils.FreshReplicaLabelName] = "true"
+		return service, nil
 	default:
 		return nil, fmt.Errorf("unknown service type: %s", serviceConf.SelectorType)
 	}
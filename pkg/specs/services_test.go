@@ -177,5 +177,17 @@ var _ = Describe("BuildManagedServices", func() {
 			Expect(services).To(HaveLen(1))
 			Expect(services[0].Spec.Ports[0].NodePort).To(Equal(int32(5533)))
 		})
+
+		It("should select the fresh replicas for the ro-fresh services", func() {
+			cluster.Spec.Managed.Services.Additional[0].SelectorType = apiv1.ServiceSelectorTypeROFresh
+			services, err := BuildManagedServices(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(services).To(HaveLen(1))
+			Expect(services[0].Spec.Selector).To(Equal(map[string]string{
+				utils.ClusterLabelName:             cluster.Name,
+				utils.ClusterInstanceRoleLabelName: ClusterRoleLabelReplica,
+				utils.FreshReplicaLabelName:        "true",
+			}))
+		})
 	})
 })
@@ -18,6 +18,7 @@ package utils
 
 import (
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -40,4 +41,17 @@ var _ = Describe("Job conditions", func() {
 		Expect(JobHasOneCompletion(nonCompleteJob)).To(BeFalse())
 		Expect(JobHasOneCompletion(completeJob)).To(BeTrue())
 	})
+
+	It("detects if a certain job has failed", func() {
+		failedJob := batchv1.Job{
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{
+					{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+		Expect(JobHasFailed(nonCompleteJob)).To(BeFalse())
+		Expect(JobHasFailed(completeJob)).To(BeFalse())
+		Expect(JobHasFailed(failedJob)).To(BeTrue())
+	})
 })
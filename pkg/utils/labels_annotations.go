@@ -63,6 +63,14 @@ const (
 	// ClusterInstanceRoleLabelName is the name of label applied to instances to mark primary/replica
 	ClusterInstanceRoleLabelName = MetadataNamespace + "/instanceRole"
 
+	// ReplicationLagLabelName is the name of the label applied to replicas
+	// containing their replication lag, rounded down to a power of two MiB
+	ReplicationLagLabelName = MetadataNamespace + "/replicationLag"
+
+	// FreshReplicaLabelName is the name of the label applied to replicas to
+	// tell if their replication lag is within the maximum lag of the fresh replicas
+	FreshReplicaLabelName = MetadataNamespace + "/freshReplica"
+
 	// ImmediateBackupLabelName is the name of the label applied to backups to tell if the first scheduled backup is
 	// taken immediately or not
 	ImmediateBackupLabelName = MetadataNamespace + "/immediateBackup"
@@ -145,9 +153,60 @@ const (
 	// PgControldataAnnotationName is the name of the annotation containing the pg_controldata output of the cluster
 	PgControldataAnnotationName = MetadataNamespace + "/pgControldata"
 
+	// FailoverAnnotationName is the name of the annotation that, when set to
+	// "disabled" on a cluster, pauses the automatic failover
+	FailoverAnnotationName = MetadataNamespace + "/failover"
+
 	// SkipWalArchiving is the name of the annotation which turns off WAL archiving
 	SkipWalArchiving = MetadataNamespace + "/skipWalArchiving"
 
+	// AllowInsecureReplicaSourceAnnotationName is the name of the annotation
+	// that, when set to "enabled" on a replica cluster, allows it to stream
+	// from an external cluster without requiring an encrypted connection
+	AllowInsecureReplicaSourceAnnotationName = MetadataNamespace + "/allowInsecureReplicaSource"
+
+	// AllowTrustAuthenticationAnnotationName is the name of the annotation
+	// that, when set to "enabled", allows the typed HBA rules of a cluster
+	// to use the trust authentication method for non-local connections
+	AllowTrustAuthenticationAnnotationName = MetadataNamespace + "/allowTrustAuthentication"
+
+	// SkipRolloutAnnotationName is the name of the annotation that, when set
+	// to "enabled" on an instance Pod, excludes it from rolling updates
+	SkipRolloutAnnotationName = MetadataNamespace + "/skipRollout"
+
+	// SkipRolloutInstancesAnnotationName is the name of the annotation containing
+	// the comma-separated list of the instances of a cluster to be excluded
+	// from rolling updates
+	SkipRolloutInstancesAnnotationName = MetadataNamespace + "/skipRolloutInstances"
+
+	// RunOnceAnnotationName is the name of the annotation that, when set on
+	// a ScheduledBackup, requests a single backup to be taken immediately
+	RunOnceAnnotationName = MetadataNamespace + "/runOnce"
+
+	// MaintenanceReasonAnnotationName is the name of the annotation containing
+	// a free-text description of the maintenance operations in progress on
+	// a cluster
+	MaintenanceReasonAnnotationName = MetadataNamespace + "/maintenanceReason"
+
+	// MaintenanceReasonTimestampAnnotationName is the name of the annotation
+	// containing the time, in RFC3339 format, when the maintenance reason
+	// was recorded
+	MaintenanceReasonTimestampAnnotationName = MetadataNamespace + "/maintenanceReasonTimestamp"
+
+	// VerifyBackupAnnotationName is the name of the annotation that, when
+	// set on a Cluster, requests the verification of its latest base backup
+	VerifyBackupAnnotationName = MetadataNamespace + "/verifyBackup"
+
+	// MinimumRecoveryWindowAnnotationName is the name of the annotation
+	// declaring the minimum point-in-time recovery window expected on a
+	// cluster, in the same format as the backup retention policy
+	MinimumRecoveryWindowAnnotationName = MetadataNamespace + "/minimumRecoveryWindow"
+
+	// WALRestoreCacheSizeAnnotationName is the name of the annotation
+	// setting the maximum size of the local cache of the WAL files
+	// restored from the object store. The cache is disabled if not set
+	WALRestoreCacheSizeAnnotationName = MetadataNamespace + "/walRestoreCacheSize"
+
 	// skipEmptyWalArchiveCheck is the name of the annotation which turns off the checks that ensure that the WAL
 	// archive is empty before writing data
 	skipEmptyWalArchiveCheck = MetadataNamespace + "/skipEmptyWalArchiveCheck"
@@ -217,6 +276,11 @@ const (
 	// latest required restart time
 	ClusterRestartAnnotationName = "kubectl.kubernetes.io/restartedAt"
 
+	// InPlaceRestartAnnotationName is the name of the annotation requesting
+	// the restart of the primary instance without a switchover. It contains
+	// the value of the restart annotation it applies to
+	InPlaceRestartAnnotationName = MetadataNamespace + "/inPlaceRestart"
+
 	// UpdateStrategyAnnotation is the name of the annotation used to indicate how to update the given resource
 	UpdateStrategyAnnotation = MetadataNamespace + "/updateStrategy"
 
@@ -434,6 +498,11 @@ func IsReconciliationDisabled(object *metav1.ObjectMeta) bool {
 	return object.Annotations[ReconciliationLoopAnnotationName] == string(annotationStatusDisabled)
 }
 
+// IsRolloutSkipped checks if the given instance Pod should be excluded from rolling updates
+func IsRolloutSkipped(object *metav1.ObjectMeta) bool {
+	return object.Annotations[SkipRolloutAnnotationName] == string(annotationStatusEnabled)
+}
+
 // IsPodSpecReconciliationDisabled checks if the pod spec reconciliation is disabled
 func IsPodSpecReconciliationDisabled(object *metav1.ObjectMeta) bool {
 	if object.Annotations == nil {
@@ -448,6 +517,23 @@ func IsEmptyWalArchiveCheckEnabled(object *metav1.ObjectMeta) bool {
 	return object.Annotations[skipEmptyWalArchiveCheck] != string(annotationStatusEnabled)
 }
 
+// IsFailoverDisabled checks if the automatic failover is paused on the given cluster
+func IsFailoverDisabled(object *metav1.ObjectMeta) bool {
+	return object.Annotations[FailoverAnnotationName] == string(annotationStatusDisabled)
+}
+
+// IsInsecureReplicaSourceAllowed returns a boolean indicating if the replica
+// cluster is allowed to stream from its source without an encrypted connection
+func IsInsecureReplicaSourceAllowed(object *metav1.ObjectMeta) bool {
+	return object.Annotations[AllowInsecureReplicaSourceAnnotationName] == string(annotationStatusEnabled)
+}
+
+// IsTrustAuthenticationAllowed returns a boolean indicating if the typed
+// HBA rules are allowed to use the trust method for non-local connections
+func IsTrustAuthenticationAllowed(object *metav1.ObjectMeta) bool {
+	return object.Annotations[AllowTrustAuthenticationAnnotationName] == string(annotationStatusEnabled)
+}
+
 // IsWalArchivingDisabled returns a boolean indicating if PostgreSQL not archive
 // WAL files
 func IsWalArchivingDisabled(object *metav1.ObjectMeta) bool {
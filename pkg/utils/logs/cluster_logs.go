@@ -19,9 +19,11 @@ package logs
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
@@ -49,6 +51,13 @@ type ClusterStreamingRequest struct {
 	Options       *corev1.PodLogOptions
 	Previous      bool `json:"previous,omitempty"`
 	FollowWaiting time.Duration
+	// PodFilter, when set, restricts the streaming to the pods it accepts
+	PodFilter func(pod *corev1.Pod) bool
+	// Level, when set, restricts the output to the JSON log records having
+	// the given level. Lines which are not JSON log records are discarded
+	Level string
+	// PrefixWithPodName prepends the name of the pod to each line
+	PrefixWithPodName bool
 	// NOTE: the Client argument may be omitted, but it is good practice to pass it
 	// Importantly, it makes the logging functions testable
 	Client kubernetes.Interface
@@ -198,6 +207,9 @@ func (csr *ClusterStreamingRequest) SingleStream(ctx context.Context, writer io.
 
 		wrappedWriter := safeWriterFrom(writer)
 		for _, pod := range podList.Items {
+			if csr.PodFilter != nil && !csr.PodFilter(&pod) {
+				continue
+			}
 			for _, container := range pod.Status.ContainerStatuses {
 				if container.State.Running != nil {
 					streamName := fmt.Sprintf("%s-%s", pod.Name, container.Name)
@@ -272,6 +284,12 @@ readLoop:
 			break readLoop
 		default:
 			data := scanner.Text()
+			if csr.Level != "" && !hasLogLevel(data, csr.Level) {
+				continue
+			}
+			if csr.PrefixWithPodName {
+				data = fmt.Sprintf("[%s] %s", podName, data)
+			}
 			if _, err := bufferedOutput.Write([]byte(data)); err != nil {
 				log.Printf("error writing log line to output: %v", err)
 			}
@@ -284,3 +302,22 @@ readLoop:
 		}
 	}
 }
+
+// hasLogLevel checks if the passed line is a JSON log record having
+// the given level. The line may be prefixed by the timestamp added
+// by Kubernetes
+func hasLogLevel(line string, level string) bool {
+	idx := strings.IndexByte(line, '{')
+	if idx < 0 {
+		return false
+	}
+
+	var record struct {
+		Level string `json:"level"`
+	}
+	if err := json.Unmarshal([]byte(line[idx:]), &record); err != nil {
+		return false
+	}
+
+	return record.Level == level
+}
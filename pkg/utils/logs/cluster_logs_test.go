@@ -175,4 +175,62 @@ var _ = Describe("Cluster logging tests", func() {
 		// the fake pod will be seen twice
 		Expect(logBuffer.String()).To(BeEquivalentTo("fake logs\nfake logs\n"))
 	})
+
+	It("should prefix each line with the pod name", func(ctx context.Context) {
+		client := fake.NewClientset(pod)
+		var logBuffer bytes.Buffer
+		streamClusterLogs := ClusterStreamingRequest{
+			Cluster:           cluster,
+			Options:           &corev1.PodLogOptions{},
+			PrefixWithPodName: true,
+			Client:            client,
+		}
+		err := streamClusterLogs.SingleStream(ctx, &logBuffer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(logBuffer.String()).To(BeEquivalentTo("[myTestCluster-1] fake logs\n"))
+	})
+
+	It("should skip the pods refused by the pod filter", func(ctx context.Context) {
+		client := fake.NewClientset(pod)
+		var logBuffer bytes.Buffer
+		streamClusterLogs := ClusterStreamingRequest{
+			Cluster: cluster,
+			Options: &corev1.PodLogOptions{},
+			PodFilter: func(*corev1.Pod) bool {
+				return false
+			},
+			Client: client,
+		}
+		err := streamClusterLogs.SingleStream(ctx, &logBuffer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(logBuffer.String()).To(BeEmpty())
+	})
+
+	It("should discard the lines which are not JSON records of the requested level", func(ctx context.Context) {
+		client := fake.NewClientset(pod)
+		var logBuffer bytes.Buffer
+		streamClusterLogs := ClusterStreamingRequest{
+			Cluster: cluster,
+			Options: &corev1.PodLogOptions{},
+			Level:   "error",
+			Client:  client,
+		}
+		err := streamClusterLogs.SingleStream(ctx, &logBuffer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(logBuffer.String()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("hasLogLevel", func() {
+	DescribeTable("matching the level of a log line",
+		func(line string, level string, expected bool) {
+			Expect(hasLogLevel(line, level)).To(Equal(expected))
+		},
+		Entry("JSON record with the requested level", `{"level":"error","msg":"failed"}`, "error", true),
+		Entry("JSON record with a different level", `{"level":"info","msg":"ok"}`, "error", false),
+		Entry("JSON record prefixed by a timestamp",
+			`2024-10-15T17:35:00.336Z {"level":"error","msg":"failed"}`, "error", true),
+		Entry("plain text line", "fake logs", "error", false),
+		Entry("malformed JSON", `{"level":"error"`, "error", false),
+	)
 })
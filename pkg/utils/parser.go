@@ -47,6 +47,10 @@ const (
 	// checkpoint's REDO location pg_controldata entry
 	PgControlDataKeyLatestCheckpointREDOLocation pgControlDataKey = "Latest checkpoint's REDO location"
 
+	// PgControlDataKeyLatestCheckpointLocation is the latest
+	// checkpoint location pg_controldata entry
+	PgControlDataKeyLatestCheckpointLocation pgControlDataKey = "Latest checkpoint location"
+
 	// PgControlDataKeyTimeOfLatestCheckpoint is the time
 	// of latest checkpoint pg_controldata entry
 	PgControlDataKeyTimeOfLatestCheckpoint pgControlDataKey = "Time of latest checkpoint"
@@ -226,9 +230,10 @@ var (
 	}
 )
 
-// CreatePromotionToken translates a parsed pgControlData into a JSON token
-func CreatePromotionToken(pgDataMap map[string]string) (string, error) {
-	content := PgControldataTokenContent{
+// NewPgControldataTokenContent extracts the content of a promotion token
+// from a parsed pgControlData
+func NewPgControldataTokenContent(pgDataMap map[string]string) *PgControldataTokenContent {
+	return &PgControldataTokenContent{
 		LatestCheckpointTimelineID:   pgDataMap[PgControlDataKeyLatestCheckpointTimelineID],
 		REDOWALFile:                  pgDataMap[PgControlDataKeyREDOWALFile],
 		DatabaseSystemIdentifier:     pgDataMap[PgControlDataKeyDatabaseSystemIdentifier],
@@ -236,13 +241,11 @@ func CreatePromotionToken(pgDataMap map[string]string) (string, error) {
 		TimeOfLatestCheckpoint:       pgDataMap[PgControlDataKeyTimeOfLatestCheckpoint],
 		OperatorVersion:              versions.Info.Version,
 	}
+}
 
-	token, err := json.Marshal(content)
-	if err != nil {
-		return "", err
-	}
-
-	return base64.StdEncoding.EncodeToString(token), nil
+// CreatePromotionToken translates a parsed pgControlData into a JSON token
+func CreatePromotionToken(pgDataMap map[string]string) (string, error) {
+	return NewPgControldataTokenContent(pgDataMap).Encode()
 }
 
 // ParsePgControldataToken parses the JSON token into usable content